@@ -9,7 +9,31 @@ CREATE TABLE IF NOT EXISTS feeds (
     sync_mode TEXT DEFAULT 'none',
     sync_count INTEGER,
     sync_date_from DATETIME,
-    initial_sync_done BOOLEAN DEFAULT 0
+    initial_sync_done BOOLEAN DEFAULT 0,
+    disabled BOOLEAN DEFAULT 0,
+    last_build_date DATETIME,
+    fetch_timeout_seconds INTEGER DEFAULT 0,
+    strip_query_params BOOLEAN DEFAULT 0,
+    auto_interval BOOLEAN DEFAULT 0,
+    auto_interval_minutes INTEGER DEFAULT 0,
+    content_selector TEXT,
+    tag_with_feed_name BOOLEAN DEFAULT 0,
+    accept_header TEXT,
+    include_categories TEXT,
+    min_age_minutes INTEGER DEFAULT 0,
+    cookie TEXT,
+    favicon_url TEXT,
+    favicon_checked_at DATETIME,
+    keep_last_n INTEGER DEFAULT 0,
+    priority INTEGER DEFAULT 0,
+    categories_as_tags BOOLEAN DEFAULT 0,
+    delivery_mode TEXT DEFAULT 'link',
+    snoozed_until DATETIME,
+    content_kind TEXT,
+    date_field TEXT DEFAULT 'auto',
+    disabled_reason TEXT,
+    ttl_minutes INTEGER DEFAULT 0,
+    min_title_length INTEGER DEFAULT 0
 );
 
 CREATE TABLE IF NOT EXISTS articles (
@@ -20,6 +44,12 @@ CREATE TABLE IF NOT EXISTS articles (
     wallabag_entry_id INTEGER,
     published_at DATETIME,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    original_url TEXT,
+    filtered BOOLEAN DEFAULT 0,
+    feed_url TEXT,
+    marked_processed BOOLEAN DEFAULT 0,
+    normalized_url TEXT,
+    clicks INTEGER DEFAULT 0,
     FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
 );
 
@@ -29,3 +59,13 @@ CREATE TABLE IF NOT EXISTS settings (
 );
 
 INSERT OR IGNORE INTO settings (key, value) VALUES ('default_poll_interval_minutes', '1440');
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    action TEXT NOT NULL,
+    feed_id INTEGER,
+    detail TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_feed_id ON audit_log(feed_id);
@@ -0,0 +1,73 @@
+-- Postgres counterpart of schema.sql. Flags are INTEGER columns holding 0 or 1, as in SQLite,
+-- and articles keep no foreign key to feeds because SQLite never enforced it: an article stays
+-- recorded as processed after its feed is deleted.
+CREATE TABLE IF NOT EXISTS feeds (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    last_fetched TIMESTAMPTZ,
+    poll_interval_minutes INTEGER DEFAULT 1440,
+    poll_interval INTEGER DEFAULT 1,
+    poll_interval_unit TEXT DEFAULT 'days',
+    sync_mode TEXT DEFAULT 'none',
+    sync_count INTEGER,
+    sync_date_from TIMESTAMPTZ,
+    initial_sync_done INTEGER DEFAULT 0,
+    disabled INTEGER DEFAULT 0,
+    last_build_date TIMESTAMPTZ,
+    fetch_timeout_seconds INTEGER DEFAULT 0,
+    strip_query_params INTEGER DEFAULT 0,
+    auto_interval INTEGER DEFAULT 0,
+    auto_interval_minutes INTEGER DEFAULT 0,
+    content_selector TEXT,
+    tag_with_feed_name INTEGER DEFAULT 0,
+    accept_header TEXT,
+    include_categories TEXT,
+    min_age_minutes INTEGER DEFAULT 0,
+    cookie TEXT,
+    favicon_url TEXT,
+    favicon_checked_at TIMESTAMPTZ,
+    keep_last_n INTEGER DEFAULT 0,
+    priority INTEGER DEFAULT 0,
+    categories_as_tags INTEGER DEFAULT 0,
+    delivery_mode TEXT DEFAULT 'link',
+    snoozed_until TIMESTAMPTZ,
+    content_kind TEXT,
+    date_field TEXT DEFAULT 'auto',
+    disabled_reason TEXT,
+    ttl_minutes INTEGER DEFAULT 0,
+    min_title_length INTEGER DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS articles (
+    id SERIAL PRIMARY KEY,
+    feed_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    url TEXT NOT NULL UNIQUE,
+    wallabag_entry_id INTEGER,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    original_url TEXT,
+    filtered INTEGER DEFAULT 0,
+    feed_url TEXT,
+    marked_processed INTEGER DEFAULT 0,
+    normalized_url TEXT,
+    clicks INTEGER DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS settings (
+    key TEXT PRIMARY KEY,
+    value TEXT
+);
+
+INSERT INTO settings (key, value) VALUES ('default_poll_interval_minutes', '1440') ON CONFLICT (key) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    action TEXT NOT NULL,
+    feed_id INTEGER,
+    detail TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_feed_id ON audit_log(feed_id);
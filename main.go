@@ -3,18 +3,29 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/tls"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
+	"wallabag-rss-tool/pkg/audit"
 	"wallabag-rss-tool/pkg/config"
 	"wallabag-rss-tool/pkg/database"
 	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
 	"wallabag-rss-tool/pkg/rss"
+	"wallabag-rss-tool/pkg/sanitize"
 	"wallabag-rss-tool/pkg/server"
 	"wallabag-rss-tool/pkg/wallabag"
 	"wallabag-rss-tool/pkg/worker"
+	"wallabag-rss-tool/views"
 )
 
 func main() {
@@ -22,13 +33,19 @@ func main() {
 	initializeLogging()
 
 	appConfig := loadApplicationConfig()
-	db := initializeDatabase(appConfig.DatabasePath)
+	db := openDatabase(appConfig)
 	defer database.CloseDB(db)
 
+	feedTransport := loadFeedTransport(appConfig)
+
 	wallabagConfig := loadWallabagConfig(db)
-	wallabagClient := createWallabagClient(wallabagConfig)
+	var wallabagTransport http.RoundTripper
+	if appConfig.WallabagUseProxy && appConfig.FeedSOCKS5Proxy != "" {
+		wallabagTransport = feedTransport
+	}
+	wallabagClient := createWallabagClient(wallabagConfig, wallabagTransport)
 
-	runApplication(db, wallabagClient, appConfig.ServerPort)
+	runApplication(db, wallabagClient, appConfig, wallabagConfig.BaseURL, feedTransport, wallabagTransport)
 }
 
 // initializeLogging sets up structured logging based on LOG_LEVEL and LOG_FORMAT environment variables
@@ -59,12 +76,42 @@ func loadApplicationConfig() *config.AppConfig {
 		os.Exit(1)
 	}
 
+	if err := views.SetDateFormat(appConfig.DateFormat); err != nil {
+		logging.Warn("Invalid DATE_FORMAT, using default",
+			"error", err,
+			"default", views.DefaultDateFormat)
+	}
+
+	if _, err := sanitize.ParsePolicy(appConfig.SanitizePolicy); err != nil {
+		logging.Warn("Invalid CONTENT_SANITIZE_POLICY, using default",
+			"error", err,
+			"default", sanitize.Strict)
+		appConfig.SanitizePolicy = string(sanitize.Strict)
+	}
+
 	return appConfig
 }
 
-// initializeDatabase sets up the database connection
+// openDatabase opens the database selected by DATABASE_DRIVER and applies its schema, exiting
+// if that fails. Migrations run later, as with initializeDatabase.
+func openDatabase(appConfig *config.AppConfig) *sql.DB {
+	if appConfig.DatabaseDriver == database.DriverSQLite {
+		return initializeDatabase(appConfig.DatabasePath)
+	}
+
+	db, err := database.OpenDB(appConfig.DatabaseDriver, appConfig.DatabasePath, appConfig.DatabaseDSN)
+	if err != nil {
+		logging.Error("Failed to initialize database", "error", err, "driver", appConfig.DatabaseDriver)
+		os.Exit(1)
+	}
+
+	return db
+}
+
+// initializeDatabase opens the SQLite database and applies its schema. Migrations run later, in
+// migrateDatabase, once the web server can show its maintenance page.
 func initializeDatabase(databasePath string) *sql.DB {
-	db, err := database.InitDBWithPath(databasePath)
+	db, err := database.OpenDBWithPath(databasePath)
 	if err != nil {
 		logging.Error("Failed to initialize database", "error", err)
 		os.Exit(1)
@@ -73,6 +120,17 @@ func initializeDatabase(databasePath string) *sql.DB {
 	return db
 }
 
+// migrateDatabase applies pending migrations, exiting if they fail
+func migrateDatabase(db *sql.DB) {
+	logging.Info("Applying database migrations")
+	if err := database.ApplyMigrations(db); err != nil {
+		logging.Error("Failed to migrate database", "error", err)
+		database.CloseDB(db)
+		os.Exit(1)
+	}
+	logging.Info("Database migrations complete")
+}
+
 // loadWallabagConfig loads and validates Wallabag configuration
 func loadWallabagConfig(db *sql.DB) *config.WallabagConfig {
 	wallabagConfig, err := config.LoadWallabagConfig()
@@ -89,8 +147,65 @@ func loadWallabagConfig(db *sql.DB) *config.WallabagConfig {
 	return wallabagConfig
 }
 
-// createWallabagClient creates and authenticates Wallabag client
-func createWallabagClient(wallabagConfig *config.WallabagConfig) *wallabag.Client {
+// loadFeedTransport builds the transport for feed fetches: through the SOCKS5 proxy when
+// FEED_SOCKS5_PROXY is set, with the FEED_MIN_TLS, FEED_CA_FILE and FEED_INSECURE_SKIP_VERIFY
+// settings applied. It returns nil, meaning Go's default transport, when none are configured.
+func loadFeedTransport(appConfig *config.AppConfig) http.RoundTripper {
+	var transport *http.Transport
+	if appConfig.FeedSOCKS5Proxy != "" {
+		var err error
+		transport, err = rss.NewSOCKS5Transport(appConfig.FeedSOCKS5Proxy)
+		if err != nil {
+			logging.Error("Invalid FEED_SOCKS5_PROXY", "error", err)
+			os.Exit(1)
+		}
+		logging.Info("Fetching feeds through SOCKS5 proxy", "wallabag_uses_proxy", appConfig.WallabagUseProxy)
+	}
+
+	tlsConfig, err := feedTLSConfig(appConfig)
+	if err != nil {
+		logging.Error("Invalid feed TLS settings", "error", err)
+		os.Exit(1)
+	}
+	if tlsConfig != nil {
+		transport = rss.NewTLSTransport(transport, tlsConfig)
+	}
+
+	if transport == nil {
+		return nil
+	}
+
+	return transport
+}
+
+// feedTLSConfig builds the TLS configuration for feed fetches, or returns nil when no TLS
+// setting is configured. An invalid FEED_MIN_TLS falls back to TLS 1.2 with a warning.
+func feedTLSConfig(appConfig *config.AppConfig) (*tls.Config, error) {
+	minVersion, err := rss.ParseTLSVersion(appConfig.FeedMinTLS)
+	if err != nil {
+		logging.Warn("Invalid FEED_MIN_TLS, using default",
+			"error", err,
+			"default", "1.2")
+		minVersion = tls.VersionTLS12
+	}
+
+	if minVersion == 0 && appConfig.FeedCAFile == "" && !appConfig.FeedInsecureTLS {
+		return nil, nil
+	}
+
+	if appConfig.FeedInsecureTLS {
+		logging.Warn("FEED_INSECURE_SKIP_VERIFY is set: feed certificates are NOT verified and fetches can be intercepted. Use this for testing only")
+	}
+
+	return rss.NewTLSConfig(rss.TLSOptions{
+		MinVersion:         minVersion,
+		CAFile:             appConfig.FeedCAFile,
+		InsecureSkipVerify: appConfig.FeedInsecureTLS,
+	})
+}
+
+// createWallabagClient creates and authenticates Wallabag client. A nil transport connects directly.
+func createWallabagClient(wallabagConfig *config.WallabagConfig, transport http.RoundTripper) *wallabag.Client {
 	wallabagClient := wallabag.NewClient(
 		wallabagConfig.BaseURL,
 		wallabagConfig.ClientID,
@@ -98,8 +213,13 @@ func createWallabagClient(wallabagConfig *config.WallabagConfig) *wallabag.Clien
 		wallabagConfig.Username,
 		wallabagConfig.Password,
 	)
+	if transport != nil {
+		wallabagClient.SetTransport(transport)
+	}
+	wallabagClient.SetInstanceTag(wallabagConfig.InstanceTag)
 
-	if err := wallabagClient.Authenticate(context.Background()); err != nil {
+	err := authenticateWithRetry(context.Background(), wallabagClient, wallabagConfig.AuthAttempts, wallabagConfig.AuthRetryDelay)
+	if err != nil {
 		logging.Warn("Initial Wallabag authentication failed",
 			"error", err,
 			"message", "Please check your environment variables")
@@ -107,25 +227,281 @@ func createWallabagClient(wallabagConfig *config.WallabagConfig) *wallabag.Clien
 		logging.Info("Successfully authenticated with Wallabag")
 	}
 
+	if wallabagConfig.SelfTest {
+		runSelfTest(context.Background(), wallabagClient)
+	}
+
 	return wallabagClient
 }
 
+// runSelfTest adds a test entry to Wallabag and deletes it again, logging whether that worked.
+// A failure is only logged; startup carries on either way.
+func runSelfTest(ctx context.Context, client *wallabag.Client) {
+	if err := client.SelfTest(ctx); err != nil {
+		logging.Warn("Wallabag self-test failed", "error", err)
+
+		return
+	}
+
+	logging.Info("Wallabag self-test passed: test entry added and deleted")
+}
+
+// authenticateWithRetry authenticates the client, retrying up to attempts times in total with a
+// delay that doubles after each failure. Fewer than one attempt is treated as one. The last
+// error is returned once the attempts are used up.
+func authenticateWithRetry(ctx context.Context, client *wallabag.Client, attempts int, delay time.Duration) error {
+	attempts = max(attempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = client.Authenticate(ctx); err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		logging.Warn("Wallabag authentication failed, retrying",
+			"error", err,
+			"attempt", attempt,
+			"max_attempts", attempts,
+			"retry_in", delay)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return err
+}
+
+// newFeedProcessor builds the feed processor shared by the worker and the server's raw and
+// preview views, so both fetch through the same proxy, TLS settings and redirect policy.
+func newFeedProcessor(appConfig *config.AppConfig, feedTransport http.RoundTripper) *rss.Processor {
+	processor := rss.NewProcessor()
+	if feedTransport != nil {
+		processor = rss.NewProcessorWithTransport(feedTransport)
+	}
+	processor.BlockCrossHostRedirects = !appConfig.FeedCrossHost
+
+	return processor
+}
+
 // runApplication initializes and runs the main application components
-func runApplication(db *sql.DB, wallabagClient *wallabag.Client, port string) {
-	store := database.NewSQLStore(db)
-	rssProcessor := rss.NewProcessor()
+func runApplication(db *sql.DB, wallabagClient *wallabag.Client, appConfig *config.AppConfig, wallabagBaseURL string, feedTransport, wallabagTransport http.RoundTripper) {
+	port := appConfig.ServerPort
+	limits := models.FieldLimits{
+		MaxTitleLength: appConfig.MaxTitleLength,
+		MaxURLLength:   appConfig.MaxURLLength,
+	}
+	store := database.NewSQLStoreWithLimits(db, limits)
+	if appConfig.FeedCookieKey != "" {
+		if err := store.SetCookieKey(appConfig.FeedCookieKey); err != nil {
+			logging.Error("Failed to set feed cookie key, feed cookies are disabled", "error", err)
+		}
+	}
+	store.SetDedupWindow(time.Duration(appConfig.DedupWindowDays) * 24 * time.Hour)
+	rssProcessor := newFeedProcessor(appConfig, feedTransport)
+
+	// Audit entries are written in the background; Close flushes them at shutdown
+	auditLog := audit.NewLog(store)
+
+	worker := worker.NewWorkerWithConfig(store, rssProcessor, wallabagClient, worker.Config{
+		MaxSendsPerCycle:     appConfig.MaxSendsPerCycle,
+		FieldLimits:          limits,
+		Transport:            feedTransport,
+		TagWithFeedName:      appConfig.TagWithFeedName,
+		StaleAfter:           appConfig.WorkerStaleAfter,
+		SanitizePolicy:       sanitize.Policy(appConfig.SanitizePolicy),
+		Favicons:             appConfig.FeedFavicons,
+		CheckExistingEntries: appConfig.CheckExisting,
+		SaveOnFailure:        appConfig.SaveOnFailure,
+		CrossFeedDedup:       appConfig.CrossFeedDedup,
+		CategoryTagPrefix:    appConfig.CategoryPrefix,
+		FallbackTag:          appConfig.FallbackTag,
+		Audit:                auditLog,
+		SendConcurrency:      appConfig.SendConcurrency,
+		EnrichConcurrency:    appConfig.EnrichWorkers,
+		OptimizeInterval:     appConfig.OptimizeInterval,
+		PauseAfterRejections: appConfig.RejectPauseAfter,
+		SendDebounce:         appConfig.SendDebounce,
+		MinTitleLength:       appConfig.MinTitleLength,
+		LinkCheckInterval:    appConfig.LinkCheckEvery,
+		PruneExpiredArticles: appConfig.DedupWindowDays > 0,
+	})
+
+	csrfSecret, err := resolveCSRFSecret(context.Background(), store, appConfig.CSRFSecret)
+	if err != nil {
+		logging.Warn("Failed to load persisted CSRF secret, forms will need reloading after a restart",
+			"error", err)
+	}
+
+	server := server.NewServerWithConfig(store, wallabagClient, worker, server.Config{
+		FieldLimits:  limits,
+		MaxBodyBytes: appConfig.MaxBodyBytes,
+		CSRFSecret:   csrfSecret,
+		WallabagURL:  wallabagBaseURL,
+		ReadOnly:     appConfig.ReadOnly,
+		Audit:        auditLog,
+		EnforceTTL:   appConfig.EnforceFeedTTL,
+		PageQueries:  appConfig.PageQueries,
+		Processor:    rssProcessor,
+		Headers: server.SecurityHeaders{
+			HSTSMaxAge:            appConfig.HSTSMaxAge,
+			HSTSIncludeSubdomains: appConfig.HSTSSubdomains,
+			PermissionsPolicy:     appConfig.PermissionPolicy,
+			CrossOriginOpener:     appConfig.OpenerPolicy,
+			ExternalScripts:       appConfig.ExternalScripts,
+		},
+		WallabagTransport: wallabagTransport,
+	})
+	// UI routes show the maintenance page until migrations finish
+	server.SetMaintenance(true)
+	logging.Info("Starting web server", "port", port)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Start(port)
+	}()
+
+	migrateDatabase(db)
+	server.SetMaintenance(false)
+	logStartupSummary(context.Background(), store, appConfig, wallabagBaseURL)
 
-	worker := worker.NewWorker(store, rssProcessor, wallabagClient)
 	worker.Start()
 	defer worker.Stop()
+	// Authentication was attempted in createWallabagClient
+	server.SetReady(true)
 
-	server := server.NewServer(store, wallabagClient, worker)
-	logging.Info("Starting web server", "port", port)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+wait:
+	for {
+		select {
+		case err := <-serverErr:
+			logging.Error("Web server failed to start", "error", err, "port", port)
+			fmt.Fprintf(os.Stderr, "Failed to start server: %v\n", err)
+			worker.Stop()
+			os.Exit(1) //nolint:gocritic // Explicit cleanup before exit is required
+		case sig := <-signals:
+			if sig == syscall.SIGHUP {
+				appConfig = reloadApplicationConfig(appConfig, worker)
+
+				continue
+			}
+			logging.Info("Shutdown signal received", "signal", sig.String(), "timeout", appConfig.ShutdownTimeout.String())
+
+			break wait
+		}
+	}
 
-	if err := server.Start(port); err != nil {
-		logging.Error("Web server failed to start", "error", err, "port", port)
-		fmt.Fprintf(os.Stderr, "Failed to start server: %v\n", err)
-		worker.Stop()
-		os.Exit(1) //nolint:gocritic // Explicit cleanup before exit is required
+	if err := shutdownApplication(server, worker, appConfig.ShutdownTimeout); err != nil {
+		logging.Error("Graceful shutdown timed out, forcing exit",
+			"error", err,
+			"in_flight_feeds", worker.InFlight())
+		os.Exit(1)
 	}
+	auditLog.Close()
+	logging.Info("Shutdown complete")
+}
+
+// reloadApplicationConfig re-reads the .env file on SIGHUP and applies the hot-reloadable
+// settings to the worker. Changed restart-only settings are logged and ignored; if the file
+// cannot be loaded the current configuration is kept.
+func reloadApplicationConfig(current *config.AppConfig, w *worker.Worker) *config.AppConfig {
+	logging.Info("Reloading configuration")
+
+	reloaded, err := config.ReloadAppConfig()
+	if err != nil {
+		logging.Error("Failed to reload configuration, keeping current settings", "error", err)
+
+		return current
+	}
+
+	applied, restartRequired := current.ApplyReload(reloaded)
+	if len(restartRequired) > 0 {
+		logging.Warn("Changed settings take effect only after a restart", "settings", restartRequired)
+	}
+
+	w.Reload(worker.Config{
+		MaxSendsPerCycle:     applied.MaxSendsPerCycle,
+		TagWithFeedName:      applied.TagWithFeedName,
+		StaleAfter:           applied.WorkerStaleAfter,
+		SanitizePolicy:       sanitize.Policy(applied.SanitizePolicy),
+		CheckExistingEntries: applied.CheckExisting,
+		SaveOnFailure:        applied.SaveOnFailure,
+		CrossFeedDedup:       applied.CrossFeedDedup,
+		CategoryTagPrefix:    applied.CategoryPrefix,
+		FallbackTag:          applied.FallbackTag,
+		SendConcurrency:      applied.SendConcurrency,
+		SendDebounce:         applied.SendDebounce,
+		PauseAfterRejections: applied.RejectPauseAfter,
+		MinTitleLength:       applied.MinTitleLength,
+	})
+
+	return applied
+}
+
+// resolveCSRFSecret returns the CSRF signing secret: CSRF_SECRET when set, otherwise the one
+// persisted in settings, generating and storing it on first run.
+func resolveCSRFSecret(ctx context.Context, store database.Storer, configured string) ([]byte, error) {
+	if configured != "" {
+		return []byte(configured), nil
+	}
+
+	candidate := make([]byte, 32)
+	if _, err := rand.Read(candidate); err != nil {
+		return nil, fmt.Errorf("failed to generate CSRF secret: %w", err)
+	}
+
+	secret, err := store.GetOrCreateCSRFSecret(ctx, hex.EncodeToString(candidate))
+	if err != nil {
+		return nil, fmt.Errorf("store.GetOrCreateCSRFSecret: %w", err)
+	}
+
+	return []byte(secret), nil
+}
+
+// shutdownApplication stops the web server and then the worker, sharing one grace period
+// between them. It returns an error if the worker is still busy when the period ends.
+func shutdownApplication(srv *server.Server, w *worker.Worker, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logging.Warn("Web server did not shut down cleanly", "error", err)
+	}
+
+	return w.Shutdown(ctx)
+}
+
+// logStartupSummary logs the effective configuration in a single line to ease support.
+// Wallabag credentials and tokens are deliberately left out.
+func logStartupSummary(ctx context.Context, store database.Storer, appConfig *config.AppConfig, wallabagBaseURL string) {
+	feedCount := 0
+	feeds, err := store.GetFeeds(ctx)
+	if err != nil {
+		logging.Warn("Failed to count feeds for startup summary", "error", err)
+	} else {
+		feedCount = len(feeds)
+	}
+
+	defaultPollInterval, err := store.GetDefaultPollInterval(ctx)
+	if err != nil {
+		logging.Warn("Failed to read default poll interval for startup summary", "error", err)
+	}
+
+	logging.Info("Startup configuration",
+		"database_driver", appConfig.DatabaseDriver,
+		"database_path", appConfig.DatabasePath,
+		"server_port", appConfig.ServerPort,
+		"default_poll_interval_minutes", defaultPollInterval,
+		"max_sends_per_cycle", appConfig.MaxSendsPerCycle,
+		"feed_count", feedCount,
+		"read_only", appConfig.ReadOnly,
+		"wallabag_base_url", wallabagBaseURL)
 }
@@ -1,10 +1,19 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -268,6 +277,84 @@ func TestCreateWallabagClient(t *testing.T) {
 	})
 }
 
+func TestAuthenticateWithRetry(t *testing.T) {
+	// tokenServer fails the first failures token requests, then issues a token
+	tokenServer := func(failures int32) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token","expires_in":3600,"token_type":"bearer"}`)
+		}))
+
+		return server, &calls
+	}
+
+	t.Run("Succeeds after the first attempt fails", func(t *testing.T) {
+		server, calls := tokenServer(1)
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		err := authenticateWithRetry(context.Background(), client, 3, time.Millisecond)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Gives up after the configured attempts", func(t *testing.T) {
+		server, calls := tokenServer(10)
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		err := authenticateWithRetry(context.Background(), client, 3, time.Millisecond)
+
+		assert.ErrorContains(t, err, "status 503")
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Zero attempts still tries once", func(t *testing.T) {
+		server, calls := tokenServer(10)
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		err := authenticateWithRetry(context.Background(), client, 0, time.Millisecond)
+
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Startup continues when Wallabag stays down", func(t *testing.T) {
+		server, _ := tokenServer(10)
+		defer server.Close()
+
+		client := createWallabagClient(&config.WallabagConfig{
+			BaseURL:        server.URL,
+			AuthAttempts:   2,
+			AuthRetryDelay: time.Millisecond,
+		}, nil)
+
+		assert.NotNil(t, client)
+	})
+
+	t.Run("Startup continues when the self-test fails", func(t *testing.T) {
+		server, _ := tokenServer(0)
+		defer server.Close()
+
+		client := createWallabagClient(&config.WallabagConfig{
+			BaseURL:        server.URL,
+			AuthAttempts:   1,
+			AuthRetryDelay: time.Millisecond,
+			SelfTest:       true,
+		}, nil)
+
+		assert.NotNil(t, client)
+	})
+}
+
 func TestApplicationComponents(t *testing.T) {
 	t.Run("Application component creation patterns", func(t *testing.T) {
 		// Test the patterns used in runApplication function
@@ -297,7 +384,7 @@ func TestApplicationComponents(t *testing.T) {
 			Password:     "test_password",
 		}
 		
-		client := createWallabagClient(wallabagConfig)
+		client := createWallabagClient(wallabagConfig, nil)
 		assert.NotNil(t, client)
 	})
 }
@@ -482,6 +569,18 @@ func TestMainFunctionCoverage(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 1, count)
 	})
+
+	t.Run("migrateDatabase function coverage", func(t *testing.T) {
+		db := initializeDatabase(filepath.Join(t.TempDir(), "migrate.db"))
+		defer db.Close()
+
+		migrateDatabase(db)
+
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_articles_normalized_url'").Scan(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
 	
 	t.Run("loadWallabagConfig function coverage", func(t *testing.T) {
 		// Save original environment
@@ -537,11 +636,44 @@ func TestMainFunctionCoverage(t *testing.T) {
 		}
 		
 		// Test function directly
-		client := createWallabagClient(wallabagConfig)
+		client := createWallabagClient(wallabagConfig, nil)
 		assert.NotNil(t, client)
 	})
 }
 
+func TestShutdownApplication(t *testing.T) {
+	store := database.NewSQLStore(nil)
+	wallabagClient := wallabag.NewClient("http://localhost:8000", "client_id", "client_secret", "username", "password")
+	w := worker.NewWorker(store, rss.NewProcessor(), wallabagClient)
+	srv := server.NewServer(store, wallabagClient, w)
+
+	// Nothing is running, so shutdown finishes well within the grace period
+	assert.NoError(t, shutdownApplication(srv, w, time.Second))
+}
+
+func TestResolveCSRFSecret(t *testing.T) {
+	db, err := database.InitDBWithPath(filepath.Join(t.TempDir(), "csrf.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	store := database.NewSQLStore(db)
+
+	t.Run("Configured secret wins", func(t *testing.T) {
+		secret, err := resolveCSRFSecret(context.Background(), store, "from-env")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("from-env"), secret)
+	})
+
+	t.Run("Generated secret persists across restarts", func(t *testing.T) {
+		first, err := resolveCSRFSecret(context.Background(), store, "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, first)
+
+		second, err := resolveCSRFSecret(context.Background(), store, "")
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+}
+
 func TestRunApplication(t *testing.T) {
 	t.Run("runApplication creates and configures components", func(t *testing.T) {
 		// Create temporary database
@@ -765,4 +897,124 @@ func TestMainHelperFunctions(t *testing.T) {
 			assert.NotNil(t, wallabagClient)
 		})
 	})
-}
\ No newline at end of file
+}
+
+func TestLogStartupSummary(t *testing.T) {
+	originalLogger := logging.GetGlobalLogger()
+	defer logging.SetGlobalLogger(originalLogger)
+
+	mockLogger := logging.NewMockLogger()
+	logging.SetGlobalLogger(mockLogger)
+
+	tempDir, err := os.MkdirTemp("", "wallabag_summary_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	db := initializeDatabase(filepath.Join(tempDir, "test.db"))
+	defer db.Close()
+
+	appConfig := &config.AppConfig{DatabasePath: "/data/wallabag.db", ServerPort: "8080"}
+
+	assert.NotPanics(t, func() {
+		logStartupSummary(context.Background(), database.NewSQLStore(db), appConfig, "https://wallabag.example.com")
+	})
+
+	fields := map[string]any{}
+	for _, entry := range mockLogger.GetEntries() {
+		if entry.Message == "Startup configuration" {
+			for i := 0; i+1 < len(entry.Args); i += 2 {
+				fields[fmt.Sprint(entry.Args[i])] = entry.Args[i+1]
+			}
+		}
+	}
+	assert.Equal(t, "/data/wallabag.db", fields["database_path"])
+	assert.Equal(t, "8080", fields["server_port"])
+	assert.Equal(t, 1440, fields["default_poll_interval_minutes"])
+	assert.Equal(t, 0, fields["feed_count"])
+	assert.Equal(t, "https://wallabag.example.com", fields["wallabag_base_url"])
+
+	for _, entry := range mockLogger.GetEntries() {
+		for i := 0; i+1 < len(entry.Args); i += 2 {
+			key := fmt.Sprint(entry.Args[i])
+			assert.NotContains(t, strings.ToLower(key), "password")
+			assert.NotContains(t, strings.ToLower(key), "secret")
+			assert.NotContains(t, strings.ToLower(key), "token")
+		}
+	}
+}
+
+func TestFeedTLSConfig(t *testing.T) {
+	originalLogger := logging.GetGlobalLogger()
+	defer logging.SetGlobalLogger(originalLogger)
+
+	warned := func(mockLogger *logging.MockLogger, message string) bool {
+		for _, entry := range mockLogger.GetEntries() {
+			if entry.Level == "WARN" && strings.Contains(entry.Message, message) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	t.Run("Nothing configured keeps the default transport", func(t *testing.T) {
+		tlsConfig, err := feedTLSConfig(&config.AppConfig{})
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+		assert.Nil(t, loadFeedTransport(&config.AppConfig{}))
+	})
+
+	t.Run("Invalid minimum version falls back to TLS 1.2 with a warning", func(t *testing.T) {
+		mockLogger := logging.NewMockLogger()
+		logging.SetGlobalLogger(mockLogger)
+
+		tlsConfig, err := feedTLSConfig(&config.AppConfig{FeedMinTLS: "1.9"})
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig)
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+		assert.True(t, warned(mockLogger, "Invalid FEED_MIN_TLS"))
+	})
+
+	t.Run("Skipping verification warns", func(t *testing.T) {
+		mockLogger := logging.NewMockLogger()
+		logging.SetGlobalLogger(mockLogger)
+
+		tlsConfig, err := feedTLSConfig(&config.AppConfig{FeedInsecureTLS: true})
+		require.NoError(t, err)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+		assert.True(t, warned(mockLogger, "FEED_INSECURE_SKIP_VERIFY"))
+	})
+
+	t.Run("CA file is loaded into the transport", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+		defer server.Close()
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+		transport, ok := loadFeedTransport(&config.AppConfig{FeedCAFile: caFile, FeedMinTLS: "1.3"}).(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	})
+}
+
+func TestNewFeedProcessor(t *testing.T) {
+	t.Run("Direct connections and cross-host redirects blocked", func(t *testing.T) {
+		processor := newFeedProcessor(&config.AppConfig{}, nil)
+
+		assert.Nil(t, processor.FeedParser.Client)
+		assert.True(t, processor.BlockCrossHostRedirects)
+	})
+
+	t.Run("Configured transport and cross-host redirects allowed", func(t *testing.T) {
+		transport := &http.Transport{}
+		processor := newFeedProcessor(&config.AppConfig{FeedCrossHost: true}, transport)
+
+		require.NotNil(t, processor.FeedParser.Client)
+		assert.Same(t, transport, processor.FeedParser.Client.Transport)
+		assert.False(t, processor.BlockCrossHostRedirects)
+	})
+}
@@ -0,0 +1,95 @@
+// Package audit records significant worker and server actions in the audit log without
+// slowing down the code that performs them.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// Buffer is how many entries may wait to be written. Entries recorded while the buffer is full
+// are dropped, so a slow database never blocks feed processing.
+const Buffer = 256
+
+// writeTimeout bounds how long writing a single entry may take
+const writeTimeout = 5 * time.Second
+
+// Appender stores audit entries. database.SQLStore satisfies it.
+type Appender interface {
+	AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+}
+
+// Log queues audit entries and writes them to an Appender in the background. A nil Log
+// discards recorded entries.
+type Log struct {
+	store   Appender
+	entries chan models.AuditEntry
+	done    chan struct{}
+	mu      sync.RWMutex // Guards closed so Record never sends on the closed queue
+	closed  bool
+}
+
+// NewLog creates a Log writing to store and starts its writer. Call Close to flush it.
+func NewLog(store Appender) *Log {
+	l := &Log{
+		store:   store,
+		entries: make(chan models.AuditEntry, Buffer),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+
+	return l
+}
+
+// Record queues an entry for action on the feed with feedID (0 for none) without blocking.
+func (l *Log) Record(action models.AuditAction, feedID int, detail string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return
+	}
+
+	entry := models.AuditEntry{CreatedAt: time.Now().UTC(), Action: action, FeedID: feedID, Detail: detail}
+	select {
+	case l.entries <- entry:
+	default:
+		logging.Warn("Audit log buffer full, dropping entry", "action", action, "feed_id", feedID)
+	}
+}
+
+// Close writes the entries still queued and stops the writer. Entries recorded after Close
+// are discarded. It is safe to call more than once.
+func (l *Log) Close() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.entries)
+	}
+	l.mu.Unlock()
+	<-l.done
+}
+
+// run writes queued entries until the queue is closed and drained
+func (l *Log) run() {
+	defer close(l.done)
+
+	for entry := range l.entries {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		if err := l.store.AppendAuditEntry(ctx, &entry); err != nil {
+			logging.Warn("Failed to write audit entry", "error", err, "action", entry.Action)
+		}
+		cancel()
+	}
+}
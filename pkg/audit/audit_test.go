@@ -0,0 +1,88 @@
+package audit_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/audit"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// recordingStore collects appended entries, optionally waiting on release before each one
+type recordingStore struct {
+	mu      sync.Mutex
+	entries []models.AuditEntry
+	release chan struct{}
+}
+
+func (s *recordingStore) AppendAuditEntry(_ context.Context, entry *models.AuditEntry) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, *entry)
+
+	return nil
+}
+
+func (s *recordingStore) recorded() []models.AuditEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]models.AuditEntry(nil), s.entries...)
+}
+
+func TestLog(t *testing.T) {
+	t.Run("Close writes recorded entries in order", func(t *testing.T) {
+		store := &recordingStore{}
+		log := audit.NewLog(store)
+
+		log.Record(models.AuditFeedAdded, 3, "Blog (https://example.com/feed)")
+		log.Record(models.AuditSyncRun, 0, "Completed a polling cycle over 1 feeds")
+		log.Close()
+
+		entries := store.recorded()
+		assert.Len(t, entries, 2)
+		assert.Equal(t, models.AuditFeedAdded, entries[0].Action)
+		assert.Equal(t, 3, entries[0].FeedID)
+		assert.False(t, entries[0].CreatedAt.IsZero())
+		assert.Equal(t, models.AuditSyncRun, entries[1].Action)
+	})
+
+	t.Run("Entries recorded after Close are discarded", func(t *testing.T) {
+		store := &recordingStore{}
+		log := audit.NewLog(store)
+		log.Close()
+		log.Close()
+
+		log.Record(models.AuditFeedDeleted, 1, "")
+
+		assert.Empty(t, store.recorded())
+	})
+
+	t.Run("Recording never blocks on a slow store", func(t *testing.T) {
+		store := &recordingStore{release: make(chan struct{})}
+		log := audit.NewLog(store)
+
+		// One entry is held by the writer and Buffer more fill the queue; the rest are dropped
+		for range audit.Buffer + 10 {
+			log.Record(models.AuditArticleSent, 1, "")
+		}
+		close(store.release)
+		log.Close()
+
+		recorded := len(store.recorded())
+		assert.GreaterOrEqual(t, recorded, audit.Buffer)
+		assert.LessOrEqual(t, recorded, audit.Buffer+1)
+	})
+
+	t.Run("Nil log discards entries", func(t *testing.T) {
+		var log *audit.Log
+
+		log.Record(models.AuditSyncRun, 0, "")
+		log.Close()
+	})
+}
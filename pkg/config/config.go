@@ -2,6 +2,8 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 	"wallabag-rss-tool/pkg/logging"
@@ -16,19 +18,69 @@ type WallabagConfig struct {
 	ClientSecret string `env:"WALLABAG_CLIENT_SECRET,required"`
 	Username     string `env:"WALLABAG_USERNAME,required"`
 	Password     string `env:"WALLABAG_PASSWORD,required"`
+	InstanceTag  string `env:"INSTANCE_TAG"` // Optional tag identifying this instance on every entry
+	// Startup authentication attempts and the delay before the first retry, doubled after each
+	// failure, so a Wallabag that is still booting does not leave the client unauthenticated
+	AuthAttempts   int           `env:"WALLABAG_AUTH_ATTEMPTS"    envDefault:"5"`
+	AuthRetryDelay time.Duration `env:"WALLABAG_AUTH_RETRY_DELAY" envDefault:"2s"`
+	// Add and delete a test entry at startup to check the connection end to end
+	SelfTest bool `env:"WALLABAG_SELFTEST" envDefault:"false"`
 }
 
 // AppConfig holds application configuration.
 //
 //nolint:tagliatelle // Environment variable names use standard convention
 type AppConfig struct {
-	DatabasePath string `env:"DATABASE_PATH" envDefault:"./wallabag.db"`
-	ServerPort   string `env:"SERVER_PORT"   envDefault:"8080"`
+	DatabasePath     string        `env:"DATABASE_PATH"       envDefault:"./wallabag.db"`
+	DatabaseDriver   string        `env:"DATABASE_DRIVER"     envDefault:"sqlite"` // sqlite or postgres
+	DatabaseDSN      string        `env:"DATABASE_DSN"`                            // Postgres connection string
+	ServerPort       string        `env:"SERVER_PORT"         envDefault:"8080"`
+	MaxSendsPerCycle int           `env:"MAX_SENDS_PER_CYCLE" envDefault:"0"` // 0 disables the cap
+	MaxTitleLength   int           `env:"MAX_TITLE_LENGTH"    envDefault:"1000"`
+	MaxURLLength     int           `env:"MAX_URL_LENGTH"      envDefault:"2048"`
+	MaxBodyBytes     int64         `env:"MAX_BODY_BYTES"      envDefault:"1048576"`
+	DateFormat       string        `env:"DATE_FORMAT"         envDefault:"eu"`  // iso, us or eu
+	ShutdownTimeout  time.Duration `env:"SHUTDOWN_TIMEOUT"    envDefault:"30s"` // Grace period before a forced exit
+	CSRFSecret       string        `env:"CSRF_SECRET"`                          // Generated and stored in settings when unset
+	FeedCookieKey    string        `env:"FEED_COOKIE_KEY"`                      // Encrypts per-feed cookies; unset disables them
+	FeedSOCKS5Proxy  string        `env:"FEED_SOCKS5_PROXY"`                    // host:port or socks5://host:port; unset fetches directly
+	WallabagUseProxy bool          `env:"WALLABAG_USE_SOCKS5_PROXY" envDefault:"false"`
+	TagWithFeedName  bool          `env:"TAG_WITH_FEED_NAME" envDefault:"false"`            // Tag every entry with its feed's name
+	WorkerStaleAfter time.Duration `env:"WORKER_STALE_AFTER"`                               // 0 means twice the poll interval
+	ReadOnly         bool          `env:"READ_ONLY" envDefault:"false"`                     // Serve the UI without edit controls
+	FeedFavicons     bool          `env:"FEED_FAVICONS" envDefault:"false"`                 // Look up and show each feed's site icon
+	EnrichWorkers    int           `env:"FEED_ENRICH_CONCURRENCY" envDefault:"2"`           // New feeds looked up in the background at once
+	SanitizePolicy   string        `env:"CONTENT_SANITIZE_POLICY" envDefault:"strict"`      // strict or lenient
+	CheckExisting    bool          `env:"WALLABAG_CHECK_EXISTING" envDefault:"false"`       // Ask Wallabag before adding each article
+	SaveOnFailure    bool          `env:"SAVE_ON_WALLABAG_FAILURE" envDefault:"false"`      // Record refused articles as unsent instead of retrying every poll
+	CrossFeedDedup   bool          `env:"CROSS_FEED_DEDUP" envDefault:"false"`              // Skip articles already seen under a similar URL
+	CategoryPrefix   string        `env:"CATEGORY_TAG_PREFIX"`                              // Prepended to tags made from item categories
+	FallbackTag      string        `env:"FALLBACK_TAG"`                                     // Sent with articles whose feed gives them no tags
+	SendConcurrency  int           `env:"SEND_CONCURRENCY" envDefault:"1"`                  // Articles of one feed sent to Wallabag at once
+	SendDebounce     time.Duration `env:"SEND_DEBOUNCE"`                                    // Hold a feed's new articles this long to send them together
+	FeedMinTLS       string        `env:"FEED_MIN_TLS"`                                     // 1.0 to 1.3; unset keeps Go's default
+	FeedCAFile       string        `env:"FEED_CA_FILE"`                                     // PEM CA certificates trusted for feeds
+	FeedInsecureTLS  bool          `env:"FEED_INSECURE_SKIP_VERIFY"`                        // Skip feed certificate checks; testing only
+	FeedCrossHost    bool          `env:"FEED_FOLLOW_CROSSHOST_REDIRECT" envDefault:"true"` // Follow feed redirects to another host
+	HSTSMaxAge       int           `env:"HSTS_MAX_AGE" envDefault:"0"`                      // Strict-Transport-Security max-age in seconds; 0 disables HSTS
+	HSTSSubdomains   bool          `env:"HSTS_INCLUDE_SUBDOMAINS" envDefault:"false"`       // Add includeSubDomains to HSTS
+	PermissionPolicy string        `env:"PERMISSIONS_POLICY"`                               // Permissions-Policy header value; unset sends none
+	OpenerPolicy     string        `env:"CROSS_ORIGIN_OPENER_POLICY"`                       // Cross-Origin-Opener-Policy header value; unset sends none
+	ExternalScripts  bool          `env:"EXTERNAL_SCRIPTS" envDefault:"false"`              // Serve page scripts from /static/ and forbid inline scripts in the CSP
+	OptimizeInterval time.Duration `env:"DB_OPTIMIZE_INTERVAL"`                             // Compact the database this often; 0 never does
+	RejectPauseAfter int           `env:"PAUSE_AFTER_WALLABAG_REJECTIONS"`                  // Disable a feed after this many articles in a row get 400 from Wallabag; 0 never does
+	DedupWindowDays  int           `env:"DEDUP_WINDOW_DAYS"`                                // Articles recorded longer ago than this count as new again; 0 remembers them forever
+	EnforceFeedTTL   bool          `env:"ENFORCE_FEED_TTL" envDefault:"false"`              // Reject poll intervals shorter than a feed's <ttl> instead of warning
+	PageQueries      int           `env:"ARTICLES_PAGE_CONCURRENCY" envDefault:"3"`         // Articles page queries run at once; 1 runs them in turn
+	MinTitleLength   int           `env:"MIN_TITLE_LENGTH" envDefault:"0"`                  // Skip items with shorter titles; 0 keeps them all
+	LinkCheckEvery   time.Duration `env:"FEED_LINK_CHECK_INTERVAL"`                         // Fetch every feed this often to find dead ones; 0 never does
 }
 
 // LoadEnvFile loads environment variables from .env file if it exists.
 // This should be called at application startup before loading config.
 func LoadEnvFile() {
+	recordFileKeys()
+
 	if err := godotenv.Load(); err != nil {
 		logging.Debug("No .env file found or error loading .env file", "error", err)
 		logging.Info("Using system environment variables only")
@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +34,23 @@ func TestLoadWallabagConfig(t *testing.T) {
 				assert.Equal(t, "test_client_secret", cfg.ClientSecret)
 				assert.Equal(t, "test_username", cfg.Username)
 				assert.Equal(t, "test_password", cfg.Password)
+				assert.Empty(t, cfg.InstanceTag)
+			},
+		},
+		{
+			name: "instance tag set",
+			envVars: map[string]string{
+				"WALLABAG_BASE_URL":      "https://wallabag.test.com",
+				"WALLABAG_CLIENT_ID":     "test_client_id",
+				"WALLABAG_CLIENT_SECRET": "test_client_secret",
+				"WALLABAG_USERNAME":      "test_username",
+				"WALLABAG_PASSWORD":      "test_password",
+				"INSTANCE_TAG":           "homelab",
+			},
+			wantErr: false,
+			wantCheck: func(t *testing.T, cfg *config.WallabagConfig) {
+				t.Helper()
+				assert.Equal(t, "homelab", cfg.InstanceTag)
 			},
 		},
 		{
@@ -138,6 +156,7 @@ func TestLoadWallabagConfig(t *testing.T) {
 		"WALLABAG_CLIENT_SECRET",
 		"WALLABAG_USERNAME",
 		"WALLABAG_PASSWORD",
+		"INSTANCE_TAG",
 	}
 
 	for _, env := range envVars {
@@ -286,6 +305,464 @@ func TestLoadAppConfig(t *testing.T) {
 	}
 }
 
+func TestLoadAppConfig_MaxSendsPerCycle(t *testing.T) {
+	t.Run("defaults to no cap", func(t *testing.T) {
+		t.Setenv("MAX_SENDS_PER_CYCLE", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 0, cfg.MaxSendsPerCycle)
+	})
+
+	t.Run("reads cap from environment", func(t *testing.T) {
+		t.Setenv("MAX_SENDS_PER_CYCLE", "25")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 25, cfg.MaxSendsPerCycle)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv("MAX_SENDS_PER_CYCLE", "lots")
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadAppConfig_SendConcurrency(t *testing.T) {
+	t.Run("defaults to sequential sends", func(t *testing.T) {
+		t.Setenv("SEND_CONCURRENCY", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 1, cfg.SendConcurrency)
+	})
+
+	t.Run("reads concurrency from environment", func(t *testing.T) {
+		t.Setenv("SEND_CONCURRENCY", "4")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 4, cfg.SendConcurrency)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv("SEND_CONCURRENCY", "many")
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadAppConfig_Database(t *testing.T) {
+	t.Run("defaults to SQLite", func(t *testing.T) {
+		t.Setenv("DATABASE_DRIVER", "")
+		t.Setenv("DATABASE_DSN", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "sqlite", cfg.DatabaseDriver)
+		assert.Empty(t, cfg.DatabaseDSN)
+	})
+
+	t.Run("reads Postgres settings from environment", func(t *testing.T) {
+		t.Setenv("DATABASE_DRIVER", "postgres")
+		t.Setenv("DATABASE_DSN", "postgres://wallabag@db:5432/wallabag")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "postgres", cfg.DatabaseDriver)
+		assert.Equal(t, "postgres://wallabag@db:5432/wallabag", cfg.DatabaseDSN)
+	})
+}
+
+func TestLoadAppConfig_FeedCrossHostRedirect(t *testing.T) {
+	t.Run("defaults to following", func(t *testing.T) {
+		t.Setenv("FEED_FOLLOW_CROSSHOST_REDIRECT", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.FeedCrossHost)
+	})
+
+	t.Run("can be turned off", func(t *testing.T) {
+		t.Setenv("FEED_FOLLOW_CROSSHOST_REDIRECT", "false")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.FeedCrossHost)
+	})
+}
+
+func TestLoadAppConfig_SaveOnWallabagFailure(t *testing.T) {
+	t.Run("defaults to off", func(t *testing.T) {
+		t.Setenv("SAVE_ON_WALLABAG_FAILURE", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.SaveOnFailure)
+	})
+
+	t.Run("can be turned on", func(t *testing.T) {
+		t.Setenv("SAVE_ON_WALLABAG_FAILURE", "true")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.SaveOnFailure)
+	})
+}
+
+func TestLoadAppConfig_EnrichConcurrency(t *testing.T) {
+	t.Run("defaults to two", func(t *testing.T) {
+		t.Setenv("FEED_ENRICH_CONCURRENCY", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 2, cfg.EnrichWorkers)
+	})
+
+	t.Run("reads from environment", func(t *testing.T) {
+		t.Setenv("FEED_ENRICH_CONCURRENCY", "4")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 4, cfg.EnrichWorkers)
+	})
+}
+
+func TestLoadAppConfig_SecurityHeaders(t *testing.T) {
+	t.Run("defaults to none", func(t *testing.T) {
+		t.Setenv("HSTS_MAX_AGE", "")
+		t.Setenv("HSTS_INCLUDE_SUBDOMAINS", "")
+		t.Setenv("PERMISSIONS_POLICY", "")
+		t.Setenv("CROSS_ORIGIN_OPENER_POLICY", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.HSTSMaxAge)
+		assert.False(t, cfg.HSTSSubdomains)
+		assert.Empty(t, cfg.PermissionPolicy)
+		assert.Empty(t, cfg.OpenerPolicy)
+	})
+
+	t.Run("reads headers from environment", func(t *testing.T) {
+		t.Setenv("HSTS_MAX_AGE", "31536000")
+		t.Setenv("HSTS_INCLUDE_SUBDOMAINS", "true")
+		t.Setenv("PERMISSIONS_POLICY", "geolocation=()")
+		t.Setenv("CROSS_ORIGIN_OPENER_POLICY", "same-origin")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 31536000, cfg.HSTSMaxAge)
+		assert.True(t, cfg.HSTSSubdomains)
+		assert.Equal(t, "geolocation=()", cfg.PermissionPolicy)
+		assert.Equal(t, "same-origin", cfg.OpenerPolicy)
+	})
+}
+
+func TestLoadAppConfig_SendDebounce(t *testing.T) {
+	t.Run("defaults to sending at once", func(t *testing.T) {
+		t.Setenv("SEND_DEBOUNCE", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.SendDebounce)
+	})
+
+	t.Run("reads window from environment", func(t *testing.T) {
+		t.Setenv("SEND_DEBOUNCE", "2m")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, cfg.SendDebounce)
+	})
+}
+
+func TestLoadAppConfig_OptimizeInterval(t *testing.T) {
+	t.Run("defaults to never optimizing", func(t *testing.T) {
+		t.Setenv("DB_OPTIMIZE_INTERVAL", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.OptimizeInterval)
+	})
+
+	t.Run("reads interval from environment", func(t *testing.T) {
+		t.Setenv("DB_OPTIMIZE_INTERVAL", "168h")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 168*time.Hour, cfg.OptimizeInterval)
+	})
+}
+
+func TestLoadAppConfig_RejectPauseAfter(t *testing.T) {
+	t.Run("defaults to never pausing", func(t *testing.T) {
+		t.Setenv("PAUSE_AFTER_WALLABAG_REJECTIONS", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.RejectPauseAfter)
+	})
+
+	t.Run("reads threshold from environment", func(t *testing.T) {
+		t.Setenv("PAUSE_AFTER_WALLABAG_REJECTIONS", "5")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 5, cfg.RejectPauseAfter)
+	})
+}
+
+func TestLoadAppConfig_DedupWindowDays(t *testing.T) {
+	t.Run("defaults to remembering articles forever", func(t *testing.T) {
+		t.Setenv("DEDUP_WINDOW_DAYS", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.DedupWindowDays)
+	})
+
+	t.Run("reads window from environment", func(t *testing.T) {
+		t.Setenv("DEDUP_WINDOW_DAYS", "365")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 365, cfg.DedupWindowDays)
+	})
+}
+
+func TestLoadAppConfig_FallbackTag(t *testing.T) {
+	t.Run("defaults to no fallback", func(t *testing.T) {
+		t.Setenv("FALLBACK_TAG", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.FallbackTag)
+	})
+
+	t.Run("reads tag from environment", func(t *testing.T) {
+		t.Setenv("FALLBACK_TAG", "untagged")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "untagged", cfg.FallbackTag)
+	})
+}
+
+func TestLoadAppConfig_EnforceFeedTTL(t *testing.T) {
+	t.Run("defaults to warning only", func(t *testing.T) {
+		t.Setenv("ENFORCE_FEED_TTL", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.EnforceFeedTTL)
+	})
+
+	t.Run("reads setting from environment", func(t *testing.T) {
+		t.Setenv("ENFORCE_FEED_TTL", "true")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.EnforceFeedTTL)
+	})
+}
+
+func TestLoadAppConfig_PageQueries(t *testing.T) {
+	t.Run("defaults to every query at once", func(t *testing.T) {
+		t.Setenv("ARTICLES_PAGE_CONCURRENCY", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.PageQueries)
+	})
+
+	t.Run("reads concurrency from environment", func(t *testing.T) {
+		t.Setenv("ARTICLES_PAGE_CONCURRENCY", "1")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 1, cfg.PageQueries)
+	})
+}
+
+func TestLoadAppConfig_MinTitleLength(t *testing.T) {
+	t.Run("defaults to keeping every title", func(t *testing.T) {
+		t.Setenv("MIN_TITLE_LENGTH", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 0, cfg.MinTitleLength)
+	})
+
+	t.Run("reads minimum from environment", func(t *testing.T) {
+		t.Setenv("MIN_TITLE_LENGTH", "3")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.MinTitleLength)
+	})
+}
+
+func TestLoadAppConfig_ExternalScripts(t *testing.T) {
+	t.Run("defaults to inline scripts", func(t *testing.T) {
+		t.Setenv("EXTERNAL_SCRIPTS", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.ExternalScripts)
+	})
+
+	t.Run("reads option from environment", func(t *testing.T) {
+		t.Setenv("EXTERNAL_SCRIPTS", "true")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.ExternalScripts)
+	})
+}
+
+func TestLoadAppConfig_ShutdownTimeout(t *testing.T) {
+	t.Run("defaults to 30 seconds", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("reads duration from environment", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "2m")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, cfg.ShutdownTimeout)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+		_, err := config.LoadAppConfig()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadAppConfig_MaxBodyBytes(t *testing.T) {
+	t.Run("defaults to 1 MB", func(t *testing.T) {
+		t.Setenv("MAX_BODY_BYTES", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1<<20), cfg.MaxBodyBytes)
+	})
+
+	t.Run("reads limit from environment", func(t *testing.T) {
+		t.Setenv("MAX_BODY_BYTES", "4096")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, int64(4096), cfg.MaxBodyBytes)
+	})
+}
+
+func TestLoadAppConfig_FeedSOCKS5Proxy(t *testing.T) {
+	t.Run("defaults to direct connections", func(t *testing.T) {
+		t.Setenv("FEED_SOCKS5_PROXY", "")
+		t.Setenv("WALLABAG_USE_SOCKS5_PROXY", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.FeedSOCKS5Proxy)
+		assert.False(t, cfg.WallabagUseProxy)
+	})
+
+	t.Run("reads proxy from environment", func(t *testing.T) {
+		t.Setenv("FEED_SOCKS5_PROXY", "127.0.0.1:9050")
+		t.Setenv("WALLABAG_USE_SOCKS5_PROXY", "true")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:9050", cfg.FeedSOCKS5Proxy)
+		assert.True(t, cfg.WallabagUseProxy)
+	})
+}
+
+func TestReloadAppConfig(t *testing.T) {
+	t.Run("reads settings from the .env file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("MAX_SENDS_PER_CYCLE", "")
+		os.Unsetenv("MAX_SENDS_PER_CYCLE")
+		require.NoError(t, os.WriteFile(".env", []byte("MAX_SENDS_PER_CYCLE=7\n"), 0o600))
+
+		cfg, err := config.ReloadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 7, cfg.MaxSendsPerCycle)
+	})
+
+	t.Run("process environment takes precedence over the file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("MAX_SENDS_PER_CYCLE", "3")
+		require.NoError(t, os.WriteFile(".env", []byte("MAX_SENDS_PER_CYCLE=7\n"), 0o600))
+
+		cfg, err := config.ReloadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.MaxSendsPerCycle)
+	})
+
+	t.Run("missing file uses the process environment", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("MAX_SENDS_PER_CYCLE", "4")
+
+		cfg, err := config.ReloadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 4, cfg.MaxSendsPerCycle)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		t.Setenv("MAX_SENDS_PER_CYCLE", "")
+		os.Unsetenv("MAX_SENDS_PER_CYCLE")
+		require.NoError(t, os.WriteFile(".env", []byte("MAX_SENDS_PER_CYCLE=lots\n"), 0o600))
+
+		_, err := config.ReloadAppConfig()
+		assert.Error(t, err)
+	})
+}
+
+func TestAppConfig_ApplyReload(t *testing.T) {
+	current := &config.AppConfig{
+		ServerPort:       "8080",
+		MaxSendsPerCycle: 10,
+		ShutdownTimeout:  30 * time.Second,
+	}
+	reloaded := &config.AppConfig{
+		ServerPort:       "9090",
+		MaxSendsPerCycle: 2,
+		ShutdownTimeout:  time.Minute,
+		TagWithFeedName:  true,
+		WorkerStaleAfter: 2 * time.Hour,
+		SendConcurrency:  4,
+		SendDebounce:     time.Minute,
+		FallbackTag:      "untagged",
+		EnrichWorkers:    8,
+	}
+
+	applied, restartRequired := current.ApplyReload(reloaded)
+
+	assert.Equal(t, 2, applied.MaxSendsPerCycle)
+	assert.Equal(t, time.Minute, applied.ShutdownTimeout)
+	assert.True(t, applied.TagWithFeedName)
+	assert.Equal(t, 2*time.Hour, applied.WorkerStaleAfter)
+	assert.Equal(t, 4, applied.SendConcurrency)
+	assert.Equal(t, time.Minute, applied.SendDebounce)
+	assert.Equal(t, "untagged", applied.FallbackTag)
+	assert.Equal(t, "8080", applied.ServerPort, "restart-only settings keep their current value")
+	assert.Equal(t, 0, applied.EnrichWorkers, "worker settings fixed at start need a restart")
+	assert.Equal(t, []string{"SERVER_PORT", "FEED_ENRICH_CONCURRENCY"}, restartRequired)
+	assert.Equal(t, 10, current.MaxSendsPerCycle, "the current config is not modified")
+}
+
 func TestLoadEnvFile(t *testing.T) {
 	tests := []struct {
 		setup func() func()
@@ -648,4 +1125,21 @@ func TestLoadAppConfigErrorHandling(t *testing.T) {
 		assert.NotEmpty(t, cfg.DatabasePath)
 		assert.NotEmpty(t, cfg.ServerPort)
 	})
-}
\ No newline at end of file
+}
+func TestLoadAppConfig_LinkCheckInterval(t *testing.T) {
+	t.Run("defaults to never checking", func(t *testing.T) {
+		t.Setenv("FEED_LINK_CHECK_INTERVAL", "")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.LinkCheckEvery)
+	})
+
+	t.Run("reads interval from environment", func(t *testing.T) {
+		t.Setenv("FEED_LINK_CHECK_INTERVAL", "24h")
+
+		cfg, err := config.LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, cfg.LinkCheckEvery)
+	})
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+
+	env "github.com/caarlos0/env/v11"
+	"github.com/joho/godotenv"
+)
+
+// hotReloadable lists, by environment variable, the AppConfig settings that ApplyReload takes
+// from a reloaded configuration. Every other setting only changes on restart.
+var hotReloadable = map[string]bool{
+	"MAX_SENDS_PER_CYCLE":             true,
+	"TAG_WITH_FEED_NAME":              true,
+	"WORKER_STALE_AFTER":              true,
+	"SHUTDOWN_TIMEOUT":                true,
+	"CONTENT_SANITIZE_POLICY":         true,
+	"WALLABAG_CHECK_EXISTING":         true,
+	"SAVE_ON_WALLABAG_FAILURE":        true,
+	"CROSS_FEED_DEDUP":                true,
+	"CATEGORY_TAG_PREFIX":             true,
+	"FALLBACK_TAG":                    true,
+	"SEND_CONCURRENCY":                true,
+	"SEND_DEBOUNCE":                   true,
+	"PAUSE_AFTER_WALLABAG_REJECTIONS": true,
+	"MIN_TITLE_LENGTH":                true,
+}
+
+// fileKeys records the variables LoadEnvFile took from the .env file rather than from the
+// process environment, so a reload knows which of them the file may replace.
+var fileKeys = map[string]bool{}
+
+// recordFileKeys notes the .env variables not already set in the process environment.
+func recordFileKeys() {
+	values, err := godotenv.Read()
+	if err != nil {
+		return
+	}
+
+	for key := range values {
+		if _, set := os.LookupEnv(key); !set {
+			fileKeys[key] = true
+		}
+	}
+}
+
+// ReloadAppConfig re-reads the .env file and returns the application configuration it
+// yields. As at startup, variables set in the process environment take precedence over the
+// file. A missing .env file leaves only the process environment.
+func ReloadAppConfig() (*AppConfig, error) {
+	values, err := godotenv.Read()
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("godotenv.Read: %w", err)
+		}
+		values = map[string]string{}
+	}
+
+	environment := env.ToMap(os.Environ())
+	for key := range fileKeys {
+		if _, inFile := values[key]; !inFile {
+			delete(environment, key)
+		}
+	}
+	for key, value := range values {
+		if _, set := environment[key]; !set || fileKeys[key] {
+			environment[key] = value
+		}
+	}
+
+	var cfg AppConfig
+	if err := env.ParseWithOptions(&cfg, env.Options{Environment: environment}); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// ApplyReload returns a copy of c with the hot-reloadable settings taken from reloaded, and
+// the environment variable names of restart-only settings whose values differ.
+func (c *AppConfig) ApplyReload(reloaded *AppConfig) (*AppConfig, []string) {
+	applied := *c
+	current := reflect.ValueOf(&applied).Elem()
+	next := reflect.ValueOf(reloaded).Elem()
+
+	var restartRequired []string
+	for i := range current.NumField() {
+		name, _, _ := strings.Cut(current.Type().Field(i).Tag.Get("env"), ",")
+		switch {
+		case hotReloadable[name]:
+			current.Field(i).Set(next.Field(i))
+		case !reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()):
+			restartRequired = append(restartRequired, name)
+		}
+	}
+
+	return &applied, restartRequired
+}
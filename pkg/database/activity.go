@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// GetRecentSentArticles returns up to limit of the articles most recently sent to Wallabag,
+// newest first, with their feed's name. Articles of deleted feeds are included without one.
+func (s *SQLStore) GetRecentSentArticles(ctx context.Context, limit int) ([]models.RecentArticle, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT articles.id, articles.feed_id, articles.title, articles.url, articles.created_at,
+			COALESCE(feeds.name, '')
+		FROM articles LEFT JOIN feeds ON feeds.id = articles.feed_id
+		WHERE articles.wallabag_entry_id IS NOT NULL
+		ORDER BY articles.created_at DESC, articles.id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent articles: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logging.Error("Failed to close recent article rows", "error", err)
+		}
+	}()
+
+	var articles []models.RecentArticle
+	for rows.Next() {
+		var article models.RecentArticle
+		if err := rows.Scan(&article.ID, &article.FeedID, &article.Title, &article.URL, &article.SentAt, &article.FeedName); err != nil {
+			return nil, fmt.Errorf("failed to scan recent article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent articles: %w", err)
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,65 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestSQLStore_GetRecentSentArticles(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	t.Run("Empty database", func(t *testing.T) {
+		articles, err := store.GetRecentSentArticles(ctx, 5)
+		require.NoError(t, err)
+		assert.Empty(t, articles)
+	})
+
+	blogID, err := store.InsertFeed(ctx, &models.Feed{Name: "Blog", URL: "https://blog.example.com/feed"})
+	require.NoError(t, err)
+	newsID, err := store.InsertFeed(ctx, &models.Feed{Name: "News", URL: "https://news.example.com/feed"})
+	require.NoError(t, err)
+
+	save := func(feedID int64, articleURL string, entryID int) {
+		require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Title of " + articleURL, URL: articleURL}, entryID))
+	}
+	save(blogID, "https://blog.example.com/first", 1)
+	save(newsID, "https://news.example.com/second", 2)
+	save(blogID, "https://blog.example.com/third", 3)
+	// Neither filtered nor unsent articles reached Wallabag
+	require.NoError(t, store.SaveFilteredArticle(ctx, int(blogID), &models.Article{Title: "Filtered", URL: "https://blog.example.com/filtered"}))
+	require.NoError(t, store.SaveFailedArticle(ctx, int(newsID), &models.Article{Title: "Unsent", URL: "https://news.example.com/unsent"}))
+
+	t.Run("Newest sent articles first, with feed names", func(t *testing.T) {
+		articles, err := store.GetRecentSentArticles(ctx, 2)
+		require.NoError(t, err)
+
+		require.Len(t, articles, 2)
+		assert.Equal(t, "https://blog.example.com/third", articles[0].URL)
+		assert.Equal(t, "Title of https://blog.example.com/third", articles[0].Title)
+		assert.Equal(t, "Blog", articles[0].FeedName)
+		assert.Equal(t, int(blogID), articles[0].FeedID)
+		assert.False(t, articles[0].SentAt.IsZero())
+		assert.Equal(t, "https://news.example.com/second", articles[1].URL)
+		assert.Equal(t, "News", articles[1].FeedName)
+	})
+
+	t.Run("Articles of deleted feeds have no feed name", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM feeds WHERE id = ?", newsID)
+		require.NoError(t, err)
+
+		articles, err := store.GetRecentSentArticles(ctx, 5)
+		require.NoError(t, err)
+
+		require.Len(t, articles, 3)
+		assert.Equal(t, "https://news.example.com/second", articles[1].URL)
+		assert.Empty(t, articles[1].FeedName)
+	})
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// AppendAuditEntry records entry in the audit log. A zero CreatedAt means now and a zero
+// FeedID is stored as no feed.
+func (s *SQLStore) AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	var createdAt any
+	if !entry.CreatedAt.IsZero() {
+		createdAt = entry.CreatedAt.UTC()
+	}
+
+	var feedID any
+	if entry.FeedID != 0 {
+		feedID = entry.FeedID
+	}
+
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx,
+			"INSERT INTO audit_log (created_at, action, feed_id, detail) VALUES (COALESCE(?, CURRENT_TIMESTAMP), ?, ?, ?)",
+			createdAt, string(entry.Action), feedID, entry.Detail)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuditEntries retrieves up to limit audit entries matching filter, newest first, skipping
+// the first offset, along with the total number of matching entries.
+func (s *SQLStore) GetAuditEntries(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, int, error) {
+	var conditions []string
+	var args []any
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, string(filter.Action))
+	}
+	if filter.FeedID != 0 {
+		conditions = append(conditions, "feed_id = ?")
+		args = append(args, filter.FeedID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, created_at, action, COALESCE(feed_id, 0), COALESCE(detail, '') FROM audit_log"+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit entries: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logging.Error("Failed to close audit rows", "error", err)
+		}
+	}()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		var createdAt sql.NullTime
+		if err := rows.Scan(&entry.ID, &createdAt, &entry.Action, &entry.FeedID, &entry.Detail); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit row: %w", err)
+		}
+		entry.CreatedAt = createdAt.Time
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over audit rows: %w", err)
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestSQLStore_AuditLog(t *testing.T) {
+	ctx := context.Background()
+	// The schema file is read relative to the project root
+	dbPath := filepath.Join(t.TempDir(), "audit.db")
+	t.Chdir("../../")
+	db, err := database.InitDBWithPath(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	store := database.NewSQLStore(db)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		entry := &models.AuditEntry{
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+			Action:    models.AuditArticleSent,
+			FeedID:    1,
+			Detail:    fmt.Sprintf("https://example.com/%d", i),
+		}
+		require.NoError(t, store.AppendAuditEntry(ctx, entry))
+	}
+	require.NoError(t, store.AppendAuditEntry(ctx, &models.AuditEntry{Action: models.AuditArticleFailed, FeedID: 2, Detail: "boom"}))
+	require.NoError(t, store.AppendAuditEntry(ctx, &models.AuditEntry{Action: models.AuditSyncRun, Detail: "Completed a polling cycle over 2 feeds"}))
+
+	t.Run("Pages newest first", func(t *testing.T) {
+		entries, total, err := store.GetAuditEntries(ctx, models.AuditFilter{}, 3, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 7, total)
+		require.Len(t, entries, 3)
+		assert.Equal(t, models.AuditSyncRun, entries[0].Action)
+		assert.Zero(t, entries[0].FeedID)
+		assert.False(t, entries[0].CreatedAt.IsZero(), "a zero time is stored as now")
+		assert.Equal(t, models.AuditArticleFailed, entries[1].Action)
+		assert.Equal(t, "https://example.com/5", entries[2].Detail)
+		assert.True(t, entries[2].CreatedAt.Equal(start.Add(5*time.Minute)))
+
+		entries, _, err = store.GetAuditEntries(ctx, models.AuditFilter{}, 3, 6)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "https://example.com/1", entries[0].Detail)
+	})
+
+	t.Run("Filters by feed", func(t *testing.T) {
+		entries, total, err := store.GetAuditEntries(ctx, models.AuditFilter{FeedID: 1}, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "https://example.com/3", entries[0].Detail)
+		assert.Equal(t, "https://example.com/2", entries[1].Detail)
+		for _, entry := range entries {
+			assert.Equal(t, 1, entry.FeedID)
+		}
+	})
+
+	t.Run("Filters by action and feed", func(t *testing.T) {
+		entries, total, err := store.GetAuditEntries(ctx, models.AuditFilter{Action: models.AuditArticleFailed, FeedID: 2}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "boom", entries[0].Detail)
+
+		entries, total, err = store.GetAuditEntries(ctx, models.AuditFilter{Action: models.AuditArticleFailed, FeedID: 1}, 10, 0)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, entries)
+	})
+}
@@ -1,4 +1,5 @@
-// Package database provides SQLite database initialization, schema management and path validation.
+// Package database provides SQLite and Postgres database initialization, schema management and
+// path validation.
 package database
 
 import (
@@ -11,6 +12,7 @@ import (
 
 	_ "modernc.org/sqlite"
 	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
 )
 
 const schemaPath = "./db/schema.sql"
@@ -22,6 +24,24 @@ func InitDB() (*sql.DB, error) {
 
 // InitDBWithPath initializes the SQLite database with a custom path and applies migrations.
 func InitDBWithPath(dbPath string) (*sql.DB, error) {
+	db, err := OpenDBWithPath(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ApplyMigrations(db); err != nil {
+		return nil, fmt.Errorf("applyMigrations failed: %w", err)
+	}
+
+	logging.Info("Database initialized successfully", "db_path", dbPath)
+
+	return db, nil
+}
+
+// OpenDBWithPath opens the SQLite database at dbPath, creating it if needed, and applies the
+// schema but not migrations. Callers run ApplyMigrations themselves, e.g. while the web server
+// shows its maintenance page.
+func OpenDBWithPath(dbPath string) (*sql.DB, error) {
 	// Validate and sanitize database path
 	if err := ValidateDatabasePath(dbPath); err != nil {
 		return nil, fmt.Errorf("invalid database path: %w", err)
@@ -48,16 +68,19 @@ func InitDBWithPath(dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("applySchema failed: %w", err)
 	}
 
-	logging.Info("Database initialized successfully", "db_path", dbPath)
-
 	return db, nil
 }
 
-// ApplySchema reads the schema.sql file and executes its contents.
+// ApplySchema reads the schema file for db's driver and executes its contents.
 func ApplySchema(db *sql.DB) error {
-	schema, err := os.ReadFile(schemaPath)
+	path := schemaPath
+	if isPostgres(db) {
+		path = postgresSchemaPath
+	}
+
+	schema, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("os.ReadFile failed for schema file %s: %w", schemaPath, err)
+		return fmt.Errorf("os.ReadFile failed for schema file %s: %w", path, err)
 	}
 
 	_, err = db.Exec(string(schema))
@@ -68,6 +91,198 @@ func ApplySchema(db *sql.DB) error {
 	return nil
 }
 
+// columnMigration describes a column added to an existing table after the original schema.
+// backfill, if set, runs once right after the column is added to fill it in for existing rows.
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+	backfill   string
+}
+
+// columnMigrations lists columns added since the original schema. New databases get them from
+// schema.sql, existing databases are upgraded in place by ApplyMigrations.
+var columnMigrations = []columnMigration{
+	{table: "feeds", column: "disabled", definition: "BOOLEAN DEFAULT 0"},
+	{table: "feeds", column: "last_build_date", definition: "DATETIME"},
+	{table: "feeds", column: "fetch_timeout_seconds", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "strip_query_params", definition: "BOOLEAN DEFAULT 0"},
+	{table: "feeds", column: "auto_interval", definition: "BOOLEAN DEFAULT 0"},
+	{table: "feeds", column: "auto_interval_minutes", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "content_selector", definition: "TEXT"},
+	{table: "feeds", column: "tag_with_feed_name", definition: "BOOLEAN DEFAULT 0"},
+	{table: "feeds", column: "accept_header", definition: "TEXT"},
+	{table: "articles", column: "original_url", definition: "TEXT"},
+	{table: "feeds", column: "include_categories", definition: "TEXT"},
+	{table: "articles", column: "filtered", definition: "BOOLEAN DEFAULT 0"},
+	{table: "articles", column: "feed_url", definition: "TEXT"},
+	{table: "feeds", column: "min_age_minutes", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "cookie", definition: "TEXT"},
+	{table: "articles", column: "marked_processed", definition: "BOOLEAN DEFAULT 0"},
+	{table: "feeds", column: "favicon_url", definition: "TEXT"},
+	{table: "feeds", column: "favicon_checked_at", definition: "DATETIME"},
+	{table: "feeds", column: "keep_last_n", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "priority", definition: "INTEGER DEFAULT 0"},
+	{table: "articles", column: "normalized_url", definition: "TEXT"},
+	{table: "feeds", column: "categories_as_tags", definition: "BOOLEAN DEFAULT 0"},
+	{table: "articles", column: "clicks", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "snoozed_until", definition: "DATETIME"},
+	{table: "feeds", column: "content_kind", definition: "TEXT"},
+	// Feeds with a content selector already had their content extracted, falling back to the link
+	{
+		table: "feeds", column: "delivery_mode", definition: "TEXT DEFAULT 'link'",
+		backfill: "UPDATE feeds SET delivery_mode = 'content_or_link' WHERE COALESCE(content_selector, '') != ''",
+	},
+	{table: "feeds", column: "date_field", definition: "TEXT DEFAULT 'auto'"},
+	{table: "feeds", column: "disabled_reason", definition: "TEXT"},
+	{table: "feeds", column: "ttl_minutes", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "min_title_length", definition: "INTEGER DEFAULT 0"},
+}
+
+// indexMigrations create indexes on migrated columns. They run after columnMigrations rather
+// than from schema.sql, where an older database would not have the column yet.
+var indexMigrations = []string{
+	"CREATE INDEX IF NOT EXISTS idx_articles_normalized_url ON articles(normalized_url)",
+}
+
+// ApplyMigrations adds any columns and indexes missing from a database created with an older
+// schema, and fills in normalized URLs for articles recorded before they were stored.
+func ApplyMigrations(db *sql.DB) error {
+	for _, migration := range columnMigrations {
+		exists, err := columnExists(db, migration.table, migration.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		definition := migration.definition
+		if isPostgres(db) {
+			definition = postgresColumnDefinition(definition)
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", migration.table, migration.column, definition)
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", migration.table, migration.column, err)
+		}
+		if migration.backfill != "" {
+			if _, err := db.Exec(migration.backfill); err != nil {
+				return fmt.Errorf("failed to fill in column %s.%s: %w", migration.table, migration.column, err)
+			}
+		}
+		logging.Info("Applied database migration", "table", migration.table, "column", migration.column)
+	}
+
+	for _, stmt := range indexMigrations {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	return backfillNormalizedURLs(db)
+}
+
+// backfillNormalizedURLs sets normalized_url on articles saved before the column existed, so
+// cross-feed deduplication also sees them
+func backfillNormalizedURLs(db *sql.DB) error {
+	rows, err := db.Query("SELECT id, url FROM articles WHERE normalized_url IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to read articles without normalized URLs: %w", err)
+	}
+
+	normalized := make(map[int]string)
+	for rows.Next() {
+		var (
+			id         int
+			articleURL string
+		)
+		if err := rows.Scan(&id, &articleURL); err != nil {
+			rows.Close()
+
+			return fmt.Errorf("failed to scan article URL: %w", err)
+		}
+		normalized[id] = models.NormalizeArticleURL(articleURL)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+
+		return fmt.Errorf("error iterating articles without normalized URLs: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to close article rows: %w", err)
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin normalized URL backfill: %w", err)
+	}
+	for id, normalizedURL := range normalized {
+		if _, err := tx.Exec("UPDATE articles SET normalized_url = ? WHERE id = ?", normalizedURL, id); err != nil {
+			_ = tx.Rollback()
+
+			return fmt.Errorf("failed to backfill normalized URL of article %d: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit normalized URL backfill: %w", err)
+	}
+	logging.Info("Backfilled normalized article URLs", "articles", len(normalized))
+
+	return nil
+}
+
+// columnExists reports whether the given table already has the named column.
+func columnExists(db *sql.DB, table, column string) (bool, error) {
+	if isPostgres(db) {
+		var exists bool
+		err := db.QueryRow(`SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?
+		)`, table, column).Scan(&exists)
+		if err != nil {
+			return false, fmt.Errorf("failed to read table info for %s: %w", table, err)
+		}
+
+		return exists, nil
+	}
+
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("failed to read table info for %s: %w", table, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logging.Error("Failed to close table info rows", "error", err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name         string
+			columnType   string
+			notNull      bool
+			defaultValue sql.NullString
+			primaryKey   int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return false, fmt.Errorf("failed to scan table info for %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("error iterating table info for %s: %w", table, err)
+	}
+
+	return false, nil
+}
+
 // CloseDB closes the database connection.
 func CloseDB(db *sql.DB) {
 	if db != nil {
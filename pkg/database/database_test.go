@@ -255,6 +255,134 @@ func TestApplySchema(t *testing.T) {
 	}
 }
 
+func TestApplyMigrations(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wallabag_migrations_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	db, err := sql.Open("sqlite", filepath.Join(tempDir, "test.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Tables as created by the original schema, before any column additions
+	_, err = db.Exec(`
+		CREATE TABLE feeds (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL
+		);
+		CREATE TABLE articles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			feed_id INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			url TEXT NOT NULL UNIQUE
+		);
+		CREATE TABLE settings (
+			key TEXT PRIMARY KEY,
+			value TEXT
+		);
+	`)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO feeds (url, name) VALUES ('https://example.com/feed', 'Existing')")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO articles (feed_id, title, url) VALUES (1, 'Old', 'https://www.example.com/old/?utm_source=rss')")
+	assert.NoError(t, err)
+
+	t.Run("adds missing columns with defaults", func(t *testing.T) {
+		err := database.ApplyMigrations(db)
+		assert.NoError(t, err)
+
+		var disabled bool
+		err = db.QueryRow("SELECT disabled FROM feeds WHERE name = 'Existing'").Scan(&disabled)
+		assert.NoError(t, err)
+		assert.False(t, disabled)
+	})
+
+	t.Run("backfills normalized article URLs", func(t *testing.T) {
+		var normalized string
+		err := db.QueryRow("SELECT normalized_url FROM articles WHERE title = 'Old'").Scan(&normalized)
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com/old", normalized)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		err := database.ApplyMigrations(db)
+		assert.NoError(t, err)
+	})
+}
+
+func TestApplyMigrations_DeliveryMode(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wallabag_migrations_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	db, err := sql.Open("sqlite", filepath.Join(tempDir, "test.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Tables from before delivery modes, when a content selector alone turned on extraction
+	_, err = db.Exec(`
+		CREATE TABLE feeds (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			content_selector TEXT
+		);
+		CREATE TABLE articles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			feed_id INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			url TEXT NOT NULL UNIQUE
+		);
+		INSERT INTO feeds (url, name, content_selector) VALUES
+			('https://example.com/plain', 'Plain', NULL),
+			('https://example.com/blank', 'Blank', ''),
+			('https://example.com/extracted', 'Extracted', 'article');
+	`)
+	assert.NoError(t, err)
+
+	err = database.ApplyMigrations(db)
+	assert.NoError(t, err)
+
+	modes := make(map[string]string)
+	rows, err := db.Query("SELECT name, delivery_mode FROM feeds")
+	assert.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var name, mode string
+		assert.NoError(t, rows.Scan(&name, &mode))
+		modes[name] = mode
+	}
+	assert.NoError(t, rows.Err())
+
+	assert.Equal(t, map[string]string{"Plain": "link", "Blank": "link", "Extracted": "content_or_link"}, modes)
+}
+
+func TestOpenDB(t *testing.T) {
+	t.Run("Unsupported driver", func(t *testing.T) {
+		db, err := database.OpenDB("mysql", "test.db", "")
+		assert.ErrorContains(t, err, `unsupported database driver "mysql"`)
+		assert.Nil(t, db)
+	})
+
+	t.Run("Postgres requires a DSN", func(t *testing.T) {
+		db, err := database.OpenDB(database.DriverPostgres, "", "")
+		assert.ErrorContains(t, err, "DATABASE_DSN must be set")
+		assert.Nil(t, db)
+	})
+
+	t.Run("Postgres connection failure", func(t *testing.T) {
+		db, err := database.OpenDB(database.DriverPostgres, "", "postgres://user@127.0.0.1:1/wallabag?connect_timeout=1")
+		assert.ErrorContains(t, err, "failed to connect to database")
+		assert.Nil(t, db)
+	})
+
+	t.Run("SQLite uses the path", func(t *testing.T) {
+		_, err := database.OpenDB(database.DriverSQLite, "/etc/wallabag.db", "")
+		assert.ErrorContains(t, err, "invalid database path")
+	})
+}
+
 func TestCloseDB(t *testing.T) {
 	tests := []struct {
 		setupDB func() *sql.DB
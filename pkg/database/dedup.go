@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqliteTimestampLayout is how SQLite's CURRENT_TIMESTAMP writes times: UTC text that sorts
+// in time order.
+const sqliteTimestampLayout = "2006-01-02 15:04:05"
+
+// SetDedupWindow limits how long a recorded article counts as processed. Articles recorded
+// longer ago than window are treated as new, so an old URL that reappears in a feed is sent
+// again and its record replaced. Zero, the default, remembers every article for good.
+func (s *SQLStore) SetDedupWindow(window time.Duration) {
+	s.dedupWindow = window
+}
+
+// dedupCondition returns the SQL condition, and its arguments, that limits a query to articles
+// recorded within the dedup window, or "" when there is no window
+func (s *SQLStore) dedupCondition() (string, []any) {
+	if s.dedupWindow <= 0 {
+		return "", nil
+	}
+
+	return " AND created_at >= ?", []any{s.dedupCutoff()}
+}
+
+// dedupCutoff returns when the dedup window starts, in a form comparable with created_at
+func (s *SQLStore) dedupCutoff() any {
+	return s.timestampArg(time.Now().Add(-s.dedupWindow))
+}
+
+// timestampArg returns t as a query argument comparable with a CURRENT_TIMESTAMP column
+func (s *SQLStore) timestampArg(t time.Time) any {
+	if s.postgres {
+		return t.UTC()
+	}
+
+	return t.UTC().Format(sqliteTimestampLayout)
+}
+
+// forgetExpiredArticle deletes the record of articleURL if it is older than the dedup window,
+// so the article can be recorded again now that it has been sent again.
+func (s *SQLStore) forgetExpiredArticle(ctx context.Context, articleURL string) error {
+	if s.dedupWindow <= 0 {
+		return nil
+	}
+
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "DELETE FROM articles WHERE url = ? AND created_at < ?", articleURL, s.dedupCutoff())
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete expired article: %w", err)
+	}
+
+	return nil
+}
+
+// PruneExpiredArticles deletes every article recorded longer ago than the dedup window and
+// returns how many were deleted. Those records no longer count as processed, so deleting them
+// does not change what gets sent. Without a window nothing is deleted.
+func (s *SQLStore) PruneExpiredArticles(ctx context.Context) (int64, error) {
+	if s.dedupWindow <= 0 {
+		return 0, nil
+	}
+
+	var res sql.Result
+	err := retryOnLock(func() error {
+		var execErr error
+		res, execErr = s.db.ExecContext(ctx, "DELETE FROM articles WHERE created_at < ?", s.dedupCutoff())
+
+		return execErr
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune expired articles: %w", err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pruned article count: %w", err)
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,95 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestSQLStore_DedupWindow(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	store.SetDedupWindow(30 * 24 * time.Hour)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	const (
+		recentURL = "https://example.com/recent"
+		oldURL    = "https://www.example.com/old?utm_source=rss"
+	)
+	require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Recent", URL: recentURL}, 1))
+	require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Old", URL: oldURL}, 2))
+	_, err = db.Exec("UPDATE articles SET created_at = ? WHERE url = ?", "2020-01-01 00:00:00", oldURL)
+	require.NoError(t, err)
+
+	t.Run("Recent article is processed", func(t *testing.T) {
+		processed, err := store.IsArticleAlreadyProcessed(ctx, recentURL)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		similar, err := store.IsSimilarArticleProcessed(ctx, "https://example.com/recent/")
+		require.NoError(t, err)
+		assert.True(t, similar)
+	})
+
+	t.Run("Article older than the window is not", func(t *testing.T) {
+		processed, err := store.IsArticleAlreadyProcessed(ctx, oldURL)
+		require.NoError(t, err)
+		assert.False(t, processed)
+
+		similar, err := store.IsSimilarArticleProcessed(ctx, "https://example.com/old")
+		require.NoError(t, err)
+		assert.False(t, similar)
+	})
+
+	t.Run("Sending it again replaces the expired record", func(t *testing.T) {
+		require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Old, again", URL: oldURL}, 3))
+
+		processed, err := store.IsArticleAlreadyProcessed(ctx, oldURL)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		articles, err := store.GetFeedArticles(ctx, int(feedID))
+		require.NoError(t, err)
+		assert.Len(t, articles, 2)
+	})
+
+	t.Run("Pruning deletes only articles older than the window", func(t *testing.T) {
+		const expiredURL = "https://example.com/expired"
+		require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Expired", URL: expiredURL}, 4))
+		_, err := db.Exec("UPDATE articles SET created_at = ? WHERE url = ?", "2020-01-01 00:00:00", expiredURL)
+		require.NoError(t, err)
+
+		unpruned, err := database.NewSQLStore(db).PruneExpiredArticles(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, unpruned, "without a window nothing is pruned")
+
+		pruned, err := store.PruneExpiredArticles(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), pruned)
+
+		articles, err := store.GetFeedArticles(ctx, int(feedID))
+		require.NoError(t, err)
+		require.Len(t, articles, 2)
+		for _, article := range articles {
+			assert.NotEqual(t, expiredURL, article.URL)
+		}
+	})
+
+	t.Run("Without a window every article is remembered", func(t *testing.T) {
+		_, err = db.Exec("UPDATE articles SET created_at = ? WHERE url = ?", "2020-01-01 00:00:00", recentURL)
+		require.NoError(t, err)
+
+		processed, err := database.NewSQLStore(db).IsArticleAlreadyProcessed(ctx, recentURL)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	})
+}
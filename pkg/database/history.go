@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// historyColumns is articleColumns qualified for a query joining feeds, followed by the feed name
+const historyColumns = "articles.id, articles.feed_id, articles.title, articles.url, articles.wallabag_entry_id, articles.published_at, articles.created_at, articles.original_url, COALESCE(articles.filtered, 0), COALESCE(articles.feed_url, ''), COALESCE(articles.marked_processed, 0), COALESCE(articles.clicks, 0), COALESCE(feeds.name, '')"
+
+// GetArticleHistory returns a page of recorded articles matching filter, newest first, together
+// with the total number that match.
+func (s *SQLStore) GetArticleHistory(ctx context.Context, filter models.ArticleFilter, limit, offset int) ([]models.HistoryArticle, int, error) {
+	var conditions []string
+	var args []any
+	if filter.FeedID != 0 {
+		conditions = append(conditions, "articles.feed_id = ?")
+		args = append(args, filter.FeedID)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "articles.created_at >= ?")
+		args = append(args, s.timestampArg(*filter.Since))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM articles"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+historyColumns+" FROM articles LEFT JOIN feeds ON feeds.id = articles.feed_id"+where+
+			" ORDER BY articles.created_at DESC, articles.id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query article history: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logging.Error("Failed to close article history rows", "error", err)
+		}
+	}()
+
+	var articles []models.HistoryArticle
+	for rows.Next() {
+		var article models.HistoryArticle
+		if err := scanArticle(rows, &article.Article, &article.FeedName); err != nil {
+			return nil, 0, err
+		}
+		articles = append(articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over article history rows: %w", err)
+	}
+
+	return articles, total, nil
+}
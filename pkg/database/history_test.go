@@ -0,0 +1,79 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestSQLStore_GetArticleHistory(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	t.Run("Empty database", func(t *testing.T) {
+		articles, total, err := store.GetArticleHistory(ctx, models.ArticleFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, articles)
+		assert.Zero(t, total)
+	})
+
+	blogID, err := store.InsertFeed(ctx, &models.Feed{Name: "Blog", URL: "https://blog.example.com/feed"})
+	require.NoError(t, err)
+	newsID, err := store.InsertFeed(ctx, &models.Feed{Name: "News", URL: "https://news.example.com/feed"})
+	require.NoError(t, err)
+
+	// Each article is recorded a day after the previous one, starting 2024-01-01
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	save := func(day int, feedID int64, articleURL string, entryID int) {
+		require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Title of " + articleURL, URL: articleURL}, entryID))
+		_, err := db.Exec("UPDATE articles SET created_at = ? WHERE url = ?", start.AddDate(0, 0, day).Format("2006-01-02 15:04:05"), articleURL)
+		require.NoError(t, err)
+	}
+	save(0, blogID, "https://blog.example.com/first", 1)
+	save(1, newsID, "https://news.example.com/second", 2)
+	save(2, blogID, "https://blog.example.com/third", 3)
+	save(3, blogID, "https://blog.example.com/fourth", 4)
+
+	t.Run("Newest first, paged, with feed names and entry IDs", func(t *testing.T) {
+		articles, total, err := store.GetArticleHistory(ctx, models.ArticleFilter{}, 2, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, total)
+		require.Len(t, articles, 2)
+		assert.Equal(t, "https://blog.example.com/third", articles[0].URL)
+		assert.Equal(t, "Blog", articles[0].FeedName)
+		require.NotNil(t, articles[0].WallabagEntryID)
+		assert.Equal(t, 3, *articles[0].WallabagEntryID)
+		assert.Equal(t, "https://news.example.com/second", articles[1].URL)
+		assert.Equal(t, "News", articles[1].FeedName)
+	})
+
+	t.Run("Filtered by feed and since", func(t *testing.T) {
+		since := start.AddDate(0, 0, 1)
+		articles, total, err := store.GetArticleHistory(ctx, models.ArticleFilter{FeedID: int(blogID), Since: &since}, 10, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, total)
+		require.Len(t, articles, 2)
+		assert.Equal(t, "https://blog.example.com/fourth", articles[0].URL)
+		assert.Equal(t, "https://blog.example.com/third", articles[1].URL)
+	})
+
+	t.Run("Articles of deleted feeds have no feed name", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM feeds WHERE id = ?", newsID)
+		require.NoError(t, err)
+
+		articles, _, err := store.GetArticleHistory(ctx, models.ArticleFilter{FeedID: int(newsID)}, 10, 0)
+		require.NoError(t, err)
+
+		require.Len(t, articles, 1)
+		assert.Empty(t, articles[0].FeedName)
+	})
+}
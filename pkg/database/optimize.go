@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// Optimize compacts the database and refreshes the query planner's statistics: VACUUM and
+// PRAGMA optimize on SQLite, VACUUM ANALYZE on Postgres. SQLite only gives space freed by
+// deletes back to the file system through VACUUM. It rewrites the whole database, which
+// blocks writers until it finishes.
+func (s *SQLStore) Optimize(ctx context.Context) error {
+	statements := []string{"VACUUM", "PRAGMA optimize"}
+	if s.postgres {
+		statements = []string{"VACUUM ANALYZE"}
+	}
+
+	for _, statement := range statements {
+		err := retryOnLock(func() error {
+			_, execErr := s.db.ExecContext(ctx, statement)
+
+			return execErr
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run %s: %w", statement, err)
+		}
+	}
+
+	return nil
+}
+
+// DatabaseSize returns the size of the database in bytes: the pages of the SQLite file, or
+// the disk space of the Postgres database.
+func (s *SQLStore) DatabaseSize(ctx context.Context) (int64, error) {
+	query := "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+	if s.postgres {
+		query = "SELECT pg_database_size(current_database())"
+	}
+
+	var size int64
+	if err := s.db.QueryRowContext(ctx, query).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// feedOverlapsQuery pairs feeds by the normalized article URLs they both recorded, counting
+// each feed's distinct URLs alongside; articles of deleted feeds are left out
+const feedOverlapsQuery = `
+	WITH feed_urls AS (
+		SELECT DISTINCT articles.feed_id, articles.normalized_url
+		FROM articles JOIN feeds ON feeds.id = articles.feed_id
+		WHERE articles.normalized_url IS NOT NULL AND articles.normalized_url <> ''
+	), totals AS (
+		SELECT feed_id, COUNT(*) AS articles FROM feed_urls GROUP BY feed_id
+	)
+	SELECT a.feed_id, b.feed_id, COUNT(*), ta.articles, tb.articles
+	FROM feed_urls a
+	JOIN feed_urls b ON b.normalized_url = a.normalized_url AND b.feed_id > a.feed_id
+	JOIN totals ta ON ta.feed_id = a.feed_id
+	JOIN totals tb ON tb.feed_id = b.feed_id
+	GROUP BY a.feed_id, b.feed_id, ta.articles, tb.articles
+	HAVING COUNT(*) >= ?
+	ORDER BY COUNT(*) DESC, a.feed_id, b.feed_id`
+
+// GetFeedOverlaps returns the pairs of feeds that recorded at least minShared articles with the
+// same normalized URL, most shared first.
+func (s *SQLStore) GetFeedOverlaps(ctx context.Context, minShared int) ([]models.FeedOverlap, error) {
+	rows, err := s.db.QueryContext(ctx, feedOverlapsQuery, minShared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feed overlaps: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logging.Error("Failed to close feed overlap rows", "error", err)
+		}
+	}()
+
+	var overlaps []models.FeedOverlap
+	for rows.Next() {
+		var overlap models.FeedOverlap
+		if err := rows.Scan(&overlap.FeedID, &overlap.OtherFeedID, &overlap.Shared, &overlap.Articles, &overlap.OtherArticles); err != nil {
+			return nil, fmt.Errorf("failed to scan feed overlap: %w", err)
+		}
+		overlaps = append(overlaps, overlap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feed overlaps: %w", err)
+	}
+
+	return overlaps, nil
+}
@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestSQLStore_GetFeedOverlaps(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	insertFeed := func(name string) int {
+		id, err := store.InsertFeed(ctx, &models.Feed{Name: name, URL: "https://" + name + ".example.com/feed"})
+		require.NoError(t, err)
+
+		return int(id)
+	}
+	direct := insertFeed("direct")
+	tracked := insertFeed("tracked")
+	unrelated := insertFeed("unrelated")
+	aggregator := insertFeed("aggregator")
+
+	entryID := 0
+	save := func(feedID int, articleURL string) {
+		entryID++
+		require.NoError(t, store.SaveArticle(ctx, feedID, &models.Article{Title: articleURL, URL: articleURL}, entryID))
+	}
+	for i := 1; i <= 4; i++ {
+		save(direct, fmt.Sprintf("https://blog.example.com/post-%d", i))
+	}
+	// The same posts through a feed that adds tracking parameters, plus one of its own
+	for i := 1; i <= 3; i++ {
+		save(tracked, fmt.Sprintf("https://blog.example.com/post-%d?utm_source=rss", i))
+	}
+	save(tracked, "https://blog.example.com/extra")
+	for i := 1; i <= 3; i++ {
+		save(unrelated, fmt.Sprintf("https://other.example.com/story-%d", i))
+	}
+	save(aggregator, "http://www.blog.example.com/post-4/")
+	save(aggregator, "https://other.example.com/story-1#comments")
+
+	t.Run("Flags feeds sharing many normalized URLs", func(t *testing.T) {
+		overlaps, err := store.GetFeedOverlaps(ctx, 2)
+		require.NoError(t, err)
+
+		require.Len(t, overlaps, 1, "pairs sharing a single article are ignored")
+		assert.Equal(t, models.FeedOverlap{
+			FeedID: direct, OtherFeedID: tracked, Shared: 3, Articles: 4, OtherArticles: 4,
+		}, overlaps[0])
+		assert.Equal(t, 75, overlaps[0].Percent())
+	})
+
+	t.Run("A lower threshold includes smaller overlaps, most shared first", func(t *testing.T) {
+		overlaps, err := store.GetFeedOverlaps(ctx, 1)
+		require.NoError(t, err)
+
+		require.Len(t, overlaps, 3)
+		assert.Equal(t, 3, overlaps[0].Shared)
+		for _, overlap := range overlaps[1:] {
+			assert.Equal(t, 1, overlap.Shared)
+			assert.Equal(t, aggregator, overlap.OtherFeedID)
+		}
+	})
+
+	t.Run("Articles of deleted feeds are left out", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM feeds WHERE id = ?", tracked)
+		require.NoError(t, err)
+
+		overlaps, err := store.GetFeedOverlaps(ctx, 2)
+		require.NoError(t, err)
+		assert.Empty(t, overlaps)
+	})
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// Supported values of DATABASE_DRIVER.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// postgresDriverName is the database/sql name of the Postgres driver. It wraps pgx so the
+// store's SQL, written with SQLite's ? placeholders, runs unchanged on Postgres.
+const postgresDriverName = "wallabag-postgres"
+
+const postgresSchemaPath = "./db/schema_postgres.sql"
+
+func init() {
+	sql.Register(postgresDriverName, postgresDriver{})
+}
+
+// OpenDB opens the database selected by DATABASE_DRIVER and applies the schema but not
+// migrations. SQLite uses path; Postgres connects to dsn.
+func OpenDB(driverName, path, dsn string) (*sql.DB, error) {
+	switch driverName {
+	case "", DriverSQLite:
+		return OpenDBWithPath(path)
+	case DriverPostgres:
+		return OpenPostgres(dsn)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q (use %s or %s)", driverName, DriverSQLite, DriverPostgres)
+	}
+}
+
+// OpenPostgres connects to the Postgres database at dsn and applies the schema but not
+// migrations.
+func OpenPostgres(dsn string) (*sql.DB, error) {
+	if dsn == "" {
+		return nil, errors.New("DATABASE_DSN must be set for the postgres driver")
+	}
+
+	db, err := sql.Open(postgresDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open failed for database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		CloseDB(db)
+
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err = ApplySchema(db); err != nil {
+		CloseDB(db)
+
+		return nil, fmt.Errorf("applySchema failed: %w", err)
+	}
+
+	logging.Info("Connected to Postgres database")
+
+	return db, nil
+}
+
+// isPostgres reports whether db was opened with the Postgres driver.
+func isPostgres(db *sql.DB) bool {
+	_, ok := db.Driver().(postgresDriver)
+
+	return ok
+}
+
+// postgresColumnDefinition translates a SQLite column definition from columnMigrations to
+// Postgres. Flags stay integers, as in SQLite, so the store's COALESCE(flag, 0) comparisons work.
+func postgresColumnDefinition(definition string) string {
+	return strings.NewReplacer("BOOLEAN", "INTEGER", "DATETIME", "TIMESTAMPTZ").Replace(definition)
+}
+
+// postgresDriver opens pgx connections that accept the store's SQLite-style queries.
+type postgresDriver struct{}
+
+func (postgresDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := stdlib.GetDefaultDriver().Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	pgxConn, ok := conn.(*stdlib.Conn)
+	if !ok {
+		return nil, fmt.Errorf("unexpected pgx connection type %T", conn)
+	}
+
+	return &postgresConn{Conn: pgxConn}, nil
+}
+
+// postgresConn rewrites ? placeholders to Postgres' numbered ones and stores booleans as the
+// integers the schema uses for flags.
+type postgresConn struct {
+	*stdlib.Conn
+}
+
+func (c *postgresConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(rebindPostgres(query))
+}
+
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.PrepareContext(ctx, rebindPostgres(query))
+}
+
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.ExecContext(ctx, rebindPostgres(query), args)
+}
+
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.QueryContext(ctx, rebindPostgres(query), args)
+}
+
+func (c *postgresConn) CheckNamedValue(value *driver.NamedValue) error {
+	if b, ok := value.Value.(bool); ok {
+		value.Value = int64(0)
+		if b {
+			value.Value = int64(1)
+		}
+	}
+
+	return c.Conn.CheckNamedValue(value)
+}
+
+// rebindPostgres replaces each ? placeholder outside quoted strings and identifiers with $1,
+// $2 and so on.
+func rebindPostgres(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var rebound strings.Builder
+	rebound.Grow(len(query) + 8)
+	var quote rune
+	n := 0
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			rebound.WriteString("$" + strconv.Itoa(n))
+
+			continue
+		}
+		rebound.WriteRune(r)
+	}
+
+	return rebound.String()
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// lockRetryAttempts and lockRetryBaseDelay bound how long a write waits out lock contention:
+// 5 attempts with doubling delays add up to roughly 300ms before the error is returned.
+const (
+	lockRetryAttempts  = 5
+	lockRetryBaseDelay = 20 * time.Millisecond
+)
+
+// isLockError reports whether err is SQLite lock contention rather than a genuine failure
+// such as a constraint violation.
+func isLockError(err error) bool {
+	msg := err.Error()
+
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "SQLITE_BUSY")
+}
+
+// isUniqueViolation reports whether err is a write rejected by a unique constraint, on SQLite
+// or Postgres.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "SQLSTATE 23505")
+}
+
+// retryOnLock runs fn, retrying with exponential backoff while it fails with a lock error.
+// Any other error, or the last lock error once attempts run out, is returned unchanged.
+func retryOnLock(fn func() error) error {
+	delay := lockRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isLockError(err) || attempt == lockRetryAttempts {
+			return err
+		}
+
+		logging.Warn("Database locked, retrying write", "attempt", attempt, "retry_in", delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// ImportState restores an exported state in a single transaction: each feed is matched by URL
+// and updated, or inserted as a new feed awaiting its initial sync, and the settings are
+// applied. A feed's cookie and sync progress are left as they are. It returns how many feeds
+// were created and updated; on error nothing is changed.
+func (s *SQLStore) ImportState(ctx context.Context, state *models.State) (created, updated int, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin import transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logging.Error("Failed to roll back transaction", "error", err)
+		}
+	}()
+
+	for _, config := range state.Feeds {
+		feed := config.Feed()
+
+		var id int
+		err := tx.QueryRowContext(ctx, "SELECT id FROM feeds WHERE url = ?", feed.URL).Scan(&id)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if err := insertImportedFeed(ctx, tx, feed); err != nil {
+				return 0, 0, err
+			}
+			created++
+		case err != nil:
+			return 0, 0, fmt.Errorf("failed to look up feed %s: %w", feed.URL, err)
+		default:
+			feed.ID = id
+			if err := updateImportedFeed(ctx, tx, feed); err != nil {
+				return 0, 0, err
+			}
+			updated++
+		}
+	}
+
+	if state.Settings.DefaultPollIntervalMinutes > 0 {
+		_, err := tx.ExecContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value",
+			"default_poll_interval_minutes", strconv.Itoa(state.Settings.DefaultPollIntervalMinutes))
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to import settings: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit import transaction: %w", err)
+	}
+
+	return created, updated, nil
+}
+
+// insertImportedFeed inserts feed's configuration as a new feed using db
+func insertImportedFeed(ctx context.Context, db execer, feed *models.Feed) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO feeds (
+			name, url, poll_interval_minutes, poll_interval, poll_interval_unit,
+			sync_mode, sync_count, sync_date_from, disabled, fetch_timeout_seconds,
+			strip_query_params, auto_interval, content_selector, tag_with_feed_name, accept_header,
+			include_categories, min_age_minutes, keep_last_n, priority, categories_as_tags, delivery_mode,
+			date_field, min_title_length
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, importedFeedArgs(feed)...)
+	if err != nil {
+		return fmt.Errorf("failed to insert imported feed %s: %w", feed.URL, err)
+	}
+
+	return nil
+}
+
+// updateImportedFeed overwrites the configuration of the feed with feed.ID using db
+func updateImportedFeed(ctx context.Context, db execer, feed *models.Feed) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE feeds SET
+			name = ?, url = ?, poll_interval_minutes = ?, poll_interval = ?, poll_interval_unit = ?,
+			sync_mode = ?, sync_count = ?, sync_date_from = ?, disabled = ?, fetch_timeout_seconds = ?,
+			strip_query_params = ?, auto_interval = ?, content_selector = ?, tag_with_feed_name = ?,
+			accept_header = ?, include_categories = ?, min_age_minutes = ?, keep_last_n = ?, priority = ?,
+			categories_as_tags = ?, delivery_mode = ?, date_field = ?, min_title_length = ?
+		WHERE id = ?
+	`, append(importedFeedArgs(feed), feed.ID)...)
+	if err != nil {
+		return fmt.Errorf("failed to update imported feed %s: %w", feed.URL, err)
+	}
+
+	return nil
+}
+
+// importedFeedArgs returns the column values shared by insertImportedFeed and updateImportedFeed
+func importedFeedArgs(feed *models.Feed) []any {
+	var syncCount any
+	if feed.SyncCount != nil {
+		syncCount = *feed.SyncCount
+	}
+
+	var syncDateFrom any
+	if feed.SyncDateFrom != nil {
+		syncDateFrom = *feed.SyncDateFrom
+	}
+
+	return []any{
+		feed.Name, feed.URL, feed.PollIntervalMinutes, feed.PollInterval, string(feed.PollIntervalUnit),
+		string(feed.SyncMode), syncCount, syncDateFrom, feed.Disabled, feed.FetchTimeoutSeconds,
+		feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader,
+		models.FormatCategories(feed.IncludeCategories), feed.MinAgeMinutes, feed.KeepLastN, feed.Priority,
+		feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), feed.MinTitleLength,
+	}
+}
@@ -0,0 +1,126 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// exportState builds the document GET /admin/export would produce from store
+func exportState(t *testing.T, store *database.SQLStore) *models.State {
+	t.Helper()
+	ctx := context.Background()
+
+	feeds, err := store.GetFeeds(ctx)
+	require.NoError(t, err)
+	interval, err := store.GetDefaultPollInterval(ctx)
+	require.NoError(t, err)
+
+	state := &models.State{Version: models.StateVersion, Settings: models.StateSettings{DefaultPollIntervalMinutes: interval}}
+	for i := range feeds {
+		state.Feeds = append(state.Feeds, models.NewFeedConfig(&feeds[i]))
+	}
+
+	return state
+}
+
+func TestSQLStore_ImportState(t *testing.T) {
+	ctx := context.Background()
+
+	sourceDB, cleanupSource := setupTestDB(t)
+	defer cleanupSource()
+	source := database.NewSQLStore(sourceDB)
+
+	count := 5
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	feeds := []*models.Feed{
+		{Name: "Counted", URL: "https://example.com/counted.xml", SyncMode: models.SyncModeCount, SyncCount: &count, Priority: 3, KeepLastN: 20},
+		{Name: "Dated", URL: "https://example.com/dated.xml", SyncMode: models.SyncModeDateFrom, SyncDateFrom: &from, Disabled: true},
+		{Name: "Filtered", URL: "https://example.com/filtered.xml", SyncMode: models.SyncModeNone, IncludeCategories: []string{"go", "sql"},
+			StripQueryParams: true, TagWithFeedName: true, CategoriesAsTags: true, ContentSelector: "main",
+			DeliveryMode: models.DeliveryModeContent, AcceptHeader: "application/atom+xml", MinAgeMinutes: 15,
+			DateField: models.DateFieldUpdated, MinTitleLength: 4},
+	}
+	feeds[0].SetPollInterval(2, models.TimeUnitHours)
+	feeds[1].SetPollInterval(1, models.TimeUnitDays)
+	for _, feed := range feeds {
+		_, err := source.InsertFeed(ctx, feed)
+		require.NoError(t, err)
+	}
+	require.NoError(t, source.UpdateDefaultPollInterval(ctx, 90))
+
+	// Export travels as JSON between hosts
+	encoded, err := json.Marshal(exportState(t, source))
+	require.NoError(t, err)
+	var state models.State
+	require.NoError(t, json.Unmarshal(encoded, &state))
+
+	targetDB, cleanupTarget := setupTestDB(t)
+	defer cleanupTarget()
+	target := database.NewSQLStore(targetDB)
+
+	t.Run("Fresh store receives every feed and the settings", func(t *testing.T) {
+		created, updated, err := target.ImportState(ctx, &state)
+		require.NoError(t, err)
+		assert.Equal(t, 3, created)
+		assert.Equal(t, 0, updated)
+
+		assert.Equal(t, exportState(t, source).Feeds, exportState(t, target).Feeds)
+		interval, err := target.GetDefaultPollInterval(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 90, interval)
+
+		imported, err := target.GetFeeds(ctx)
+		require.NoError(t, err)
+		for _, feed := range imported {
+			assert.False(t, feed.InitialSyncDone, "imported feeds start with their initial sync")
+		}
+	})
+
+	t.Run("Importing again updates feeds matched by URL", func(t *testing.T) {
+		imported, err := target.GetFeeds(ctx)
+		require.NoError(t, err)
+		require.NoError(t, target.UpdateFeedLastFetched(ctx, imported[0].ID))
+
+		state.Feeds[0].Name = "Renamed"
+		created, updated, err := target.ImportState(ctx, &state)
+		require.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Equal(t, 3, updated)
+
+		feed, err := target.GetFeedByID(ctx, imported[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", feed.Name)
+		assert.NotNil(t, feed.LastFetched, "sync progress is kept")
+	})
+
+	t.Run("A failing feed leaves the store unchanged", func(t *testing.T) {
+		broken := models.State{
+			Version:  models.StateVersion,
+			Settings: models.StateSettings{DefaultPollIntervalMinutes: 15},
+			Feeds: []models.FeedConfig{
+				{Name: "New", URL: "https://example.com/new.xml", SyncMode: models.SyncModeNone},
+				{Name: "Rejected", URL: "https://example.com/rejected.xml", SyncMode: models.SyncModeNone},
+			},
+		}
+		_, err := targetDB.Exec(`CREATE TRIGGER reject_feed BEFORE INSERT ON feeds WHEN NEW.name = 'Rejected'
+			BEGIN SELECT RAISE(ABORT, 'rejected'); END`)
+		require.NoError(t, err)
+
+		_, _, err = target.ImportState(ctx, &broken)
+		require.Error(t, err)
+
+		feeds, err := target.GetFeeds(ctx)
+		require.NoError(t, err)
+		assert.Len(t, feeds, 3)
+		interval, err := target.GetDefaultPollInterval(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 90, interval)
+	})
+}
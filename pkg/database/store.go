@@ -2,8 +2,11 @@ package database
 
 import (
 	"context"
+	"crypto/cipher"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"wallabag-rss-tool/pkg/logging"
@@ -13,40 +16,183 @@ import (
 // Storer defines the interface for database operations.
 type Storer interface {
 	GetFeeds(ctx context.Context) ([]models.Feed, error)
+	GetFeedsPaged(ctx context.Context, limit, offset int) ([]models.Feed, int, error)
+	GetFeedsSorted(ctx context.Context, sort string, limit, offset int) ([]models.Feed, int, error)
 	GetFeedByID(ctx context.Context, id int) (*models.Feed, error)
 	InsertFeed(ctx context.Context, feed *models.Feed) (int64, error)
 	UpdateFeed(ctx context.Context, feed *models.Feed) error
 	DeleteFeed(ctx context.Context, id int) error
 	GetArticles(ctx context.Context) ([]models.Article, error)
+	GetUnsentArticles(ctx context.Context) ([]models.Article, error)
+	GetArticleByID(ctx context.Context, id int) (*models.Article, error)
+	GetFeedArticles(ctx context.Context, feedID int) ([]models.Article, error)
+	GetLatestArticlesForFeeds(ctx context.Context, n int) (map[int][]models.Article, error)
 	SaveArticle(ctx context.Context, feedID int, article *models.Article, wallabagEntryID int) error
+	SaveFilteredArticle(ctx context.Context, feedID int, article *models.Article) error
+	SaveMarkedArticle(ctx context.Context, feedID int, article *models.Article) error
+	SaveFailedArticle(ctx context.Context, feedID int, article *models.Article) error
+	SetArticleWallabagEntry(ctx context.Context, id, wallabagEntryID int) error
 	IsArticleAlreadyProcessed(ctx context.Context, articleURL string) (bool, error)
+	IsSimilarArticleProcessed(ctx context.Context, articleURL string) (bool, error)
 	GetDefaultPollInterval(ctx context.Context) (int, error)
 	UpdateDefaultPollInterval(ctx context.Context, interval int) error
+	GetOrCreateCSRFSecret(ctx context.Context, candidate string) (string, error)
+	IsSetupComplete(ctx context.Context) (bool, error)
+	MarkSetupComplete(ctx context.Context) error
 	UpdateFeedLastFetched(ctx context.Context, feedID int) error
 	MarkFeedInitialSyncCompleted(ctx context.Context, feedID int) error
+	SetFeedsEnabled(ctx context.Context, ids []int, enabled bool) error
+	DisableFeed(ctx context.Context, feedID int, reason string) error
+	UpdateFeedLastBuildDate(ctx context.Context, feedID int, lastBuildDate time.Time) error
+	Ping(ctx context.Context) error
+	CheckWritable(ctx context.Context) error
+	UpdateFeedAutoInterval(ctx context.Context, feedID int, minutes int) error
+	UpdateFeedFavicon(ctx context.Context, feedID int, faviconURL string) error
+	UpdateFeedContentKind(ctx context.Context, feedID int, kind models.ContentKind) error
+	UpdateFeedTTL(ctx context.Context, feedID int, minutes int) error
+	UpdateFeedURL(ctx context.Context, feedID int, feedURL string) error
+	SnoozeFeed(ctx context.Context, feedID int, until *time.Time) error
+	IncrementArticleClicks(ctx context.Context, id int) error
+	UpdateArticlesFeedID(ctx context.Context, fromID, toID int) error
+	MergeFeeds(ctx context.Context, fromID, toID int) (int64, error)
+	PruneFeedArticlesKeepingLatest(ctx context.Context, feedID, n int) (int64, error)
+	PruneExpiredArticles(ctx context.Context) (int64, error)
+	GetOrphanedArticles(ctx context.Context) ([]models.Article, error)
+	DeleteOrphanedArticles(ctx context.Context) (int64, error)
+	GetFeedOverlaps(ctx context.Context, minShared int) ([]models.FeedOverlap, error)
+	GetRecentSentArticles(ctx context.Context, limit int) ([]models.RecentArticle, error)
+	GetArticleHistory(ctx context.Context, filter models.ArticleFilter, limit, offset int) ([]models.HistoryArticle, int, error)
+	Optimize(ctx context.Context) error
+	DatabaseSize(ctx context.Context) (int64, error)
+	ImportState(ctx context.Context, state *models.State) (created, updated int, err error)
+	AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+	GetAuditEntries(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]models.AuditEntry, int, error)
 }
 
+// ErrFeedNotFound is returned when a requested feed does not exist.
+var ErrFeedNotFound = errors.New("feed not found")
+
+// ErrFeedExists is returned when adding a feed whose URL another feed already has.
+var ErrFeedExists = errors.New("feed already exists")
+
+// ErrArticleNotFound is returned when a requested article does not exist.
+var ErrArticleNotFound = errors.New("article not found")
+
+// ErrArticleExists is returned when saving an article whose URL is already recorded, for
+// example by another feed that found the same article at the same time.
+var ErrArticleExists = errors.New("article already recorded")
+
 // SQLStore implements Storer using a SQL database.
 type SQLStore struct {
-	db *sql.DB
+	db           *sql.DB
+	postgres     bool // db was opened with the Postgres driver
+	limits       models.FieldLimits
+	cookieCipher cipher.AEAD   // Seals feed cookies; nil until SetCookieKey
+	dedupWindow  time.Duration // How long a recorded article counts as processed; 0 = forever
 }
 
 // NewSQLStore creates a new SQLStore.
 func NewSQLStore(db *sql.DB) *SQLStore {
-	return &SQLStore{db: db}
+	return &SQLStore{db: db, postgres: db != nil && isPostgres(db)}
 }
 
-// GetFeeds retrieves all feeds from the database.
-func (s *SQLStore) GetFeeds(ctx context.Context) ([]models.Feed, error) {
-	query := `
-		SELECT 
+// NewSQLStoreWithLimits creates a new SQLStore that enforces the given article field limits.
+func NewSQLStoreWithLimits(db *sql.DB, limits models.FieldLimits) *SQLStore {
+	return &SQLStore{db: db, limits: limits, postgres: db != nil && isPostgres(db)}
+}
+
+// feedColumns is the column list shared by every query that loads complete feed rows.
+const feedColumns = `
 			id, url, name, last_fetched,
 			COALESCE(poll_interval, 1) as poll_interval,
 			COALESCE(poll_interval_unit, 'days') as poll_interval_unit,
-			sync_mode, sync_count, sync_date_from, initial_sync_done 
-		FROM feeds
-	`
-	rows, err := s.db.QueryContext(ctx, query)
+			sync_mode, sync_count, sync_date_from, initial_sync_done,
+			COALESCE(disabled, 0) as disabled, last_build_date,
+			COALESCE(fetch_timeout_seconds, 0) as fetch_timeout_seconds,
+			COALESCE(strip_query_params, 0) as strip_query_params,
+			COALESCE(auto_interval, 0) as auto_interval,
+			COALESCE(auto_interval_minutes, 0) as auto_interval_minutes,
+			COALESCE(content_selector, '') as content_selector,
+			COALESCE(tag_with_feed_name, 0) as tag_with_feed_name,
+			COALESCE(accept_header, '') as accept_header,
+			COALESCE(include_categories, '') as include_categories,
+			COALESCE(min_age_minutes, 0) as min_age_minutes,
+			COALESCE(cookie, '') as cookie,
+			COALESCE(favicon_url, '') as favicon_url, favicon_checked_at,
+			COALESCE(keep_last_n, 0) as keep_last_n,
+			COALESCE(priority, 0) as priority,
+			COALESCE(categories_as_tags, 0) as categories_as_tags,
+			COALESCE(NULLIF(delivery_mode, ''), 'link') as delivery_mode, snoozed_until,
+			COALESCE(content_kind, '') as content_kind,
+			COALESCE(NULLIF(date_field, ''), 'auto') as date_field,
+			CASE WHEN COALESCE(disabled, 0) <> 0 THEN COALESCE(disabled_reason, '') ELSE '' END as disabled_reason,
+			COALESCE(ttl_minutes, 0) as ttl_minutes,
+			COALESCE(min_title_length, 0) as min_title_length`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// GetFeeds retrieves all feeds from the database.
+func (s *SQLStore) GetFeeds(ctx context.Context) ([]models.Feed, error) {
+	return s.queryFeeds(ctx, `SELECT `+feedColumns+` FROM feeds`)
+}
+
+// GetFeedsPaged retrieves up to limit feeds in ID order, skipping the first offset, along with
+// the total number of feeds.
+func (s *SQLStore) GetFeedsPaged(ctx context.Context, limit, offset int) ([]models.Feed, int, error) {
+	return s.GetFeedsSorted(ctx, FeedSortID, limit, offset)
+}
+
+// Feed list orders accepted by GetFeedsSorted.
+const (
+	FeedSortID          = "id"           // Insertion order
+	FeedSortName        = "name"         // Name, case-insensitive
+	FeedSortLastFetched = "last_fetched" // Most recently fetched first, never-fetched feeds last
+	FeedSortArticles    = "articles"     // Most saved articles first
+)
+
+// feedSortOrders maps each feed sort to its ORDER BY clause. Only these fixed clauses are ever
+// put into a query, so the sort parameter cannot inject SQL. Ties fall back to ID order.
+var feedSortOrders = map[string]string{
+	FeedSortID:          "id",
+	FeedSortName:        "LOWER(name), id",
+	FeedSortLastFetched: "last_fetched IS NULL, last_fetched DESC, id",
+	FeedSortArticles:    "(SELECT COUNT(*) FROM articles WHERE articles.feed_id = feeds.id) DESC, id",
+}
+
+// IsFeedSort reports whether sort is one of the orders accepted by GetFeedsSorted.
+func IsFeedSort(sort string) bool {
+	_, ok := feedSortOrders[sort]
+
+	return ok
+}
+
+// GetFeedsSorted retrieves up to limit feeds in the given sort order, skipping the first
+// offset, along with the total number of feeds. An unknown sort falls back to FeedSortID.
+func (s *SQLStore) GetFeedsSorted(ctx context.Context, sort string, limit, offset int) ([]models.Feed, int, error) {
+	order, ok := feedSortOrders[sort]
+	if !ok {
+		order = feedSortOrders[FeedSortID]
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM feeds").Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count feeds: %w", err)
+	}
+
+	feeds, err := s.queryFeeds(ctx, `SELECT `+feedColumns+` FROM feeds ORDER BY `+order+` LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return feeds, total, nil
+}
+
+// queryFeeds runs a feed query selecting feedColumns and scans the rows
+func (s *SQLStore) queryFeeds(ctx context.Context, query string, args ...any) ([]models.Feed, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query feeds: %w", err)
 	}
@@ -60,7 +206,7 @@ func (s *SQLStore) GetFeeds(ctx context.Context) ([]models.Feed, error) {
 	for rows.Next() {
 		feed, err := s.scanFeedRow(rows)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to scan feed row: %w", err)
 		}
 		feeds = append(feeds, feed)
 	}
@@ -72,41 +218,69 @@ func (s *SQLStore) GetFeeds(ctx context.Context) ([]models.Feed, error) {
 	return feeds, nil
 }
 
-// scanFeedRow scans a single feed row from the database
-func (s *SQLStore) scanFeedRow(rows *sql.Rows) (models.Feed, error) {
+// feedRow holds the nullable columns of a feed row while it is being scanned.
+type feedRow struct {
+	lastFetched      sql.NullTime
+	pollInterval     sql.NullInt64
+	pollIntervalUnit sql.NullString
+	syncMode         sql.NullString
+	syncCount        sql.NullInt64
+	syncDateFrom     sql.NullTime
+	initialSyncDone  sql.NullBool
+	disabled         sql.NullBool
+	lastBuildDate    sql.NullTime
+	faviconChecked   sql.NullTime
+	snoozedUntil     sql.NullTime
+	categories       string
+	cookie           string
+}
+
+// scanFeedRow scans a single feed row, in feedColumns order, into a Feed model
+func (s *SQLStore) scanFeedRow(scanner rowScanner) (models.Feed, error) {
 	var feed models.Feed
-	var lastFetched sql.NullTime
-	var pollInterval sql.NullInt64
-	var pollIntervalUnit sql.NullString
-	var syncMode sql.NullString
-	var syncCount sql.NullInt64
-	var syncDateFrom sql.NullTime
-	var initialSyncDone sql.NullBool
+	var row feedRow
 
-	if err := rows.Scan(&feed.ID, &feed.URL, &feed.Name, &lastFetched,
-		&pollInterval, &pollIntervalUnit, &syncMode, &syncCount, &syncDateFrom, &initialSyncDone); err != nil {
-		return models.Feed{}, fmt.Errorf("failed to scan feed row: %w", err)
+	if err := scanner.Scan(&feed.ID, &feed.URL, &feed.Name, &row.lastFetched,
+		&row.pollInterval, &row.pollIntervalUnit, &row.syncMode, &row.syncCount, &row.syncDateFrom,
+		&row.initialSyncDone, &row.disabled, &row.lastBuildDate, &feed.FetchTimeoutSeconds,
+		&feed.StripQueryParams, &feed.AutoInterval, &feed.AutoIntervalMinutes, &feed.ContentSelector,
+		&feed.TagWithFeedName, &feed.AcceptHeader, &row.categories, &feed.MinAgeMinutes,
+		&row.cookie, &feed.FaviconURL, &row.faviconChecked, &feed.KeepLastN,
+		&feed.Priority, &feed.CategoriesAsTags, &feed.DeliveryMode, &row.snoozedUntil, &feed.ContentKind,
+		&feed.DateField, &feed.DisabledReason, &feed.TTLMinutes, &feed.MinTitleLength); err != nil {
+		return models.Feed{}, err
 	}
 
-	s.setFeedNullableFields(&feed, lastFetched, pollInterval, pollIntervalUnit, syncMode, syncCount, syncDateFrom, initialSyncDone)
+	s.setFeedNullableFields(&feed, &row)
+
+	// A cookie that cannot be opened is dropped rather than failing every feed query, and
+	// flagged so saving the feed does not overwrite it
+	cookie, err := s.openCookie(row.cookie)
+	if err != nil {
+		logging.Warn("Failed to open stored feed cookie, fetching without it",
+			"error", err,
+			"feed_id", feed.ID)
+		feed.CookieUnreadable = true
+	}
+	feed.Cookie = cookie
 
 	return feed, nil
 }
 
 // setFeedNullableFields sets nullable database fields on the feed model
-func (s *SQLStore) setFeedNullableFields(feed *models.Feed, lastFetched sql.NullTime, pollInterval sql.NullInt64, pollIntervalUnit, syncMode sql.NullString, syncCount sql.NullInt64, syncDateFrom sql.NullTime, initialSyncDone sql.NullBool) {
-	if lastFetched.Valid {
-		feed.LastFetched = &lastFetched.Time
+func (s *SQLStore) setFeedNullableFields(feed *models.Feed, row *feedRow) {
+	if row.lastFetched.Valid {
+		feed.LastFetched = &row.lastFetched.Time
 	}
 
-	if pollInterval.Valid {
-		feed.PollInterval = int(pollInterval.Int64)
+	if row.pollInterval.Valid {
+		feed.PollInterval = int(row.pollInterval.Int64)
 	} else {
 		feed.PollInterval = 1 // Default
 	}
 
-	if pollIntervalUnit.Valid {
-		feed.PollIntervalUnit = models.TimeUnit(pollIntervalUnit.String)
+	if row.pollIntervalUnit.Valid {
+		feed.PollIntervalUnit = models.TimeUnit(row.pollIntervalUnit.String)
 	} else {
 		feed.PollIntervalUnit = models.TimeUnitDays // Default
 	}
@@ -114,69 +288,80 @@ func (s *SQLStore) setFeedNullableFields(feed *models.Feed, lastFetched sql.Null
 	// Compute poll interval in minutes
 	feed.PollIntervalMinutes = feed.GetPollIntervalMinutes()
 
-	if syncMode.Valid {
-		feed.SyncMode = models.SyncMode(syncMode.String)
+	if row.syncMode.Valid {
+		feed.SyncMode = models.SyncMode(row.syncMode.String)
 	} else {
 		feed.SyncMode = models.SyncModeNone
 	}
 
-	if syncCount.Valid {
-		count := int(syncCount.Int64)
+	if row.syncCount.Valid {
+		count := int(row.syncCount.Int64)
 		feed.SyncCount = &count
 	}
 
-	if syncDateFrom.Valid {
-		feed.SyncDateFrom = &syncDateFrom.Time
+	if row.syncDateFrom.Valid {
+		feed.SyncDateFrom = &row.syncDateFrom.Time
+	}
+
+	if row.initialSyncDone.Valid {
+		feed.InitialSyncDone = row.initialSyncDone.Bool
+	}
+
+	if row.disabled.Valid {
+		feed.Disabled = row.disabled.Bool
+	}
+
+	if row.lastBuildDate.Valid {
+		feed.LastBuildDate = &row.lastBuildDate.Time
 	}
 
-	if initialSyncDone.Valid {
-		feed.InitialSyncDone = initialSyncDone.Bool
+	if row.faviconChecked.Valid {
+		feed.FaviconCheckedAt = &row.faviconChecked.Time
 	}
+
+	if row.snoozedUntil.Valid {
+		feed.SnoozedUntil = &row.snoozedUntil.Time
+	}
+
+	feed.IncludeCategories = models.ParseCategories(row.categories)
 }
 
 // GetFeedByID retrieves a single feed by its ID.
 func (s *SQLStore) GetFeedByID(ctx context.Context, id int) (*models.Feed, error) {
-	var feed models.Feed
-	var lastFetched sql.NullTime
-	var pollInterval sql.NullInt64
-	var pollIntervalUnit sql.NullString
-	var syncMode sql.NullString
-	var syncCount sql.NullInt64
-	var syncDateFrom sql.NullTime
-	var initialSyncDone sql.NullBool
-
-	query := `
-		SELECT 
-			id, url, name, last_fetched,
-			COALESCE(poll_interval, 1) as poll_interval,
-			COALESCE(poll_interval_unit, 'days') as poll_interval_unit,
-			sync_mode, sync_count, sync_date_from, initial_sync_done 
-		FROM feeds WHERE id = ?
-	`
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&feed.ID, &feed.URL, &feed.Name, &lastFetched,
-		&pollInterval, &pollIntervalUnit, &syncMode, &syncCount, &syncDateFrom, &initialSyncDone)
+	query := `SELECT ` + feedColumns + ` FROM feeds WHERE id = ?`
+	feed, err := s.scanFeedRow(s.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("feed with ID %d not found", id)
+			return nil, fmt.Errorf("feed with ID %d: %w", id, ErrFeedNotFound)
 		}
 
 		return nil, fmt.Errorf("failed to query feed by ID: %w", err)
 	}
 
-	s.setFeedNullableFields(&feed, lastFetched, pollInterval, pollIntervalUnit, syncMode, syncCount, syncDateFrom, initialSyncDone)
-
 	return &feed, nil
 }
 
 // InsertFeed inserts a new feed into the database.
 func (s *SQLStore) InsertFeed(ctx context.Context, feed *models.Feed) (int64, error) {
-	stmt, err := s.db.PrepareContext(ctx, `
+	cookie, err := s.sealCookie(feed.Cookie)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
 		INSERT INTO feeds (
 			name, url, poll_interval_minutes, poll_interval, poll_interval_unit, 
-			sync_mode, sync_count, sync_date_from, initial_sync_done
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`)
+			sync_mode, sync_count, sync_date_from, initial_sync_done, disabled, fetch_timeout_seconds,
+			strip_query_params, auto_interval, content_selector, tag_with_feed_name, accept_header,
+			include_categories, min_age_minutes, cookie, keep_last_n, priority, categories_as_tags,
+			delivery_mode, date_field, min_title_length
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if s.postgres {
+		// Postgres has no last insert ID; the new ID comes back from RETURNING instead
+		query += " RETURNING id"
+	}
+	stmt, err := s.db.PrepareContext(ctx, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to prepare insert feed statement: %w", err)
 	}
@@ -199,12 +384,33 @@ func (s *SQLStore) InsertFeed(ctx context.Context, feed *models.Feed) (int64, er
 	// Ensure PollIntervalMinutes is calculated
 	feed.PollIntervalMinutes = feed.GetPollIntervalMinutes()
 
-	res, err := stmt.Exec(
+	args := []any{
 		feed.Name, feed.URL, feed.PollIntervalMinutes,
 		feed.PollInterval, string(feed.PollIntervalUnit),
-		string(feed.SyncMode), syncCount, syncDateFrom, feed.InitialSyncDone)
+		string(feed.SyncMode), syncCount, syncDateFrom, feed.InitialSyncDone, feed.Disabled, feed.FetchTimeoutSeconds,
+		feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader,
+		models.FormatCategories(feed.IncludeCategories), feed.MinAgeMinutes, cookie, feed.KeepLastN, feed.Priority,
+		feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), feed.MinTitleLength,
+	}
+
+	if s.postgres {
+		var id int64
+		if err := stmt.QueryRow(args...).Scan(&id); err != nil {
+			return 0, insertFeedError(err)
+		}
+
+		return id, nil
+	}
+
+	var res sql.Result
+	err = retryOnLock(func() error {
+		var execErr error
+		res, execErr = stmt.Exec(args...)
+
+		return execErr
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert feed: %w", err)
+		return 0, insertFeedError(err)
 	}
 
 	id, err := res.LastInsertId()
@@ -215,12 +421,33 @@ func (s *SQLStore) InsertFeed(ctx context.Context, feed *models.Feed) (int64, er
 	return id, nil
 }
 
+// insertFeedError wraps an error from inserting a feed, as ErrFeedExists if the URL is taken
+func insertFeedError(err error) error {
+	if isUniqueViolation(err) {
+		return fmt.Errorf("failed to insert feed: %w", ErrFeedExists)
+	}
+
+	return fmt.Errorf("failed to insert feed: %w", err)
+}
+
 // UpdateFeed updates an existing feed in the database.
 func (s *SQLStore) UpdateFeed(ctx context.Context, feed *models.Feed) error {
+	cookie, err := s.sealCookie(feed.Cookie)
+	if err != nil {
+		return err
+	}
+	// A stored cookie that could not be opened stays as it is until it is replaced or cleared,
+	// so it comes back once the right FEED_COOKIE_KEY is set again
+	keepCookie := feed.Cookie == "" && feed.CookieUnreadable
+
 	stmt, err := s.db.PrepareContext(ctx, `
 		UPDATE feeds SET 
 			name = ?, url = ?, poll_interval_minutes = ?, poll_interval = ?, poll_interval_unit = ?,
-			sync_mode = ?, sync_count = ?, sync_date_from = ?, initial_sync_done = ? 
+			sync_mode = ?, sync_count = ?, sync_date_from = ?, initial_sync_done = ?, disabled = ?,
+			fetch_timeout_seconds = ?, strip_query_params = ?, auto_interval = ?, content_selector = ?,
+			tag_with_feed_name = ?, accept_header = ?, include_categories = ?, min_age_minutes = ?,
+			cookie = CASE WHEN ? THEN cookie ELSE ? END, keep_last_n = ?, priority = ?, categories_as_tags = ?, delivery_mode = ?,
+			date_field = ?, disabled_reason = ?, min_title_length = ?
 		WHERE id = ?
 	`)
 	if err != nil {
@@ -242,13 +469,26 @@ func (s *SQLStore) UpdateFeed(ctx context.Context, feed *models.Feed) error {
 		syncDateFrom = *feed.SyncDateFrom
 	}
 
+	// The reason only stands while the feed stays disabled
+	var disabledReason any
+	if feed.Disabled && feed.DisabledReason != "" {
+		disabledReason = feed.DisabledReason
+	}
+
 	// Ensure PollIntervalMinutes is calculated
 	feed.PollIntervalMinutes = feed.GetPollIntervalMinutes()
 
-	_, err = stmt.Exec(
-		feed.Name, feed.URL, feed.PollIntervalMinutes,
-		feed.PollInterval, string(feed.PollIntervalUnit),
-		string(feed.SyncMode), syncCount, syncDateFrom, feed.InitialSyncDone, feed.ID)
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(
+			feed.Name, feed.URL, feed.PollIntervalMinutes,
+			feed.PollInterval, string(feed.PollIntervalUnit),
+			string(feed.SyncMode), syncCount, syncDateFrom, feed.InitialSyncDone, feed.Disabled, feed.FetchTimeoutSeconds,
+			feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader,
+			models.FormatCategories(feed.IncludeCategories), feed.MinAgeMinutes, keepCookie, cookie, feed.KeepLastN, feed.Priority,
+			feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), disabledReason, feed.MinTitleLength, feed.ID)
+
+		return execErr
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update feed: %w", err)
 	}
@@ -278,7 +518,63 @@ func (s *SQLStore) DeleteFeed(ctx context.Context, id int) error {
 
 // GetArticles retrieves all articles from the database.
 func (s *SQLStore) GetArticles(ctx context.Context) ([]models.Article, error) {
-	rows, err := s.db.Query("SELECT id, feed_id, title, url, wallabag_entry_id, published_at, created_at FROM articles ORDER BY created_at DESC")
+	return s.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles ORDER BY created_at DESC")
+}
+
+// GetUnsentArticles retrieves articles saved locally that never reached Wallabag.
+func (s *SQLStore) GetUnsentArticles(ctx context.Context) ([]models.Article, error) {
+	return s.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles WHERE wallabag_entry_id IS NULL AND COALESCE(filtered, 0) = 0 AND COALESCE(marked_processed, 0) = 0 ORDER BY created_at DESC")
+}
+
+// GetFeedArticles retrieves the articles recorded for one feed, newest first.
+func (s *SQLStore) GetFeedArticles(ctx context.Context, feedID int) ([]models.Article, error) {
+	return s.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles WHERE feed_id = ? ORDER BY created_at DESC, id DESC", feedID)
+}
+
+// GetArticleByID retrieves a single article by its ID. It returns ErrArticleNotFound when no
+// article has the ID.
+func (s *SQLStore) GetArticleByID(ctx context.Context, id int) (*models.Article, error) {
+	articles, err := s.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return nil, ErrArticleNotFound
+	}
+
+	return &articles[0], nil
+}
+
+// GetLatestArticlesForFeeds returns the n most recently recorded articles of every feed, newest
+// first, keyed by feed ID, in a single query. Feeds without articles have no entry.
+func (s *SQLStore) GetLatestArticlesForFeeds(ctx context.Context, n int) (map[int][]models.Article, error) {
+	latest := make(map[int][]models.Article)
+	if n <= 0 {
+		return latest, nil
+	}
+
+	articles, err := s.queryArticles(ctx, `
+		SELECT `+articleColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY feed_id ORDER BY created_at DESC, id DESC) AS position
+			FROM articles
+		) AS ranked WHERE position <= ? ORDER BY feed_id, position`, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, article := range articles {
+		latest[article.FeedID] = append(latest[article.FeedID], article)
+	}
+
+	return latest, nil
+}
+
+// articleColumns lists the article columns in the order scanned by queryArticles
+const articleColumns = "id, feed_id, title, url, wallabag_entry_id, published_at, created_at, original_url, COALESCE(filtered, 0), COALESCE(feed_url, ''), COALESCE(marked_processed, 0), COALESCE(clicks, 0)"
+
+// queryArticles runs an article query selecting articleColumns and scans the rows
+func (s *SQLStore) queryArticles(ctx context.Context, query string, args ...any) ([]models.Article, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query articles: %w", err)
 	}
@@ -291,18 +587,8 @@ func (s *SQLStore) GetArticles(ctx context.Context) ([]models.Article, error) {
 	var articles []models.Article
 	for rows.Next() {
 		var article models.Article
-		var wallabagEntryID sql.NullInt64
-		var publishedAt sql.NullTime
-
-		if err := rows.Scan(&article.ID, &article.FeedID, &article.Title, &article.URL, &wallabagEntryID, &publishedAt, &article.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan article row: %w", err)
-		}
-		if wallabagEntryID.Valid {
-			id := int(wallabagEntryID.Int64)
-			article.WallabagEntryID = &id
-		}
-		if publishedAt.Valid {
-			article.PublishedAt = &publishedAt.Time
+		if err := scanArticle(rows, &article); err != nil {
+			return nil, err
 		}
 		articles = append(articles, article)
 	}
@@ -314,10 +600,75 @@ func (s *SQLStore) GetArticles(ctx context.Context) ([]models.Article, error) {
 	return articles, nil
 }
 
+// scanArticle scans a row selecting articleColumns into article. Any extra destinations are
+// scanned from the columns that follow them.
+func scanArticle(rows *sql.Rows, article *models.Article, extra ...any) error {
+	var wallabagEntryID sql.NullInt64
+	var publishedAt sql.NullTime
+	var originalURL sql.NullString
+
+	dest := []any{&article.ID, &article.FeedID, &article.Title, &article.URL, &wallabagEntryID, &publishedAt, &article.CreatedAt, &originalURL, &article.Filtered, &article.FeedURL, &article.MarkedProcessed, &article.Clicks}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return fmt.Errorf("failed to scan article row: %w", err)
+	}
+	if wallabagEntryID.Valid {
+		id := int(wallabagEntryID.Int64)
+		article.WallabagEntryID = &id
+	}
+	if publishedAt.Valid {
+		article.PublishedAt = &publishedAt.Time
+	}
+	article.OriginalURL = originalURL.String
+
+	return nil
+}
+
 // SaveArticle saves a new article to the database.
 func (s *SQLStore) SaveArticle(ctx context.Context, feedID int, article *models.Article, wallabagEntryID int) error {
+	return s.insertArticle(ctx, feedID, article, wallabagEntryID, false, false)
+}
+
+// SaveFilteredArticle records an article a feed filter kept from Wallabag, so it counts as
+// processed without being listed as unsent.
+func (s *SQLStore) SaveFilteredArticle(ctx context.Context, feedID int, article *models.Article) error {
+	return s.insertArticle(ctx, feedID, article, nil, true, false)
+}
+
+// SaveMarkedArticle records an article that existed when its feed was marked processed, so it
+// counts as processed without being sent or listed as unsent.
+func (s *SQLStore) SaveMarkedArticle(ctx context.Context, feedID int, article *models.Article) error {
+	return s.insertArticle(ctx, feedID, article, nil, false, true)
+}
+
+// SaveFailedArticle records an article Wallabag refused, so it counts as processed and is not
+// retried on every poll. It is listed as unsent until a manual retry records its entry with
+// SetArticleWallabagEntry.
+func (s *SQLStore) SaveFailedArticle(ctx context.Context, feedID int, article *models.Article) error {
+	return s.insertArticle(ctx, feedID, article, nil, false, false)
+}
+
+// insertArticle inserts an article row; wallabagEntryID is nil for articles never sent. The
+// feed's current URL is copied onto the row so the article stays traceable to its source.
+func (s *SQLStore) insertArticle(ctx context.Context, feedID int, article *models.Article, wallabagEntryID any, filtered, marked bool) error {
+	if err := s.limits.ValidateURL(article.URL); err != nil {
+		return fmt.Errorf("refusing to save article: %w", err)
+	}
+
+	if err := s.forgetExpiredArticle(ctx, article.URL); err != nil {
+		return err
+	}
+
+	title := s.limits.TruncateTitle(article.Title)
+	if title != article.Title {
+		logging.Warn("Truncating over-long article title",
+			"article_url", article.URL,
+			"title_length", len(article.Title),
+			"max_title_length", s.limits.TitleLimit())
+	}
+
 	stmt, err := s.db.PrepareContext(ctx,
-		"INSERT INTO articles (feed_id, title, url, wallabag_entry_id, published_at) VALUES (?, ?, ?, ?, ?)")
+		`INSERT INTO articles (feed_id, title, url, wallabag_entry_id, published_at, original_url, filtered, marked_processed, feed_url, normalized_url)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, (SELECT url FROM feeds WHERE id = ?), ?)`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare insert article statement: %w", err)
 	}
@@ -327,18 +678,34 @@ func (s *SQLStore) SaveArticle(ctx context.Context, feedID int, article *models.
 		}
 	}()
 
-	_, err = stmt.Exec(feedID, article.Title, article.URL, wallabagEntryID, article.PublishedAt)
+	var originalURL interface{}
+	if article.OriginalURL != "" {
+		originalURL = article.OriginalURL
+	}
+
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(feedID, title, article.URL, wallabagEntryID, article.PublishedAt, originalURL, filtered, marked, feedID,
+			models.NormalizeArticleURL(article.URL))
+
+		return execErr
+	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to insert article: %w", ErrArticleExists)
+		}
+
 		return fmt.Errorf("failed to insert article: %w", err)
 	}
 
 	return nil
 }
 
-// IsArticleAlreadyProcessed checks if an article with the given URL already exists in the database.
+// IsArticleAlreadyProcessed checks if an article with the given URL already exists in the database,
+// recorded within the dedup window if one is set.
 func (s *SQLStore) IsArticleAlreadyProcessed(ctx context.Context, articleURL string) (bool, error) {
+	condition, args := s.dedupCondition()
 	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM articles WHERE url = ?", articleURL).Scan(&count)
+	err := s.db.QueryRow("SELECT COUNT(*) FROM articles WHERE url = ?"+condition, append([]any{articleURL}, args...)...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("error checking for existing article: %w", err)
 	}
@@ -346,6 +713,21 @@ func (s *SQLStore) IsArticleAlreadyProcessed(ctx context.Context, articleURL str
 	return count > 0, nil
 }
 
+// IsSimilarArticleProcessed reports whether an article whose URL normalizes to the same key as
+// articleURL (see models.NormalizeArticleURL) has been recorded by any feed, within the dedup
+// window if one is set.
+func (s *SQLStore) IsSimilarArticleProcessed(ctx context.Context, articleURL string) (bool, error) {
+	condition, args := s.dedupCondition()
+	var count int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM articles WHERE normalized_url = ?"+condition,
+		append([]any{models.NormalizeArticleURL(articleURL)}, args...)...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking for similar article: %w", err)
+	}
+
+	return count > 0, nil
+}
+
 // GetDefaultPollInterval retrieves the default poll interval from settings.
 func (s *SQLStore) GetDefaultPollInterval(ctx context.Context) (int, error) {
 	var interval int
@@ -359,7 +741,7 @@ func (s *SQLStore) GetDefaultPollInterval(ctx context.Context) (int, error) {
 
 // UpdateDefaultPollInterval updates the default poll interval in settings.
 func (s *SQLStore) UpdateDefaultPollInterval(ctx context.Context, interval int) error {
-	stmt, err := s.db.PrepareContext(ctx, "INSERT OR REPLACE INTO settings (key, value) VALUES (?, ?)")
+	stmt, err := s.db.PrepareContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value")
 	if err != nil {
 		return fmt.Errorf("failed to prepare update settings statement: %w", err)
 	}
@@ -369,7 +751,7 @@ func (s *SQLStore) UpdateDefaultPollInterval(ctx context.Context, interval int)
 		}
 	}()
 
-	_, err = stmt.Exec("default_poll_interval_minutes", interval)
+	_, err = stmt.Exec("default_poll_interval_minutes", strconv.Itoa(interval))
 	if err != nil {
 		return fmt.Errorf("failed to update settings: %w", err)
 	}
@@ -377,6 +759,57 @@ func (s *SQLStore) UpdateDefaultPollInterval(ctx context.Context, interval int)
 	return nil
 }
 
+// GetOrCreateCSRFSecret returns the CSRF secret stored in settings, storing candidate first if
+// none exists yet. Instances sharing the database therefore agree on the secret.
+func (s *SQLStore) GetOrCreateCSRFSecret(ctx context.Context, candidate string) (string, error) {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO NOTHING", "csrf_secret", candidate)
+
+		return execErr
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store CSRF secret: %w", err)
+	}
+
+	var secret string
+	if err := s.db.QueryRowContext(ctx, "SELECT value FROM settings WHERE key = ?", "csrf_secret").Scan(&secret); err != nil {
+		return "", fmt.Errorf("failed to get CSRF secret from settings: %w", err)
+	}
+
+	return secret, nil
+}
+
+// setupCompleteKey is the settings key recording that the first-run setup was finished.
+const setupCompleteKey = "setup_complete"
+
+// IsSetupComplete reports whether the first-run setup has been finished or dismissed.
+func (s *SQLStore) IsSetupComplete(ctx context.Context) (bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM settings WHERE key = ?", setupCompleteKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get setup state from settings: %w", err)
+	}
+
+	return value == "true", nil
+}
+
+// MarkSetupComplete records that the first-run setup is finished, so it is not offered again.
+func (s *SQLStore) MarkSetupComplete(ctx context.Context) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "INSERT INTO settings (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value", setupCompleteKey, "true")
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store setup state: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateFeedLastFetched updates the last_fetched timestamp for a feed.
 func (s *SQLStore) UpdateFeedLastFetched(ctx context.Context, feedID int) error {
 	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET last_fetched = ? WHERE id = ?")
@@ -389,7 +822,11 @@ func (s *SQLStore) UpdateFeedLastFetched(ctx context.Context, feedID int) error
 		}
 	}()
 
-	_, err = stmt.Exec(time.Now(), feedID)
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(time.Now(), feedID)
+
+		return execErr
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update feed last_fetched: %w", err)
 	}
@@ -397,6 +834,199 @@ func (s *SQLStore) UpdateFeedLastFetched(ctx context.Context, feedID int) error
 	return nil
 }
 
+// UpdateFeedLastBuildDate records the feed-level build date seen on the last processed fetch.
+func (s *SQLStore) UpdateFeedLastBuildDate(ctx context.Context, feedID int, lastBuildDate time.Time) error {
+	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET last_build_date = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare update feed build date statement: %w", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logging.Error("Failed to close statement", "error", err)
+		}
+	}()
+
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(lastBuildDate, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed last_build_date: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFeedFavicon stores the favicon found for a feed's site ("" = none found) and records
+// when it was looked up.
+func (s *SQLStore) UpdateFeedFavicon(ctx context.Context, feedID int, faviconURL string) error {
+	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET favicon_url = ?, favicon_checked_at = CURRENT_TIMESTAMP WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare update feed favicon statement: %w", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logging.Error("Failed to close statement", "error", err)
+		}
+	}()
+
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(faviconURL, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed favicon: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFeedURL changes the URL a feed is fetched from. It fails if another feed already uses
+// feedURL.
+func (s *SQLStore) UpdateFeedURL(ctx context.Context, feedID int, feedURL string) error {
+	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET url = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare update feed URL statement: %w", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logging.Error("Failed to close statement", "error", err)
+		}
+	}()
+
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(feedURL, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed URL: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementArticleClicks records that an article was opened from the articles list.
+func (s *SQLStore) IncrementArticleClicks(ctx context.Context, id int) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "UPDATE articles SET clicks = COALESCE(clicks, 0) + 1 WHERE id = ?", id)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to increment article clicks: %w", err)
+	}
+
+	return nil
+}
+
+// SetArticleWallabagEntry records the Wallabag entry of an article that was saved unsent.
+func (s *SQLStore) SetArticleWallabagEntry(ctx context.Context, id, wallabagEntryID int) error {
+	var res sql.Result
+	err := retryOnLock(func() error {
+		var execErr error
+		res, execErr = s.db.ExecContext(ctx, "UPDATE articles SET wallabag_entry_id = ? WHERE id = ?", wallabagEntryID, id)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set article wallabag entry: %w", err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get updated article count: %w", err)
+	}
+	if updated == 0 {
+		return ErrArticleNotFound
+	}
+
+	return nil
+}
+
+// UpdateFeedContentKind stores whether the feed's items carried full content when last fetched.
+func (s *SQLStore) UpdateFeedContentKind(ctx context.Context, feedID int, kind models.ContentKind) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "UPDATE feeds SET content_kind = ? WHERE id = ?", string(kind), feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed content kind: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFeedTTL stores the <ttl> the feed advertised when last fetched, in minutes (0 = none).
+func (s *SQLStore) UpdateFeedTTL(ctx context.Context, feedID int, minutes int) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "UPDATE feeds SET ttl_minutes = ? WHERE id = ?", minutes, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed ttl: %w", err)
+	}
+
+	return nil
+}
+
+// DisableFeed disables a feed on the worker's behalf, recording why so it can be shown with the
+// feed. Enabling the feed again clears the reason.
+func (s *SQLStore) DisableFeed(ctx context.Context, feedID int, reason string) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "UPDATE feeds SET disabled = ?, disabled_reason = ? WHERE id = ?", true, reason, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable feed: %w", err)
+	}
+
+	return nil
+}
+
+// SnoozeFeed pauses a feed until the given time; nil wakes it.
+func (s *SQLStore) SnoozeFeed(ctx context.Context, feedID int, until *time.Time) error {
+	err := retryOnLock(func() error {
+		_, execErr := s.db.ExecContext(ctx, "UPDATE feeds SET snoozed_until = ? WHERE id = ?", until, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to snooze feed: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFeedAutoInterval stores the poll interval derived for an auto-interval feed.
+func (s *SQLStore) UpdateFeedAutoInterval(ctx context.Context, feedID int, minutes int) error {
+	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET auto_interval_minutes = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare update feed auto interval statement: %w", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logging.Error("Failed to close statement", "error", err)
+		}
+	}()
+
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(minutes, feedID)
+
+		return execErr
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update feed auto_interval_minutes: %w", err)
+	}
+
+	return nil
+}
+
 // MarkFeedInitialSyncCompleted marks a feed's initial sync as completed.
 func (s *SQLStore) MarkFeedInitialSyncCompleted(ctx context.Context, feedID int) error {
 	stmt, err := s.db.PrepareContext(ctx, "UPDATE feeds SET initial_sync_done = 1 WHERE id = ?")
@@ -409,10 +1039,214 @@ func (s *SQLStore) MarkFeedInitialSyncCompleted(ctx context.Context, feedID int)
 		}
 	}()
 
-	_, err = stmt.Exec(feedID)
+	err = retryOnLock(func() error {
+		_, execErr := stmt.Exec(feedID)
+
+		return execErr
+	})
 	if err != nil {
 		return fmt.Errorf("failed to mark feed initial sync completed: %w", err)
 	}
 
 	return nil
 }
+
+// SetFeedsEnabled enables or disables the given feeds in a single transaction, clearing any
+// reason the worker gave for disabling them.
+func (s *SQLStore) SetFeedsEnabled(ctx context.Context, ids []int, enabled bool) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin set feeds enabled transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logging.Error("Failed to roll back transaction", "error", err)
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, "UPDATE feeds SET disabled = ?, disabled_reason = NULL WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("failed to prepare set feeds enabled statement: %w", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logging.Error("Failed to close statement", "error", err)
+		}
+	}()
+
+	for _, id := range ids {
+		if _, err := stmt.ExecContext(ctx, !enabled, id); err != nil {
+			return fmt.Errorf("failed to set enabled state for feed %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit set feeds enabled transaction: %w", err)
+	}
+
+	return nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// UpdateArticlesFeedID moves every article of feed fromID to feed toID.
+func (s *SQLStore) UpdateArticlesFeedID(ctx context.Context, fromID, toID int) error {
+	return retryOnLock(func() error {
+		_, err := updateArticlesFeedID(ctx, s.db, fromID, toID)
+
+		return err
+	})
+}
+
+// updateArticlesFeedID reassigns fromID's articles to toID using db and returns how many moved
+func updateArticlesFeedID(ctx context.Context, db execer, fromID, toID int) (int64, error) {
+	res, err := db.ExecContext(ctx, "UPDATE articles SET feed_id = ? WHERE feed_id = ?", toID, fromID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign articles from feed %d to feed %d: %w", fromID, toID, err)
+	}
+
+	moved, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reassigned article count: %w", err)
+	}
+
+	return moved, nil
+}
+
+// PruneFeedArticlesKeepingLatest deletes all but the n most recently recorded articles of a
+// feed and returns how many were deleted. n of zero or less keeps everything. Pruned articles
+// no longer count as processed, so n must cover every item the feed still lists.
+func (s *SQLStore) PruneFeedArticlesKeepingLatest(ctx context.Context, feedID, n int) (int64, error) {
+	if n <= 0 {
+		return 0, nil
+	}
+
+	var res sql.Result
+	err := retryOnLock(func() error {
+		var execErr error
+		res, execErr = s.db.ExecContext(ctx, `
+			DELETE FROM articles WHERE feed_id = ? AND id NOT IN (
+				SELECT id FROM articles WHERE feed_id = ? ORDER BY id DESC LIMIT ?
+			)`, feedID, feedID, n)
+
+		return execErr
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune articles of feed %d: %w", feedID, err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pruned article count: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// orphanedArticlesCondition matches articles whose feed no longer exists, left behind by feeds
+// deleted while SQLite was not enforcing the foreign key.
+const orphanedArticlesCondition = "feed_id NOT IN (SELECT id FROM feeds)"
+
+// GetOrphanedArticles retrieves articles whose feed no longer exists, newest first.
+func (s *SQLStore) GetOrphanedArticles(ctx context.Context) ([]models.Article, error) {
+	return s.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles WHERE "+orphanedArticlesCondition+" ORDER BY created_at DESC")
+}
+
+// DeleteOrphanedArticles deletes articles whose feed no longer exists and returns how many
+// were deleted.
+func (s *SQLStore) DeleteOrphanedArticles(ctx context.Context) (int64, error) {
+	var res sql.Result
+	err := retryOnLock(func() error {
+		var execErr error
+		res, execErr = s.db.ExecContext(ctx, "DELETE FROM articles WHERE "+orphanedArticlesCondition)
+
+		return execErr
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphaned articles: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted article count: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// MergeFeeds folds feed fromID into feed toID in a single transaction: its articles move to
+// toID, any that could not be moved as duplicates are dropped, and fromID is deleted. It
+// returns the number of articles moved.
+func (s *SQLStore) MergeFeeds(ctx context.Context, fromID, toID int) (int64, error) {
+	if fromID == toID {
+		return 0, errors.New("cannot merge a feed into itself")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin merge feeds transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logging.Error("Failed to roll back transaction", "error", err)
+		}
+	}()
+
+	var exists int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM feeds WHERE id IN (?, ?)", fromID, toID).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to look up feeds to merge: %w", err)
+	}
+	if exists != 2 {
+		return 0, fmt.Errorf("feeds %d and %d must both exist to be merged", fromID, toID)
+	}
+
+	moved, err := updateArticlesFeedID(ctx, tx, fromID, toID)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM articles WHERE feed_id = ?", fromID); err != nil {
+		return 0, fmt.Errorf("failed to drop duplicate articles of feed %d: %w", fromID, err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM feeds WHERE id = ?", fromID); err != nil {
+		return 0, fmt.Errorf("failed to delete merged feed %d: %w", fromID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit merge feeds transaction: %w", err)
+	}
+
+	return moved, nil
+}
+
+// Ping verifies that the database connection is still usable.
+func (s *SQLStore) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// CheckWritable verifies that the database accepts writes, e.g. that its file is not read-only,
+// by starting a write in a transaction that is rolled back.
+func (s *SQLStore) CheckWritable(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin write check: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logging.Error("Failed to roll back write check", "error", err)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE settings SET value = value WHERE key = ?", "default_poll_interval_minutes"); err != nil {
+		return fmt.Errorf("database is not writable: %w", err)
+	}
+
+	return nil
+}
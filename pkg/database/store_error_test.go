@@ -55,7 +55,7 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		// Mock successful preparation but failed execution
 		mock.ExpectPrepare("UPDATE feeds SET").ExpectExec().
 			WithArgs(feed.Name, feed.URL, feed.PollIntervalMinutes, feed.PollInterval, 
-				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone, feed.ID).
+				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone, feed.Disabled, feed.FetchTimeoutSeconds, feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader, "", feed.MinAgeMinutes, false, "", feed.KeepLastN, feed.Priority, feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), nil, feed.MinTitleLength, feed.ID).
 			WillReturnError(errors.New("execution failed"))
 
 		err = store.UpdateFeed(ctx, feed)
@@ -138,7 +138,7 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 
 		mock.ExpectPrepare("INSERT INTO feeds").ExpectExec().
 			WithArgs(feed.Name, feed.URL, feed.PollIntervalMinutes, feed.PollInterval,
-				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone).
+				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone, feed.Disabled, feed.FetchTimeoutSeconds, feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader, "", feed.MinAgeMinutes, "", feed.KeepLastN, feed.Priority, feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), feed.MinTitleLength).
 			WillReturnError(errors.New("execution failed"))
 
 		_, err = store.InsertFeed(ctx, feed)
@@ -165,7 +165,7 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		result := sqlmock.NewErrorResult(errors.New("last insert id failed"))
 		mock.ExpectPrepare("INSERT INTO feeds").ExpectExec().
 			WithArgs(feed.Name, feed.URL, feed.PollIntervalMinutes, feed.PollInterval,
-				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone).
+				string(feed.PollIntervalUnit), string(feed.SyncMode), nil, nil, feed.InitialSyncDone, feed.Disabled, feed.FetchTimeoutSeconds, feed.StripQueryParams, feed.AutoInterval, feed.ContentSelector, feed.TagWithFeedName, feed.AcceptHeader, "", feed.MinAgeMinutes, "", feed.KeepLastN, feed.Priority, feed.CategoriesAsTags, string(feed.DeliveryMode), string(feed.DateField), feed.MinTitleLength).
 			WillReturnResult(result)
 
 		_, err = store.InsertFeed(ctx, feed)
@@ -183,7 +183,7 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		store := database.NewSQLStore(db)
 		ctx := context.Background()
 
-		mock.ExpectPrepare("INSERT OR REPLACE INTO settings").WillReturnError(errors.New("prepare failed"))
+		mock.ExpectPrepare("INSERT INTO settings").WillReturnError(errors.New("prepare failed"))
 
 		err = store.UpdateDefaultPollInterval(ctx, 60)
 		assert.Error(t, err)
@@ -200,8 +200,8 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		store := database.NewSQLStore(db)
 		ctx := context.Background()
 
-		mock.ExpectPrepare("INSERT OR REPLACE INTO settings").ExpectExec().
-			WithArgs("default_poll_interval_minutes", 60).
+		mock.ExpectPrepare("INSERT INTO settings").ExpectExec().
+			WithArgs("default_poll_interval_minutes", "60").
 			WillReturnError(errors.New("execution failed"))
 
 		err = store.UpdateDefaultPollInterval(ctx, 60)
@@ -318,7 +318,7 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		}
 
 		mock.ExpectPrepare("INSERT INTO articles").ExpectExec().
-			WithArgs(1, article.Title, article.URL, 123, article.PublishedAt).
+			WithArgs(1, article.Title, article.URL, 123, article.PublishedAt, nil, false, false, 1, "example.com/article").
 			WillReturnError(errors.New("execution failed"))
 
 		err = store.SaveArticle(ctx, 1, article, 123)
@@ -393,8 +393,8 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 		store := database.NewSQLStore(db)
 		ctx := context.Background()
 
-		rows := sqlmock.NewRows([]string{"id", "feed_id", "title", "url", "wallabag_entry_id", "published_at", "created_at"}).
-			AddRow(1, 1, "Test Article", "https://example.com", nil, nil, time.Now()).
+		rows := sqlmock.NewRows([]string{"id", "feed_id", "title", "url", "wallabag_entry_id", "published_at", "created_at", "original_url"}).
+			AddRow(1, 1, "Test Article", "https://example.com", nil, nil, time.Now(), nil).
 			RowError(0, errors.New("row error"))
 
 		mock.ExpectQuery("SELECT id, feed_id, title, url").WillReturnRows(rows)
@@ -423,4 +423,60 @@ func TestSQLStore_ErrorPaths(t *testing.T) {
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
-}
\ No newline at end of file
+}
+func TestSQLStore_RetryOnLock(t *testing.T) {
+	t.Run("SaveArticle succeeds after transient lock errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		store := database.NewSQLStore(db)
+		article := &models.Article{Title: "Test Article", URL: "https://example.com/article"}
+
+		prep := mock.ExpectPrepare("INSERT INTO articles")
+		prep.ExpectExec().WillReturnError(errors.New("database is locked (5) (SQLITE_BUSY)"))
+		prep.ExpectExec().WillReturnError(errors.New("database is locked (5) (SQLITE_BUSY)"))
+		prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err = store.SaveArticle(context.Background(), 1, article, 123)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("UpdateFeedLastFetched gives up after repeated lock errors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		store := database.NewSQLStore(db)
+
+		prep := mock.ExpectPrepare("UPDATE feeds SET last_fetched")
+		for i := 0; i < 5; i++ {
+			prep.ExpectExec().WillReturnError(errors.New("database is locked"))
+		}
+
+		err = store.UpdateFeedLastFetched(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database is locked")
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Constraint errors are not retried", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		store := database.NewSQLStore(db)
+		feed := &models.Feed{Name: "Dup", URL: "https://example.com/feed"}
+
+		mock.ExpectPrepare("INSERT INTO feeds").ExpectExec().
+			WillReturnError(errors.New("constraint failed: UNIQUE constraint failed: feeds.url"))
+
+		_, err = store.InsertFeed(context.Background(), feed)
+		assert.Error(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
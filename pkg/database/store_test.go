@@ -1,6 +1,7 @@
 package database_test
 
 import (
+	"strings"
 	"context"
 	"database/sql"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
 	"wallabag-rss-tool/pkg/database"
 	"wallabag-rss-tool/pkg/models"
@@ -62,6 +64,10 @@ INSERT INTO settings (key, value) VALUES ('default_poll_interval_minutes', '60')
 	_, err = db.Exec(schema)
 	assert.NoError(t, err)
 
+	// Bring the original schema above up to date with later column additions
+	err = database.ApplyMigrations(db)
+	assert.NoError(t, err)
+
 	cleanup = func() {
 		db.Close()
 		os.RemoveAll(tempDir)
@@ -122,6 +128,122 @@ func TestSQLStore_GetFeeds(t *testing.T) {
 	})
 }
 
+func TestSQLStore_GetFeedsPaged(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	var ids []int
+	for i := 1; i <= 5; i++ {
+		id, err := store.InsertFeed(ctx, &models.Feed{
+			Name: fmt.Sprintf("Feed %d", i),
+			URL:  fmt.Sprintf("https://example.com/feed%d", i),
+		})
+		require.NoError(t, err)
+		ids = append(ids, int(id))
+	}
+
+	tests := []struct {
+		name    string
+		limit   int
+		offset  int
+		wantIDs []int
+	}{
+		{name: "First page", limit: 2, offset: 0, wantIDs: ids[0:2]},
+		{name: "Middle page", limit: 2, offset: 2, wantIDs: ids[2:4]},
+		{name: "Partial last page", limit: 2, offset: 4, wantIDs: ids[4:5]},
+		{name: "Past the end", limit: 2, offset: 6, wantIDs: []int{}},
+		{name: "Page larger than the table", limit: 50, offset: 0, wantIDs: ids},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds, total, err := store.GetFeedsPaged(ctx, tt.limit, tt.offset)
+			require.NoError(t, err)
+			assert.Equal(t, 5, total)
+
+			gotIDs := make([]int, 0, len(feeds))
+			for _, feed := range feeds {
+				gotIDs = append(gotIDs, feed.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestSQLStore_GetFeedsSorted(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	// Inserted out of name order; articles and fetch times differ per feed
+	setup := []struct {
+		name        string
+		lastFetched string
+		articles    int
+	}{
+		{name: "charlie", lastFetched: "2024-01-02 00:00:00", articles: 1},
+		{name: "Alpha", lastFetched: "", articles: 3},
+		{name: "bravo", lastFetched: "2024-01-03 00:00:00", articles: 0},
+		{name: "Delta", lastFetched: "", articles: 2},
+	}
+	ids := make([]int, 0, len(setup))
+	for i, feed := range setup {
+		id, err := store.InsertFeed(ctx, &models.Feed{Name: feed.name, URL: fmt.Sprintf("https://example.com/sorted%d", i)})
+		require.NoError(t, err)
+		ids = append(ids, int(id))
+		if feed.lastFetched != "" {
+			_, err = db.Exec("UPDATE feeds SET last_fetched = ? WHERE id = ?", feed.lastFetched, id)
+			require.NoError(t, err)
+		}
+		for j := 0; j < feed.articles; j++ {
+			article := &models.Article{Title: "Post", URL: fmt.Sprintf("https://example.com/sorted%d/%d", i, j)}
+			require.NoError(t, store.SaveArticle(ctx, int(id), article, j+1))
+		}
+	}
+
+	tests := []struct {
+		name    string
+		sort    string
+		wantIDs []int
+	}{
+		{name: "ID", sort: database.FeedSortID, wantIDs: []int{ids[0], ids[1], ids[2], ids[3]}},
+		{name: "Name ignores case", sort: database.FeedSortName, wantIDs: []int{ids[1], ids[2], ids[0], ids[3]}},
+		{name: "Last fetched puts never-fetched feeds last", sort: database.FeedSortLastFetched, wantIDs: []int{ids[2], ids[0], ids[1], ids[3]}},
+		{name: "Article count", sort: database.FeedSortArticles, wantIDs: []int{ids[1], ids[3], ids[0], ids[2]}},
+		{name: "Unknown sort falls back to ID", sort: "name; DROP TABLE feeds", wantIDs: []int{ids[0], ids[1], ids[2], ids[3]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds, total, err := store.GetFeedsSorted(ctx, tt.sort, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, 4, total)
+
+			gotIDs := make([]int, 0, len(feeds))
+			for _, feed := range feeds {
+				gotIDs = append(gotIDs, feed.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+
+	t.Run("Paging follows the sort", func(t *testing.T) {
+		feeds, _, err := store.GetFeedsSorted(ctx, database.FeedSortName, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, feeds, 2)
+		assert.Equal(t, "charlie", feeds[0].Name)
+		assert.Equal(t, "Delta", feeds[1].Name)
+	})
+
+	t.Run("IsFeedSort", func(t *testing.T) {
+		assert.True(t, database.IsFeedSort(database.FeedSortArticles))
+		assert.False(t, database.IsFeedSort("url"))
+	})
+}
+
 func TestSQLStore_GetFeedByID(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -131,7 +253,8 @@ func TestSQLStore_GetFeedByID(t *testing.T) {
 		feed, err := store.GetFeedByID(context.Background(), 999)
 		assert.Error(t, err)
 		assert.Nil(t, feed)
-		assert.Contains(t, err.Error(), "feed with ID 999 not found")
+		assert.ErrorIs(t, err, database.ErrFeedNotFound)
+		assert.Contains(t, err.Error(), "feed with ID 999")
 	})
 
 	t.Run("Get existing feed", func(t *testing.T) {
@@ -217,7 +340,7 @@ func TestSQLStore_InsertFeed(t *testing.T) {
 
 		// Second insert should fail due to unique constraint
 		_, err = store.InsertFeed(context.Background(), &feed2)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, database.ErrFeedExists)
 		assert.Contains(t, err.Error(), "failed to insert feed")
 	})
 }
@@ -354,6 +477,79 @@ func TestSQLStore_GetArticles(t *testing.T) {
 	})
 }
 
+func TestSQLStore_GetUnsentArticles(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", true)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	now := time.Now()
+	_, err = db.Exec("INSERT INTO articles (feed_id, title, url, wallabag_entry_id, created_at) VALUES (?, ?, ?, ?, ?)",
+		feedID, "Sent", "https://example.com/sent", 123, now)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO articles (feed_id, title, url, created_at) VALUES (?, ?, ?, ?)",
+		feedID, "Unsent 1", "https://example.com/unsent1", now.Add(-time.Minute))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO articles (feed_id, title, url, wallabag_entry_id, created_at) VALUES (?, ?, ?, ?, ?)",
+		feedID, "Also Sent", "https://example.com/sent2", 124, now.Add(-2*time.Minute))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO articles (feed_id, title, url, created_at) VALUES (?, ?, ?, ?)",
+		feedID, "Unsent 2", "https://example.com/unsent2", now.Add(-3*time.Minute))
+	assert.NoError(t, err)
+
+	articles, err := store.GetUnsentArticles(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 2) {
+		assert.Equal(t, "Unsent 1", articles[0].Title)
+		assert.Equal(t, "Unsent 2", articles[1].Title)
+		for _, article := range articles {
+			assert.Nil(t, article.WallabagEntryID)
+		}
+	}
+}
+
+func TestSQLStore_SaveFailedArticle(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveFailedArticle(ctx, int(feedID), &models.Article{Title: "Refused", URL: "https://example.com/refused"}))
+
+	// Recorded as processed, so polls skip it, and listed as unsent for a retry
+	processed, err := store.IsArticleAlreadyProcessed(ctx, "https://example.com/refused")
+	require.NoError(t, err)
+	assert.True(t, processed)
+	unsent, err := store.GetUnsentArticles(ctx)
+	require.NoError(t, err)
+	require.Len(t, unsent, 1)
+	assert.Nil(t, unsent[0].WallabagEntryID)
+	assert.False(t, unsent[0].Filtered)
+	assert.False(t, unsent[0].MarkedProcessed)
+
+	t.Run("Retry records the entry", func(t *testing.T) {
+		require.NoError(t, store.SetArticleWallabagEntry(ctx, unsent[0].ID, 42))
+
+		article, err := store.GetArticleByID(ctx, unsent[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, article.WallabagEntryID)
+		assert.Equal(t, 42, *article.WallabagEntryID)
+		unsent, err := store.GetUnsentArticles(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, unsent)
+	})
+
+	t.Run("Unknown article", func(t *testing.T) {
+		assert.ErrorIs(t, store.SetArticleWallabagEntry(ctx, 999, 1), database.ErrArticleNotFound)
+	})
+}
+
 func TestSQLStore_SaveArticle(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -415,9 +611,171 @@ func TestSQLStore_SaveArticle(t *testing.T) {
 		err = store.SaveArticle(context.Background(), int(feedID), &article, 222)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to insert article")
+		assert.ErrorIs(t, err, database.ErrArticleExists)
+	})
+}
+
+func TestSQLStore_SaveArticle_FieldLimits(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStoreWithLimits(db, models.FieldLimits{MaxTitleLength: 10, MaxURLLength: 40})
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", true)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	t.Run("Over-long title is truncated", func(t *testing.T) {
+		article := models.Article{Title: "A very long article title", URL: "https://example.com/long-title"}
+
+		err := store.SaveArticle(context.Background(), int(feedID), &article, 1)
+		assert.NoError(t, err)
+
+		var title string
+		err = db.QueryRow("SELECT title FROM articles WHERE url = ?", article.URL).Scan(&title)
+		assert.NoError(t, err)
+		assert.Equal(t, "A very lon", title)
+	})
+
+	t.Run("Over-long URL is rejected", func(t *testing.T) {
+		article := models.Article{Title: "Title", URL: "https://example.com/" + strings.Repeat("x", 40)}
+
+		err := store.SaveArticle(context.Background(), int(feedID), &article, 2)
+		assert.Error(t, err)
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM articles WHERE wallabag_entry_id = 2").Scan(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
 	})
 }
 
+func TestSQLStore_SaveArticle_OriginalURL(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", true)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	article := models.Article{
+		Title:       "Tracked",
+		URL:         "https://example.com/post",
+		OriginalURL: "https://example.com/post?utm_source=rss",
+	}
+	assert.NoError(t, store.SaveArticle(context.Background(), int(feedID), &article, 1))
+
+	processed, err := store.IsArticleAlreadyProcessed(context.Background(), "https://example.com/post")
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	articles, err := store.GetArticles(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 1) {
+		assert.Equal(t, "https://example.com/post", articles[0].URL)
+		assert.Equal(t, "https://example.com/post?utm_source=rss", articles[0].OriginalURL)
+	}
+}
+
+func TestSQLStore_SaveArticle_FeedURL(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feed := &models.Feed{Name: "Source", URL: "https://example.com/old-feed.xml", SyncMode: models.SyncModeNone}
+	id, err := store.InsertFeed(ctx, feed)
+	assert.NoError(t, err)
+	feed.ID = int(id)
+
+	article := models.Article{Title: "Traced", URL: "https://example.com/traced"}
+	assert.NoError(t, store.SaveArticle(ctx, feed.ID, &article, 1))
+
+	// The snapshot keeps the URL the feed had when the article was saved
+	feed.URL = "https://example.com/new-feed.xml"
+	assert.NoError(t, store.UpdateFeed(ctx, feed))
+
+	articles, err := store.GetArticles(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 1) {
+		assert.Equal(t, "https://example.com/old-feed.xml", articles[0].FeedURL)
+	}
+
+	second := models.Article{Title: "After Move", URL: "https://example.com/after-move"}
+	assert.NoError(t, store.SaveArticle(ctx, feed.ID, &second, 2))
+
+	articles, err = store.GetArticles(ctx)
+	assert.NoError(t, err)
+	feedURLs := map[string]string{}
+	for _, saved := range articles {
+		feedURLs[saved.URL] = saved.FeedURL
+	}
+	assert.Equal(t, "https://example.com/old-feed.xml", feedURLs["https://example.com/traced"])
+	assert.Equal(t, "https://example.com/new-feed.xml", feedURLs["https://example.com/after-move"])
+}
+
+func TestSQLStore_SaveFilteredArticle(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", true)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	article := models.Article{Title: "Off Topic", URL: "https://example.com/off-topic"}
+	assert.NoError(t, store.SaveFilteredArticle(context.Background(), int(feedID), &article))
+
+	processed, err := store.IsArticleAlreadyProcessed(context.Background(), article.URL)
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	articles, err := store.GetArticles(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 1) {
+		assert.True(t, articles[0].Filtered)
+		assert.Nil(t, articles[0].WallabagEntryID)
+	}
+
+	// Filtered articles were never meant to reach Wallabag, so they are not unsent
+	unsent, err := store.GetUnsentArticles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, unsent)
+}
+
+func TestSQLStore_SaveMarkedArticle(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", false)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	article := models.Article{Title: "Already Read", URL: "https://example.com/already-read"}
+	assert.NoError(t, store.SaveMarkedArticle(context.Background(), int(feedID), &article))
+
+	processed, err := store.IsArticleAlreadyProcessed(context.Background(), article.URL)
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	articles, err := store.GetArticles(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 1) {
+		assert.True(t, articles[0].MarkedProcessed)
+		assert.False(t, articles[0].Filtered)
+		assert.Nil(t, articles[0].WallabagEntryID)
+	}
+
+	unsent, err := store.GetUnsentArticles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, unsent)
+}
+
 func TestSQLStore_IsArticleAlreadyProcessed(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -532,6 +890,471 @@ func TestSQLStore_UpdateFeedLastFetched(t *testing.T) {
 	})
 }
 
+func TestSQLStore_UpdateFeedLastBuildDate(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	res, err := db.Exec("INSERT INTO feeds (url, name, sync_mode, initial_sync_done) VALUES (?, ?, ?, ?)",
+		"https://example.com/feed", "Test Feed", "none", true)
+	assert.NoError(t, err)
+	feedID, _ := res.LastInsertId()
+
+	buildDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	err = store.UpdateFeedLastBuildDate(context.Background(), int(feedID), buildDate)
+	assert.NoError(t, err)
+
+	feed, err := store.GetFeedByID(context.Background(), int(feedID))
+	assert.NoError(t, err)
+	if assert.NotNil(t, feed.LastBuildDate) {
+		assert.True(t, buildDate.Equal(*feed.LastBuildDate))
+	}
+}
+
+func TestSQLStore_GetOrCreateCSRFSecret(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	secret, err := store.GetOrCreateCSRFSecret(context.Background(), "first-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "first-secret", secret)
+
+	// A later run keeps the stored secret rather than its own candidate
+	secret, err = store.GetOrCreateCSRFSecret(context.Background(), "second-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "first-secret", secret)
+}
+
+func TestSQLStore_SetupComplete(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	complete, err := store.IsSetupComplete(ctx)
+	require.NoError(t, err)
+	assert.False(t, complete, "a new database has not been set up")
+
+	require.NoError(t, store.MarkSetupComplete(ctx))
+	require.NoError(t, store.MarkSetupComplete(ctx), "marking twice is harmless")
+
+	complete, err = store.IsSetupComplete(ctx)
+	require.NoError(t, err)
+	assert.True(t, complete)
+}
+
+func TestSQLStore_UpdateFeedAutoInterval(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feedID, err := store.InsertFeed(context.Background(), &models.Feed{
+		URL: "https://example.com/feed", Name: "Auto Feed", SyncMode: models.SyncModeNone, AutoInterval: true,
+	})
+	assert.NoError(t, err)
+
+	err = store.UpdateFeedAutoInterval(context.Background(), int(feedID), 90)
+	assert.NoError(t, err)
+
+	feed, err := store.GetFeedByID(context.Background(), int(feedID))
+	assert.NoError(t, err)
+	assert.True(t, feed.AutoInterval)
+	assert.Equal(t, 90, feed.AutoIntervalMinutes)
+}
+
+func TestSQLStore_UpdateFeedFavicon(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feedID, err := store.InsertFeed(context.Background(), &models.Feed{
+		URL: "https://example.com/feed", Name: "Icon Feed", SyncMode: models.SyncModeNone,
+	})
+	assert.NoError(t, err)
+
+	feed, err := store.GetFeedByID(context.Background(), int(feedID))
+	assert.NoError(t, err)
+	assert.Empty(t, feed.FaviconURL)
+	assert.Nil(t, feed.FaviconCheckedAt, "a new feed has never been looked up")
+
+	err = store.UpdateFeedFavicon(context.Background(), int(feedID), "https://example.com/favicon.ico")
+	assert.NoError(t, err)
+
+	feed, err = store.GetFeedByID(context.Background(), int(feedID))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/favicon.ico", feed.FaviconURL)
+	assert.NotNil(t, feed.FaviconCheckedAt)
+
+	// Editing the feed leaves the worker-managed favicon alone
+	feed.Name = "Renamed"
+	assert.NoError(t, store.UpdateFeed(context.Background(), feed))
+	feed, err = store.GetFeedByID(context.Background(), int(feedID))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/favicon.ico", feed.FaviconURL)
+}
+
+func TestSQLStore_UpdateFeedURL(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	id, err := store.InsertFeed(ctx, &models.Feed{URL: "http://example.com/feed", Name: "Plain", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	_, err = store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/other", Name: "Other", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpdateFeedURL(ctx, int(id), "https://example.com/feed"))
+	feed, err := store.GetFeedByID(ctx, int(id))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/feed", feed.URL)
+
+	err = store.UpdateFeedURL(ctx, int(id), "https://example.com/other")
+	assert.Error(t, err, "another feed already uses the URL")
+}
+
+func TestSQLStore_IncrementArticleClicks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Post", URL: "https://example.com/post"}, 1))
+	articles, err := store.GetFeedArticles(ctx, int(feedID))
+	require.NoError(t, err)
+	require.Len(t, articles, 1)
+	assert.Equal(t, 0, articles[0].Clicks)
+
+	require.NoError(t, store.IncrementArticleClicks(ctx, articles[0].ID))
+	require.NoError(t, store.IncrementArticleClicks(ctx, articles[0].ID))
+
+	article, err := store.GetArticleByID(ctx, articles[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/post", article.URL)
+	assert.Equal(t, 2, article.Clicks)
+
+	_, err = store.GetArticleByID(ctx, articles[0].ID+1)
+	assert.ErrorIs(t, err, database.ErrArticleNotFound)
+}
+
+func TestSQLStore_UpdateFeedContentKind(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	feed, err := store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentKind(""), feed.ContentKind, "not known before the first fetch")
+
+	require.NoError(t, store.UpdateFeedContentKind(ctx, int(feedID), models.ContentKindFull))
+
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentKindFull, feed.ContentKind)
+}
+
+func TestSQLStore_UpdateFeedTTL(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpdateFeedTTL(ctx, int(feedID), 60))
+
+	feed, err := store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.Equal(t, 60, feed.TTLMinutes)
+}
+
+func TestSQLStore_SnoozeFeed(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	until := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, store.SnoozeFeed(ctx, int(feedID), &until))
+
+	feed, err := store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	require.NotNil(t, feed.SnoozedUntil)
+	assert.True(t, until.Equal(*feed.SnoozedUntil))
+	assert.True(t, feed.IsSnoozed(time.Now()))
+
+	require.NoError(t, store.SnoozeFeed(ctx, int(feedID), nil))
+
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.Nil(t, feed.SnoozedUntil)
+	assert.False(t, feed.IsSnoozed(time.Now()))
+}
+
+func TestSQLStore_DisableFeed(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	const reason = "Wallabag rejected 5 articles in a row"
+	require.NoError(t, store.DisableFeed(ctx, int(feedID), reason))
+
+	feed, err := store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.True(t, feed.Disabled)
+	assert.Equal(t, reason, feed.DisabledReason)
+
+	// Editing the feed while it stays disabled keeps the reason
+	feed.Name = "Renamed"
+	require.NoError(t, store.UpdateFeed(ctx, feed))
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.Equal(t, reason, feed.DisabledReason)
+
+	// Enabling it clears the reason, so disabling it by hand later shows none
+	require.NoError(t, store.SetFeedsEnabled(ctx, []int{int(feedID)}, true))
+	require.NoError(t, store.SetFeedsEnabled(ctx, []int{int(feedID)}, false))
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.True(t, feed.Disabled)
+	assert.Empty(t, feed.DisabledReason)
+
+	// So does enabling it through an edit
+	require.NoError(t, store.DisableFeed(ctx, int(feedID), reason))
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	feed.Disabled = false
+	require.NoError(t, store.UpdateFeed(ctx, feed))
+	feed, err = store.GetFeedByID(ctx, int(feedID))
+	require.NoError(t, err)
+	assert.False(t, feed.Disabled)
+	assert.Empty(t, feed.DisabledReason)
+}
+
+func TestSQLStore_PruneFeedArticlesKeepingLatest(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	busyID, err := store.InsertFeed(ctx, &models.Feed{
+		URL: "https://example.com/busy", Name: "Busy", SyncMode: models.SyncModeNone, KeepLastN: 2,
+	})
+	assert.NoError(t, err)
+	otherID, err := store.InsertFeed(ctx, &models.Feed{
+		URL: "https://example.com/other", Name: "Other", SyncMode: models.SyncModeNone,
+	})
+	assert.NoError(t, err)
+
+	feed, err := store.GetFeedByID(ctx, int(busyID))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, feed.KeepLastN)
+
+	for _, url := range []string{"https://example.com/1", "https://example.com/2", "https://example.com/3"} {
+		assert.NoError(t, store.SaveMarkedArticle(ctx, int(busyID), &models.Article{Title: "Busy", URL: url}))
+	}
+	assert.NoError(t, store.SaveMarkedArticle(ctx, int(otherID), &models.Article{Title: "Other", URL: "https://example.com/other/1"}))
+
+	t.Run("Zero keeps everything", func(t *testing.T) {
+		deleted, err := store.PruneFeedArticlesKeepingLatest(ctx, int(busyID), 0)
+		assert.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("Keeps the newest articles of the feed only", func(t *testing.T) {
+		deleted, err := store.PruneFeedArticlesKeepingLatest(ctx, int(busyID), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		processed, err := store.IsArticleAlreadyProcessed(ctx, "https://example.com/1")
+		assert.NoError(t, err)
+		assert.False(t, processed, "the oldest article is pruned")
+		for _, url := range []string{"https://example.com/2", "https://example.com/3", "https://example.com/other/1"} {
+			processed, err := store.IsArticleAlreadyProcessed(ctx, url)
+			assert.NoError(t, err)
+			assert.True(t, processed, url)
+		}
+	})
+
+	t.Run("Nothing to prune", func(t *testing.T) {
+		deleted, err := store.PruneFeedArticlesKeepingLatest(ctx, int(busyID), 5)
+		assert.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+func TestSQLStore_OrphanedArticles(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	keptID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/kept", Name: "Kept", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	goneID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/gone", Name: "Gone", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveMarkedArticle(ctx, int(keptID), &models.Article{Title: "Kept", URL: "https://example.com/kept/1"}))
+	require.NoError(t, store.SaveMarkedArticle(ctx, int(goneID), &models.Article{Title: "Orphan", URL: "https://example.com/gone/1"}))
+
+	t.Run("None while every feed exists", func(t *testing.T) {
+		orphans, err := store.GetOrphanedArticles(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, orphans)
+	})
+
+	// Delete the feed directly, bypassing the cascade as databases without foreign key
+	// enforcement did
+	_, err = db.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM feeds WHERE id = ?", goneID)
+	require.NoError(t, err)
+
+	t.Run("Finds articles of deleted feeds", func(t *testing.T) {
+		orphans, err := store.GetOrphanedArticles(ctx)
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "https://example.com/gone/1", orphans[0].URL)
+		assert.Equal(t, int(goneID), orphans[0].FeedID)
+	})
+
+	t.Run("Deletes only the orphans", func(t *testing.T) {
+		deleted, err := store.DeleteOrphanedArticles(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		articles, err := store.GetArticles(ctx)
+		require.NoError(t, err)
+		require.Len(t, articles, 1)
+		assert.Equal(t, "https://example.com/kept/1", articles[0].URL)
+
+		deleted, err = store.DeleteOrphanedArticles(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+func TestSQLStore_Optimize(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	for i := range 200 {
+		title := fmt.Sprintf("Article %d %s", i, strings.Repeat("padding ", 50))
+		require.NoError(t, store.SaveMarkedArticle(ctx, int(feedID), &models.Article{Title: title, URL: fmt.Sprintf("https://example.com/%d", i)}))
+	}
+	// Leave free pages behind, as pruning does
+	deleted, err := store.PruneFeedArticlesKeepingLatest(ctx, int(feedID), 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(190), deleted)
+
+	before, err := store.DatabaseSize(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, before)
+
+	require.NoError(t, store.Optimize(ctx))
+
+	after, err := store.DatabaseSize(ctx)
+	require.NoError(t, err)
+	assert.Less(t, after, before, "VACUUM gives the freed pages back")
+
+	var seq int
+	var name, path string
+	require.NoError(t, db.QueryRowContext(ctx, "PRAGMA database_list").Scan(&seq, &name, &path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, after, info.Size())
+
+	articles, err := store.GetArticles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, articles, 10)
+}
+
+func TestSQLStore_GetFeedArticles(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	feedID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Feed", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	otherID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/other", Name: "Other", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	require.NoError(t, store.SaveArticle(ctx, int(feedID), &models.Article{Title: "Old", URL: "https://example.com/1"}, 1))
+	require.NoError(t, store.SaveArticle(ctx, int(otherID), &models.Article{Title: "Other", URL: "https://example.com/2"}, 2))
+	require.NoError(t, store.SaveFilteredArticle(ctx, int(feedID), &models.Article{Title: "New", URL: "https://example.com/3"}))
+
+	articles, err := store.GetFeedArticles(ctx, int(feedID))
+	require.NoError(t, err)
+	require.Len(t, articles, 2)
+	assert.Equal(t, "New", articles[0].Title)
+	assert.Nil(t, articles[0].WallabagEntryID)
+	assert.Equal(t, "Old", articles[1].Title)
+	require.NotNil(t, articles[1].WallabagEntryID)
+	assert.Equal(t, 1, *articles[1].WallabagEntryID)
+}
+
+func TestSQLStore_GetLatestArticlesForFeeds(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	busyID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/busy", Name: "Busy", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	quietID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/quiet", Name: "Quiet", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+	emptyID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/empty", Name: "Empty", SyncMode: models.SyncModeNone})
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		article := &models.Article{Title: fmt.Sprintf("Busy %d", i), URL: fmt.Sprintf("https://example.com/busy/%d", i)}
+		require.NoError(t, store.SaveArticle(ctx, int(busyID), article, i))
+	}
+	require.NoError(t, store.SaveArticle(ctx, int(quietID), &models.Article{Title: "Quiet 1", URL: "https://example.com/quiet/1"}, 10))
+
+	t.Run("Returns at most n newest articles per feed", func(t *testing.T) {
+		latest, err := store.GetLatestArticlesForFeeds(ctx, 3)
+		require.NoError(t, err)
+
+		titles := func(articles []models.Article) []string {
+			var result []string
+			for _, article := range articles {
+				result = append(result, article.Title)
+			}
+
+			return result
+		}
+		assert.Equal(t, []string{"Busy 5", "Busy 4", "Busy 3"}, titles(latest[int(busyID)]))
+		assert.Equal(t, []string{"Quiet 1"}, titles(latest[int(quietID)]), "feeds with fewer than n articles get all of them")
+		assert.NotContains(t, latest, int(emptyID))
+	})
+
+	t.Run("Zero returns nothing", func(t *testing.T) {
+		latest, err := store.GetLatestArticlesForFeeds(ctx, 0)
+		require.NoError(t, err)
+		assert.Empty(t, latest)
+	})
+}
+
 func TestSQLStore_MarkFeedInitialSyncCompleted(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -560,6 +1383,151 @@ func TestSQLStore_MarkFeedInitialSyncCompleted(t *testing.T) {
 	})
 }
 
+func TestSQLStore_SetFeedsEnabled(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	var ids []int
+	for i := 1; i <= 3; i++ {
+		id, err := store.InsertFeed(ctx, &models.Feed{
+			Name: fmt.Sprintf("Feed %d", i),
+			URL:  fmt.Sprintf("https://example.com/feed%d", i),
+		})
+		assert.NoError(t, err)
+		ids = append(ids, int(id))
+	}
+
+	t.Run("Disable only the given feeds", func(t *testing.T) {
+		err := store.SetFeedsEnabled(ctx, []int{ids[0], ids[2]}, false)
+		assert.NoError(t, err)
+
+		first, err := store.GetFeedByID(ctx, ids[0])
+		assert.NoError(t, err)
+		assert.True(t, first.Disabled)
+
+		second, err := store.GetFeedByID(ctx, ids[1])
+		assert.NoError(t, err)
+		assert.False(t, second.Disabled)
+
+		third, err := store.GetFeedByID(ctx, ids[2])
+		assert.NoError(t, err)
+		assert.True(t, third.Disabled)
+	})
+
+	t.Run("Re-enable a subset", func(t *testing.T) {
+		err := store.SetFeedsEnabled(ctx, []int{ids[2]}, true)
+		assert.NoError(t, err)
+
+		feeds, err := store.GetFeeds(ctx)
+		assert.NoError(t, err)
+		disabled := map[int]bool{}
+		for _, feed := range feeds {
+			disabled[feed.ID] = feed.Disabled
+		}
+		assert.Equal(t, map[int]bool{ids[0]: true, ids[1]: false, ids[2]: false}, disabled)
+	})
+
+	t.Run("Empty ID list is a no-op", func(t *testing.T) {
+		err := store.SetFeedsEnabled(ctx, nil, false)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSQLStore_MergeFeeds(t *testing.T) {
+	ctx := context.Background()
+
+	// setup creates an http and an https copy of a feed, each with its own articles
+	setup := func(t *testing.T) (*sql.DB, *database.SQLStore, int, int) {
+		db, cleanup := setupTestDB(t)
+		t.Cleanup(cleanup)
+		store := database.NewSQLStore(db)
+
+		httpID, err := store.InsertFeed(ctx, &models.Feed{Name: "Blog (http)", URL: "http://example.com/feed"})
+		require.NoError(t, err)
+		httpsID, err := store.InsertFeed(ctx, &models.Feed{Name: "Blog", URL: "https://example.com/feed"})
+		require.NoError(t, err)
+
+		require.NoError(t, store.SaveArticle(ctx, int(httpID), &models.Article{Title: "Old 1", URL: "https://example.com/old1"}, 1))
+		require.NoError(t, store.SaveArticle(ctx, int(httpID), &models.Article{Title: "Old 2", URL: "https://example.com/old2"}, 2))
+		require.NoError(t, store.SaveArticle(ctx, int(httpsID), &models.Article{Title: "New", URL: "https://example.com/new"}, 3))
+
+		return db, store, int(httpID), int(httpsID)
+	}
+
+	countArticles := func(t *testing.T, db *sql.DB, feedID int) int {
+		var count int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM articles WHERE feed_id = ?", feedID).Scan(&count))
+
+		return count
+	}
+
+	t.Run("Moves articles and deletes the source feed", func(t *testing.T) {
+		db, store, sourceID, targetID := setup(t)
+
+		moved, err := store.MergeFeeds(ctx, sourceID, targetID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), moved)
+
+		assert.Equal(t, 0, countArticles(t, db, sourceID))
+		assert.Equal(t, 3, countArticles(t, db, targetID))
+		_, err = store.GetFeedByID(ctx, sourceID)
+		assert.Error(t, err)
+		_, err = store.GetFeedByID(ctx, targetID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failure rolls back the whole merge", func(t *testing.T) {
+		db, store, sourceID, targetID := setup(t)
+		_, err := db.Exec(fmt.Sprintf(`CREATE TRIGGER block_feed_delete BEFORE DELETE ON feeds
+			WHEN old.id = %d BEGIN SELECT RAISE(ABORT, 'feed delete blocked'); END`, sourceID))
+		require.NoError(t, err)
+
+		_, err = store.MergeFeeds(ctx, sourceID, targetID)
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, countArticles(t, db, sourceID), "articles should not move when the feed delete fails")
+		assert.Equal(t, 1, countArticles(t, db, targetID))
+		_, err = store.GetFeedByID(ctx, sourceID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Missing target feed", func(t *testing.T) {
+		db, store, sourceID, _ := setup(t)
+
+		_, err := store.MergeFeeds(ctx, sourceID, 9999)
+		assert.Error(t, err)
+		assert.Equal(t, 2, countArticles(t, db, sourceID))
+	})
+
+	t.Run("Feed cannot be merged into itself", func(t *testing.T) {
+		_, store, sourceID, _ := setup(t)
+
+		_, err := store.MergeFeeds(ctx, sourceID, sourceID)
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLStore_UpdateArticlesFeedID(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	fromID, err := store.InsertFeed(ctx, &models.Feed{Name: "From", URL: "https://example.com/from"})
+	require.NoError(t, err)
+	toID, err := store.InsertFeed(ctx, &models.Feed{Name: "To", URL: "https://example.com/to"})
+	require.NoError(t, err)
+	require.NoError(t, store.SaveArticle(ctx, int(fromID), &models.Article{Title: "Post", URL: "https://example.com/post"}, 1))
+
+	require.NoError(t, store.UpdateArticlesFeedID(ctx, int(fromID), int(toID)))
+
+	var feedID int
+	require.NoError(t, db.QueryRow("SELECT feed_id FROM articles WHERE url = ?", "https://example.com/post").Scan(&feedID))
+	assert.Equal(t, int(toID), feedID)
+}
+
 func TestStore_ComprehensiveCoverage(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -900,3 +1868,240 @@ func TestStore_EdgeCaseCoverage(t *testing.T) {
 		}
 	})
 }
+
+func TestSQLStore_Ping(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	assert.NoError(t, store.Ping(context.Background()))
+
+	db.Close()
+	assert.Error(t, store.Ping(context.Background()))
+}
+
+func TestSQLStore_FetchTimeoutSecondsRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feed := &models.Feed{Name: "Slow Feed", URL: "https://example.com/slow", SyncMode: models.SyncModeNone, FetchTimeoutSeconds: 120}
+	id, err := store.InsertFeed(context.Background(), feed)
+	assert.NoError(t, err)
+
+	got, err := store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 120, got.FetchTimeoutSeconds)
+
+	got.FetchTimeoutSeconds = 0
+	assert.NoError(t, store.UpdateFeed(context.Background(), got))
+
+	got, err = store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.FetchTimeoutSeconds)
+}
+
+func TestSQLStore_IncludeCategoriesRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feed := &models.Feed{
+		Name:              "Tech",
+		URL:               "https://example.com/tech",
+		SyncMode:          models.SyncModeNone,
+		IncludeCategories: []string{"Go", "Databases"},
+	}
+	id, err := store.InsertFeed(context.Background(), feed)
+	assert.NoError(t, err)
+
+	got, err := store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go", "Databases"}, got.IncludeCategories)
+
+	got.IncludeCategories = nil
+	assert.NoError(t, store.UpdateFeed(context.Background(), got))
+
+	got, err = store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Empty(t, got.IncludeCategories)
+}
+
+func TestSQLStore_MinAgeMinutesRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feed := &models.Feed{Name: "Edited Feed", URL: "https://example.com/edited", SyncMode: models.SyncModeNone, MinAgeMinutes: 30}
+	id, err := store.InsertFeed(context.Background(), feed)
+	assert.NoError(t, err)
+
+	got, err := store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 30, got.MinAgeMinutes)
+
+	got.MinAgeMinutes = 0
+	assert.NoError(t, store.UpdateFeed(context.Background(), got))
+
+	got, err = store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.MinAgeMinutes)
+}
+
+func TestSQLStore_MinTitleLengthRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feed := &models.Feed{Name: "Edited Feed", URL: "https://example.com/edited", SyncMode: models.SyncModeNone, MinTitleLength: 5}
+	id, err := store.InsertFeed(context.Background(), feed)
+	assert.NoError(t, err)
+
+	got, err := store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.MinTitleLength)
+
+	got.MinTitleLength = 0
+	assert.NoError(t, store.UpdateFeed(context.Background(), got))
+
+	got, err = store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.MinTitleLength)
+}
+
+func TestSQLStore_IsSimilarArticleProcessed(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+	ctx := context.Background()
+
+	siteID, err := store.InsertFeed(ctx, &models.Feed{URL: "https://example.com/feed", Name: "Site", SyncMode: models.SyncModeNone})
+	assert.NoError(t, err)
+	assert.NoError(t, store.SaveArticle(ctx, int(siteID), &models.Article{
+		Title: "Post", URL: "https://www.example.com/post/?utm_source=rss",
+	}, 1))
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "http://example.com/post", want: true},
+		{url: "https://example.com/post?utm_medium=aggregator#top", want: true},
+		{url: "https://example.com/other-post", want: false},
+		{url: "https://example.com/post?page=2", want: false},
+	}
+	for _, tt := range tests {
+		similar, err := store.IsSimilarArticleProcessed(ctx, tt.url)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, similar, tt.url)
+	}
+}
+
+func TestSQLStore_PriorityRoundTrip(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	store := database.NewSQLStore(db)
+
+	feed := &models.Feed{Name: "Urgent Feed", URL: "https://example.com/urgent", SyncMode: models.SyncModeNone, Priority: 10}
+	id, err := store.InsertFeed(context.Background(), feed)
+	assert.NoError(t, err)
+
+	got, err := store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, got.Priority)
+
+	got.Priority = -3
+	assert.NoError(t, store.UpdateFeed(context.Background(), got))
+
+	got, err = store.GetFeedByID(context.Background(), int(id))
+	assert.NoError(t, err)
+	assert.Equal(t, -3, got.Priority)
+}
+
+func TestSQLStore_CheckWritable(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	db.SetMaxOpenConns(1) // query_only is per connection
+	store := database.NewSQLStore(db)
+
+	assert.NoError(t, store.CheckWritable(context.Background()))
+
+	_, err := db.Exec("PRAGMA query_only = ON")
+	require.NoError(t, err)
+	assert.Error(t, store.CheckWritable(context.Background()))
+}
+
+func TestSQLStore_FeedCookie(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	t.Run("Encrypted at rest and decrypted on load", func(t *testing.T) {
+		store := database.NewSQLStore(db)
+		require.NoError(t, store.SetCookieKey("test-cookie-key"))
+
+		feed := &models.Feed{Name: "Members", URL: "https://example.com/members", SyncMode: models.SyncModeNone, Cookie: "session=abc123"}
+		id, err := store.InsertFeed(context.Background(), feed)
+		require.NoError(t, err)
+
+		var stored string
+		require.NoError(t, db.QueryRow("SELECT cookie FROM feeds WHERE id = ?", id).Scan(&stored))
+		assert.NotContains(t, stored, "abc123")
+
+		got, err := store.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		assert.Equal(t, "session=abc123", got.Cookie)
+
+		got.Cookie = ""
+		require.NoError(t, store.UpdateFeed(context.Background(), got))
+		got, err = store.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		assert.Empty(t, got.Cookie)
+	})
+
+	t.Run("Rejected without a key", func(t *testing.T) {
+		store := database.NewSQLStore(db)
+
+		_, err := store.InsertFeed(context.Background(), &models.Feed{Name: "Locked", URL: "https://example.com/locked", SyncMode: models.SyncModeNone, Cookie: "session=abc123"})
+		assert.ErrorIs(t, err, database.ErrNoCookieKey)
+	})
+
+	t.Run("Wrong key drops the cookie", func(t *testing.T) {
+		writer := database.NewSQLStore(db)
+		require.NoError(t, writer.SetCookieKey("old-key"))
+		id, err := writer.InsertFeed(context.Background(), &models.Feed{Name: "Rotated", URL: "https://example.com/rotated", SyncMode: models.SyncModeNone, Cookie: "session=abc123"})
+		require.NoError(t, err)
+
+		reader := database.NewSQLStore(db)
+		require.NoError(t, reader.SetCookieKey("new-key"))
+		got, err := reader.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		assert.Empty(t, got.Cookie)
+		assert.True(t, got.CookieUnreadable)
+	})
+
+	t.Run("Saving under the wrong key keeps the cookie", func(t *testing.T) {
+		writer := database.NewSQLStore(db)
+		require.NoError(t, writer.SetCookieKey("old-key"))
+		id, err := writer.InsertFeed(context.Background(), &models.Feed{Name: "Kept", URL: "https://example.com/kept", SyncMode: models.SyncModeNone, Cookie: "session=abc123"})
+		require.NoError(t, err)
+
+		reader := database.NewSQLStore(db)
+		require.NoError(t, reader.SetCookieKey("new-key"))
+		got, err := reader.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		got.Name = "Kept, renamed"
+		require.NoError(t, reader.UpdateFeed(context.Background(), got))
+
+		restored, err := writer.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		assert.Equal(t, "Kept, renamed", restored.Name)
+		assert.Equal(t, "session=abc123", restored.Cookie, "the old key still opens the cookie")
+
+		got.CookieUnreadable = false
+		require.NoError(t, reader.UpdateFeed(context.Background(), got))
+		cleared, err := writer.GetFeedByID(context.Background(), int(id))
+		require.NoError(t, err)
+		assert.Empty(t, cleared.Cookie, "clearing the flag clears the cookie")
+		assert.False(t, cleared.CookieUnreadable)
+	})
+}
@@ -0,0 +1,107 @@
+// Package events fans out worker activity to connected live UI clients.
+package events
+
+import (
+	"sync"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// Event types published by the worker.
+const (
+	TypeArticle      = "article"       // An article was sent to Wallabag and saved
+	TypeSyncStarted  = "sync-started"  // A polling cycle began
+	TypeSyncFinished = "sync-finished" // A polling cycle ended
+	TypeFeedUpdated  = "feed-updated"  // A feed's details were refreshed in the background
+	TypeFeedHealth   = "feed-health"   // A feed failed its link check, or passed it again after failing
+)
+
+// ClientBuffer is how many events a subscriber may fall behind by. Events published while
+// its buffer is full are dropped for that subscriber so a slow client never blocks the worker.
+const ClientBuffer = 16
+
+// Event is a single notification. Data is encoded as JSON for SSE clients.
+type Event struct {
+	Data any    `json:"data,omitempty"`
+	Type string `json:"type"`
+}
+
+// ArticleData describes an article that was sent to Wallabag.
+type ArticleData struct {
+	FeedName        string `json:"feed_name"`
+	Title           string `json:"title"`
+	URL             string `json:"url"`
+	FeedID          int    `json:"feed_id"`
+	WallabagEntryID int    `json:"wallabag_entry_id"`
+}
+
+// FeedData identifies a feed whose row should be reloaded.
+type FeedData struct {
+	FeedID int `json:"feed_id"`
+}
+
+// FeedHealthData is the outcome of a feed's link check.
+type FeedHealthData struct {
+	Error   string `json:"error,omitempty"`
+	FeedID  int    `json:"feed_id"`
+	Healthy bool   `json:"healthy"`
+}
+
+// Hub is a publish/subscribe broker. A nil Hub discards published events.
+type Hub struct {
+	clients map[chan Event]struct{}
+	mu      sync.Mutex
+}
+
+// NewHub creates a Hub with no subscribers.
+func NewHub() *Hub {
+	return &Hub{clients: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a client and returns its event channel, along with a function that
+// unregisters the client and closes the channel. The function may be called more than once.
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	client := make(chan Event, ClientBuffer)
+
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.clients, client)
+			h.mu.Unlock()
+			close(client)
+		})
+	}
+
+	return client, unsubscribe
+}
+
+// Publish delivers event to every subscriber without blocking.
+func (h *Hub) Publish(event Event) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.clients {
+		select {
+		case client <- event:
+		default:
+			logging.Debug("Dropping event for slow client", "event_type", event.Type)
+		}
+	}
+}
+
+// Subscribers returns the number of registered clients.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.clients)
+}
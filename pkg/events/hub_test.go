@@ -0,0 +1,63 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/events"
+)
+
+func TestHub(t *testing.T) {
+	t.Run("Published event reaches every subscriber", func(t *testing.T) {
+		hub := events.NewHub()
+		first, unsubscribeFirst := hub.Subscribe()
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := hub.Subscribe()
+		defer unsubscribeSecond()
+		assert.Equal(t, 2, hub.Subscribers())
+
+		hub.Publish(events.Event{Type: events.TypeArticle, Data: events.ArticleData{Title: "Hello"}})
+
+		for _, client := range []<-chan events.Event{first, second} {
+			select {
+			case event := <-client:
+				assert.Equal(t, events.TypeArticle, event.Type)
+				assert.Equal(t, events.ArticleData{Title: "Hello"}, event.Data)
+			default:
+				t.Fatal("expected an event on the subscriber channel")
+			}
+		}
+	})
+
+	t.Run("Unsubscribe removes the client and closes its channel", func(t *testing.T) {
+		hub := events.NewHub()
+		client, unsubscribe := hub.Subscribe()
+
+		unsubscribe()
+		unsubscribe() // Safe to call twice
+
+		assert.Equal(t, 0, hub.Subscribers())
+		_, open := <-client
+		assert.False(t, open)
+		assert.NotPanics(t, func() { hub.Publish(events.Event{Type: events.TypeSyncStarted}) })
+	})
+
+	t.Run("Slow client misses events instead of blocking", func(t *testing.T) {
+		hub := events.NewHub()
+		client, unsubscribe := hub.Subscribe()
+		defer unsubscribe()
+
+		for range events.ClientBuffer + 5 {
+			hub.Publish(events.Event{Type: events.TypeSyncStarted})
+		}
+
+		assert.Len(t, client, events.ClientBuffer)
+	})
+
+	t.Run("Nil hub discards events", func(t *testing.T) {
+		var hub *events.Hub
+
+		require.NotPanics(t, func() { hub.Publish(events.Event{Type: events.TypeSyncFinished}) })
+	})
+}
@@ -0,0 +1,116 @@
+// Package extract pulls article content out of web pages using CSS selectors, for feeds whose
+// entries carry only a summary and whose sites Wallabag cannot scrape itself.
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"wallabag-rss-tool/pkg/sanitize"
+)
+
+// MaxPageBytes is the most of an article page that is read; anything beyond is ignored.
+const MaxPageBytes = 5 << 20 // 5 MB
+
+// fetchTimeout bounds a page fetch when the Extractor has no HTTP client of its own.
+const fetchTimeout = 30 * time.Second
+
+// ErrNoMatch is returned when the selector matches nothing on the page.
+var ErrNoMatch = errors.New("selector matched no content")
+
+// Extractor fetches article pages and extracts content from them.
+type Extractor struct {
+	Client    *http.Client
+	UserAgent string
+}
+
+// NewExtractor creates a new Extractor.
+func NewExtractor() *Extractor {
+	return &Extractor{
+		Client:    &http.Client{Timeout: fetchTimeout},
+		UserAgent: "wallabag-rss-tool",
+	}
+}
+
+// ValidateSelector returns an error when selector is not a valid CSS selector.
+func ValidateSelector(selector string) error {
+	if strings.TrimSpace(selector) == "" {
+		return errors.New("selector cannot be empty")
+	}
+	if _, err := cascadia.Compile(selector); err != nil {
+		return fmt.Errorf("invalid CSS selector %q: %w", selector, err)
+	}
+
+	return nil
+}
+
+// Extract fetches pageURL and returns the sanitized inner HTML of the elements matching selector.
+func (e *Extractor) Extract(ctx context.Context, pageURL, selector string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create page request: %w", err)
+	}
+	if e.UserAgent != "" {
+		req.Header.Set("User-Agent", e.UserAgent)
+	}
+
+	client := e.Client
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to fetch page with status %d", resp.StatusCode)
+	}
+
+	return ExtractFromHTML(io.LimitReader(resp.Body, MaxPageBytes), selector)
+}
+
+// ExtractFromHTML parses an HTML document and returns the sanitized inner HTML of every element
+// matching selector, joined in document order.
+func ExtractFromHTML(page io.Reader, selector string) (string, error) {
+	if err := ValidateSelector(selector); err != nil {
+		return "", err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	matches := doc.Find(selector)
+	if matches.Length() == 0 {
+		return "", ErrNoMatch
+	}
+
+	// Active content never leaves the extractor; callers may apply a stricter policy on top
+	sanitize.Nodes(matches.Nodes, sanitize.Lenient)
+
+	var content strings.Builder
+	for i := range matches.Nodes {
+		inner, err := matches.Eq(i).Html()
+		if err != nil {
+			return "", fmt.Errorf("failed to render extracted content: %w", err)
+		}
+		content.WriteString(strings.TrimSpace(inner))
+	}
+
+	if strings.TrimSpace(content.String()) == "" {
+		return "", ErrNoMatch
+	}
+
+	return content.String(), nil
+}
@@ -0,0 +1,110 @@
+package extract_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/extract"
+)
+
+const samplePage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Sample Post</title>
+	<script>trackVisitor()</script>
+</head>
+<body>
+	<nav><a href="/">Home</a></nav>
+	<article>
+		<h1>Sample Post</h1>
+		<div class="post-body">
+			<p>First paragraph with <a href="https://example.com/ref" onclick="steal()">a link</a>.</p>
+			<script>alert("xss")</script>
+			<p>Second paragraph.</p>
+			<a href="javascript:alert(1)">bad link</a>
+			<iframe src="https://ads.example.com"></iframe>
+		</div>
+	</article>
+	<footer>Copyright</footer>
+</body>
+</html>`
+
+func TestExtractFromHTML(t *testing.T) {
+	t.Run("Extracts inner HTML of the selected element", func(t *testing.T) {
+		content, err := extract.ExtractFromHTML(strings.NewReader(samplePage), "article .post-body")
+		require.NoError(t, err)
+
+		assert.Contains(t, content, "<p>First paragraph with")
+		assert.Contains(t, content, "<p>Second paragraph.</p>")
+		assert.NotContains(t, content, "post-body", "the selected element's own tag is not included")
+		assert.NotContains(t, content, "Home")
+		assert.NotContains(t, content, "Copyright")
+	})
+
+	t.Run("Sanitizes scripts, frames and event handlers", func(t *testing.T) {
+		content, err := extract.ExtractFromHTML(strings.NewReader(samplePage), ".post-body")
+		require.NoError(t, err)
+
+		assert.NotContains(t, content, "<script")
+		assert.NotContains(t, content, "<iframe")
+		assert.NotContains(t, content, "onclick")
+		assert.NotContains(t, content, "javascript:")
+		assert.Contains(t, content, `href="https://example.com/ref"`)
+	})
+
+	t.Run("Multiple matches are joined in order", func(t *testing.T) {
+		page := `<div class="part">One</div><div class="other">Skip</div><div class="part">Two</div>`
+		content, err := extract.ExtractFromHTML(strings.NewReader(page), ".part")
+		require.NoError(t, err)
+		assert.Equal(t, "OneTwo", content)
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		_, err := extract.ExtractFromHTML(strings.NewReader(samplePage), ".missing")
+		assert.ErrorIs(t, err, extract.ErrNoMatch)
+	})
+
+	t.Run("Invalid selector", func(t *testing.T) {
+		_, err := extract.ExtractFromHTML(strings.NewReader(samplePage), "div[")
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateSelector(t *testing.T) {
+	assert.NoError(t, extract.ValidateSelector("article .post-body > p"))
+	assert.Error(t, extract.ValidateSelector(""))
+	assert.Error(t, extract.ValidateSelector("   "))
+	assert.Error(t, extract.ValidateSelector("div[class="))
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(samplePage))
+	}))
+	defer server.Close()
+
+	extractor := extract.NewExtractor()
+
+	t.Run("Fetches and extracts page content", func(t *testing.T) {
+		content, err := extractor.Extract(context.Background(), server.URL+"/post", ".post-body")
+		require.NoError(t, err)
+		assert.Contains(t, content, "Second paragraph.")
+	})
+
+	t.Run("Non-2xx response", func(t *testing.T) {
+		_, err := extractor.Extract(context.Background(), server.URL+"/missing", ".post-body")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+}
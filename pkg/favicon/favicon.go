@@ -0,0 +1,140 @@
+// Package favicon finds the icon a website declares for itself, so feeds can be shown with
+// their site's favicon.
+package favicon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MaxPageBytes is the most of a site's home page that is read when looking for icon links.
+const MaxPageBytes = 1 << 20 // 1 MB
+
+// fetchTimeout bounds each request when the Finder has no HTTP client of its own.
+const fetchTimeout = 15 * time.Second
+
+// ErrNotFound is returned when a site neither declares an icon nor serves /favicon.ico.
+var ErrNotFound = errors.New("no favicon found")
+
+// Finder discovers site favicons.
+type Finder struct {
+	Client    *http.Client
+	UserAgent string
+}
+
+// NewFinder creates a new Finder.
+func NewFinder() *Finder {
+	return &Finder{
+		Client:    &http.Client{Timeout: fetchTimeout},
+		UserAgent: "wallabag-rss-tool",
+	}
+}
+
+// Discover returns the absolute URL of siteURL's favicon. The first <link rel="icon"> (or
+// "shortcut icon") on the page wins; without one, /favicon.ico at the site's root is used if
+// it exists. Sites with neither yield ErrNotFound.
+func (f *Finder) Discover(ctx context.Context, siteURL string) (string, error) {
+	site, err := url.Parse(siteURL)
+	if err != nil || (site.Scheme != "http" && site.Scheme != "https") || site.Host == "" {
+		return "", fmt.Errorf("invalid site URL %q", siteURL)
+	}
+
+	// A page that cannot be fetched or parsed still leaves the /favicon.ico fallback
+	if icon, err := f.declaredIcon(ctx, site); err == nil && icon != "" {
+		return icon, nil
+	}
+
+	fallback := &url.URL{Scheme: site.Scheme, Host: site.Host, Path: "/favicon.ico"}
+	resp, err := f.get(ctx, fallback.String())
+	if err != nil {
+		return "", ErrNotFound
+	}
+	resp.Body.Close()
+
+	return fallback.String(), nil
+}
+
+// declaredIcon fetches the site page and returns the first icon link it declares, resolved
+// against the page's final URL, or "" when there is none
+func (f *Finder) declaredIcon(ctx context.Context, site *url.URL) (string, error) {
+	resp, err := f.get(ctx, site.String())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, MaxPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	var icon string
+	doc.Find("link[rel][href]").EachWithBreak(func(_ int, link *goquery.Selection) bool {
+		if !isIconRel(link.AttrOr("rel", "")) {
+			return true
+		}
+
+		ref, err := url.Parse(strings.TrimSpace(link.AttrOr("href", "")))
+		if err != nil {
+			return true
+		}
+		resolved := resp.Request.URL.ResolveReference(ref)
+		if resolved.Scheme != "http" && resolved.Scheme != "https" {
+			return true // data: and other inline icons are not stored
+		}
+		icon = resolved.String()
+
+		return false
+	})
+
+	return icon, nil
+}
+
+// isIconRel reports whether a link's rel attribute marks it as the page icon
+func isIconRel(rel string) bool {
+	for _, token := range strings.Fields(strings.ToLower(rel)) {
+		if token == "icon" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// get fetches target, returning an error for responses outside 2xx. The caller must close the
+// body.
+func (f *Finder) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if f.UserAgent != "" {
+		req.Header.Set("User-Agent", f.UserAgent)
+	}
+
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+
+		return nil, fmt.Errorf("failed to fetch %s with status %d", target, resp.StatusCode)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,72 @@
+package favicon_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/favicon"
+)
+
+func TestFinder_Discover(t *testing.T) {
+	t.Run("Declared icon link is resolved against the page", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/blog/":
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html><head>
+					<link rel="stylesheet" href="/style.css">
+					<link rel="Shortcut Icon" href="../static/icon.png">
+					<link rel="icon" href="/other.png">
+				</head><body></body></html>`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		icon, err := favicon.NewFinder().Discover(context.Background(), server.URL+"/blog/")
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/static/icon.png", icon)
+	})
+
+	t.Run("Falls back to favicon.ico at the site root", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/favicon.ico":
+				w.Header().Set("Content-Type", "image/x-icon")
+				w.Write([]byte{0, 0, 1, 0})
+			default:
+				w.Write([]byte(`<html><head><link rel="icon" href="data:image/png;base64,AAAA"></head></html>`))
+			}
+		}))
+		defer server.Close()
+
+		icon, err := favicon.NewFinder().Discover(context.Background(), server.URL+"/news")
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/favicon.ico", icon)
+	})
+
+	t.Run("Site without a favicon", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/" {
+				w.Write([]byte(`<html><head><title>No icon</title></head></html>`))
+
+				return
+			}
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		_, err := favicon.NewFinder().Discover(context.Background(), server.URL)
+		assert.ErrorIs(t, err, favicon.ErrNotFound)
+	})
+
+	t.Run("Invalid site URL", func(t *testing.T) {
+		_, err := favicon.NewFinder().Discover(context.Background(), "not a url")
+		assert.Error(t, err)
+	})
+}
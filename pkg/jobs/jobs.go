@@ -0,0 +1,261 @@
+// Package jobs runs long admin operations in the background and tracks their progress, so the
+// UI can show how far they have got and cancel them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// Job statuses.
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// KeepFinished is how many finished jobs the manager remembers; older ones are forgotten.
+const KeepFinished = 20
+
+var (
+	// ErrNotFound is returned for a job ID the manager does not know.
+	ErrNotFound = errors.New("job not found")
+	// ErrFinished is returned by Cancel for a job that is no longer running.
+	ErrFinished = errors.New("job has already finished")
+)
+
+// Status is where a job is in its lifecycle.
+type Status string
+
+// Func is the work a job does. It reports progress through progress, stops when ctx is
+// cancelled, and returns a summary for the UI. A summary returned with an error is kept, so a
+// cancelled job can still say what it finished.
+type Func func(ctx context.Context, progress func(done, total int)) (string, error)
+
+// Job is a snapshot of a job's state.
+type Job struct {
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Name       string     `json:"name"`
+	Status     Status     `json:"status"`
+	Result     string     `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	ID         int        `json:"id"`
+	Done       int        `json:"done"`
+	Total      int        `json:"total"`   // Zero until the job knows how much there is to do
+	Percent    int        `json:"percent"` // Done as a percentage of Total; 100 once succeeded
+}
+
+// Running reports whether the job has not finished yet.
+func (j Job) Running() bool {
+	return j.Status == StatusRunning
+}
+
+// job is a tracked job; its fields are guarded by Manager.mu
+type job struct {
+	Job
+	cancel context.CancelFunc
+}
+
+// Manager runs jobs and keeps their state. It is safe for concurrent use.
+type Manager struct {
+	jobs   map[int]*job
+	order  []int // Job IDs, oldest first
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	nextID int
+}
+
+// NewManager creates a Manager with no jobs.
+func NewManager() *Manager {
+	return &Manager{jobs: make(map[int]*job)}
+}
+
+// Start runs fn in the background as a job called name and returns its initial state. Only one
+// job of a name runs at a time: while one is running, Start returns it instead of starting
+// another, with started false.
+func (m *Manager) Start(name string, fn Func) (current Job, started bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.jobs {
+		if existing.Name == name && existing.Running() {
+			return existing.snapshot(), false
+		}
+	}
+
+	m.nextID++
+	ctx, cancel := context.WithCancel(context.Background())
+	tracked := &job{
+		Job:    Job{ID: m.nextID, Name: name, Status: StatusRunning, StartedAt: time.Now()},
+		cancel: cancel,
+	}
+	m.jobs[tracked.ID] = tracked
+	m.order = append(m.order, tracked.ID)
+	m.forgetOldJobs()
+
+	logging.Info("Job started", "job_id", tracked.ID, "job", name)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer cancel()
+
+		result, err := fn(ctx, func(done, total int) { m.setProgress(tracked, done, total) })
+		m.finish(ctx, tracked, result, err)
+	}()
+
+	return tracked.snapshot(), true
+}
+
+// Get returns the job with the given ID.
+func (m *Manager) Get(id int) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tracked, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+
+	return tracked.snapshot(), nil
+}
+
+// List returns every remembered job, newest first.
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := make([]Job, 0, len(m.order))
+	for i := len(m.order) - 1; i >= 0; i-- {
+		list = append(list, m.jobs[m.order[i]].snapshot())
+	}
+
+	return list
+}
+
+// Cancel asks a running job to stop. It returns straight away; the job is marked cancelled
+// once its Func returns.
+func (m *Manager) Cancel(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tracked, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if !tracked.Running() {
+		return ErrFinished
+	}
+
+	tracked.cancel()
+
+	return nil
+}
+
+// Wait blocks until every job started so far has finished.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// Shutdown cancels every running job and waits for them to stop, or for ctx to be done.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	for _, tracked := range m.jobs {
+		if tracked.Running() {
+			tracked.cancel()
+		}
+	}
+	m.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("jobs still running at shutdown: %w", ctx.Err())
+	}
+}
+
+// setProgress records how far a job has got
+func (m *Manager) setProgress(tracked *job, done, total int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tracked.Done = done
+	tracked.Total = total
+}
+
+// finish records a job's outcome. A job that fails after ctx, its own context, is cancelled
+// counts as cancelled.
+func (m *Manager) finish(ctx context.Context, tracked *job, result string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finishedAt := time.Now()
+	tracked.FinishedAt = &finishedAt
+	tracked.Result = result
+	switch {
+	case err != nil && ctx.Err() != nil:
+		tracked.Status = StatusCancelled
+	case err != nil:
+		tracked.Status = StatusFailed
+		tracked.Error = err.Error()
+	default:
+		tracked.Status = StatusSucceeded
+	}
+
+	logging.Info("Job finished",
+		"job_id", tracked.ID,
+		"job", tracked.Name,
+		"status", tracked.Status,
+		"done", tracked.Done,
+		"total", tracked.Total,
+		"duration", finishedAt.Sub(tracked.StartedAt).String())
+}
+
+// forgetOldJobs drops the oldest finished jobs beyond KeepFinished. Running jobs are kept.
+func (m *Manager) forgetOldJobs() {
+	finished := 0
+	for _, id := range m.order {
+		if !m.jobs[id].Running() {
+			finished++
+		}
+	}
+
+	kept := m.order[:0]
+	for _, id := range m.order {
+		if finished > KeepFinished && !m.jobs[id].Running() {
+			delete(m.jobs, id)
+			finished--
+
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+// snapshot copies the job's state; callers hold Manager.mu
+func (j *job) snapshot() Job {
+	snapshot := j.Job
+	switch {
+	case snapshot.Status == StatusSucceeded:
+		snapshot.Percent = 100
+	case snapshot.Total > 0:
+		snapshot.Percent = min(100, snapshot.Done*100/snapshot.Total)
+	}
+
+	return snapshot
+}
@@ -0,0 +1,128 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/jobs"
+)
+
+func TestManager_Lifecycle(t *testing.T) {
+	manager := jobs.NewManager()
+	step := make(chan struct{})
+
+	job, started := manager.Start("Count", func(_ context.Context, progress func(done, total int)) (string, error) {
+		for done := 1; done <= 4; done++ {
+			<-step
+			progress(done, 4)
+		}
+
+		return "Counted 4.", nil
+	})
+	require.True(t, started)
+	assert.Equal(t, jobs.StatusRunning, job.Status)
+	assert.Zero(t, job.Percent)
+
+	t.Run("Progress updates", func(t *testing.T) {
+		step <- struct{}{}
+		step <- struct{}{}
+
+		assert.Eventually(t, func() bool {
+			current, err := manager.Get(job.ID)
+
+			return err == nil && current.Done == 2 && current.Total == 4 && current.Percent == 50
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("A job of the same name is not started twice", func(t *testing.T) {
+		existing, started := manager.Start("Count", func(context.Context, func(done, total int)) (string, error) {
+			t.Error("second job ran")
+
+			return "", nil
+		})
+		assert.False(t, started)
+		assert.Equal(t, job.ID, existing.ID)
+	})
+
+	t.Run("Completion", func(t *testing.T) {
+		step <- struct{}{}
+		step <- struct{}{}
+		manager.Wait()
+
+		current, err := manager.Get(job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusSucceeded, current.Status)
+		assert.Equal(t, 100, current.Percent)
+		assert.Equal(t, "Counted 4.", current.Result)
+		assert.NotNil(t, current.FinishedAt)
+		assert.ErrorIs(t, manager.Cancel(job.ID), jobs.ErrFinished)
+	})
+}
+
+func TestManager_Cancel(t *testing.T) {
+	manager := jobs.NewManager()
+
+	job, _ := manager.Start("Wait", func(ctx context.Context, progress func(done, total int)) (string, error) {
+		progress(1, 10)
+		<-ctx.Done()
+
+		return "Stopped after 1 of 10.", ctx.Err()
+	})
+	require.NoError(t, manager.Cancel(job.ID))
+	manager.Wait()
+
+	current, err := manager.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusCancelled, current.Status)
+	assert.Equal(t, "Stopped after 1 of 10.", current.Result)
+	assert.Empty(t, current.Error)
+
+	assert.ErrorIs(t, manager.Cancel(99), jobs.ErrNotFound)
+}
+
+func TestManager_Failure(t *testing.T) {
+	manager := jobs.NewManager()
+
+	job, _ := manager.Start("Fail", func(context.Context, func(done, total int)) (string, error) {
+		return "", errors.New("database closed")
+	})
+	manager.Wait()
+
+	current, err := manager.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusFailed, current.Status)
+	assert.Equal(t, "database closed", current.Error)
+}
+
+func TestManager_List(t *testing.T) {
+	manager := jobs.NewManager()
+	noop := func(context.Context, func(done, total int)) (string, error) { return "", nil }
+
+	for range jobs.KeepFinished + 5 {
+		manager.Start("Noop", noop)
+		manager.Wait()
+	}
+
+	list := manager.List()
+	require.Len(t, list, jobs.KeepFinished+1, "Finished jobs beyond the limit are forgotten when the next starts")
+	assert.Equal(t, jobs.KeepFinished+5, list[0].ID, "Newest first")
+
+	_, err := manager.Get(1)
+	assert.ErrorIs(t, err, jobs.ErrNotFound)
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	manager := jobs.NewManager()
+	manager.Start("Wait", func(ctx context.Context, _ func(done, total int)) (string, error) {
+		<-ctx.Done()
+
+		return "", ctx.Err()
+	})
+
+	require.NoError(t, manager.Shutdown(context.Background()))
+	assert.Equal(t, jobs.StatusCancelled, manager.List()[0].Status)
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Throttler coalesces a repeated log message so it does not drown out the rest of the log. The
+// first occurrence of an error for a key, or an error with a different signature than the last
+// one for that key, is logged in full. Identical repeats within the window are only counted, and
+// once the window has passed the next repeat is logged as a summary of how many were skipped.
+type Throttler struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[string]*throttleEntry
+}
+
+// throttleEntry tracks the last error logged for one key
+type throttleEntry struct {
+	loggedAt   time.Time
+	signature  string
+	suppressed int
+}
+
+// NewThrottler creates a Throttler that summarizes identical repeats at most once per window.
+func NewThrottler(window time.Duration) *Throttler {
+	return &Throttler{window: window, entries: make(map[string]*throttleEntry)}
+}
+
+// Allow records an occurrence of the error identified by signature for key at now. It reports
+// whether the occurrence should be logged and how many identical occurrences were skipped since
+// the last one that was; a new key or a changed signature is always logged with none skipped.
+func (t *Throttler) Allow(key, signature string, now time.Time) (bool, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, found := t.entries[key]
+	if !found || entry.signature != signature {
+		t.entries[key] = &throttleEntry{loggedAt: now, signature: signature}
+
+		return true, 0
+	}
+
+	if now.Sub(entry.loggedAt) < t.window {
+		entry.suppressed++
+
+		return false, 0
+	}
+
+	suppressed := entry.suppressed
+	entry.loggedAt = now
+	entry.suppressed = 0
+
+	return true, suppressed
+}
+
+// Reset forgets key, so its next error is logged in full. Call it once the failing operation
+// succeeds.
+func (t *Throttler) Reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+}
+
+// Error logs msg with args through logger, throttled by key and signature. A repeat logged
+// after the window is summarized as msg with the number of skipped occurrences and the
+// signature in place of args.
+func (t *Throttler) Error(logger Logger, key, signature, msg string, args ...any) {
+	allowed, suppressed := t.Allow(key, signature, time.Now())
+	switch {
+	case !allowed:
+		return
+	case suppressed > 0:
+		logger.Error(fmt.Sprintf("%s (%d more occurrences)", msg, suppressed),
+			"error", signature,
+			"more_occurrences", suppressed)
+	default:
+		logger.Error(msg, args...)
+	}
+}
@@ -0,0 +1,90 @@
+package logging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/logging"
+)
+
+func TestThrottler_Allow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Identical errors within the window are coalesced", func(t *testing.T) {
+		throttler := logging.NewThrottler(time.Hour)
+
+		allowed, suppressed := throttler.Allow("1", "status 500", start)
+		assert.True(t, allowed)
+		assert.Zero(t, suppressed)
+
+		for i := 1; i <= 3; i++ {
+			allowed, _ = throttler.Allow("1", "status 500", start.Add(time.Duration(i)*10*time.Minute))
+			assert.False(t, allowed)
+		}
+
+		allowed, suppressed = throttler.Allow("1", "status 500", start.Add(time.Hour))
+		assert.True(t, allowed, "logged again once the window has passed")
+		assert.Equal(t, 3, suppressed)
+
+		allowed, _ = throttler.Allow("1", "status 500", start.Add(time.Hour+time.Minute))
+		assert.False(t, allowed, "a new window starts after the summary")
+	})
+
+	t.Run("A new error logs right away", func(t *testing.T) {
+		throttler := logging.NewThrottler(time.Hour)
+		throttler.Allow("1", "status 500", start)
+
+		allowed, suppressed := throttler.Allow("1", "connection refused", start.Add(time.Minute))
+		assert.True(t, allowed)
+		assert.Zero(t, suppressed)
+	})
+
+	t.Run("Keys are throttled independently", func(t *testing.T) {
+		throttler := logging.NewThrottler(time.Hour)
+		throttler.Allow("1", "status 500", start)
+
+		allowed, _ := throttler.Allow("2", "status 500", start.Add(time.Minute))
+		assert.True(t, allowed)
+	})
+
+	t.Run("Reset logs the next error in full", func(t *testing.T) {
+		throttler := logging.NewThrottler(time.Hour)
+		throttler.Allow("1", "status 500", start)
+		throttler.Reset("1")
+
+		allowed, suppressed := throttler.Allow("1", "status 500", start.Add(time.Minute))
+		assert.True(t, allowed)
+		assert.Zero(t, suppressed)
+	})
+}
+
+func TestThrottler_Error(t *testing.T) {
+	logger := logging.NewMockLogger()
+
+	t.Run("Repeats are dropped until the window passes", func(t *testing.T) {
+		throttler := logging.NewThrottler(time.Hour)
+		for range 5 {
+			throttler.Error(logger, "1", "status 500", "Failed to fetch feed", "error", "status 500", "attempt", 1)
+		}
+
+		assert.Equal(t, 1, logger.CountByLevel("ERROR"))
+		assert.True(t, logger.HasEntryWithArgs("ERROR", "Failed to fetch feed", "error", "status 500"))
+
+		throttler.Error(logger, "1", "timeout", "Failed to fetch feed", "error", "timeout")
+		assert.Equal(t, 2, logger.CountByLevel("ERROR"), "a different error is logged at once")
+	})
+
+	t.Run("Summary after the window", func(t *testing.T) {
+		logger.Clear()
+		throttler := logging.NewThrottler(50 * time.Millisecond)
+		for range 3 {
+			throttler.Error(logger, "1", "status 500", "Failed to fetch feed", "error", "status 500")
+		}
+		time.Sleep(60 * time.Millisecond)
+		throttler.Error(logger, "1", "status 500", "Failed to fetch feed", "error", "status 500")
+
+		assert.Equal(t, 2, logger.CountByLevel("ERROR"))
+		assert.True(t, logger.HasEntryWithArgs("ERROR", "Failed to fetch feed (2 more occurrences)", "error", "status 500"))
+	})
+}
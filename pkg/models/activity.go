@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RecentArticle is an article recently sent to Wallabag, with the name of its feed, as listed in
+// the home page's recent activity.
+type RecentArticle struct {
+	SentAt   time.Time
+	Title    string
+	URL      string
+	FeedName string // Name of the article's feed ("" = the feed has since been deleted)
+	ID       int
+	FeedID   int
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AuditAction names a significant worker or server action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditFeedAdded       AuditAction = "feed_added"       // A feed was created
+	AuditFeedUpdated     AuditAction = "feed_updated"     // A feed's settings were edited
+	AuditFeedDeleted     AuditAction = "feed_deleted"     // A feed was deleted
+	AuditSyncRun         AuditAction = "sync_run"         // The worker completed a polling cycle
+	AuditArticleSent     AuditAction = "article_sent"     // An article was added to Wallabag
+	AuditArticleFailed   AuditAction = "article_failed"   // An article could not be added to Wallabag
+	AuditFeedPaused      AuditAction = "feed_paused"      // The worker disabled a feed whose articles Wallabag kept rejecting
+	AuditFeedUnreachable AuditAction = "feed_unreachable" // A feed failed its link check after passing, or on its first
+)
+
+// AuditActions lists every audit action, in the order the audit page offers them as filters.
+var AuditActions = []AuditAction{
+	AuditFeedAdded, AuditFeedUpdated, AuditFeedDeleted, AuditSyncRun, AuditArticleSent, AuditArticleFailed,
+	AuditFeedPaused, AuditFeedUnreachable,
+}
+
+// AuditEntry is one record in the audit log.
+type AuditEntry struct {
+	CreatedAt time.Time
+	Action    AuditAction
+	Detail    string // Human-readable description, e.g. the article URL or the error
+	ID        int
+	FeedID    int // Feed the action concerns (0 = none)
+}
+
+// AuditFilter narrows an audit log query. Zero fields match everything.
+type AuditFilter struct {
+	Action AuditAction
+	FeedID int
+}
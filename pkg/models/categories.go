@@ -0,0 +1,48 @@
+package models
+
+import "strings"
+
+// ParseCategories splits a comma-separated category list, trimming whitespace and dropping
+// empty entries. An empty list yields nil.
+func ParseCategories(list string) []string {
+	var categories []string
+	for _, category := range strings.Split(list, ",") {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories
+}
+
+// FormatCategories joins categories into the comma-separated form read by ParseCategories.
+func FormatCategories(categories []string) string {
+	return strings.Join(categories, ", ")
+}
+
+// MatchesCategories reports whether an item with the given categories passes the feed's
+// IncludeCategories filter: at least one category must match, ignoring case and surrounding
+// whitespace. A feed without a filter matches every item; with a filter, items without
+// categories never match.
+func (f *Feed) MatchesCategories(categories []string) bool {
+	if len(f.IncludeCategories) == 0 {
+		return true
+	}
+	_, ok := f.MatchingCategory(categories)
+
+	return ok
+}
+
+// MatchingCategory returns the first of an item's categories that the feed's IncludeCategories
+// filter accepts, and whether there was one.
+func (f *Feed) MatchingCategory(categories []string) (string, bool) {
+	for _, category := range categories {
+		for _, included := range f.IncludeCategories {
+			if strings.EqualFold(strings.TrimSpace(category), included) {
+				return strings.TrimSpace(category), true
+			}
+		}
+	}
+
+	return "", false
+}
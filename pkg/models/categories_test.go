@@ -0,0 +1,44 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestParseCategories(t *testing.T) {
+	assert.Equal(t, []string{"Go", "Databases"}, models.ParseCategories(" Go ,Databases,, "))
+	assert.Nil(t, models.ParseCategories(""))
+	assert.Nil(t, models.ParseCategories(" , "))
+}
+
+func TestFormatCategories(t *testing.T) {
+	assert.Equal(t, "Go, Databases", models.FormatCategories([]string{"Go", "Databases"}))
+	assert.Equal(t, []string{"Go", "Databases"}, models.ParseCategories(models.FormatCategories([]string{"Go", "Databases"})))
+	assert.Empty(t, models.FormatCategories(nil))
+}
+
+func TestFeed_MatchesCategories(t *testing.T) {
+	filtered := models.Feed{IncludeCategories: []string{"Go", "Databases"}}
+
+	tests := []struct {
+		name       string
+		feed       models.Feed
+		categories []string
+		want       bool
+	}{
+		{name: "No filter matches items without categories", feed: models.Feed{}, categories: nil, want: true},
+		{name: "No filter matches any categories", feed: models.Feed{}, categories: []string{"Sport"}, want: true},
+		{name: "One listed category matches", feed: filtered, categories: []string{"Sport", "Go"}, want: true},
+		{name: "Match ignores case and whitespace", feed: filtered, categories: []string{" databases "}, want: true},
+		{name: "Unlisted categories do not match", feed: filtered, categories: []string{"Sport"}, want: false},
+		{name: "Items without categories do not match a filter", feed: filtered, categories: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.feed.MatchesCategories(tt.categories))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ArticleFilter narrows an article history query. Zero fields match everything.
+type ArticleFilter struct {
+	Since  *time.Time // Only articles recorded at or after this time
+	FeedID int
+}
+
+// HistoryArticle is a recorded article with the name of its feed, as listed by the articles API.
+type HistoryArticle struct {
+	FeedName string // Name of the article's feed ("" = the feed has since been deleted)
+	Article
+}
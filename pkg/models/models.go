@@ -15,6 +15,51 @@ const (
 	SyncModeDateFrom SyncMode = "date_from" // Sync articles from specific date
 )
 
+// DeliveryMode is how a feed's articles get into Wallabag
+type DeliveryMode string
+
+const (
+	DeliveryModeLink          DeliveryMode = "link"            // Send the URL and let Wallabag fetch the page
+	DeliveryModeContent       DeliveryMode = "content"         // Send content extracted with the feed's selector, failing the article if extraction fails
+	DeliveryModeContentOrLink DeliveryMode = "content_or_link" // Send extracted content, falling back to the URL if extraction fails
+)
+
+// UsesContent reports whether the mode sends extracted page content and so needs a content selector.
+func (m DeliveryMode) UsesContent() bool {
+	return m == DeliveryModeContent || m == DeliveryModeContentOrLink
+}
+
+// DateField is which of an item's dates a feed's articles are dated by. It decides the order
+// articles are sent in and which ones the date-based sync and age filters let through.
+type DateField string
+
+const (
+	DateFieldAuto      DateField = "auto"      // The published date, or the updated date for items without one
+	DateFieldPublished DateField = "published" // Only the published date; items without one are undated
+	DateFieldUpdated   DateField = "updated"   // The updated date, or the published date for items never updated
+)
+
+// ContentKind is a guess, made from a feed's items, at whether the feed carries whole articles
+// or only summaries
+type ContentKind string
+
+const (
+	ContentKindFull    ContentKind = "full"    // Items carry the article's full text
+	ContentKindSummary ContentKind = "summary" // Items carry a summary or no text at all
+)
+
+// Label describes the kind for display, or returns "" when the kind is not known.
+func (k ContentKind) Label() string {
+	switch k {
+	case ContentKindFull:
+		return "full content"
+	case ContentKindSummary:
+		return "summary only"
+	default:
+		return ""
+	}
+}
+
 // TimeUnit represents the unit of time for intervals
 type TimeUnit string
 
@@ -28,15 +73,45 @@ const (
 type Feed struct {
 	LastFetched         *time.Time // Use pointer for nullable DATETIME
 	SyncDateFrom        *time.Time // Date to sync from (for SyncModeDateFrom)
+	LastBuildDate       *time.Time // Feed-level build date seen on the last fully processed fetch
+	FetchTimeoutSeconds int        // Per-feed HTTP fetch timeout in seconds (0 = use global default)
+	StripQueryParams    bool       // Send article URLs to Wallabag without their query string
+	AutoInterval        bool       // Derive the poll interval from the feed's publish cadence
+	AutoIntervalMinutes int        // Interval last derived for an auto-interval feed (0 = not yet computed)
+	ContentSelector     string     // CSS selector for extracting article content from the page, used by the content delivery modes
+	TagWithFeedName     bool       // Tag the feed's entries in Wallabag with its slugified name
+	AcceptHeader        string     // Accept header for fetching the feed ("" = rss.DefaultAccept)
+	IncludeCategories   []string   // Only send items in at least one of these categories (empty = send everything)
+	MinAgeMinutes       int        // Hold back items published less than this many minutes ago (0 = send at once)
+	MinTitleLength      int        // Skip items whose titles are shorter than this many characters (0 = use the global minimum)
+	KeepLastN           int        // Articles kept in the database for this feed, newest first (0 = keep all)
+	Priority            int        // Feeds due at the same time are processed highest priority first (default 0)
+	CategoriesAsTags    bool       // Tag each entry in Wallabag with its item's slugified categories
+	Cookie              string     // Cookie header sent when fetching the feed; encrypted at rest ("" = none)
+	FaviconURL          string     // Icon of the feed's site, found by the worker ("" = none or not yet looked up)
+	FaviconCheckedAt    *time.Time // When the site's favicon was last looked up (nil = never)
+	SnoozedUntil        *time.Time // The worker skips the feed until this time (nil = not snoozed)
+	TTLMinutes          int        // Minimum poll interval the feed asks for with <ttl> when last fetched (0 = none)
+	DisabledReason      string     // Why the worker disabled the feed itself ("" = disabled by hand, or enabled)
 	SyncCount           *int       // Number of articles to sync (for SyncModeCount)
 	URL                 string
 	Name                string
-	SyncMode            SyncMode // How to handle historical articles on initial sync
-	PollIntervalUnit    TimeUnit // Unit for poll interval (minutes, hours, days)
+	DeliveryMode        DeliveryMode // How articles are sent to Wallabag ("" = DeliveryModeLink)
+	DateField           DateField    // Which item date articles are dated by ("" = DateFieldAuto)
+	ContentKind         ContentKind  // Whether the feed's items carried full content when last fetched ("" = not yet known)
+	SyncMode            SyncMode     // How to handle historical articles on initial sync
+	PollIntervalUnit    TimeUnit     // Unit for poll interval (minutes, hours, days)
 	ID                  int
 	PollInterval        int  // Poll interval value
 	PollIntervalMinutes int  // Legacy field for backward compatibility, computed from PollInterval and PollIntervalUnit
 	InitialSyncDone     bool // Whether initial historical sync has been completed
+	Disabled            bool // Whether the worker skips this feed when polling
+	CookieUnreadable    bool // The stored cookie could not be decrypted, so Cookie is empty; UpdateFeed keeps it as stored
+}
+
+// IsSnoozed reports whether the feed is snoozed at now.
+func (f *Feed) IsSnoozed(now time.Time) bool {
+	return f.SnoozedUntil != nil && now.Before(*f.SnoozedUntil)
 }
 
 // GetPollIntervalMinutes calculates the poll interval in minutes based on the interval and unit
@@ -62,6 +137,45 @@ func (f *Feed) GetPollIntervalMinutes() int {
 	}
 }
 
+// OwnPollIntervalMinutes returns the interval the feed is polled at regardless of the global
+// default: the derived interval for an auto-interval feed once computed, otherwise the
+// configured one. Zero means the feed follows the default poll interval.
+func (f *Feed) OwnPollIntervalMinutes() int {
+	if f.AutoInterval && f.AutoIntervalMinutes > 0 {
+		return f.AutoIntervalMinutes
+	}
+
+	return f.PollIntervalMinutes
+}
+
+// NextPollTime returns when the feed is next due given its effective poll interval, or nil
+// when it has never been fetched and is due at once.
+func (f *Feed) NextPollTime(intervalMinutes int) *time.Time {
+	if f.LastFetched == nil {
+		return nil
+	}
+
+	next := f.LastFetched.Add(time.Duration(intervalMinutes) * time.Minute)
+
+	return &next
+}
+
+// PollsFasterThanTTL reports whether the feed is polled more often than the <ttl> it advertised
+// asks: its own poll interval, or defaultMinutes when it follows the default, is shorter than
+// TTLMinutes.
+func (f *Feed) PollsFasterThanTTL(defaultMinutes int) bool {
+	if f.TTLMinutes <= 0 {
+		return false
+	}
+
+	interval := f.OwnPollIntervalMinutes()
+	if interval == 0 {
+		interval = defaultMinutes
+	}
+
+	return interval > 0 && interval < f.TTLMinutes
+}
+
 // SetPollInterval sets the poll interval with the specified value and unit
 func (f *Feed) SetPollInterval(value int, unit TimeUnit) {
 	f.PollInterval = value
@@ -76,6 +190,11 @@ type Article struct {
 	CreatedAt       time.Time
 	Title           string
 	URL             string
+	OriginalURL     string // URL as published in the feed, when it differs from URL
+	Filtered        bool   // Recorded as processed without being sent because a feed filter excluded it
+	MarkedProcessed bool   // Recorded as processed without being sent when existing items were marked processed
+	FeedURL         string // URL of the feed when the article was saved; kept if the feed changes or is deleted
+	Clicks          int    // Times the article was opened through the articles list
 	ID              int
 	FeedID          int
 }
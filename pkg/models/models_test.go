@@ -118,6 +118,58 @@ func TestFeed_GetPollIntervalMinutes(t *testing.T) {
 	}
 }
 
+func TestFeed_OwnPollIntervalMinutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		feed     models.Feed
+		expected int
+	}{
+		{name: "Configured interval", feed: models.Feed{PollIntervalMinutes: 120}, expected: 120},
+		{name: "Derived auto interval wins", feed: models.Feed{PollIntervalMinutes: 120, AutoInterval: true, AutoIntervalMinutes: 45}, expected: 45},
+		{name: "Auto interval not yet derived", feed: models.Feed{PollIntervalMinutes: 120, AutoInterval: true}, expected: 120},
+		{name: "Follows the default", feed: models.Feed{}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.feed.OwnPollIntervalMinutes())
+		})
+	}
+}
+
+func TestFeed_PollsFasterThanTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		feed     models.Feed
+		expected bool
+	}{
+		{name: "Interval below ttl", feed: models.Feed{PollIntervalMinutes: 5, TTLMinutes: 60}, expected: true},
+		{name: "Interval at ttl", feed: models.Feed{PollIntervalMinutes: 60, TTLMinutes: 60}, expected: false},
+		{name: "Interval above ttl", feed: models.Feed{PollIntervalMinutes: 120, TTLMinutes: 60}, expected: false},
+		{name: "Default below ttl", feed: models.Feed{TTLMinutes: 90}, expected: true},
+		{name: "Auto interval below ttl", feed: models.Feed{PollIntervalMinutes: 120, AutoInterval: true, AutoIntervalMinutes: 30, TTLMinutes: 60}, expected: true},
+		{name: "No ttl", feed: models.Feed{PollIntervalMinutes: 5}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.feed.PollsFasterThanTTL(60))
+		})
+	}
+}
+
+func TestFeed_NextPollTime(t *testing.T) {
+	feed := models.Feed{}
+	assert.Nil(t, feed.NextPollTime(60), "a feed never fetched is due at once")
+
+	lastFetched := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	feed.LastFetched = &lastFetched
+	next := feed.NextPollTime(90)
+	if assert.NotNil(t, next) {
+		assert.Equal(t, time.Date(2024, 3, 1, 13, 30, 0, 0, time.UTC), *next)
+	}
+}
+
 func TestFeed_SetPollInterval(t *testing.T) {
 	tests := []struct {
 		expectedUnit         models.TimeUnit
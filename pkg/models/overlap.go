@@ -0,0 +1,22 @@
+package models
+
+// FeedOverlap is a pair of feeds that recorded articles with the same normalized URL (see
+// NormalizeArticleURL), e.g. the same site's feed added twice with different tracking links.
+type FeedOverlap struct {
+	FeedID        int
+	OtherFeedID   int // Always greater than FeedID
+	Shared        int // Normalized article URLs both feeds recorded
+	Articles      int // Normalized article URLs FeedID recorded
+	OtherArticles int // Normalized article URLs OtherFeedID recorded
+}
+
+// Percent returns Shared as a percentage of the smaller feed's articles, so a small feed whose
+// every article another feed also carries scores 100.
+func (o FeedOverlap) Percent() int {
+	smaller := min(o.Articles, o.OtherArticles)
+	if smaller == 0 {
+		return 0
+	}
+
+	return o.Shared * 100 / smaller
+}
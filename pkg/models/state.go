@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// StateVersion is the version of the State format written by exports. Bump it when the format
+// changes incompatibly, and teach CheckVersion to upgrade or reject older documents.
+const StateVersion = 1
+
+// State is the application's configuration as exported for moving between hosts: every feed's
+// settings and the global settings. Article history, worker bookkeeping and secrets such as
+// feed cookies are not included.
+type State struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Settings   StateSettings `json:"settings"`
+	Feeds      []FeedConfig  `json:"feeds"`
+}
+
+// StateSettings holds the global settings carried in a State.
+type StateSettings struct {
+	DefaultPollIntervalMinutes int `json:"default_poll_interval_minutes"`
+}
+
+// FeedConfig is a feed's user-set configuration as carried in a State. Feeds are matched by URL
+// on import.
+type FeedConfig struct {
+	SyncDateFrom        *time.Time   `json:"sync_date_from,omitempty"`
+	SyncCount           *int         `json:"sync_count,omitempty"`
+	Name                string       `json:"name"`
+	URL                 string       `json:"url"`
+	PollIntervalUnit    TimeUnit     `json:"poll_interval_unit"`
+	SyncMode            SyncMode     `json:"sync_mode"`
+	ContentSelector     string       `json:"content_selector,omitempty"`
+	DeliveryMode        DeliveryMode `json:"delivery_mode,omitempty"`
+	DateField           DateField    `json:"date_field,omitempty"`
+	AcceptHeader        string       `json:"accept_header,omitempty"`
+	IncludeCategories   []string     `json:"include_categories,omitempty"`
+	PollInterval        int          `json:"poll_interval"`
+	FetchTimeoutSeconds int          `json:"fetch_timeout_seconds,omitempty"`
+	MinAgeMinutes       int          `json:"min_age_minutes,omitempty"`
+	MinTitleLength      int          `json:"min_title_length,omitempty"`
+	KeepLastN           int          `json:"keep_last_n,omitempty"`
+	Priority            int          `json:"priority,omitempty"`
+	Disabled            bool         `json:"disabled,omitempty"`
+	StripQueryParams    bool         `json:"strip_query_params,omitempty"`
+	AutoInterval        bool         `json:"auto_interval,omitempty"`
+	TagWithFeedName     bool         `json:"tag_with_feed_name,omitempty"`
+	CategoriesAsTags    bool         `json:"categories_as_tags,omitempty"`
+}
+
+// NewFeedConfig returns the exportable configuration of feed.
+func NewFeedConfig(feed *Feed) FeedConfig {
+	return FeedConfig{
+		SyncDateFrom:        feed.SyncDateFrom,
+		SyncCount:           feed.SyncCount,
+		Name:                feed.Name,
+		URL:                 feed.URL,
+		PollIntervalUnit:    feed.PollIntervalUnit,
+		SyncMode:            feed.SyncMode,
+		ContentSelector:     feed.ContentSelector,
+		DeliveryMode:        feed.DeliveryMode,
+		DateField:           feed.DateField,
+		AcceptHeader:        feed.AcceptHeader,
+		IncludeCategories:   feed.IncludeCategories,
+		PollInterval:        feed.PollInterval,
+		FetchTimeoutSeconds: feed.FetchTimeoutSeconds,
+		MinAgeMinutes:       feed.MinAgeMinutes,
+		MinTitleLength:      feed.MinTitleLength,
+		KeepLastN:           feed.KeepLastN,
+		Priority:            feed.Priority,
+		Disabled:            feed.Disabled,
+		StripQueryParams:    feed.StripQueryParams,
+		AutoInterval:        feed.AutoInterval,
+		TagWithFeedName:     feed.TagWithFeedName,
+		CategoriesAsTags:    feed.CategoriesAsTags,
+	}
+}
+
+// Feed returns a new, not yet synced feed with this configuration.
+func (c FeedConfig) Feed() *Feed {
+	feed := &Feed{
+		SyncDateFrom:        c.SyncDateFrom,
+		SyncCount:           c.SyncCount,
+		Name:                c.Name,
+		URL:                 c.URL,
+		SyncMode:            c.SyncMode,
+		ContentSelector:     c.ContentSelector,
+		DeliveryMode:        c.DeliveryMode,
+		DateField:           c.DateField,
+		AcceptHeader:        c.AcceptHeader,
+		IncludeCategories:   c.IncludeCategories,
+		FetchTimeoutSeconds: c.FetchTimeoutSeconds,
+		MinAgeMinutes:       c.MinAgeMinutes,
+		MinTitleLength:      c.MinTitleLength,
+		KeepLastN:           c.KeepLastN,
+		Priority:            c.Priority,
+		Disabled:            c.Disabled,
+		StripQueryParams:    c.StripQueryParams,
+		AutoInterval:        c.AutoInterval,
+		TagWithFeedName:     c.TagWithFeedName,
+		CategoriesAsTags:    c.CategoriesAsTags,
+	}
+	feed.SetPollInterval(c.PollInterval, c.PollIntervalUnit)
+
+	return feed
+}
+
+// CheckVersion returns an error when the state was written in a format this version cannot read.
+func (s *State) CheckVersion() error {
+	if s.Version < 1 || s.Version > StateVersion {
+		return fmt.Errorf("unsupported export version %d (supported: 1 to %d)", s.Version, StateVersion)
+	}
+
+	return nil
+}
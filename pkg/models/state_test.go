@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestFeedConfig_RoundTrip(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	feed := &models.Feed{
+		ID:                7,
+		Name:              "Example",
+		URL:               "https://example.com/feed.xml",
+		SyncMode:          models.SyncModeDateFrom,
+		SyncDateFrom:      &from,
+		KeepLastN:         50,
+		Priority:          2,
+		IncludeCategories: []string{"go"},
+		Cookie:            "session=secret",
+		InitialSyncDone:   true,
+	}
+	feed.SetPollInterval(6, models.TimeUnitHours)
+
+	restored := models.NewFeedConfig(feed).Feed()
+
+	assert.Equal(t, 0, restored.ID)
+	assert.Empty(t, restored.Cookie, "secrets are not carried")
+	assert.False(t, restored.InitialSyncDone)
+	assert.Equal(t, 360, restored.PollIntervalMinutes)
+	assert.Equal(t, models.NewFeedConfig(feed), models.NewFeedConfig(restored))
+}
+
+func TestState_CheckVersion(t *testing.T) {
+	assert.NoError(t, (&models.State{Version: models.StateVersion}).CheckVersion())
+	assert.Error(t, (&models.State{}).CheckVersion(), "a document without a version is rejected")
+	assert.ErrorContains(t, (&models.State{Version: models.StateVersion + 1}).CheckVersion(), "unsupported export version")
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only record where a link was shared, so the same
+// article reached through different feeds can differ by them.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "mc_cid": true, "mc_eid": true,
+}
+
+// NormalizeArticleURL reduces an article URL to a key that is the same however a feed links to
+// the article: the scheme, a leading "www.", default ports, the fragment, a trailing slash and
+// tracking parameters (utm_* and the like) are dropped, the host is lowercased and the remaining
+// query parameters are sorted. URLs that fail to parse, or have no host, are returned as is.
+func NormalizeArticleURL(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	path := strings.TrimRight(parsed.EscapedPath(), "/")
+
+	query := parsed.Query()
+	for name := range query {
+		if strings.HasPrefix(strings.ToLower(name), "utm_") || trackingParams[strings.ToLower(name)] {
+			query.Del(name)
+		}
+	}
+
+	normalized := host + path
+	if encoded := query.Encode(); encoded != "" { // Encode sorts by key
+		normalized += "?" + encoded
+	}
+
+	return normalized
+}
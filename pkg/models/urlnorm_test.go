@@ -0,0 +1,32 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestNormalizeArticleURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "Scheme and www are dropped", url: "https://www.Example.com/post", want: "example.com/post"},
+		{name: "Plain http matches https", url: "http://example.com/post", want: "example.com/post"},
+		{name: "Trailing slash and fragment", url: "https://example.com/post/#comments", want: "example.com/post"},
+		{name: "Default port", url: "https://example.com:443/post", want: "example.com/post"},
+		{name: "Other ports are kept", url: "http://example.com:8080/post", want: "example.com:8080/post"},
+		{name: "Tracking parameters", url: "https://example.com/post?utm_source=rss&UTM_Medium=feed&fbclid=abc", want: "example.com/post"},
+		{name: "Remaining parameters are sorted", url: "https://example.com/post?page=2&id=7&utm_campaign=x", want: "example.com/post?id=7&page=2"},
+		{name: "Path case is kept", url: "https://example.com/Post", want: "example.com/Post"},
+		{name: "Not a URL", url: "not a url", want: "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.NormalizeArticleURL(tt.url))
+		})
+	}
+}
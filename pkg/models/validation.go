@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Default length limits applied when a FieldLimits value leaves a limit unset.
+const (
+	DefaultMaxTitleLength = 1000
+	DefaultMaxURLLength   = 2048
+)
+
+// FieldLimits bounds the size of titles and URLs accepted from feeds and forms, so a
+// misbehaving feed cannot fill the database. Zero values fall back to the defaults.
+type FieldLimits struct {
+	MaxTitleLength int // Maximum title length in characters; longer titles are truncated
+	MaxURLLength   int // Maximum URL length in bytes; longer URLs are rejected
+}
+
+// TitleLimit returns the effective maximum title length.
+func (l FieldLimits) TitleLimit() int {
+	if l.MaxTitleLength <= 0 {
+		return DefaultMaxTitleLength
+	}
+
+	return l.MaxTitleLength
+}
+
+// URLLimit returns the effective maximum URL length.
+func (l FieldLimits) URLLimit() int {
+	if l.MaxURLLength <= 0 {
+		return DefaultMaxURLLength
+	}
+
+	return l.MaxURLLength
+}
+
+// TruncateTitle shortens title to the title limit without splitting a multi-byte character.
+func (l FieldLimits) TruncateTitle(title string) string {
+	limit := l.TitleLimit()
+	if utf8.RuneCountInString(title) <= limit {
+		return title
+	}
+
+	return string([]rune(title)[:limit])
+}
+
+// ValidateURL returns an error when url is longer than the URL limit.
+func (l FieldLimits) ValidateURL(url string) error {
+	if len(url) > l.URLLimit() {
+		return fmt.Errorf("URL length %d exceeds maximum of %d", len(url), l.URLLimit())
+	}
+
+	return nil
+}
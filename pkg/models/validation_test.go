@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestFieldLimits_TruncateTitle(t *testing.T) {
+	limits := models.FieldLimits{MaxTitleLength: 5}
+
+	assert.Equal(t, "short", limits.TruncateTitle("short"))
+	assert.Equal(t, "too l", limits.TruncateTitle("too long"))
+	assert.Equal(t, "héllo", limits.TruncateTitle("héllo wörld"), "multi-byte characters are kept whole")
+}
+
+func TestFieldLimits_ValidateURL(t *testing.T) {
+	limits := models.FieldLimits{MaxURLLength: 30}
+
+	assert.NoError(t, limits.ValidateURL("https://example.com/a"))
+	assert.Error(t, limits.ValidateURL("https://example.com/"+strings.Repeat("a", 30)))
+}
+
+func TestFieldLimits_Defaults(t *testing.T) {
+	var limits models.FieldLimits
+
+	assert.Equal(t, models.DefaultMaxTitleLength, limits.TitleLimit())
+	assert.Equal(t, models.DefaultMaxURLLength, limits.URLLimit())
+}
@@ -0,0 +1,55 @@
+package rss
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// FullContentMinChars is how much text an item needs, in characters, to count as carrying the
+// full article rather than a summary.
+const FullContentMinChars = 1000
+
+// DetectContentKind guesses whether a feed carries full articles from its items: it does when
+// at least half of them have FullContentMinChars of text. It returns "" for a feed with no
+// items.
+func DetectContentKind(articles []Article) models.ContentKind {
+	if len(articles) == 0 {
+		return ""
+	}
+
+	full := 0
+	for _, article := range articles {
+		if article.ContentChars >= FullContentMinChars {
+			full++
+		}
+	}
+	if 2*full >= len(articles) {
+		return models.ContentKindFull
+	}
+
+	return models.ContentKindSummary
+}
+
+// textLength counts the characters of text in an HTML fragment, ignoring markup and collapsing
+// runs of whitespace
+func textLength(fragment string) int {
+	if fragment == "" {
+		return 0
+	}
+
+	var text strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return utf8.RuneCountInString(strings.Join(strings.Fields(text.String()), " "))
+		case html.TextToken:
+			text.Write(tokenizer.Text())
+			text.WriteByte(' ')
+		default:
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package rss_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestProcessor_ContentKind(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Words of the article body. ", 50) + "</p>"
+
+	tests := []struct {
+		name  string
+		items string
+		want  models.ContentKind
+	}{
+		{
+			name: "Content-rich feed",
+			items: `<item><title>One</title><link>https://example.com/1</link><description>Short teaser</description>` +
+				`<content:encoded><![CDATA[` + paragraph + `]]></content:encoded></item>` +
+				`<item><title>Two</title><link>https://example.com/2</link>` +
+				`<content:encoded><![CDATA[` + paragraph + `]]></content:encoded></item>`,
+			want: models.ContentKindFull,
+		},
+		{
+			name: "Full text in the description",
+			items: `<item><title>One</title><link>https://example.com/1</link>` +
+				`<description><![CDATA[` + paragraph + `]]></description></item>`,
+			want: models.ContentKindFull,
+		},
+		{
+			name: "Summary-only feed",
+			items: `<item><title>One</title><link>https://example.com/1</link><description>A short teaser</description></item>` +
+				`<item><title>Two</title><link>https://example.com/2</link><description><![CDATA[<p>Another <b>teaser</b></p>]]></description></item>`,
+			want: models.ContentKindSummary,
+		},
+		{
+			name:  "Links only",
+			items: `<item><title>One</title><link>https://example.com/1</link></item>`,
+			want:  models.ContentKindSummary,
+		},
+		{
+			name: "No items",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/rss+xml")
+				_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">` +
+					`<channel><title>Feed</title>` + tt.items + `</channel></rss>`))
+			}))
+			defer server.Close()
+
+			// Judged on every item, even when the initial sync sends none of them
+			result, err := rss.NewProcessor().FetchFeed(context.Background(), &models.Feed{URL: server.URL, SyncMode: models.SyncModeNone})
+			require.NoError(t, err)
+			assert.Empty(t, result.Articles)
+			assert.Equal(t, tt.want, result.ContentKind)
+		})
+	}
+}
+
+func TestDetectContentKind(t *testing.T) {
+	full := rss.Article{ContentChars: rss.FullContentMinChars}
+	summary := rss.Article{ContentChars: rss.FullContentMinChars - 1}
+
+	assert.Equal(t, models.ContentKindFull, rss.DetectContentKind([]rss.Article{full, summary}), "half the items are enough")
+	assert.Equal(t, models.ContentKindSummary, rss.DetectContentKind([]rss.Article{full, summary, summary}))
+	assert.Equal(t, models.ContentKind(""), rss.DetectContentKind(nil))
+	assert.Equal(t, "full content", models.ContentKindFull.Label())
+	assert.Equal(t, "summary only", models.ContentKindSummary.Label())
+}
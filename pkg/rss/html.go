@@ -0,0 +1,111 @@
+package rss
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlSniffBytes is how much of a response body is inspected to tell an HTML page from a feed.
+const htmlSniffBytes = 512
+
+// HTMLPageError is returned when a feed URL answers with an HTML page, such as the site's home
+// page, a login form or a "not found" page served with a 200 status, instead of a feed.
+type HTMLPageError struct {
+	URL     string // URL that returned the page
+	FeedURL string // Feed the page advertises with a <link rel="alternate">, if any
+}
+
+func (e *HTMLPageError) Error() string {
+	if e.FeedURL != "" {
+		return fmt.Sprintf("%s returned an HTML page, not a feed; the page links to a feed at %s, try that URL instead", e.URL, e.FeedURL)
+	}
+
+	return fmt.Sprintf("%s returned an HTML page, not a feed; check the URL points at the feed itself rather than a web page or login form", e.URL)
+}
+
+// looksLikeHTML reports whether a response is an HTML page rather than a feed, from its
+// Content-Type and the start of its body. A body that opens like an HTML document always
+// counts; an HTML content type only counts when the body doesn't open like a feed, since some
+// servers label real feeds text/html.
+func looksLikeHTML(contentType string, prefix []byte) bool {
+	start := strings.ToLower(string(bytes.TrimLeft(bytes.TrimPrefix(prefix, []byte("\xef\xbb\xbf")), " \t\r\n")))
+	if strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html") {
+		return true
+	}
+	if !isHTMLType(contentType) {
+		return false
+	}
+
+	for _, feedStart := range []string{"<?xml", "<rss", "<feed", "<rdf", "{"} {
+		if strings.HasPrefix(start, feedStart) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// advertisedFeed returns the first feed an HTML page links to with <link rel="alternate">,
+// resolved against base, or "" when it links to none.
+func advertisedFeed(page io.Reader, base *url.URL) string {
+	tokenizer := html.NewTokenizer(io.LimitReader(page, MaxRawFeedBytes))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data == "body" {
+				return ""
+			}
+			if token.Data != "link" {
+				continue
+			}
+			if href := feedLinkHref(token.Attr); href != "" {
+				return resolveLink(base, href)
+			}
+		default:
+		}
+	}
+}
+
+// feedLinkHref returns the href of a <link> tag that advertises a feed, or ""
+func feedLinkHref(attrs []html.Attribute) string {
+	var rel, linkType, href string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "rel":
+			rel = strings.ToLower(attr.Val)
+		case "type":
+			linkType = strings.ToLower(strings.TrimSpace(attr.Val))
+		case "href":
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+
+	if !slices.Contains(strings.Fields(rel), "alternate") {
+		return ""
+	}
+	switch linkType {
+	case "application/rss+xml", "application/atom+xml", "application/feed+json":
+		return href
+	default:
+		return ""
+	}
+}
+
+// resolveLink resolves href against base, returning href unchanged when either can't be used
+func resolveLink(base *url.URL, href string) string {
+	ref, err := url.Parse(href)
+	if err != nil || base == nil {
+		return href
+	}
+
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,76 @@
+package rss_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestProcessor_HTMLPageInsteadOfFeed(t *testing.T) {
+	const feedXML = `<?xml version="1.0"?><rss version="2.0"><channel><title>Feed</title>` +
+		`<item><title>Post</title><link>https://example.com/post</link></item></channel></rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("\n<!DOCTYPE html><html><head><title>Sign in</title></head><body><form></form></body></html>"))
+		case "/blog":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><head><link rel="stylesheet" href="/style.css">` +
+				`<link rel="alternate" type="application/rss+xml" href="/blog/feed.xml"></head><body></body></html>`))
+		case "/untyped":
+			// No content type to go on, only the body
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("<html><body>Not found</body></html>"))
+		case "/mislabelled":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(feedXML))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetch := func(path string) (*rss.FeedResult, error) {
+		return rss.NewProcessor().FetchFeed(context.Background(), &models.Feed{URL: server.URL + path, InitialSyncDone: true})
+	}
+
+	t.Run("Login page", func(t *testing.T) {
+		_, err := fetch("/login")
+
+		var pageErr *rss.HTMLPageError
+		require.ErrorAs(t, err, &pageErr)
+		assert.Equal(t, server.URL+"/login", pageErr.URL)
+		assert.Empty(t, pageErr.FeedURL)
+		assert.Contains(t, err.Error(), "returned an HTML page, not a feed")
+	})
+
+	t.Run("Page that links to its feed", func(t *testing.T) {
+		_, err := fetch("/blog")
+
+		var pageErr *rss.HTMLPageError
+		require.ErrorAs(t, err, &pageErr)
+		assert.Equal(t, server.URL+"/blog/feed.xml", pageErr.FeedURL)
+		assert.Contains(t, err.Error(), "try that URL instead")
+	})
+
+	t.Run("HTML body without an HTML content type", func(t *testing.T) {
+		_, err := fetch("/untyped")
+
+		var pageErr *rss.HTMLPageError
+		assert.ErrorAs(t, err, &pageErr)
+	})
+
+	t.Run("Feed served as text/html is still parsed", func(t *testing.T) {
+		result, err := fetch("/mislabelled")
+		require.NoError(t, err)
+		assert.Len(t, result.Articles, 1)
+	})
+}
@@ -0,0 +1,128 @@
+package rss
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+)
+
+// atomTranslator translates Atom feeds like gofeed's default translator, but picks each entry's
+// link with BestAtomLink instead of taking the first rel="alternate" link, and leaves an entry's
+// published date empty when it has none rather than filling it in with the updated date, so
+// the feed's DateField decides which to use.
+type atomTranslator struct {
+	gofeed.DefaultAtomTranslator
+}
+
+// Translate converts an *atom.Feed into a gofeed.Feed with article links chosen by BestAtomLink.
+func (t *atomTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultAtomTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	atomFeed, ok := feed.(*atom.Feed)
+	if !ok || len(atomFeed.Entries) != len(result.Items) {
+		return result, nil
+	}
+
+	for i, entry := range atomFeed.Entries {
+		if link := BestAtomLink(entry.Links); link != "" {
+			result.Items[i].Link = link
+		}
+		if entry.PublishedParsed == nil {
+			result.Items[i].Published = ""
+			result.Items[i].PublishedParsed = nil
+		}
+	}
+
+	return result, nil
+}
+
+// BestAtomLink chooses the article URL from an Atom entry's links. It prefers an alternate link
+// to an HTML page, then an untyped alternate, any alternate, a related link, and finally any
+// link that isn't the entry's own feed document or an attachment. An empty rel counts as
+// alternate, as the Atom spec requires.
+func BestAtomLink(links []*atom.Link) string {
+	var untypedAlternate, anyAlternate, related, other string
+	for _, link := range links {
+		if link == nil || link.Href == "" {
+			continue
+		}
+
+		switch rel := strings.ToLower(link.Rel); rel {
+		case "", "alternate":
+			if isHTMLType(link.Type) {
+				return link.Href
+			}
+			if link.Type == "" && untypedAlternate == "" {
+				untypedAlternate = link.Href
+			}
+			if anyAlternate == "" {
+				anyAlternate = link.Href
+			}
+		case "related":
+			if related == "" {
+				related = link.Href
+			}
+		case "self", "enclosure", "edit", "replies":
+			// Not the article page
+		default:
+			if other == "" {
+				other = link.Href
+			}
+		}
+	}
+
+	for _, candidate := range []string{untypedAlternate, anyAlternate, related, other} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// isHTMLType reports whether a link's media type is an HTML page
+func isHTMLType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0]))
+
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// resolveItemLink makes an item's link absolute. gofeed resolves links against Atom's xml:base
+// but leaves other relative links, such as an RSS <link>/post/123</link>, as they are. Those
+// are resolved against the feed's site URL or, when it has none, the feed's own URL. Absolute
+// links are returned unchanged. It returns false when the link is not an http or https URL
+// even after resolving.
+func resolveItemLink(link, siteURL, feedURL string) (string, bool) {
+	ref, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", false
+	}
+	if isWebURL(ref) {
+		return link, true
+	}
+	if ref.IsAbs() {
+		return "", false
+	}
+
+	for _, candidate := range []string{siteURL, feedURL} {
+		base, err := url.Parse(candidate)
+		if err != nil || !isWebURL(base) {
+			continue
+		}
+		if resolved := base.ResolveReference(ref); isWebURL(resolved) {
+			return resolved.String(), true
+		}
+	}
+
+	return "", false
+}
+
+// isWebURL reports whether u is an absolute http or https URL with a host
+func isWebURL(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
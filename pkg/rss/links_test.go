@@ -0,0 +1,164 @@
+package rss_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestBestAtomLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		links    []*atom.Link
+		expected string
+	}{
+		{
+			name:     "No links",
+			links:    nil,
+			expected: "",
+		},
+		{
+			name: "HTML alternate preferred over earlier typed alternate",
+			links: []*atom.Link{
+				{Href: "https://example.com/post.atom", Rel: "alternate", Type: "application/atom+xml"},
+				{Href: "https://example.com/post", Rel: "alternate", Type: "text/html"},
+			},
+			expected: "https://example.com/post",
+		},
+		{
+			name: "HTML alternate preferred over related",
+			links: []*atom.Link{
+				{Href: "https://other.example.com/discussion", Rel: "related", Type: "text/html"},
+				{Href: "https://example.com/post", Rel: "alternate", Type: "text/html; charset=utf-8"},
+			},
+			expected: "https://example.com/post",
+		},
+		{
+			name: "Untyped alternate when no HTML alternate",
+			links: []*atom.Link{
+				{Href: "https://example.com/post.atom", Rel: "alternate", Type: "application/atom+xml"},
+				{Href: "https://example.com/post", Rel: "alternate"},
+			},
+			expected: "https://example.com/post",
+		},
+		{
+			name: "Any alternate when none are HTML or untyped",
+			links: []*atom.Link{
+				{Href: "https://example.com/post.json", Rel: "alternate", Type: "application/json"},
+			},
+			expected: "https://example.com/post.json",
+		},
+		{
+			name: "Related when no alternate",
+			links: []*atom.Link{
+				{Href: "https://example.com/entry.atom", Rel: "self"},
+				{Href: "https://example.com/audio.mp3", Rel: "enclosure", Type: "audio/mpeg"},
+				{Href: "https://example.com/linked-article", Rel: "related"},
+			},
+			expected: "https://example.com/linked-article",
+		},
+		{
+			name: "Self and enclosure links are never chosen",
+			links: []*atom.Link{
+				{Href: "https://example.com/entry.atom", Rel: "self"},
+				{Href: "https://example.com/audio.mp3", Rel: "enclosure"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rss.BestAtomLink(tt.links))
+		})
+	}
+}
+
+func TestProcessor_FetchAndParse_AtomMultipleLinks(t *testing.T) {
+	atomFeed := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Atom Feed</title>
+	<updated>2024-01-01T12:00:00Z</updated>
+	<entry>
+		<title>Linked Entry</title>
+		<id>urn:uuid:1</id>
+		<updated>2024-01-01T10:00:00Z</updated>
+		<link rel="alternate" type="application/atom+xml" href="https://example.com/entry.atom"/>
+		<link rel="related" type="text/html" href="https://other.example.com/discussion"/>
+		<link rel="alternate" type="text/html" href="https://example.com/entry"/>
+	</entry>
+	<entry>
+		<title>Related Only</title>
+		<id>urn:uuid:2</id>
+		<updated>2024-01-01T11:00:00Z</updated>
+		<link rel="self" href="https://example.com/entry2.atom"/>
+		<link rel="related" href="https://example.com/entry2"/>
+	</entry>
+</feed>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		w.Write([]byte(atomFeed))
+	}))
+	defer server.Close()
+
+	articles, err := rss.NewProcessor().FetchAndParse(server.URL)
+	assert.NoError(t, err)
+	if assert.Len(t, articles, 2) {
+		assert.Equal(t, "https://example.com/entry", articles[0].URL)
+		assert.Equal(t, "https://example.com/entry2", articles[1].URL)
+	}
+}
+
+func TestProcessor_FetchAndParse_RelativeLinks(t *testing.T) {
+	item := func(title, link string) string {
+		return `<item><title>` + title + `</title><link>` + link + `</link></item>`
+	}
+	feeds := map[string]string{
+		"/site.xml": `<?xml version="1.0"?><rss version="2.0"><channel><title>Blog</title>` +
+			`<link>https://blog.example.com/posts/</link>` +
+			item("Root relative", "/post/1") +
+			item("Path relative", "post/2") +
+			item("Scheme relative", "//cdn.example.com/post/3") +
+			item("Absolute", "https://other.example.com/post/4?a=1&amp;b=%20") +
+			item("Not a web link", "mailto:editor@example.com") +
+			`</channel></rss>`,
+		"/no-site.xml": `<?xml version="1.0"?><rss version="2.0"><channel><title>Blog</title>` +
+			item("Root relative", "/post/5") +
+			`</channel></rss>`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(feeds[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	t.Run("Resolved against the site URL", func(t *testing.T) {
+		articles, err := rss.NewProcessor().FetchAndParse(server.URL + "/site.xml")
+		require.NoError(t, err)
+
+		urls := make(map[string]string, len(articles))
+		for _, article := range articles {
+			urls[article.Title] = article.URL
+		}
+		assert.Equal(t, map[string]string{
+			"Root relative":   "https://blog.example.com/post/1",
+			"Path relative":   "https://blog.example.com/posts/post/2",
+			"Scheme relative": "https://cdn.example.com/post/3",
+			"Absolute":        "https://other.example.com/post/4?a=1&b=%20",
+		}, urls, "the mailto link is skipped")
+	})
+
+	t.Run("Resolved against the feed URL without a site URL", func(t *testing.T) {
+		articles, err := rss.NewProcessor().FetchAndParse(server.URL + "/no-site.xml")
+		require.NoError(t, err)
+		require.Len(t, articles, 1)
+		assert.Equal(t, server.URL+"/post/5", articles[0].URL)
+	})
+}
@@ -2,8 +2,12 @@
 package rss
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
@@ -15,36 +19,143 @@ import (
 type Processorer interface {
 	FetchAndParse(feedURL string) ([]Article, error)
 	FetchAndParseWithSyncOptions(feedURL string, syncMode models.SyncMode, syncCount *int, syncDateFrom *time.Time) ([]Article, error)
+	FetchFeed(ctx context.Context, feed *models.Feed) (*FeedResult, error)
+	FetchRaw(ctx context.Context, feed *models.Feed) ([]byte, string, error)
 }
 
+// MaxRawFeedBytes is the most FetchRaw will return; longer bodies are truncated.
+const MaxRawFeedBytes = 1 << 20 // 1 MB
+
+// rawFetchTimeout bounds FetchRaw when the parser has no HTTP client of its own.
+const rawFetchTimeout = 30 * time.Second
+
 // Article represents a simplified article structure from an RSS feed.
 type Article struct {
-	PublishedAt *time.Time
-	Title       string
-	URL         string
+	PublishedAt  *time.Time
+	Title        string
+	URL          string
+	Categories   []string // The item's <category> values, if any
+	ContentChars int      // Characters of text in the item's content:encoded or description, whichever is longer
+	DateGuessed  bool     // PublishedAt is not the item's own date but a fallback
+}
+
+// FeedResult is the outcome of fetching a feed: the articles to consider plus feed-level metadata.
+type FeedResult struct {
+	LastBuildDate *time.Time         // RSS <lastBuildDate> or Atom <updated>, nil when the feed omits it
+	SiteURL       string             // Website the feed belongs to (RSS <link>, Atom alternate link), "" when omitted
+	MovedTo       string             // Where the feed permanently redirected to on another host, "" when it did not
+	ContentKind   models.ContentKind // Whether the items carry full content, judged before sync filtering
+	TTLMinutes    int                // RSS <ttl>: minutes the feed asks readers to wait between fetches, 0 when omitted
+	Articles      []Article
 }
 
 // Processor handles fetching and parsing RSS feeds.
 type Processor struct {
 	FeedParser *gofeed.Parser
+	// BlockCrossHostRedirects makes fetches fail with a CrossHostRedirectError instead of
+	// following a redirect to another host.
+	BlockCrossHostRedirects bool
 }
 
-// NewProcessor creates a new RSS Processor.
+// NewProcessor creates a new RSS Processor. Atom entries with several links resolve to the
+// link chosen by BestAtomLink.
 func NewProcessor() *Processor {
+	parser := gofeed.NewParser()
+	parser.AtomTranslator = &atomTranslator{}
+	parser.RSSTranslator = &rssTranslator{}
+
 	return &Processor{
-		FeedParser: gofeed.NewParser(),
+		FeedParser: parser,
 	}
 }
 
 // FetchAndParse fetches an RSS feed from the given URL and parses it.
 func (p *Processor) FetchAndParse(feedURL string) ([]Article, error) {
 	logging.Debug("Fetching RSS feed", "feed_url", feedURL)
-	feed, err := p.FeedParser.ParseURL(feedURL)
+	feed, _, err := p.parseFeed(context.Background(), &models.Feed{URL: feedURL})
 	if err != nil {
-		return nil, fmt.Errorf("feedParser.ParseURL failed for %s: %w", feedURL, err)
+		return nil, fmt.Errorf("parseFeed failed for %s: %w", feedURL, err)
 	}
 
+	return p.extractArticles(feedURL, feed, models.DateFieldAuto), nil
+}
+
+// FetchFeed fetches and parses a feed, returning its build date alongside the articles.
+// Until the feed's initial sync is done, the articles are filtered by its sync options.
+func (p *Processor) FetchFeed(ctx context.Context, feed *models.Feed) (*FeedResult, error) {
+	ctx, cancel := FetchContext(ctx, feed)
+	defer cancel()
+
+	logging.Debug("Fetching RSS feed", "feed_url", feed.URL, "fetch_timeout_seconds", feed.FetchTimeoutSeconds)
+	parsed, movedTo, err := p.parseFeed(ctx, feed)
+	if err != nil {
+		return nil, fmt.Errorf("parseFeed failed for %s: %w", feed.URL, err)
+	}
+
+	articles := p.extractArticles(feed.URL, parsed, feed.DateField)
+	contentKind := DetectContentKind(articles)
+	if !feed.InitialSyncDone {
+		articles, err = p.applySyncFiltering(feed.URL, articles, feed.SyncMode, feed.SyncCount, feed.SyncDateFrom)
+		if err != nil {
+			return nil, fmt.Errorf("applySyncFiltering failed: %w", err)
+		}
+	}
+
+	return &FeedResult{
+		LastBuildDate: parsed.UpdatedParsed,
+		SiteURL:       parsed.Link,
+		MovedTo:       movedTo,
+		ContentKind:   contentKind,
+		TTLMinutes:    ParseTTL(parsed.Custom[ttlKey]),
+		Articles:      articles,
+	}, nil
+}
+
+// FetchContext derives the context for a single fetch of feed, applying the feed's timeout
+// override as a deadline. Without an override the parent context is returned unchanged.
+func FetchContext(ctx context.Context, feed *models.Feed) (context.Context, context.CancelFunc) {
+	if feed.FetchTimeoutSeconds <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Duration(feed.FetchTimeoutSeconds)*time.Second)
+}
+
+// FetchRaw fetches the feed without parsing it and returns the body, truncated to
+// MaxRawFeedBytes, together with the response content type. The request is built and sent
+// the same way as normal fetching, including the feed's Accept header, cookie and timeout.
+func (p *Processor) FetchRaw(ctx context.Context, feed *models.Feed) ([]byte, string, error) {
+	ctx, cancel := FetchContext(ctx, feed)
+	defer cancel()
+
+	logging.Debug("Fetching raw feed", "feed_url", feed.URL, "fetch_timeout_seconds", feed.FetchTimeoutSeconds)
+
+	client := p.FeedParser.Client
+	if client == nil {
+		client = &http.Client{Timeout: rawFetchTimeout}
+	}
+
+	resp, err := p.doFeedRequest(ctx, client, feed)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch raw feed %s: %w", feed.URL, err)
+	}
+	defer closeBody(resp)
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxRawFeedBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read body from %s: %w", feed.URL, err)
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// extractArticles converts parsed feed items into articles, skipping items without a link or
+// title. Relative links are resolved by resolveItemLink, and items whose link can't be are
+// skipped too. Each article is dated by the item date dateField picks.
+func (p *Processor) extractArticles(feedURL string, feed *gofeed.Feed, dateField models.DateField) []Article {
 	articles := make([]Article, 0, len(feed.Items))
+	// Undated items share one time, so sorting orders them by URL
+	fetchedAt := time.Now()
 	for _, item := range feed.Items {
 		if item.Link == "" || item.Title == "" {
 			logging.Warn("Skipping RSS item with missing link or title",
@@ -55,19 +166,32 @@ func (p *Processor) FetchAndParse(feedURL string) ([]Article, error) {
 			continue
 		}
 
+		link, ok := resolveItemLink(item.Link, feed.Link, feedURL)
+		if !ok {
+			logging.Warn("Skipping RSS item whose link cannot be made absolute",
+				"feed_url", feedURL,
+				"item_title", item.Title,
+				"item_link", item.Link)
+
+			continue
+		}
+
 		article := Article{
-			Title: item.Title,
-			URL:   item.Link,
+			Title:        item.Title,
+			URL:          link,
+			Categories:   item.Categories,
+			ContentChars: max(textLength(item.Content), textLength(item.Description)),
 		}
-		if item.PublishedParsed != nil {
-			article.PublishedAt = item.PublishedParsed
+		if date := ItemDate(item, dateField); date != nil {
+			article.PublishedAt = date
 		} else if feed.PublishedParsed != nil {
 			// Fallback to feed's published date if item's is missing
 			article.PublishedAt = feed.PublishedParsed
+			article.DateGuessed = true
 		} else {
 			// If no published date, use current time as a last resort
-			now := time.Now()
-			article.PublishedAt = &now
+			article.PublishedAt = &fetchedAt
+			article.DateGuessed = true
 		}
 		articles = append(articles, article)
 	}
@@ -76,7 +200,28 @@ func (p *Processor) FetchAndParse(feedURL string) ([]Article, error) {
 		"feed_url", feedURL,
 		"article_count", len(articles))
 
-	return articles, nil
+	return articles
+}
+
+// ItemDate returns the date of item that field picks, or nil when the item has no such date. An
+// empty field counts as models.DateFieldAuto.
+func ItemDate(item *gofeed.Item, field models.DateField) *time.Time {
+	switch field {
+	case models.DateFieldPublished:
+		return item.PublishedParsed
+	case models.DateFieldUpdated:
+		if item.UpdatedParsed != nil {
+			return item.UpdatedParsed
+		}
+
+		return item.PublishedParsed
+	default:
+		if item.PublishedParsed != nil {
+			return item.PublishedParsed
+		}
+
+		return item.UpdatedParsed
+	}
 }
 
 // FetchAndParseWithSyncOptions fetches and parses RSS feed with filtering based on sync options
@@ -197,54 +342,55 @@ func (p *Processor) handleUnknownSyncMode(feedURL string, syncMode models.SyncMo
 	return []Article{}, nil
 }
 
-// SortArticlesByDate sorts articles by published date (oldest first)
+// SortArticlesByDate sorts articles by published date (oldest first). Articles published at
+// the same time are ordered by URL and undated articles go last, so a backfill sends the same
+// articles in the same order on every fetch and one interrupted by the send cap resumes where
+// it stopped.
 func (p *Processor) SortArticlesByDate(articles []Article) []Article {
 	sortedArticles := make([]Article, len(articles))
 	copy(sortedArticles, articles)
-	sort.Slice(sortedArticles, func(firstIdx, secondIdx int) bool {
-		firstTime := sortedArticles[firstIdx].PublishedAt
-		secondTime := sortedArticles[secondIdx].PublishedAt
-
-		if firstTime == nil && secondTime == nil {
-			return false
-		}
-		if firstTime == nil {
-			return false
-		}
-		if secondTime == nil {
-			return true
-		}
-
-		return firstTime.Before(*secondTime)
+	sort.SliceStable(sortedArticles, func(firstIdx, secondIdx int) bool {
+		return compareArticles(sortedArticles[firstIdx], sortedArticles[secondIdx], false) < 0
 	})
 
 	return sortedArticles
 }
 
-// SortArticlesByDateNewestFirst sorts articles by published date (newest first)
+// SortArticlesByDateNewestFirst sorts articles by published date (newest first), the reverse
+// of SortArticlesByDate except that undated articles still go last
 func (p *Processor) SortArticlesByDateNewestFirst(articles []Article) []Article {
 	sortedArticles := make([]Article, len(articles))
 	copy(sortedArticles, articles)
-	sort.Slice(sortedArticles, func(firstIdx, secondIdx int) bool {
-		firstTime := sortedArticles[firstIdx].PublishedAt
-		secondTime := sortedArticles[secondIdx].PublishedAt
-
-		if firstTime == nil && secondTime == nil {
-			return false
-		}
-		if firstTime == nil {
-			return false
-		}
-		if secondTime == nil {
-			return true
-		}
-
-		return firstTime.After(*secondTime)
+	sort.SliceStable(sortedArticles, func(firstIdx, secondIdx int) bool {
+		return compareArticles(sortedArticles[firstIdx], sortedArticles[secondIdx], true) < 0
 	})
 
 	return sortedArticles
 }
 
+// compareArticles orders two articles by published date, oldest first or newest first, then
+// by URL in the same direction. Undated articles come after dated ones, ordered by URL.
+func compareArticles(first, second Article, newestFirst bool) int {
+	switch {
+	case first.PublishedAt == nil && second.PublishedAt == nil:
+		return strings.Compare(first.URL, second.URL)
+	case first.PublishedAt == nil:
+		return 1
+	case second.PublishedAt == nil:
+		return -1
+	}
+
+	order := first.PublishedAt.Compare(*second.PublishedAt)
+	if order == 0 {
+		order = strings.Compare(first.URL, second.URL)
+	}
+	if newestFirst {
+		return -order
+	}
+
+	return order
+}
+
 // filterArticlesByDate filters articles published on or after the specified date
 func (p *Processor) filterArticlesByDate(articles []Article, syncDateFrom *time.Time) []Article {
 	var filteredArticles []Article
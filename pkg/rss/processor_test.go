@@ -1,13 +1,16 @@
 package rss_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"wallabag-rss-tool/pkg/models"
 	"wallabag-rss-tool/pkg/rss"
 )
@@ -231,14 +234,14 @@ func TestProcessor_FetchAndParse(t *testing.T) {
 		articles, err := processor.FetchAndParse("invalid-url")
 		assert.Error(t, err)
 		assert.Nil(t, articles)
-		assert.Contains(t, err.Error(), "feedParser.ParseURL failed for invalid-url")
+		assert.Contains(t, err.Error(), "parseFeed failed for invalid-url")
 	})
 
 	t.Run("URL not found", func(t *testing.T) {
 		articles, err := processor.FetchAndParse("https://nonexistent.example.com/feed.rss")
 		assert.Error(t, err)
 		assert.Nil(t, articles)
-		assert.Contains(t, err.Error(), "feedParser.ParseURL failed for https://nonexistent.example.com/feed.rss")
+		assert.Contains(t, err.Error(), "parseFeed failed for https://nonexistent.example.com/feed.rss")
 	})
 
 	t.Run("Invalid RSS content", func(t *testing.T) {
@@ -253,7 +256,7 @@ func TestProcessor_FetchAndParse(t *testing.T) {
 		articles, err := processor.FetchAndParse(server.URL)
 		assert.Error(t, err)
 		assert.Nil(t, articles)
-		assert.Contains(t, err.Error(), "feedParser.ParseURL failed for")
+		assert.Contains(t, err.Error(), "parseFeed failed for")
 	})
 
 	t.Run("Server error", func(t *testing.T) {
@@ -267,7 +270,7 @@ func TestProcessor_FetchAndParse(t *testing.T) {
 		articles, err := processor.FetchAndParse(server.URL)
 		assert.Error(t, err)
 		assert.Nil(t, articles)
-		assert.Contains(t, err.Error(), "feedParser.ParseURL failed for")
+		assert.Contains(t, err.Error(), "parseFeed failed for")
 	})
 
 	t.Run("Empty RSS feed", func(t *testing.T) {
@@ -328,6 +331,256 @@ func TestProcessor_FetchAndParse(t *testing.T) {
 	})
 }
 
+func TestProcessor_FetchFeed(t *testing.T) {
+	processor := rss.NewProcessor()
+
+	feedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+	<channel>
+		<title>Test Feed</title>
+		<link>https://example.com</link>
+		<lastBuildDate>Mon, 01 Jan 2024 12:00:00 GMT</lastBuildDate>
+		<ttl>60</ttl>
+		<item>
+			<title>Old Article</title>
+			<link>https://example.com/old</link>
+			<pubDate>Mon, 01 Jan 2024 10:00:00 GMT</pubDate>
+			<category>Go</category>
+			<category>Databases</category>
+		</item>
+		<item>
+			<title>New Article</title>
+			<link>https://example.com/new</link>
+			<pubDate>Mon, 01 Jan 2024 11:00:00 GMT</pubDate>
+		</item>
+	</channel>
+</rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(feedXML))
+	}))
+	defer server.Close()
+
+	t.Run("Exposes feed build date", func(t *testing.T) {
+		feed := &models.Feed{URL: server.URL, InitialSyncDone: true}
+
+		result, err := processor.FetchFeed(context.Background(), feed)
+		assert.NoError(t, err)
+		assert.Len(t, result.Articles, 2)
+		if assert.NotNil(t, result.LastBuildDate) {
+			assert.True(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Equal(*result.LastBuildDate))
+		}
+	})
+
+	t.Run("Exposes ttl", func(t *testing.T) {
+		feed := &models.Feed{URL: server.URL, InitialSyncDone: true}
+
+		result, err := processor.FetchFeed(context.Background(), feed)
+		assert.NoError(t, err)
+		assert.Equal(t, 60, result.TTLMinutes)
+	})
+
+	t.Run("Exposes item categories", func(t *testing.T) {
+		feed := &models.Feed{URL: server.URL, InitialSyncDone: true}
+
+		result, err := processor.FetchFeed(context.Background(), feed)
+		assert.NoError(t, err)
+		categories := map[string][]string{}
+		for _, article := range result.Articles {
+			categories[article.Title] = article.Categories
+		}
+		assert.Equal(t, []string{"Go", "Databases"}, categories["Old Article"])
+		assert.Empty(t, categories["New Article"])
+	})
+
+	t.Run("Applies sync options before initial sync", func(t *testing.T) {
+		count := 1
+		feed := &models.Feed{URL: server.URL, SyncMode: models.SyncModeCount, SyncCount: &count}
+
+		result, err := processor.FetchFeed(context.Background(), feed)
+		assert.NoError(t, err)
+		if assert.Len(t, result.Articles, 1) {
+			assert.Equal(t, "New Article", result.Articles[0].Title)
+		}
+	})
+
+	t.Run("Fetch error", func(t *testing.T) {
+		feed := &models.Feed{URL: "http://127.0.0.1:0/feed"}
+
+		result, err := processor.FetchFeed(context.Background(), feed)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestProcessor_FetchFeed_DateField(t *testing.T) {
+	feedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Test Feed</title>
+	<entry>
+		<title>Edited Article</title>
+		<link href="https://example.com/edited"/>
+		<published>2024-01-01T10:00:00Z</published>
+		<updated>2024-06-01T10:00:00Z</updated>
+	</entry>
+	<entry>
+		<title>Recent Article</title>
+		<link href="https://example.com/recent"/>
+		<published>2024-03-01T10:00:00Z</published>
+		<updated>2024-03-01T10:00:00Z</updated>
+	</entry>
+</feed>`
+	// An entry with only an updated date
+	undatedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Test Feed</title>
+	<entry>
+		<title>Undated Article</title>
+		<link href="https://example.com/undated"/>
+		<updated>2024-02-01T10:00:00Z</updated>
+	</entry>
+</feed>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		if r.URL.Path == "/undated" {
+			_, _ = w.Write([]byte(undatedXML))
+
+			return
+		}
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer server.Close()
+
+	from := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	count := 1
+
+	titles := func(articles []rss.Article) []string {
+		result := make([]string, 0, len(articles))
+		for _, article := range articles {
+			result = append(result, article.Title)
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		dateField models.DateField
+		syncMode  models.SyncMode
+		want      []string
+	}{
+		{name: "Published ignores a later update when counting", dateField: models.DateFieldPublished, syncMode: models.SyncModeCount, want: []string{"Recent Article"}},
+		{name: "Updated dates an edited post by its update", dateField: models.DateFieldUpdated, syncMode: models.SyncModeCount, want: []string{"Edited Article"}},
+		{name: "Auto prefers the published date", dateField: models.DateFieldAuto, syncMode: models.SyncModeDateFrom, want: []string{"Recent Article"}},
+		{name: "Unset behaves like auto", syncMode: models.SyncModeDateFrom, want: []string{"Recent Article"}},
+		{name: "Updated lets edited posts through the date filter", dateField: models.DateFieldUpdated, syncMode: models.SyncModeDateFrom, want: []string{"Edited Article", "Recent Article"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := &models.Feed{URL: server.URL, DateField: tt.dateField, SyncMode: tt.syncMode, SyncCount: &count, SyncDateFrom: &from}
+
+			result, err := rss.NewProcessor().FetchFeed(context.Background(), feed)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, titles(result.Articles))
+		})
+	}
+
+	t.Run("Entry without a published date", func(t *testing.T) {
+		undated := func(field models.DateField) rss.Article {
+			result, err := rss.NewProcessor().FetchFeed(context.Background(), &models.Feed{URL: server.URL + "/undated", DateField: field, InitialSyncDone: true})
+			require.NoError(t, err)
+			require.Len(t, result.Articles, 1)
+
+			return result.Articles[0]
+		}
+
+		auto := undated(models.DateFieldAuto)
+		require.NotNil(t, auto.PublishedAt)
+		assert.True(t, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC).Equal(*auto.PublishedAt), "auto falls back to the updated date")
+		assert.False(t, auto.DateGuessed)
+
+		assert.True(t, undated(models.DateFieldPublished).DateGuessed, "published has no date of the entry's own to use")
+	})
+}
+
+func TestFetchContext(t *testing.T) {
+	t.Run("Override sets deadline", func(t *testing.T) {
+		before := time.Now()
+		ctx, cancel := rss.FetchContext(context.Background(), &models.Feed{FetchTimeoutSeconds: 90})
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, before.Add(90*time.Second), deadline, time.Second)
+	})
+
+	t.Run("No override keeps parent deadline", func(t *testing.T) {
+		ctx, cancel := rss.FetchContext(context.Background(), &models.Feed{})
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
+
+func TestProcessor_FetchFeed_TimeoutOverride(t *testing.T) {
+	processor := rss.NewProcessor()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(3 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := processor.FetchFeed(context.Background(), &models.Feed{URL: server.URL, FetchTimeoutSeconds: 1, InitialSyncDone: true})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestProcessor_FetchRaw(t *testing.T) {
+	processor := rss.NewProcessor()
+
+	t.Run("Returns body and content type", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/atom+xml")
+			w.Write([]byte("<feed/>"))
+		}))
+		defer server.Close()
+
+		body, contentType, err := processor.FetchRaw(context.Background(), &models.Feed{URL: server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, "<feed/>", string(body))
+		assert.Equal(t, "application/atom+xml", contentType)
+	})
+
+	t.Run("Truncates oversized bodies", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(make([]byte, rss.MaxRawFeedBytes+100))
+		}))
+		defer server.Close()
+
+		body, _, err := processor.FetchRaw(context.Background(), &models.Feed{URL: server.URL})
+		assert.NoError(t, err)
+		assert.Len(t, body, rss.MaxRawFeedBytes)
+	})
+
+	t.Run("Non-success status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, _, err := processor.FetchRaw(context.Background(), &models.Feed{URL: server.URL})
+		assert.Error(t, err)
+	})
+}
+
 func TestProcessor_Interface(t *testing.T) {
 	t.Run("Processor implements Processorer interface", func(t *testing.T) {
 		var processor rss.Processorer = rss.NewProcessor()
@@ -630,6 +883,17 @@ func TestProcessor_FetchAndParseWithSyncOptions_ArticlesWithoutDates(t *testing.
 		assert.Contains(t, titles, "Article with date")
 		assert.Contains(t, titles, "Article without date")
 	})
+
+	t.Run("Fallback dates are flagged as guessed", func(t *testing.T) {
+		articles, err := processor.FetchAndParse(server.URL)
+		assert.NoError(t, err)
+		guessed := map[string]bool{}
+		for _, article := range articles {
+			guessed[article.Title] = article.DateGuessed
+		}
+		assert.False(t, guessed["Article with date"])
+		assert.True(t, guessed["Article without date"])
+	})
 }
 
 func BenchmarkProcessor_FetchAndParse(b *testing.B) {
@@ -677,6 +941,56 @@ func BenchmarkProcessor_FetchAndParse(b *testing.B) {
 	}
 }
 
+func TestProcessor_FetchAndParseWithSyncOptions_StableOrder(t *testing.T) {
+	processor := rss.NewProcessor()
+
+	item := func(slug, pubDate string) string {
+		return `<item><title>` + slug + `</title><link>https://example.com/` + slug + `</link>` +
+			`<pubDate>` + pubDate + `</pubDate></item>`
+	}
+	const shared = "Wed, 03 Jan 2024 10:00:00 GMT"
+	// The feed lists its items in a different order on every fetch
+	orders := [][]string{
+		{item("c", shared), item("old", "Mon, 01 Jan 2024 10:00:00 GMT"), item("a", shared), item("b", shared)},
+		{item("b", shared), item("a", shared), item("c", shared), item("old", "Mon, 01 Jan 2024 10:00:00 GMT")},
+		{item("a", shared), item("c", shared), item("old", "Mon, 01 Jan 2024 10:00:00 GMT"), item("b", shared)},
+	}
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Feed</title>` +
+			strings.Join(orders[fetches%len(orders)], "") + `</channel></rss>`))
+		fetches++
+	}))
+	defer server.Close()
+
+	urls := func(articles []rss.Article) []string {
+		result := make([]string, 0, len(articles))
+		for _, article := range articles {
+			result = append(result, strings.TrimPrefix(article.URL, "https://example.com/"))
+		}
+
+		return result
+	}
+
+	t.Run("SyncModeAll sends ties in URL order on every fetch", func(t *testing.T) {
+		for range orders {
+			articles, err := processor.FetchAndParseWithSyncOptions(server.URL, models.SyncModeAll, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"old", "a", "b", "c"}, urls(articles))
+		}
+	})
+
+	t.Run("SyncModeCount picks the same ties on every fetch", func(t *testing.T) {
+		count := 2
+		for range orders {
+			articles, err := processor.FetchAndParseWithSyncOptions(server.URL, models.SyncModeCount, &count, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"b", "c"}, urls(articles))
+		}
+	})
+}
+
 func TestProcessor_sortArticlesByDate(t *testing.T) {
 	processor := rss.NewProcessor()
 	
@@ -703,6 +1017,21 @@ func TestProcessor_sortArticlesByDate(t *testing.T) {
 				{Title: "Latest", URL: "url3", PublishedAt: &date3},
 			},
 		},
+		{
+			name: "Same publish time ordered by URL",
+			articles: []rss.Article{
+				{Title: "C", URL: "https://example.com/c", PublishedAt: &date2},
+				{Title: "Later", URL: "https://example.com/a", PublishedAt: &date3},
+				{Title: "A", URL: "https://example.com/a", PublishedAt: &date2},
+				{Title: "B", URL: "https://example.com/b", PublishedAt: &date2},
+			},
+			expected: []rss.Article{
+				{Title: "A", URL: "https://example.com/a", PublishedAt: &date2},
+				{Title: "B", URL: "https://example.com/b", PublishedAt: &date2},
+				{Title: "C", URL: "https://example.com/c", PublishedAt: &date2},
+				{Title: "Later", URL: "https://example.com/a", PublishedAt: &date3},
+			},
+		},
 		{
 			name: "Articles with nil dates go to end",
 			articles: []rss.Article{
@@ -778,6 +1107,21 @@ func TestProcessor_sortArticlesByDateNewestFirst(t *testing.T) {
 				{Title: "Oldest", URL: "url1", PublishedAt: &date1},
 			},
 		},
+		{
+			name: "Same publish time in reverse URL order",
+			articles: []rss.Article{
+				{Title: "A", URL: "https://example.com/a", PublishedAt: &date2},
+				{Title: "Earlier", URL: "https://example.com/z", PublishedAt: &date1},
+				{Title: "C", URL: "https://example.com/c", PublishedAt: &date2},
+				{Title: "B", URL: "https://example.com/b", PublishedAt: &date2},
+			},
+			expected: []rss.Article{
+				{Title: "C", URL: "https://example.com/c", PublishedAt: &date2},
+				{Title: "B", URL: "https://example.com/b", PublishedAt: &date2},
+				{Title: "A", URL: "https://example.com/a", PublishedAt: &date2},
+				{Title: "Earlier", URL: "https://example.com/z", PublishedAt: &date1},
+			},
+		},
 		{
 			name: "Articles with nil dates go to end",
 			articles: []rss.Article{
@@ -0,0 +1,87 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// ParseSOCKS5Address validates a SOCKS5 proxy address and returns its host:port and optional
+// credentials. It accepts "host:port" or a socks5:// or socks5h:// URL with optional user info.
+func ParseSOCKS5Address(address string) (string, *proxy.Auth, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", nil, errors.New("SOCKS5 proxy address cannot be empty")
+	}
+
+	var auth *proxy.Auth
+	hostPort := address
+	if strings.Contains(address, "://") {
+		parsed, err := url.Parse(address)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid SOCKS5 proxy URL: %w", err)
+		}
+		if parsed.Scheme != "socks5" && parsed.Scheme != "socks5h" {
+			return "", nil, fmt.Errorf("unsupported proxy scheme %q, expected socks5 or socks5h", parsed.Scheme)
+		}
+		if parsed.User != nil {
+			password, _ := parsed.User.Password()
+			auth = &proxy.Auth{User: parsed.User.Username(), Password: password}
+		}
+		hostPort = parsed.Host
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", nil, fmt.Errorf("SOCKS5 proxy address must be host:port: %w", err)
+	}
+	if host == "" {
+		return "", nil, errors.New("SOCKS5 proxy address is missing a host")
+	}
+	if portNum, err := strconv.Atoi(port); err != nil || portNum < 1 || portNum > 65535 {
+		return "", nil, fmt.Errorf("SOCKS5 proxy port %q is not a valid port number", port)
+	}
+
+	return hostPort, auth, nil
+}
+
+// NewSOCKS5Transport returns an HTTP transport that dials every connection through the SOCKS5
+// proxy at address. Host names are resolved by the proxy, so .onion addresses work over Tor.
+func NewSOCKS5Transport(address string) (*http.Transport, error) {
+	hostPort, auth, err := ParseSOCKS5Address(address)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", hostPort, auth, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SOCKS5 dialer: %w", err)
+	}
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, errors.New("SOCKS5 dialer does not support contexts")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return contextDialer.DialContext(ctx, network, addr)
+	}
+
+	return transport, nil
+}
+
+// NewProcessorWithTransport creates a Processor whose feed fetches use transport.
+func NewProcessorWithTransport(transport http.RoundTripper) *Processor {
+	processor := NewProcessor()
+	processor.FeedParser.Client = &http.Client{Transport: transport}
+
+	return processor
+}
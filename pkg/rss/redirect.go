@@ -0,0 +1,78 @@
+package rss
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxRedirects matches the limit of Go's default redirect policy.
+const maxRedirects = 10
+
+// CrossHostRedirectError is returned for a feed that redirects to another host while
+// Processor.BlockCrossHostRedirects is set. The redirect is not followed.
+type CrossHostRedirectError struct {
+	From string // URL that answered with the redirect
+	To   string // URL it redirected to
+}
+
+func (e *CrossHostRedirectError) Error() string {
+	return fmt.Sprintf("feed redirects to another host (%s), which is not followed; update the feed URL if the move is expected", e.To)
+}
+
+// withRedirectPolicy returns client, or a copy of it that refuses redirects to another host
+// when p.BlockCrossHostRedirects is set. Redirects within a host, such as http to https, are
+// always followed.
+func (p *Processor) withRedirectPolicy(client *http.Client) *http.Client {
+	if !p.BlockCrossHostRedirects {
+		return client
+	}
+
+	policed := *client
+	policed.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		previous := via[len(via)-1]
+		if !sameHost(previous, req) {
+			return &CrossHostRedirectError{From: previous.URL.String(), To: req.URL.String()}
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
+
+	return &policed
+}
+
+// MovedTo returns where a feed fetched with resp has permanently moved: the final URL when
+// every redirect on the way was a 301 or 308 and the host changed. It returns "" otherwise.
+func MovedTo(resp *http.Response) string {
+	final := resp.Request
+	if final == nil || final.Response == nil {
+		return ""
+	}
+
+	first := final
+	for first.Response != nil {
+		status := first.Response.StatusCode
+		if status != http.StatusMovedPermanently && status != http.StatusPermanentRedirect {
+			return ""
+		}
+		first = first.Response.Request
+	}
+
+	if sameHost(first, final) {
+		return ""
+	}
+
+	return final.URL.String()
+}
+
+// sameHost reports whether two requests go to the same host, ignoring scheme and port
+func sameHost(a, b *http.Request) bool {
+	return strings.EqualFold(a.URL.Hostname(), b.URL.Hostname())
+}
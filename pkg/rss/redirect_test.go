@@ -0,0 +1,88 @@
+package rss_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestProcessor_CrossHostRedirects(t *testing.T) {
+	const feedXML = `<?xml version="1.0"?><rss version="2.0"><channel><title>Moved</title>` +
+		`<item><title>Post</title><link>https://example.com/post</link></item></channel></rss>`
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer target.Close()
+	// The same server reached by another host name
+	otherHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same-host":
+			http.Redirect(w, r, target.URL+"/feed", http.StatusMovedPermanently)
+		case "/permanent":
+			http.Redirect(w, r, otherHost+"/feed", http.StatusPermanentRedirect)
+		case "/temporary":
+			http.Redirect(w, r, otherHost+"/feed", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer origin.Close()
+
+	fetch := func(block bool, path string) (*rss.FeedResult, error) {
+		processor := rss.NewProcessor()
+		processor.BlockCrossHostRedirects = block
+
+		return processor.FetchFeed(context.Background(), &models.Feed{URL: origin.URL + path, InitialSyncDone: true})
+	}
+
+	t.Run("Same-host redirect is followed when blocking", func(t *testing.T) {
+		result, err := fetch(true, "/same-host")
+		require.NoError(t, err)
+		assert.Len(t, result.Articles, 1)
+		assert.Empty(t, result.MovedTo)
+	})
+
+	t.Run("Cross-host redirect is blocked", func(t *testing.T) {
+		_, err := fetch(true, "/permanent")
+
+		var redirectErr *rss.CrossHostRedirectError
+		require.ErrorAs(t, err, &redirectErr)
+		assert.Equal(t, origin.URL+"/permanent", redirectErr.From)
+		assert.Equal(t, otherHost+"/feed", redirectErr.To)
+		assert.Contains(t, err.Error(), otherHost+"/feed")
+	})
+
+	t.Run("Cross-host redirect is followed by default and the move recorded", func(t *testing.T) {
+		result, err := fetch(false, "/permanent")
+		require.NoError(t, err)
+		assert.Len(t, result.Articles, 1)
+		assert.Equal(t, otherHost+"/feed", result.MovedTo)
+	})
+
+	t.Run("Temporary redirect is not a move", func(t *testing.T) {
+		result, err := fetch(false, "/temporary")
+		require.NoError(t, err)
+		assert.Empty(t, result.MovedTo)
+	})
+
+	t.Run("Raw fetches follow the same policy", func(t *testing.T) {
+		processor := rss.NewProcessor()
+		processor.BlockCrossHostRedirects = true
+
+		_, _, err := processor.FetchRaw(context.Background(), &models.Feed{URL: origin.URL + "/temporary"})
+
+		var redirectErr *rss.CrossHostRedirectError
+		assert.ErrorAs(t, err, &redirectErr)
+	})
+}
@@ -0,0 +1,112 @@
+package rss
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mmcdole/gofeed"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// DefaultAccept is the Accept header sent for feeds without their own preference. It favours
+// feed formats so servers that negotiate content return a feed rather than an HTML page.
+const DefaultAccept = "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
+// NewFeedRequest builds the GET request for feed. Every feed fetch goes through it, so it is
+// the one place request headers are decided: the parser's User-Agent and basic auth, the
+// feed's Accept preference, falling back to DefaultAccept, and the feed's login cookie.
+func (p *Processor) NewFeedRequest(ctx context.Context, feed *models.Feed) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", feed.URL, err)
+	}
+
+	if p.FeedParser.UserAgent != "" {
+		req.Header.Set("User-Agent", p.FeedParser.UserAgent)
+	}
+
+	accept := feed.AcceptHeader
+	if accept == "" {
+		accept = DefaultAccept
+	}
+	req.Header.Set("Accept", accept)
+
+	if feed.Cookie != "" {
+		req.Header.Set("Cookie", feed.Cookie)
+	}
+
+	if auth := p.FeedParser.AuthConfig; auth != nil && auth.Username != "" && auth.Password != "" {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	return req, nil
+}
+
+// doFeedRequest sends the request for feed with client, under the processor's redirect policy.
+// Responses outside 2xx are closed and reported as a gofeed.HTTPError; otherwise the caller
+// must close the body.
+func (p *Processor) doFeedRequest(ctx context.Context, client *http.Client, feed *models.Feed) (*http.Response, error) {
+	req, err := p.NewFeedRequest(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.withRedirectPolicy(client).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", feed.URL, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		closeBody(resp)
+
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	return resp, nil
+}
+
+// parseFeed fetches feed and parses the response body. It also returns where the feed has
+// permanently moved to, as reported by MovedTo. An HTML page in place of the feed is reported
+// as an *HTMLPageError.
+func (p *Processor) parseFeed(ctx context.Context, feed *models.Feed) (*gofeed.Feed, string, error) {
+	client := p.FeedParser.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	resp, err := p.doFeedRequest(ctx, client, feed)
+	if err != nil {
+		return nil, "", err
+	}
+	defer closeBody(resp)
+
+	// gofeed fails on an HTML page with an opaque syntax error, so catch it first
+	body := bufio.NewReaderSize(resp.Body, htmlSniffBytes)
+	prefix, _ := body.Peek(htmlSniffBytes)
+	if looksLikeHTML(resp.Header.Get("Content-Type"), prefix) {
+		var base *url.URL
+		if resp.Request != nil {
+			base = resp.Request.URL
+		}
+
+		return nil, "", &HTMLPageError{URL: feed.URL, FeedURL: advertisedFeed(body, base)}
+	}
+
+	parsed, err := p.FeedParser.Parse(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("feedParser.Parse failed for %s: %w", feed.URL, err)
+	}
+
+	return parsed, MovedTo(resp), nil
+}
+
+// closeBody closes a response body, logging rather than returning any error
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		logging.Error("Failed to close response body", "error", err)
+	}
+}
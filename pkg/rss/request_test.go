@@ -0,0 +1,129 @@
+package rss_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestProcessor_NewFeedRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		feed       models.Feed
+		wantAccept string
+	}{
+		{name: "Default accept", feed: models.Feed{URL: "https://example.com/feed"}, wantAccept: rss.DefaultAccept},
+		{name: "Per-feed accept", feed: models.Feed{URL: "https://example.com/feed", AcceptHeader: "application/atom+xml"}, wantAccept: "application/atom+xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := rss.NewProcessor()
+
+			req, err := processor.NewFeedRequest(context.Background(), &tt.feed)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, tt.feed.URL, req.URL.String())
+			assert.Equal(t, tt.wantAccept, req.Header.Get("Accept"))
+			assert.Equal(t, "Gofeed/1.0", req.Header.Get("User-Agent"))
+			_, _, hasAuth := req.BasicAuth()
+			assert.False(t, hasAuth)
+		})
+	}
+
+	t.Run("Parser user agent and basic auth are applied", func(t *testing.T) {
+		processor := rss.NewProcessor()
+		processor.FeedParser.UserAgent = "wallabag-rss-tool/test"
+		processor.FeedParser.AuthConfig = &gofeed.Auth{Username: "reader", Password: "secret"}
+
+		req, err := processor.NewFeedRequest(context.Background(), &models.Feed{URL: "https://example.com/feed"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "wallabag-rss-tool/test", req.Header.Get("User-Agent"))
+		user, password, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "reader", user)
+		assert.Equal(t, "secret", password)
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		_, err := rss.NewProcessor().NewFeedRequest(context.Background(), &models.Feed{URL: "://bad"})
+		assert.Error(t, err)
+	})
+}
+
+func TestProcessor_FetchUsesFeedRequest(t *testing.T) {
+	var mu sync.Mutex
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		accepts = append(accepts, r.Header.Get("Accept"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>T</title>
+			<item><title>Post</title><link>https://example.com/post</link></item>
+		</channel></rss>`))
+	}))
+	defer server.Close()
+
+	processor := rss.NewProcessor()
+
+	_, err := processor.FetchFeed(context.Background(), &models.Feed{URL: server.URL, InitialSyncDone: true, AcceptHeader: "application/atom+xml"})
+	require.NoError(t, err)
+	_, err = processor.FetchFeed(context.Background(), &models.Feed{URL: server.URL, InitialSyncDone: true})
+	require.NoError(t, err)
+	_, _, err = processor.FetchRaw(context.Background(), &models.Feed{URL: server.URL, AcceptHeader: "application/feed+json"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"application/atom+xml", rss.DefaultAccept, "application/feed+json"}, accepts)
+}
+
+func TestProcessor_FetchSendsFeedCookie(t *testing.T) {
+	// The stub echoes the Cookie header it received as the item title
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := "no cookie"
+		if cookies, sent := r.Header["Cookie"]; sent {
+			title = cookies[0]
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>T</title>
+			<item><title>` + title + `</title><link>https://example.com/post</link></item>
+		</channel></rss>`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		cookie    string
+		wantTitle string
+	}{
+		{name: "Cookie configured", cookie: "session=abc123; remember=1", wantTitle: "session=abc123; remember=1"},
+		{name: "No cookie", cookie: "", wantTitle: "no cookie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rss.NewProcessor().FetchFeed(context.Background(), &models.Feed{URL: server.URL, InitialSyncDone: true, Cookie: tt.cookie})
+			require.NoError(t, err)
+
+			require.Len(t, result.Articles, 1)
+			assert.Equal(t, tt.wantTitle, result.Articles[0].Title)
+		})
+	}
+
+	t.Run("Raw fetch sends the cookie", func(t *testing.T) {
+		body, _, err := rss.NewProcessor().FetchRaw(context.Background(), &models.Feed{URL: server.URL, Cookie: "session=abc123"})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(body), "<title>session=abc123</title>")
+	})
+}
@@ -0,0 +1,97 @@
+package rss_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    uint16
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "1.2", want: tls.VersionTLS12},
+		{value: " 1.3 ", want: tls.VersionTLS13},
+		{value: "1.4", wantErr: true},
+		{value: "TLS1.2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := rss.ParseTLSVersion(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Internal</title>` +
+			`<item><title>Post</title><link>https://example.com/post</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	// The test server's self-signed certificate stands in for a private CA
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	fetch := func(options rss.TLSOptions) error {
+		config, err := rss.NewTLSConfig(options)
+		require.NoError(t, err)
+		processor := rss.NewProcessorWithTransport(rss.NewTLSTransport(nil, config))
+		_, err = processor.FetchFeed(context.Background(), &models.Feed{URL: server.URL, InitialSyncDone: true})
+
+		return err
+	}
+
+	t.Run("Custom CA file is trusted", func(t *testing.T) {
+		assert.NoError(t, fetch(rss.TLSOptions{CAFile: caFile}))
+	})
+
+	t.Run("Unknown CA is rejected without it", func(t *testing.T) {
+		assert.Error(t, fetch(rss.TLSOptions{}))
+	})
+
+	t.Run("Skipping verification accepts any certificate", func(t *testing.T) {
+		assert.NoError(t, fetch(rss.TLSOptions{InsecureSkipVerify: true}))
+	})
+
+	t.Run("Minimum version is applied", func(t *testing.T) {
+		config, err := rss.NewTLSConfig(rss.TLSOptions{MinVersion: tls.VersionTLS13})
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	})
+
+	t.Run("CA file without certificates", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+
+		_, err := rss.NewTLSConfig(rss.TLSOptions{CAFile: empty})
+		assert.ErrorContains(t, err, "no PEM certificates")
+	})
+
+	t.Run("Missing CA file", func(t *testing.T) {
+		_, err := rss.NewTLSConfig(rss.TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+		assert.ErrorContains(t, err, "failed to read CA file")
+	})
+}
@@ -0,0 +1,47 @@
+package rss
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// ttlKey is where rssTranslator keeps a feed's <ttl> in the parsed feed's Custom map, which
+// gofeed's own translator leaves out.
+const ttlKey = "ttl"
+
+// rssTranslator translates RSS feeds like gofeed's default translator, and also keeps the
+// channel's <ttl>.
+type rssTranslator struct {
+	gofeed.DefaultRSSTranslator
+}
+
+// Translate converts an *rss.Feed into a gofeed.Feed, keeping its <ttl> for ParseTTL.
+func (t *rssTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultRSSTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	if rssFeed, ok := feed.(*rss.Feed); ok && rssFeed.TTL != "" {
+		if result.Custom == nil {
+			result.Custom = make(map[string]string)
+		}
+		result.Custom[ttlKey] = rssFeed.TTL
+	}
+
+	return result, nil
+}
+
+// ParseTTL returns the minutes an RSS <ttl> value asks readers to wait between fetches, or 0
+// when it is missing or not a positive whole number.
+func ParseTTL(value string) int {
+	minutes, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || minutes < 0 {
+		return 0
+	}
+
+	return minutes
+}
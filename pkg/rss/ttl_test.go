@@ -0,0 +1,27 @@
+package rss_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "60", want: 60},
+		{value: " 15 ", want: 15},
+		{value: "", want: 0},
+		{value: "-5", want: 0},
+		{value: "an hour", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, rss.ParseTTL(tt.value))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Package sanitize strips untrusted HTML of anything that could run script, so content taken
+// from feeds and article pages is safe to forward to Wallabag.
+package sanitize
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Policy selects how much markup survives sanitization.
+type Policy string
+
+const (
+	// Strict keeps basic formatting, links, images and tables. Other elements are replaced by
+	// their content and attributes outside a small allowlist are dropped.
+	Strict Policy = "strict"
+	// Lenient keeps any markup except active content, event handlers and script URLs.
+	Lenient Policy = "lenient"
+)
+
+// removedElements are dropped together with their content under every policy.
+var removedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "form": true, "noscript": true,
+	"link": true, "meta": true, "base": true, "template": true,
+}
+
+// strictElements lists the elements Strict keeps and the attributes each may carry.
+var strictElements = map[string][]string{
+	"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+	"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil,
+	"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+	"mark": nil, "small": nil, "sub": nil, "sup": nil, "abbr": {"title"}, "cite": nil,
+	"q": {"cite"}, "blockquote": {"cite"}, "pre": nil, "code": nil, "kbd": nil, "samp": nil,
+	"time": {"datetime"}, "h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"figure": nil, "figcaption": nil, "table": nil, "caption": nil, "thead": nil, "tbody": nil,
+	"tfoot": nil, "tr": nil, "th": {"colspan", "rowspan", "scope"}, "td": {"colspan", "rowspan"},
+}
+
+// urlAttributes are checked for script and data URLs.
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true, "cite": true,
+	"background": true, "poster": true, "xlink:href": true,
+}
+
+// ParsePolicy returns the policy with the given name.
+func ParsePolicy(name string) (Policy, error) {
+	switch policy := Policy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case Strict, Lenient:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown sanitize policy %q (want %q or %q)", name, Strict, Lenient)
+	}
+}
+
+// HTML sanitizes an HTML fragment under policy. Any policy other than Lenient, including the
+// empty one, is applied as Strict.
+func HTML(content string, policy Policy) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse content: %w", err)
+	}
+
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+	clean(body, policy)
+
+	var sanitized strings.Builder
+	for node := body.FirstChild; node != nil; node = node.NextSibling {
+		if err := html.Render(&sanitized, node); err != nil {
+			return "", fmt.Errorf("failed to render content: %w", err)
+		}
+	}
+
+	return sanitized.String(), nil
+}
+
+// Nodes sanitizes the content and attributes of each node in place. The nodes themselves are
+// kept; under Strict, ones outside the allowlist lose all their attributes.
+func Nodes(nodes []*html.Node, policy Policy) {
+	for _, node := range nodes {
+		clean(node, policy)
+		if node.Type == html.ElementNode {
+			node.Attr = cleanAttributes(strings.ToLower(node.Data), node.Attr, policy)
+		}
+	}
+}
+
+// clean sanitizes the children of parent, depth first
+func clean(parent *html.Node, policy Policy) {
+	for child := parent.FirstChild; child != nil; {
+		next := child.NextSibling
+
+		switch child.Type {
+		case html.CommentNode:
+			parent.RemoveChild(child)
+		case html.ElementNode:
+			name := strings.ToLower(child.Data)
+			if removedElements[name] {
+				parent.RemoveChild(child)
+
+				break
+			}
+
+			clean(child, policy)
+			if _, allowed := strictElements[name]; policy != Lenient && !allowed {
+				unwrap(child)
+
+				break
+			}
+			child.Attr = cleanAttributes(name, child.Attr, policy)
+		}
+
+		child = next
+	}
+}
+
+// unwrap replaces node with its children
+func unwrap(node *html.Node) {
+	for node.FirstChild != nil {
+		grandchild := node.FirstChild
+		node.RemoveChild(grandchild)
+		node.Parent.InsertBefore(grandchild, node)
+	}
+	node.Parent.RemoveChild(node)
+}
+
+// cleanAttributes returns the attributes of element that policy keeps
+func cleanAttributes(element string, attrs []html.Attribute, policy Policy) []html.Attribute {
+	allowed := strictElements[element]
+
+	kept := attrs[:0]
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" {
+			key = strings.ToLower(attr.Namespace) + ":" + key
+		}
+
+		if policy != Lenient && !slices.Contains(allowed, key) {
+			continue
+		}
+		if strings.HasPrefix(key, "on") || key == "srcdoc" {
+			continue
+		}
+		if urlAttributes[key] && !safeURL(element, key, attr.Val, policy) {
+			continue
+		}
+		kept = append(kept, attr)
+	}
+
+	return kept
+}
+
+// safeURL reports whether a URL attribute value is safe to keep. Strict accepts relative,
+// http, https and mailto URLs; Lenient rejects javascript: and vbscript: URLs, and data: URLs
+// anywhere but an image source.
+func safeURL(element, key, value string, policy Policy) bool {
+	scheme := urlScheme(value)
+	if policy != Lenient {
+		return scheme == "" || scheme == "http" || scheme == "https" || scheme == "mailto"
+	}
+
+	switch scheme {
+	case "javascript", "vbscript":
+		return false
+	case "data":
+		return element == "img" && key == "src"
+	default:
+		return true
+	}
+}
+
+// urlScheme returns the lowercased scheme of a URL, ignoring the whitespace and control
+// characters browsers skip, or "" for a relative URL
+func urlScheme(value string) string {
+	compact := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	end := strings.IndexAny(compact, ":/?#")
+	if end <= 0 || compact[end] != ':' {
+		return ""
+	}
+
+	return strings.ToLower(compact[:end])
+}
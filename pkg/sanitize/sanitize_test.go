@@ -0,0 +1,107 @@
+package sanitize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+	"wallabag-rss-tool/pkg/sanitize"
+)
+
+const untrusted = `<section class="post">
+	<h2 onclick="track()">Title</h2>
+	<script>alert("xss")</script>
+	<p style="color: red">Some <strong>bold</strong> and <em>italic</em> text with <a href="https://example.com/ref" title="Ref" onmouseover="steal()">a link</a>.</p>
+	<a href=" java&#09;script:alert(1)">bad link</a>
+	<img src="/images/photo.jpg" alt="Photo" onerror="steal()">
+	<img src="data:image/png;base64,AAAA" alt="Inline">
+	<iframe src="https://evil.example"></iframe>
+	<!-- tracking comment -->
+	<ul><li>One</li><li>Two</li></ul>
+	<svg><a xlink:href="javascript:alert(2)">svg link</a></svg>
+</section>`
+
+func TestHTML(t *testing.T) {
+	for _, policy := range []sanitize.Policy{sanitize.Strict, sanitize.Lenient} {
+		t.Run(string(policy)+" removes active content", func(t *testing.T) {
+			content, err := sanitize.HTML(untrusted, policy)
+			require.NoError(t, err)
+
+			assert.NotContains(t, content, "<script")
+			assert.NotContains(t, content, "alert")
+			assert.NotContains(t, content, "<iframe")
+			assert.NotContains(t, content, "onclick")
+			assert.NotContains(t, content, "onmouseover")
+			assert.NotContains(t, content, "onerror")
+			assert.NotContains(t, strings.ToLower(content), "script:")
+			assert.NotContains(t, content, "tracking comment")
+		})
+
+		t.Run(string(policy)+" keeps basic formatting", func(t *testing.T) {
+			content, err := sanitize.HTML(untrusted, policy)
+			require.NoError(t, err)
+
+			assert.Contains(t, content, "<strong>bold</strong>")
+			assert.Contains(t, content, "<em>italic</em>")
+			assert.Contains(t, content, `<a href="https://example.com/ref" title="Ref">a link</a>`)
+			assert.Contains(t, content, `<img src="/images/photo.jpg" alt="Photo"/>`)
+			assert.Contains(t, content, "<ul><li>One</li><li>Two</li></ul>")
+			assert.Contains(t, content, "bad link")
+		})
+	}
+
+	t.Run("Strict unwraps unknown elements and drops other attributes", func(t *testing.T) {
+		content, err := sanitize.HTML(untrusted, sanitize.Strict)
+		require.NoError(t, err)
+
+		assert.NotContains(t, content, "<section")
+		assert.NotContains(t, content, "<svg")
+		assert.Contains(t, content, "svg link")
+		assert.Contains(t, content, "<p>Some")
+		assert.NotContains(t, content, "style=")
+		assert.NotContains(t, content, "data:image", "only web and mail URLs are allowed")
+	})
+
+	t.Run("Lenient keeps other markup", func(t *testing.T) {
+		content, err := sanitize.HTML(untrusted, sanitize.Lenient)
+		require.NoError(t, err)
+
+		assert.Contains(t, content, `<section class="post">`)
+		assert.Contains(t, content, `<p style="color: red">`)
+		assert.Contains(t, content, `<img src="data:image/png;base64,AAAA" alt="Inline"/>`)
+	})
+
+	t.Run("Unknown policy is applied as strict", func(t *testing.T) {
+		content, err := sanitize.HTML(`<section class="x"><p>Text</p></section>`, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "<p>Text</p>", content)
+	})
+}
+
+func TestNodes(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div class="body" onclick="x()"><p>Kept</p><script>bad()</script></div>`))
+	require.NoError(t, err)
+	div := doc.FirstChild.LastChild.FirstChild // html > body > div
+
+	sanitize.Nodes([]*html.Node{div}, sanitize.Lenient)
+
+	var rendered strings.Builder
+	require.NoError(t, html.Render(&rendered, div))
+	assert.Equal(t, `<div class="body"><p>Kept</p></div>`, rendered.String())
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := sanitize.ParsePolicy(" Lenient ")
+	require.NoError(t, err)
+	assert.Equal(t, sanitize.Lenient, policy)
+
+	policy, err = sanitize.ParsePolicy("strict")
+	require.NoError(t, err)
+	assert.Equal(t, sanitize.Strict, policy)
+
+	_, err = sanitize.ParsePolicy("none")
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/views"
+)
+
+// How many sent articles and polling cycles the home page's recent activity lists
+const (
+	recentArticlesShown = 5
+	recentSyncRunsShown = 3
+)
+
+// handleActivity renders the home page's recent activity: the articles last sent to Wallabag and
+// the last polling cycles from the audit log. The home page loads it with HTMX once shown, so
+// its queries do not hold up the page.
+func (s *Server) handleActivity(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	articles, err := s.store.GetRecentSentArticles(request.Context(), recentArticlesShown)
+	if err != nil {
+		logging.Error("Failed to get recent articles", "error", fmt.Errorf("store.GetRecentSentArticles: %w", err))
+		http.Error(writer, "Failed to get recent activity", http.StatusInternalServerError)
+
+		return
+	}
+
+	runs, _, err := s.store.GetAuditEntries(request.Context(), models.AuditFilter{Action: models.AuditSyncRun}, recentSyncRunsShown, 0)
+	if err != nil {
+		logging.Error("Failed to get recent sync runs", "error", fmt.Errorf("store.GetAuditEntries: %w", err))
+		http.Error(writer, "Failed to get recent activity", http.StatusInternalServerError)
+
+		return
+	}
+
+	data := views.ActivityData{Articles: articles, SyncRuns: runs}
+	if err := views.RecentActivity(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render recent activity", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleActivity(t *testing.T) {
+	syncRuns := models.AuditFilter{Action: models.AuditSyncRun}
+	getActivity := func(serv *Server) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serv.handleActivity(rr, httptest.NewRequest(http.MethodGet, "/activity", http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Lists recent articles and sync runs", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		sentAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+		mockStore.EXPECT().GetRecentSentArticles(gomock.Any(), recentArticlesShown).Return([]models.RecentArticle{
+			{ID: 7, FeedID: 1, Title: "Generics in Go", URL: "https://go.dev/blog/generics", FeedName: "The Go Blog", SentAt: sentAt},
+			{ID: 5, FeedID: 2, Title: "Orphaned Post", URL: "https://gone.example.com/post", SentAt: sentAt.Add(-time.Hour)},
+		}, nil)
+		mockStore.EXPECT().GetAuditEntries(gomock.Any(), syncRuns, recentSyncRunsShown, 0).Return([]models.AuditEntry{
+			{ID: 3, Action: models.AuditSyncRun, Detail: "Completed a polling cycle over 2 feeds", CreatedAt: sentAt},
+		}, 1, nil)
+
+		rr := getActivity(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		generics := strings.Index(body, "Generics in Go")
+		orphaned := strings.Index(body, "Orphaned Post")
+		require.NotEqual(t, -1, generics)
+		require.NotEqual(t, -1, orphaned)
+		assert.Less(t, generics, orphaned, "listed in the store's order")
+		assert.Contains(t, body, `href="/articles/7/open"`)
+		assert.Contains(t, body, "The Go Blog,")
+		assert.Contains(t, body, "Deleted feed,")
+		assert.Contains(t, body, "Completed a polling cycle over 2 feeds")
+		assert.NotContains(t, body, "No articles have been sent")
+	})
+
+	t.Run("Empty database", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetRecentSentArticles(gomock.Any(), recentArticlesShown).Return(nil, nil)
+		mockStore.EXPECT().GetAuditEntries(gomock.Any(), syncRuns, recentSyncRunsShown, 0).Return(nil, 0, nil)
+
+		rr := getActivity(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "No articles have been sent to Wallabag yet.")
+		assert.Contains(t, rr.Body.String(), "No polling cycles have completed yet.")
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetRecentSentArticles(gomock.Any(), recentArticlesShown).Return(nil, errors.New("database locked"))
+
+		rr := getActivity(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "database locked")
+	})
+
+	t.Run("Rejects other methods", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		rr := httptest.NewRecorder()
+		NewServer(mockStore, mockClient, w).handleActivity(rr, httptest.NewRequest(http.MethodPost, "/activity", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
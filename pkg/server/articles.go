@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+// handleArticleOpen redirects to an article's URL and counts the click, so the articles list
+// shows which articles were actually read
+func (s *Server) handleArticleOpen(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	idStr, ok := strings.CutSuffix(strings.TrimPrefix(request.URL.Path, "/articles/"), "/open")
+	if !ok {
+		http.NotFound(writer, request)
+
+		return
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(writer, "Invalid article ID", http.StatusBadRequest)
+
+		return
+	}
+
+	article, err := s.store.GetArticleByID(request.Context(), id)
+	if errors.Is(err, database.ErrArticleNotFound) {
+		http.NotFound(writer, request)
+
+		return
+	}
+	if err != nil {
+		logging.Error("Failed to get article", "error", fmt.Errorf("store.GetArticleByID: %w", err), "article_id", id)
+		http.Error(writer, "Failed to get article", http.StatusInternalServerError)
+
+		return
+	}
+
+	// Only redirect to web URLs, so a stored javascript: or data: URL cannot be opened from here
+	target, err := url.Parse(article.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		http.Error(writer, "Article URL cannot be opened", http.StatusBadRequest)
+
+		return
+	}
+
+	// A failed count must not stop the article from opening
+	if err := s.store.IncrementArticleClicks(request.Context(), id); err != nil {
+		logging.Warn("Failed to record article click", "error", fmt.Errorf("store.IncrementArticleClicks: %w", err), "article_id", id)
+	}
+
+	http.Redirect(writer, request, target.String(), http.StatusFound)
+}
+
+// handleArticlePath routes /articles/{id}/... requests: /retry to handleArticleRetry, which
+// changes state and so is CSRF protected and refused in read-only mode, and anything else to
+// handleArticleOpen
+func (s *Server) handleArticlePath(writer http.ResponseWriter, request *http.Request) {
+	if strings.HasSuffix(request.URL.Path, "/retry") {
+		s.readOnly(s.csrfProtection(s.handleArticleRetry))(writer, request)
+
+		return
+	}
+
+	s.handleArticleOpen(writer, request)
+}
+
+// handleArticleRetry sends an unsent article to Wallabag again and responds with its entry ID,
+// for the Retry button of the articles list
+func (s *Server) handleArticleRetry(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(request.URL.Path, "/articles/"), "/retry"))
+	if err != nil {
+		http.Error(writer, "Invalid article ID", http.StatusBadRequest)
+
+		return
+	}
+
+	entry, err := s.worker.RetryArticle(request.Context(), id)
+	switch {
+	case errors.Is(err, database.ErrArticleNotFound):
+		http.NotFound(writer, request)
+
+		return
+	case errors.Is(err, worker.ErrArticleNotRetryable):
+		http.Error(writer, "Article is not unsent", http.StatusConflict)
+
+		return
+	case err != nil:
+		logging.Error("Failed to retry article", "error", fmt.Errorf("worker.RetryArticle: %w", err), "article_id", id)
+		http.Error(writer, "Wallabag refused the article again", http.StatusBadGateway)
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte(strconv.Itoa(entry.ID))); err != nil {
+		logging.Error("Failed to write article retry response", "error", err)
+	}
+}
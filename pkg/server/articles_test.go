@@ -0,0 +1,138 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+func TestServer_handleArticleOpen(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Redirects and counts the click", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 5).Return(&models.Article{ID: 5, URL: "https://example.com/post"}, nil)
+		mockStore.EXPECT().IncrementArticleClicks(gomock.Any(), 5).Return(nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/articles/5/open", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticleOpen(rr, req)
+
+		assert.Equal(t, http.StatusFound, rr.Code)
+		assert.Equal(t, "https://example.com/post", rr.Header().Get("Location"))
+	})
+
+	t.Run("Refuses non-web URLs", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 6).Return(&models.Article{ID: 6, URL: "javascript:alert(1)"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/articles/6/open", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticleOpen(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get("Location"))
+	})
+
+	t.Run("Unknown article", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 7).Return(nil, database.ErrArticleNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/articles/7/open", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticleOpen(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/articles/abc/open", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticleOpen(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestServer_handleArticleRetry(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	retry := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticleRetry(rr, req)
+
+		return rr
+	}
+
+	t.Run("Responds with the new entry", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 3).Return(&models.Article{ID: 3, FeedID: 1, URL: "https://example.com/refused"}, nil)
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 1).Return(&models.Feed{ID: 1}, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/refused", nil).Return(&wallabag.Entry{ID: 77}, nil)
+		mockStore.EXPECT().SetArticleWallabagEntry(gomock.Any(), 3, 77).Return(nil)
+
+		rr := retry(http.MethodPost, "/articles/3/retry")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "77", rr.Body.String())
+	})
+
+	t.Run("Wallabag refuses again", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 3).Return(&models.Article{ID: 3, FeedID: 1, URL: "https://example.com/refused"}, nil)
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 1).Return(&models.Feed{ID: 1}, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/refused", nil).Return(nil, errors.New("status 500"))
+
+		rr := retry(http.MethodPost, "/articles/3/retry")
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+	})
+
+	t.Run("Article already sent", func(t *testing.T) {
+		entryID := 5
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 4).Return(&models.Article{ID: 4, WallabagEntryID: &entryID}, nil)
+
+		rr := retry(http.MethodPost, "/articles/4/retry")
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Article not found", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 9).Return(nil, database.ErrArticleNotFound)
+
+		rr := retry(http.MethodPost, "/articles/9/retry")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		rr := retry(http.MethodPost, "/articles/abc/retry")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := retry(http.MethodGet, "/articles/3/retry")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+
+	t.Run("Requires a CSRF token through the router", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/articles/3/retry", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticlePath(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
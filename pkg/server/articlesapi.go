@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// Page sizes of /api/v1/articles when limit is left out, and the most one request may ask for
+const (
+	defaultArticlesAPILimit = 50
+	maxArticlesAPILimit     = 500
+)
+
+// articlesAPIResponse is the JSON body of /api/v1/articles: one page of article history and
+// the number of articles matching the filter across all pages.
+type articlesAPIResponse struct {
+	Articles []articleResponse `json:"articles"`
+	Total    int               `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// articleResponse is one recorded article in the articles API
+type articleResponse struct {
+	PublishedAt     *time.Time `json:"published_at"`
+	WallabagEntryID *int       `json:"wallabag_entry_id"` // null unless the article reached Wallabag
+	CreatedAt       time.Time  `json:"created_at"`
+	Title           string     `json:"title"`
+	URL             string     `json:"url"`
+	FeedName        string     `json:"feed_name"` // "" once the feed has been deleted
+	ID              int        `json:"id"`
+	FeedID          int        `json:"feed_id"`
+	Filtered        bool       `json:"filtered"`
+	MarkedProcessed bool       `json:"marked_processed"`
+}
+
+// handleAPIArticles returns the processing history as JSON for external tools, filtered by the
+// feed_id and since query parameters and paged by limit and offset.
+func (s *Server) handleAPIArticles(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	filter, limit, offset, err := parseArticlesAPIQuery(request)
+	if err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	articles, total, err := s.store.GetArticleHistory(request.Context(), filter, limit, offset)
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.GetArticleHistory: %w", err), "Failed to get article history")
+
+		return
+	}
+
+	response := articlesAPIResponse{
+		Articles: make([]articleResponse, 0, len(articles)),
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	for i := range articles {
+		article := &articles[i]
+		response.Articles = append(response.Articles, articleResponse{
+			ID:              article.ID,
+			FeedID:          article.FeedID,
+			FeedName:        article.FeedName,
+			Title:           article.Title,
+			URL:             article.URL,
+			WallabagEntryID: article.WallabagEntryID,
+			PublishedAt:     article.PublishedAt,
+			CreatedAt:       article.CreatedAt,
+			Filtered:        article.Filtered,
+			MarkedProcessed: article.MarkedProcessed,
+		})
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logging.Error("Failed to write articles response", "error", err)
+	}
+}
+
+// parseArticlesAPIQuery reads the feed_id, since, limit and offset query parameters. since is
+// an RFC 3339 time or a YYYY-MM-DD date.
+func parseArticlesAPIQuery(request *http.Request) (models.ArticleFilter, int, int, error) {
+	var filter models.ArticleFilter
+	query := request.URL.Query()
+
+	if feed := query.Get("feed_id"); feed != "" {
+		feedID, err := strconv.Atoi(feed)
+		if err != nil || feedID < 1 {
+			return filter, 0, 0, fmt.Errorf("feed_id must be a positive feed ID")
+		}
+		filter.FeedID = feedID
+	}
+
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, since)
+		}
+		if err != nil {
+			return filter, 0, 0, fmt.Errorf("since must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+		filter.Since = &t
+	}
+
+	limit := defaultArticlesAPILimit
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxArticlesAPILimit {
+			return filter, 0, 0, fmt.Errorf("limit must be between 1 and %d", maxArticlesAPILimit)
+		}
+		limit = n
+	}
+
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return filter, 0, 0, fmt.Errorf("offset must not be negative")
+		}
+		offset = n
+	}
+
+	return filter, limit, offset, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleAPIArticles(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAPIArticles(rr, req)
+
+		return rr
+	}
+
+	t.Run("Returns a stable JSON shape", func(t *testing.T) {
+		created := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+		published := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+		entryID := 42
+		articles := []models.HistoryArticle{
+			{FeedName: "Blog", Article: models.Article{ID: 7, FeedID: 3, Title: "Post", URL: "https://blog.example.com/post", WallabagEntryID: &entryID, PublishedAt: &published, CreatedAt: created, Clicks: 2}},
+			{Article: models.Article{ID: 6, FeedID: 9, Title: "Skipped", URL: "https://gone.example.com/skipped", Filtered: true, CreatedAt: created}},
+		}
+		mockStore.EXPECT().GetArticleHistory(gomock.Any(), models.ArticleFilter{}, defaultArticlesAPILimit, 0).Return(articles, 12, nil)
+
+		rr := get("/api/v1/articles")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"articles": [
+				{"id": 7, "feed_id": 3, "feed_name": "Blog", "title": "Post", "url": "https://blog.example.com/post",
+				 "wallabag_entry_id": 42, "published_at": "2024-03-01T08:30:00Z", "created_at": "2024-03-02T10:00:00Z",
+				 "filtered": false, "marked_processed": false},
+				{"id": 6, "feed_id": 9, "feed_name": "", "title": "Skipped", "url": "https://gone.example.com/skipped",
+				 "wallabag_entry_id": null, "published_at": null, "created_at": "2024-03-02T10:00:00Z",
+				 "filtered": true, "marked_processed": false}
+			],
+			"total": 12, "limit": 50, "offset": 0
+		}`, rr.Body.String())
+	})
+
+	t.Run("No articles is an empty list", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleHistory(gomock.Any(), models.ArticleFilter{}, defaultArticlesAPILimit, 0).Return(nil, 0, nil)
+
+		rr := get("/api/v1/articles")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"articles": [], "total": 0, "limit": 50, "offset": 0}`, rr.Body.String())
+	})
+
+	t.Run("Filter and paging parameters reach the store", func(t *testing.T) {
+		sinceDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		sinceTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		tests := []struct {
+			name   string
+			query  string
+			filter models.ArticleFilter
+			limit  int
+			offset int
+		}{
+			{name: "Feed", query: "?feed_id=3", filter: models.ArticleFilter{FeedID: 3}, limit: defaultArticlesAPILimit},
+			{name: "Since date", query: "?since=2024-03-01", filter: models.ArticleFilter{Since: &sinceDate}, limit: defaultArticlesAPILimit},
+			{name: "Since time", query: "?since=2024-03-01T12:00:00Z", filter: models.ArticleFilter{Since: &sinceTime}, limit: defaultArticlesAPILimit},
+			{name: "Limit and offset", query: "?feed_id=2&limit=10&offset=20", filter: models.ArticleFilter{FeedID: 2}, limit: 10, offset: 20},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockStore.EXPECT().GetArticleHistory(gomock.Any(), tt.filter, tt.limit, tt.offset).Return(nil, 0, nil)
+
+				rr := get("/api/v1/articles" + tt.query)
+
+				assert.Equal(t, http.StatusOK, rr.Code)
+			})
+		}
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"?feed_id=abc", "?feed_id=0", "?since=yesterday", "?limit=0", "?limit=501", "?offset=-1"} {
+			t.Run(query, func(t *testing.T) {
+				rr := get("/api/v1/articles" + query)
+
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Equal(t, errorCodeInvalidRequest, decodeJSONError(t, rr).Code)
+			})
+		}
+	})
+
+	t.Run("Store failure", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, errors.New("database is locked"))
+
+		rr := get("/api/v1/articles")
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, errorCodeInternal, decodeJSONError(t, rr).Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/articles", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAPIArticles(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, errorCodeMethodNotAllowed, decodeJSONError(t, rr).Code)
+	})
+}
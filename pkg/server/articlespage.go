@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/views"
+)
+
+// articlesPageData loads the articles page, running its store queries at once, or at most
+// Config.PageQueries at a time. Only the articles are essential: their error is returned. The
+// feeds, for names and icons, and the unsent count only decorate the page, so their errors are
+// logged together and the page is shown without them.
+func (s *Server) articlesPageData(ctx context.Context, unsentOnly bool) (views.ArticlesData, error) {
+	data := views.ArticlesData{
+		PageData:    s.pageData("Processed Articles"),
+		UnsentOnly:  unsentOnly,
+		WallabagURL: s.config.WallabagURL,
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	if s.config.PageQueries > 0 {
+		group.SetLimit(s.config.PageQueries)
+	}
+
+	var mu sync.Mutex
+	var optionalErrs []error
+	optional := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		optionalErrs = append(optionalErrs, err)
+	}
+
+	group.Go(func() error {
+		var err error
+		if unsentOnly {
+			data.Articles, err = s.store.GetUnsentArticles(groupCtx)
+			if err != nil {
+				return fmt.Errorf("store.GetUnsentArticles: %w", err)
+			}
+		} else {
+			data.Articles, err = s.store.GetArticles(groupCtx)
+			if err != nil {
+				return fmt.Errorf("store.GetArticles: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	group.Go(func() error {
+		feeds, err := s.store.GetFeeds(groupCtx)
+		if err != nil {
+			optional(fmt.Errorf("store.GetFeeds: %w", err))
+
+			return nil
+		}
+
+		data.Feeds = make(map[int]models.Feed, len(feeds))
+		for _, feed := range feeds {
+			data.Feeds[feed.ID] = feed
+		}
+
+		return nil
+	})
+
+	// The unsent list counts itself
+	if !unsentOnly {
+		group.Go(func() error {
+			unsent, err := s.store.GetUnsentArticles(groupCtx)
+			if err != nil {
+				optional(fmt.Errorf("store.GetUnsentArticles: %w", err))
+
+				return nil
+			}
+
+			count := len(unsent)
+			data.UnsentCount = &count
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return data, err
+	}
+
+	if unsentOnly {
+		count := len(data.Articles)
+		data.UnsentCount = &count
+	}
+	if len(optionalErrs) > 0 {
+		logging.Warn("Showing articles page without some details", "error", errors.Join(optionalErrs...))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleArticles_PageData(t *testing.T) {
+	articles := []models.Article{
+		{ID: 1, FeedID: 10, URL: "https://example.com/one", Title: "Article One", FeedURL: "https://example.com/feed.xml", CreatedAt: time.Now()},
+		{ID: 2, FeedID: 20, URL: "https://example.com/two", Title: "Article Two", CreatedAt: time.Now()},
+	}
+	getArticles := func(serv *Server) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serv.handleArticles(rr, httptest.NewRequest(http.MethodGet, "/articles", http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Shows feed names, icons and the unsent count", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(articles, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{
+			{ID: 10, Name: "Example Blog", FaviconURL: "https://example.com/favicon.ico"},
+		}, nil)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(articles[1:], nil)
+
+		rr := getArticles(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "Example Blog")
+		assert.Contains(t, body, `src="https://example.com/favicon.ico"`)
+		assert.Contains(t, body, "https://example.com/feed.xml")
+		assert.Contains(t, body, `<span class="badge text-bg-secondary ms-1">1</span>`)
+	})
+
+	t.Run("Failing optional queries still show the articles", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(articles, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, assert.AnError)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, assert.AnError)
+
+		rr := getArticles(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "Article One")
+		assert.Contains(t, body, "Article Two")
+		assert.Contains(t, body, "https://example.com/feed.xml", "the article's own feed URL stands in for the name")
+		assert.NotContains(t, body, "badge")
+	})
+
+	t.Run("Failing articles query is an error", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(nil, assert.AnError)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).MaxTimes(1)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, nil).MaxTimes(1)
+
+		rr := getArticles(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to get articles")
+	})
+
+	t.Run("Queries run at most PageQueries at once", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		var running, most atomic.Int32
+		track := func() {
+			now := running.Add(1)
+			defer running.Add(-1)
+			if now > most.Load() {
+				most.Store(now)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		mockStore.EXPECT().GetArticles(gomock.Any()).DoAndReturn(func(any) ([]models.Article, error) {
+			track()
+
+			return articles, nil
+		})
+		mockStore.EXPECT().GetFeeds(gomock.Any()).DoAndReturn(func(any) ([]models.Feed, error) {
+			track()
+
+			return nil, nil
+		})
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).DoAndReturn(func(any) ([]models.Article, error) {
+			track()
+
+			return nil, nil
+		})
+
+		rr := getArticles(NewServerWithConfig(mockStore, mockClient, w, Config{PageQueries: 1}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int32(1), most.Load())
+	})
+}
+
+func TestServer_handleArticles_UnsentCount(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	unsent := []models.Article{
+		{ID: 3, FeedID: 10, URL: "https://example.com/a", Title: "A", CreatedAt: time.Now()},
+		{ID: 4, FeedID: 10, URL: "https://example.com/b", Title: "B", CreatedAt: time.Now()},
+	}
+	mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(unsent, nil).Times(1)
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+
+	rr := httptest.NewRecorder()
+	serv.handleArticles(rr, httptest.NewRequest(http.MethodGet, "/articles?filter=unsent", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `<span class="badge text-bg-secondary ms-1">2</span>`, "the unsent list is counted without a second query")
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/views"
+)
+
+// auditPageSize is how many entries a page of the audit log shows.
+const auditPageSize = 50
+
+// handleAdminAudit lists the audit log a page at a time, newest first, optionally filtered by
+// the action and feed query parameters.
+func (s *Server) handleAdminAudit(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	filter, err := parseAuditFilter(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	page, err := strconv.Atoi(request.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	entries, total, err := s.store.GetAuditEntries(request.Context(), filter, auditPageSize, (page-1)*auditPageSize)
+	if err != nil {
+		logging.Error("Failed to get audit entries", "error", fmt.Errorf("store.GetAuditEntries: %w", err))
+		http.Error(writer, "Failed to get audit log", http.StatusInternalServerError)
+
+		return
+	}
+
+	data := views.AuditData{
+		PageData: s.pageData("Audit Log"),
+		Entries:  entries,
+		Filter:   filter,
+		Page:     page,
+		NextPage: nextFeedPage(page, auditPageSize, total),
+		Total:    total,
+	}
+	if err := views.Audit(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render audit log", http.StatusInternalServerError)
+	}
+}
+
+// parseAuditFilter reads the action and feed query parameters. Empty values match everything.
+func parseAuditFilter(request *http.Request) (models.AuditFilter, error) {
+	var filter models.AuditFilter
+
+	if action := models.AuditAction(request.URL.Query().Get("action")); action != "" {
+		if !slices.Contains(models.AuditActions, action) {
+			return filter, fmt.Errorf("unknown audit action %q", action)
+		}
+		filter.Action = action
+	}
+
+	if feed := request.URL.Query().Get("feed"); feed != "" {
+		feedID, err := strconv.Atoi(feed)
+		if err != nil || feedID < 1 {
+			return filter, fmt.Errorf("feed must be a positive feed ID")
+		}
+		filter.FeedID = feedID
+	}
+
+	return filter, nil
+}
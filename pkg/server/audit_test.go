@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/audit"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// auditRecorder collects the entries an audit.Log writes
+type auditRecorder struct {
+	mu      sync.Mutex
+	entries []models.AuditEntry
+}
+
+func (r *auditRecorder) AppendAuditEntry(_ context.Context, entry *models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, *entry)
+
+	return nil
+}
+
+func TestServer_handleAdminAudit(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Lists a filtered page", func(t *testing.T) {
+		entries := []models.AuditEntry{
+			{ID: 60, CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), Action: models.AuditArticleFailed, FeedID: 4, Detail: "https://example.com/a: status 500"},
+		}
+		mockStore.EXPECT().GetAuditEntries(gomock.Any(), models.AuditFilter{Action: models.AuditArticleFailed, FeedID: 4}, auditPageSize, auditPageSize).
+			Return(entries, auditPageSize+1, nil)
+
+		rr := httptest.NewRecorder()
+		serv.handleAdminAudit(rr, httptest.NewRequest(http.MethodGet, "/admin/audit?action=article_failed&feed=4&page=2", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "https://example.com/a: status 500")
+		assert.Contains(t, body, `href="/admin/audit?action=article_failed&amp;feed=4"`, "links back to the first page keeping the filter")
+		assert.NotContains(t, body, "page=3", "the last page has no older link")
+	})
+
+	t.Run("Defaults to the first unfiltered page", func(t *testing.T) {
+		mockStore.EXPECT().GetAuditEntries(gomock.Any(), models.AuditFilter{}, auditPageSize, 0).Return(nil, 0, nil)
+
+		rr := httptest.NewRecorder()
+		serv.handleAdminAudit(rr, httptest.NewRequest(http.MethodGet, "/admin/audit?page=x", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "No audit entries found.")
+	})
+
+	for _, query := range []string{"action=unknown", "feed=abc", "feed=0"} {
+		t.Run("Rejects "+query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serv.handleAdminAudit(rr, httptest.NewRequest(http.MethodGet, "/admin/audit?"+query, http.NoBody))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestServer_AuditFeedChanges(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	recorder := &auditRecorder{}
+	log := audit.NewLog(recorder)
+	serv := NewServerWithConfig(mockStore, mockClient, w, Config{Audit: log})
+
+	mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).Return(int64(7), nil)
+	mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+	mockStore.EXPECT().DeleteFeed(gomock.Any(), 7).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/feeds", http.NoBody)
+	req.Form = map[string][]string{"name": {"Blog"}, "url": {"https://example.com/feed.xml"}}
+	serv.handleFeedsPost(httptest.NewRecorder(), req)
+	serv.handleFeedsDelete(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/feeds/7", http.NoBody))
+	log.Close()
+
+	assert.Len(t, recorder.entries, 2)
+	assert.Equal(t, models.AuditEntry{CreatedAt: recorder.entries[0].CreatedAt, Action: models.AuditFeedAdded, FeedID: 7,
+		Detail: "Blog (https://example.com/feed.xml)"}, recorder.entries[0])
+	assert.Equal(t, models.AuditFeedDeleted, recorder.entries[1].Action)
+	assert.Equal(t, 7, recorder.entries[1].FeedID)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// DefaultMaxBodyBytes caps request bodies on write routes when Config.MaxBodyBytes is unset.
+const DefaultMaxBodyBytes = 1 << 20 // 1 MB
+
+// bodyLimit returns the effective request body limit.
+func (c Config) bodyLimit() int64 {
+	if c.MaxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+
+	return c.MaxBodyBytes
+}
+
+// maxBodyBytes rejects requests whose body exceeds the configured limit with 413. The body is
+// read up front so the limit applies before CSRF checks or form parsing touch it.
+func (s *Server) maxBodyBytes(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		limit := s.config.bodyLimit()
+		if request.ContentLength > limit {
+			http.Error(writer, "Request body too large", http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, limit))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(writer, "Request body too large", http.StatusRequestEntityTooLarge)
+
+				return
+			}
+			logging.Warn("Failed to read request body", "path", request.URL.Path, "error", err)
+			http.Error(writer, "Failed to read request body", http.StatusBadRequest)
+
+			return
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+
+		next(writer, request)
+	}
+}
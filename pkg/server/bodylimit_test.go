@@ -0,0 +1,61 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_maxBodyBytes(t *testing.T) {
+	serv := &Server{config: Config{MaxBodyBytes: 16}}
+
+	var received string
+	handler := serv.maxBodyBytes(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		received = string(body)
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("Under-limit body is processed", func(t *testing.T) {
+		received = ""
+		req := httptest.NewRequest("POST", "/feeds", strings.NewReader("name=small"))
+		rr := httptest.NewRecorder()
+
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "name=small", received)
+	})
+
+	t.Run("Over-limit body is rejected", func(t *testing.T) {
+		received = ""
+		req := httptest.NewRequest("POST", "/feeds", strings.NewReader(strings.Repeat("x", 17)))
+		rr := httptest.NewRecorder()
+
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Empty(t, received)
+	})
+
+	t.Run("Over-limit body without content length is rejected", func(t *testing.T) {
+		received = ""
+		req := httptest.NewRequest("POST", "/feeds", io.NopCloser(strings.NewReader(strings.Repeat("x", 17))))
+		req.ContentLength = -1
+		rr := httptest.NewRecorder()
+
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Empty(t, received)
+	})
+
+	t.Run("Default limit applies when unset", func(t *testing.T) {
+		assert.Equal(t, int64(DefaultMaxBodyBytes), Config{}.bodyLimit())
+	})
+}
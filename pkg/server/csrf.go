@@ -2,10 +2,14 @@
 package server
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"net/http"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,95 +19,83 @@ const (
 	httpMethodDELETE = "DELETE"
 )
 
-type CSRFToken struct {
-	ExpiresAt time.Time
-	Value     string
-}
+// csrfTokenTTL is how long a generated token stays valid.
+const csrfTokenTTL = 24 * time.Hour
 
+// CSRFManager issues and checks CSRF tokens. Tokens are signed with the manager's secret and
+// carry their own expiry, so managers sharing a secret accept each other's tokens, including
+// after a restart or on another replica.
 type CSRFManager struct {
-	tokens map[string]CSRFToken
-	stop   chan struct{}
-	mutex  sync.RWMutex
+	secret []byte
 }
 
+// NewCSRFManager creates a manager with a random secret; its tokens do not survive a restart.
 func NewCSRFManager() *CSRFManager {
-	manager := &CSRFManager{
-		tokens: make(map[string]CSRFToken),
-		stop:   make(chan struct{}),
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("failed to generate CSRF secret: %v", err))
 	}
-	// Clean up expired tokens every hour
-	go manager.cleanupExpiredTokens()
 
-	return manager
+	return NewCSRFManagerWithSecret(secret)
+}
+
+// NewCSRFManagerWithSecret creates a manager that signs tokens with secret.
+func NewCSRFManagerWithSecret(secret []byte) *CSRFManager {
+	return &CSRFManager{secret: secret}
 }
 
+// GenerateToken returns a new token of the form nonce.expiry.signature.
 func (c *CSRFManager) GenerateToken() (string, error) {
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
+	return c.generateTokenExpiringAt(time.Now().Add(csrfTokenTTL))
+}
+
+func (c *CSRFManager) generateTokenExpiringAt(expiresAt time.Time) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
 		return "", err
 	}
 
-	token := hex.EncodeToString(bytes)
+	payload := hex.EncodeToString(nonce) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
 
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	c.tokens[token] = CSRFToken{
-		Value:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // 24 hour expiry
-	}
-
-	return token, nil
+	return payload + "." + c.sign(payload), nil
 }
 
+// ValidateToken reports whether token was signed with this manager's secret and has not expired.
 func (c *CSRFManager) ValidateToken(token string) bool {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-
-	storedToken, exists := c.tokens[token]
-	if !exists {
+	lastDot := strings.LastIndex(token, ".")
+	if lastDot < 0 {
 		return false
 	}
+	payload, signature := token[:lastDot], token[lastDot+1:]
 
-	if time.Now().After(storedToken.ExpiresAt) {
-		// Token expired
-		go func() {
-			c.mutex.Lock()
-			delete(c.tokens, token)
-			c.mutex.Unlock()
-		}()
+	if !hmac.Equal([]byte(signature), []byte(c.sign(payload))) {
+		return false
+	}
 
+	_, expiryStr, found := strings.Cut(payload, ".")
+	if !found {
+		return false
+	}
+	expiry, err := strconv.ParseInt(expiryStr, 10, 64)
+	if err != nil {
 		return false
 	}
 
-	return true
+	return time.Now().Before(time.Unix(expiry, 0))
 }
 
-func (c *CSRFManager) cleanupExpiredTokens() {
-	ticker := time.NewTicker(1 * time.Hour)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-c.stop:
-			return
-		case <-ticker.C:
-			c.mutex.Lock()
-			now := time.Now()
-			for token, csrfToken := range c.tokens {
-				if now.After(csrfToken.ExpiresAt) {
-					delete(c.tokens, token)
-				}
-			}
-			c.mutex.Unlock()
-		}
-	}
-}
+// sign returns the hex HMAC-SHA256 of payload under the manager's secret
+func (c *CSRFManager) sign(payload string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(payload))
 
-func (c *CSRFManager) Stop() {
-	close(c.stop)
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// Stop is kept for callers written when the manager ran a token cleanup goroutine. Signed
+// tokens need no cleanup, so it does nothing.
+func (c *CSRFManager) Stop() {}
+
 // CSRF middleware for protecting state-changing operations
 func (s *Server) csrfProtection(next http.HandlerFunc) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
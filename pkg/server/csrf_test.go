@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -82,6 +83,46 @@ func TestCSRFManager_TokenValidation(t *testing.T) {
 	})
 }
 
+func TestCSRFManager_SharedSecret(t *testing.T) {
+	secret := []byte("shared-test-secret")
+
+	t.Run("Managers with the same secret accept each other's tokens", func(t *testing.T) {
+		first := NewCSRFManagerWithSecret(secret)
+		second := NewCSRFManagerWithSecret(secret)
+
+		token, err := first.GenerateToken()
+		assert.NoError(t, err)
+		assert.True(t, second.ValidateToken(token))
+
+		token, err = second.GenerateToken()
+		assert.NoError(t, err)
+		assert.True(t, first.ValidateToken(token))
+	})
+
+	t.Run("Different secret rejects token", func(t *testing.T) {
+		token, err := NewCSRFManagerWithSecret(secret).GenerateToken()
+		assert.NoError(t, err)
+		assert.False(t, NewCSRFManagerWithSecret([]byte("other-secret")).ValidateToken(token))
+	})
+
+	t.Run("Tampered expiry is rejected", func(t *testing.T) {
+		manager := NewCSRFManagerWithSecret(secret)
+		token := CreateExpiredToken(manager)
+		assert.False(t, manager.ValidateToken(token))
+
+		parts := strings.Split(token, ".")
+		parts[1] = strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+		assert.False(t, manager.ValidateToken(strings.Join(parts, ".")))
+	})
+
+	t.Run("Malformed tokens are rejected", func(t *testing.T) {
+		manager := NewCSRFManagerWithSecret(secret)
+		for _, token := range []string{"", "no-dots", "a.b", "a.b.c"} {
+			assert.False(t, manager.ValidateToken(token), token)
+		}
+	})
+}
+
 func TestCSRFManager_TokenCleanup(t *testing.T) {
 	t.Run("cleanup mechanism exists", func(t *testing.T) {
 		manager := NewCSRFManager()
@@ -16,26 +16,14 @@ func CreateCSRFProtectedHandler(manager *CSRFManager, handler http.HandlerFunc)
 	return s.csrfProtection(handler)
 }
 
-// CreateExpiredToken creates an expired token for testing
+// CreateExpiredToken creates a correctly signed token that expired an hour ago, for testing
 func CreateExpiredToken(manager *CSRFManager) string {
-	manager.mutex.Lock()
-	defer manager.mutex.Unlock()
-	
-	token := "expired-test-token"
-	manager.tokens[token] = CSRFToken{
-		Value:     token,
-		ExpiresAt: time.Now().Add(-1 * time.Hour), // Expired 1 hour ago
+	token, err := manager.generateTokenExpiringAt(time.Now().Add(-1 * time.Hour))
+	if err != nil {
+		panic(err)
 	}
-	
-	return token
-}
 
-// GetTokenCount returns the number of tokens stored (for testing)
-func (c *CSRFManager) GetTokenCount() int {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-
-	return len(c.tokens)
+	return token
 }
 
 // TestGetCSRFTokenHelper tests the getCSRFToken helper
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/views"
+)
+
+// Two feeds are listed as likely duplicates for their articles when they share at least
+// duplicateMinShared normalized article URLs, making up duplicateMinPercent of the smaller feed's.
+const (
+	duplicateMinShared  = 3
+	duplicateMinPercent = 50
+)
+
+// handleAdminDuplicates lists pairs of feeds that are likely the same feed, for merging: feeds
+// whose URLs normalize to the same address, and feeds that recorded many of the same articles.
+func (s *Server) handleAdminDuplicates(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	feeds, err := s.store.GetFeeds(request.Context())
+	if err != nil {
+		logging.Error("Failed to get feeds for duplicate report", "error", fmt.Errorf("store.GetFeeds: %w", err))
+		http.Error(writer, "Failed to get feeds", http.StatusInternalServerError)
+
+		return
+	}
+
+	overlaps, err := s.store.GetFeedOverlaps(request.Context(), duplicateMinShared)
+	if err != nil {
+		logging.Error("Failed to get feed overlaps", "error", fmt.Errorf("store.GetFeedOverlaps: %w", err))
+		http.Error(writer, "Failed to find duplicate feeds", http.StatusInternalServerError)
+
+		return
+	}
+
+	data := views.DuplicatesData{
+		PageData:   s.pageData("Likely Duplicate Feeds"),
+		Candidates: duplicateCandidates(feeds, overlaps),
+		MinShared:  duplicateMinShared,
+		MinPercent: duplicateMinPercent,
+	}
+	if err := views.Duplicates(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render duplicate report", http.StatusInternalServerError)
+	}
+}
+
+// duplicateCandidates pairs feeds whose URLs normalize to the same address with feeds whose
+// overlap reaches duplicateMinPercent, same URLs first and then by articles shared. Overlaps
+// naming a feed not in feeds are skipped.
+func duplicateCandidates(feeds []models.Feed, overlaps []models.FeedOverlap) []views.DuplicateCandidate {
+	byID := make(map[int]models.Feed, len(feeds))
+	for _, feed := range feeds {
+		byID[feed.ID] = feed
+	}
+
+	type pair struct{ feedID, otherID int }
+	candidates := make(map[pair]*views.DuplicateCandidate)
+	candidate := func(a, b models.Feed) *views.DuplicateCandidate {
+		if a.ID > b.ID {
+			a, b = b, a
+		}
+		key := pair{a.ID, b.ID}
+		if candidates[key] == nil {
+			candidates[key] = &views.DuplicateCandidate{Feed: a, Other: b}
+		}
+
+		return candidates[key]
+	}
+
+	byURL := make(map[string][]models.Feed)
+	for _, feed := range feeds {
+		normalized := models.NormalizeArticleURL(feed.URL)
+		for _, same := range byURL[normalized] {
+			candidate(same, feed).SameURL = true
+		}
+		byURL[normalized] = append(byURL[normalized], feed)
+	}
+
+	for _, overlap := range overlaps {
+		feed, ok := byID[overlap.FeedID]
+		other, otherOK := byID[overlap.OtherFeedID]
+		if !ok || !otherOK || overlap.Percent() < duplicateMinPercent {
+			continue
+		}
+		candidate(feed, other).Overlap = &overlap
+	}
+
+	list := make([]views.DuplicateCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		list = append(list, *c)
+	}
+	slices.SortFunc(list, func(a, b views.DuplicateCandidate) int {
+		if a.SameURL != b.SameURL {
+			if a.SameURL {
+				return -1
+			}
+
+			return 1
+		}
+
+		return cmp.Or(
+			cmp.Compare(sharedArticles(b), sharedArticles(a)),
+			cmp.Compare(a.Feed.ID, b.Feed.ID),
+			cmp.Compare(a.Other.ID, b.Other.ID),
+		)
+	})
+
+	return list
+}
+
+// sharedArticles returns how many articles a candidate pair shares, 0 when too few to flag
+func sharedArticles(candidate views.DuplicateCandidate) int {
+	if candidate.Overlap == nil {
+		return 0
+	}
+
+	return candidate.Overlap.Shared
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleAdminDuplicates(t *testing.T) {
+	feeds := []models.Feed{
+		{ID: 1, Name: "Blog", URL: "https://blog.example.com/feed"},
+		{ID: 2, Name: "Blog via proxy", URL: "https://proxy.example.net/blog"},
+		{ID: 3, Name: "News", URL: "https://news.example.org/rss"},
+		{ID: 4, Name: "Blog (http)", URL: "http://www.blog.example.com/feed/"},
+	}
+	getReport := func(serv *Server) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serv.handleAdminDuplicates(rr, httptest.NewRequest(http.MethodGet, "/admin/duplicates", http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Flags same URLs and large overlaps", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockStore.EXPECT().GetFeedOverlaps(gomock.Any(), duplicateMinShared).Return([]models.FeedOverlap{
+			{FeedID: 1, OtherFeedID: 2, Shared: 18, Articles: 20, OtherArticles: 25},
+			{FeedID: 2, OtherFeedID: 3, Shared: 3, Articles: 25, OtherArticles: 40},
+			{FeedID: 3, OtherFeedID: 99, Shared: 10, Articles: 40, OtherArticles: 10},
+		}, nil)
+
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Equal(t, 2, strings.Count(body, `hx-post="/feeds/merge"`), "unrelated and deleted feeds are not listed")
+		sameURL := strings.Index(body, "Same feed URL")
+		overlap := strings.Index(body, "18 shared articles, 90% of the smaller feed's")
+		require.NotEqual(t, -1, sameURL)
+		require.NotEqual(t, -1, overlap)
+		assert.Less(t, sameURL, overlap, "same URLs are listed first")
+		assert.Contains(t, body, `<input type="hidden" name="source_id" value="4">`)
+		assert.Contains(t, body, `<input type="hidden" name="target_id" value="1">`)
+		assert.Contains(t, body, `hx-confirm="Merge Blog via proxy into Blog? Blog via proxy will be deleted."`)
+		assert.NotContains(t, body, "News</td>")
+	})
+
+	t.Run("Read-only mode hides the merge buttons", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockStore.EXPECT().GetFeedOverlaps(gomock.Any(), duplicateMinShared).Return(nil, nil)
+
+		rr := getReport(NewServerWithConfig(mockStore, mockClient, w, Config{ReadOnly: true}))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Same feed URL")
+		assert.NotContains(t, rr.Body.String(), "/feeds/merge")
+	})
+
+	t.Run("No duplicates", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds[:3], nil)
+		mockStore.EXPECT().GetFeedOverlaps(gomock.Any(), duplicateMinShared).Return(nil, nil)
+
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "No likely duplicates found.")
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockStore.EXPECT().GetFeedOverlaps(gomock.Any(), duplicateMinShared).Return(nil, assert.AnError)
+
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		rr := httptest.NewRecorder()
+		NewServer(mockStore, mockClient, w).handleAdminDuplicates(rr, httptest.NewRequest(http.MethodPost, "/admin/duplicates", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// eventsHeartbeat is how often /events sends a comment line so proxies keep the idle
+// connection open and disconnected clients are noticed.
+var eventsHeartbeat = 30 * time.Second
+
+// handleEvents streams worker events to the client as Server-Sent Events until the client
+// disconnects or the server shuts down. Each event's name is its type and its data is JSON.
+func (s *Server) handleEvents(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+	if s.worker == nil {
+		http.Error(writer, "Live events are unavailable", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	controller := http.NewResponseController(writer)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		logging.Debug("Could not clear write deadline for event stream", "error", err)
+	}
+
+	eventStream, unsubscribe := s.worker.Events().Subscribe()
+	defer unsubscribe()
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		logging.Error("Event stream does not support flushing", "error", err)
+
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-request.Context().Done():
+			return
+		case <-s.stopStreams:
+			return
+		case event, ok := <-eventStream:
+			if !ok {
+				return
+			}
+			err = writeEvent(writer, event.Type, event.Data)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(writer, ": ping\n\n")
+		}
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			logging.Debug("Event stream client gone", "error", err)
+
+			return
+		}
+	}
+}
+
+// writeEvent writes one SSE event with data encoded as JSON.
+func writeEvent(writer http.ResponseWriter, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	if _, err := fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", eventType, payload); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
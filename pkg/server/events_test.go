@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/events"
+)
+
+func TestServer_handleEvents(t *testing.T) {
+	openStream := func(t *testing.T, serv *Server) (*bufio.Reader, context.CancelFunc) {
+		t.Helper()
+		ts := httptest.NewServer(http.HandlerFunc(serv.handleEvents))
+		t.Cleanup(ts.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		return bufio.NewReader(resp.Body), cancel
+	}
+
+	readFrame := func(t *testing.T, reader *bufio.Reader) string {
+		t.Helper()
+		var frame strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				return frame.String()
+			}
+			frame.WriteString(line)
+		}
+	}
+
+	t.Run("Published events reach the client", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+		reader, cancel := openStream(t, serv)
+		defer cancel()
+
+		require.Eventually(t, func() bool { return w.Events().Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+		w.Events().Publish(events.Event{Type: events.TypeArticle, Data: events.ArticleData{FeedID: 3, Title: "Hello"}})
+
+		frame := readFrame(t, reader)
+		assert.Contains(t, frame, "event: article\n")
+		assert.Contains(t, frame, `"feed_id":3`)
+		assert.Contains(t, frame, `"title":"Hello"`)
+	})
+
+	t.Run("Idle stream sends heartbeats", func(t *testing.T) {
+		original := eventsHeartbeat
+		eventsHeartbeat = 10 * time.Millisecond
+		defer func() { eventsHeartbeat = original }()
+
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+		reader, cancel := openStream(t, serv)
+		defer cancel()
+
+		assert.Equal(t, ": ping\n", readFrame(t, reader))
+	})
+
+	t.Run("Disconnected client is unsubscribed", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+		_, cancel := openStream(t, serv)
+
+		require.Eventually(t, func() bool { return w.Events().Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+		cancel()
+
+		assert.Eventually(t, func() bool { return w.Events().Subscribers() == 0 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Shutdown ends open streams", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+		reader, cancel := openStream(t, serv)
+		defer cancel()
+
+		require.Eventually(t, func() bool { return w.Events().Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+		require.NoError(t, serv.Shutdown(context.Background()))
+
+		_, err := reader.ReadString('\n')
+		assert.Error(t, err)
+		assert.Equal(t, 0, w.Events().Subscribers())
+	})
+
+	t.Run("Only GET is allowed", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+		req := httptest.NewRequest(http.MethodPost, "/events", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleEvents(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
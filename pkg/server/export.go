@@ -0,0 +1,148 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// handleFeedExport returns a curl command that recreates the feed through POST /feeds, so a
+// setup can be documented or copied to another instance. The feed's cookie is a secret and is
+// left out; the CSRF token is read from the CSRF_TOKEN shell variable.
+func (s *Server) handleFeedExport(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/export"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte(feedCurlCommand(requestBaseURL(request), feed))); err != nil {
+		logging.Error("Failed to write feed export response", "error", err)
+	}
+}
+
+// feedFormValues returns the POST /feeds form fields that create a feed configured like feed.
+// Worker-managed state and the cookie are not included.
+func feedFormValues(feed *models.Feed) url.Values {
+	values := url.Values{}
+	values.Set("name", feed.Name)
+	values.Set("url", feed.URL)
+	values.Set("poll_interval", strconv.Itoa(feed.PollInterval))
+	values.Set("poll_interval_unit", string(feed.PollIntervalUnit))
+	values.Set("sync_mode", string(feed.SyncMode))
+	if feed.SyncMode == models.SyncModeCount && feed.SyncCount != nil {
+		values.Set("sync_count", strconv.Itoa(*feed.SyncCount))
+	}
+	if feed.SyncMode == models.SyncModeDateFrom && feed.SyncDateFrom != nil {
+		values.Set("sync_date_from", feed.SyncDateFrom.Format("2006-01-02"))
+	}
+	if feed.FetchTimeoutSeconds > 0 {
+		values.Set("fetch_timeout_seconds", strconv.Itoa(feed.FetchTimeoutSeconds))
+	}
+	if feed.MinAgeMinutes > 0 {
+		values.Set("min_age_minutes", strconv.Itoa(feed.MinAgeMinutes))
+	}
+	if feed.MinTitleLength > 0 {
+		values.Set("min_title_length", strconv.Itoa(feed.MinTitleLength))
+	}
+	if feed.KeepLastN > 0 {
+		values.Set("keep_last_n", strconv.Itoa(feed.KeepLastN))
+	}
+	if feed.Priority != 0 {
+		values.Set("priority", strconv.Itoa(feed.Priority))
+	}
+	if feed.StripQueryParams {
+		values.Set("strip_query_params", "on")
+	}
+	if feed.AutoInterval {
+		values.Set("auto_interval", "on")
+	}
+	if feed.TagWithFeedName {
+		values.Set("tag_with_feed_name", "on")
+	}
+	if feed.CategoriesAsTags {
+		values.Set("categories_as_tags", "on")
+	}
+	if feed.ContentSelector != "" {
+		values.Set("content_selector", feed.ContentSelector)
+	}
+	if feed.DeliveryMode != "" {
+		values.Set("delivery_mode", string(feed.DeliveryMode))
+	}
+	if feed.DateField != "" && feed.DateField != models.DateFieldAuto {
+		values.Set("date_field", string(feed.DateField))
+	}
+	if feed.AcceptHeader != "" {
+		values.Set("accept_header", feed.AcceptHeader)
+	}
+	if len(feed.IncludeCategories) > 0 {
+		values.Set("include_categories", models.FormatCategories(feed.IncludeCategories))
+	}
+
+	return values
+}
+
+// exportFieldOrder is the order fields appear in an exported command, matching the add form
+var exportFieldOrder = []string{
+	"name", "url", "poll_interval", "poll_interval_unit", "sync_mode", "sync_count", "sync_date_from",
+	"fetch_timeout_seconds", "min_age_minutes", "min_title_length", "keep_last_n", "priority", "strip_query_params",
+	"auto_interval", "tag_with_feed_name", "categories_as_tags", "content_selector", "delivery_mode", "date_field",
+	"accept_header", "include_categories",
+}
+
+// feedCurlCommand renders feed as a curl command posting its form fields to baseURL
+func feedCurlCommand(baseURL string, feed *models.Feed) string {
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "# Recreate feed %q. Set CSRF_TOKEN to a token from the target instance first.\n", feed.Name)
+	if feed.Cookie != "" {
+		cmd.WriteString("# The feed's cookie is not exported; add --data-urlencode 'cookie=...' to send one.\n")
+	}
+	fmt.Fprintf(&cmd, "curl -X POST %s \\\n  -H \"X-CSRF-Token: $CSRF_TOKEN\"", shellQuote(baseURL+"/feeds/"))
+
+	values := feedFormValues(feed)
+	for _, field := range exportFieldOrder {
+		if !values.Has(field) {
+			continue
+		}
+		fmt.Fprintf(&cmd, " \\\n  --data-urlencode %s", shellQuote(field+"="+values.Get(field)))
+	}
+	cmd.WriteString("\n")
+
+	return cmd.String()
+}
+
+// shellQuote wraps value in single quotes for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// requestBaseURL returns the scheme and host the request was made to
+func requestBaseURL(request *http.Request) string {
+	scheme := "http"
+	if request.TLS != nil {
+		scheme = "https"
+	}
+
+	return scheme + "://" + request.Host
+}
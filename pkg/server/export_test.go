@@ -0,0 +1,125 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// exportedFormValues reads the --data-urlencode arguments back out of an exported command
+func exportedFormValues(t *testing.T, command string) url.Values {
+	t.Helper()
+
+	values := url.Values{}
+	for _, line := range strings.Split(command, "\n") {
+		arg, found := strings.CutPrefix(strings.TrimSpace(line), "--data-urlencode ")
+		if !found {
+			continue
+		}
+		arg = strings.TrimSpace(strings.TrimSuffix(arg, `\`))
+		require.True(t, strings.HasPrefix(arg, "'") && strings.HasSuffix(arg, "'"), "argument is quoted: %s", arg)
+		field, value, ok := strings.Cut(strings.ReplaceAll(arg[1:len(arg)-1], `'\''`, "'"), "=")
+		require.True(t, ok)
+		values.Set(field, value)
+	}
+
+	return values
+}
+
+func TestServer_handleFeedExport(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	syncCount := 10
+	original := &models.Feed{
+		ID:                  9,
+		Name:                "Bob's Blog",
+		URL:                 "https://example.com/feed.xml?format=rss&lang=en",
+		SyncMode:            models.SyncModeCount,
+		SyncCount:           &syncCount,
+		FetchTimeoutSeconds: 45,
+		MinAgeMinutes:       20,
+		MinTitleLength:      4,
+		KeepLastN:           300,
+		Priority:            5,
+		StripQueryParams:    true,
+		AutoInterval:        true,
+		TagWithFeedName:     true,
+		CategoriesAsTags:    true,
+		ContentSelector:     "article .body",
+		DeliveryMode:        models.DeliveryModeContent,
+		DateField:           models.DateFieldPublished,
+		AcceptHeader:        "application/rss+xml",
+		IncludeCategories:   []string{"go", "databases"},
+		Cookie:              "session=secret-value",
+		InitialSyncDone:     true,
+	}
+	original.SetPollInterval(3, models.TimeUnitHours)
+
+	mockStore.EXPECT().GetFeedByID(gomock.Any(), 9).Return(original, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/9/export", http.NoBody)
+	req.Host = "rss.example.net"
+	rr := httptest.NewRecorder()
+
+	serv.handleFeeds(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+	command := rr.Body.String()
+	assert.Contains(t, command, "curl -X POST 'http://rss.example.net/feeds/'")
+	assert.Contains(t, command, `-H "X-CSRF-Token: $CSRF_TOKEN"`)
+	assert.NotContains(t, command, "secret-value", "the cookie is never exported")
+	assert.Contains(t, command, "cookie is not exported")
+
+	t.Run("Command recreates an equivalent feed", func(t *testing.T) {
+		var created *models.Feed
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, feed *models.Feed) (int64, error) {
+				created = feed
+				return 10, nil
+			})
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		post := httptest.NewRequest(http.MethodPost, "/feeds/", strings.NewReader(exportedFormValues(t, command).Encode()))
+		post.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, post)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NotNil(t, created)
+
+		expected := *original
+		expected.ID = 10
+		expected.Cookie = ""
+		expected.InitialSyncDone = false
+		assert.Equal(t, expected, *created)
+	})
+
+	t.Run("Sync date survives the round trip", func(t *testing.T) {
+		from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		values := feedFormValues(&models.Feed{Name: "Dated", URL: "https://example.com/d.xml", SyncMode: models.SyncModeDateFrom, SyncDateFrom: &from})
+
+		assert.Equal(t, "2024-03-01", values.Get("sync_date_from"))
+		assert.False(t, values.Has("sync_count"))
+		assert.False(t, values.Has("strip_query_params"))
+	})
+
+	t.Run("Unknown feed", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 404).Return(nil, assert.AnError)
+
+		rr := httptest.NewRecorder()
+		serv.handleFeeds(rr, httptest.NewRequest(http.MethodGet, "/feeds/404/export", http.NoBody))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/worker"
+	"wallabag-rss-tool/views"
+)
+
+// handleAdminFeedHealth reports the outcome of each feed's last link check, failing feeds first.
+func (s *Server) handleAdminFeedHealth(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	feeds, err := s.store.GetFeeds(request.Context())
+	if err != nil {
+		logging.Error("Failed to get feeds for link check report", "error", fmt.Errorf("store.GetFeeds: %w", err))
+		http.Error(writer, "Failed to get feeds", http.StatusInternalServerError)
+
+		return
+	}
+
+	var checks map[int]worker.FeedCheck
+	var interval time.Duration
+	if s.worker != nil {
+		checks = s.worker.FeedChecks()
+		interval = s.worker.Config().LinkCheckInterval
+	}
+
+	rows := make([]views.FeedHealthRow, 0, len(feeds))
+	for _, feed := range feeds {
+		check := checks[feed.ID]
+		rows = append(rows, views.FeedHealthRow{Feed: feed, CheckedAt: check.CheckedAt, Error: check.Error})
+	}
+	slices.SortStableFunc(rows, func(a, b views.FeedHealthRow) int {
+		return cmp.Or(
+			cmp.Compare(feedHealthRank(a), feedHealthRank(b)),
+			strings.Compare(strings.ToLower(a.Feed.Name), strings.ToLower(b.Feed.Name)),
+		)
+	})
+
+	data := views.FeedHealthData{
+		PageData: s.pageData("Feed Link Check"),
+		Feeds:    rows,
+		Interval: interval,
+	}
+	if err := views.FeedHealth(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render link check report", http.StatusInternalServerError)
+	}
+}
+
+// feedHealthRank orders the report: failing feeds, then feeds not checked yet, then healthy ones
+func feedHealthRank(row views.FeedHealthRow) int {
+	switch {
+	case row.CheckedAt.IsZero():
+		return 1
+	case row.Error != "":
+		return 0
+	default:
+		return 2
+	}
+}
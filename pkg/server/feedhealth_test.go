@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestServer_handleAdminFeedHealth(t *testing.T) {
+	getReport := func(serv *Server) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serv.handleAdminFeedHealth(rr, httptest.NewRequest(http.MethodGet, "/admin/feed-health", http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Lists failing feeds first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		good := models.Feed{ID: 1, Name: "Good", URL: "https://example.com/good"}
+		dead := models.Feed{ID: 2, Name: "Dead", URL: "https://example.com/dead"}
+		added := models.Feed{ID: 3, Name: "Added since", URL: "https://example.com/new"}
+
+		w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{LinkCheckInterval: 24 * time.Hour})
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{good, dead}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(nil, errors.New("no such host"))
+		w.CheckFeedLinks(context.Background())
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{good, dead, added}, nil)
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "every 24h0m0s")
+		assert.Contains(t, body, "no such host")
+		unreachable := strings.Index(body, "Unreachable")
+		notChecked := strings.Index(body, "Not checked")
+		healthy := strings.Index(body, "Healthy")
+		require.NotEqual(t, -1, unreachable)
+		require.NotEqual(t, -1, notChecked)
+		require.NotEqual(t, -1, healthy)
+		assert.Less(t, unreachable, notChecked)
+		assert.Less(t, notChecked, healthy)
+	})
+
+	t.Run("Says when link checks are off", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Link checks are off")
+		assert.Contains(t, rr.Body.String(), "No feeds configured.")
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, errors.New("database locked"))
+
+		rr := getReport(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Rejects other methods", func(t *testing.T) {
+		mockStore, mockClient, w := setupTestServer(t)
+
+		rr := httptest.NewRecorder()
+		NewServer(mockStore, mockClient, w).handleAdminFeedHealth(rr, httptest.NewRequest(http.MethodPost, "/admin/feed-health", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// recentArticlesWindow is how far back feedStats.RecentArticles counts.
+const recentArticlesWindow = 7 * 24 * time.Hour
+
+// feedDetailResponse is the JSON body of /feeds/{id}.json: the stored feed with its schedule,
+// article counts and the worker's latest view of it.
+type feedDetailResponse struct {
+	Feed      models.Feed          `json:"feed"`
+	Schedule  feedScheduleResponse `json:"schedule"`
+	Stats     feedStats            `json:"stats"`
+	LastError string               `json:"last_error,omitempty"` // Error from the last fetch, if it failed
+	MovedTo   string               `json:"moved_to,omitempty"`   // Host the feed permanently redirects to
+}
+
+// feedStats counts the articles recorded for a feed.
+type feedStats struct {
+	LastArticleAt   *time.Time `json:"last_article_at,omitempty"`
+	Articles        int        `json:"articles"`
+	Sent            int        `json:"sent"`
+	Filtered        int        `json:"filtered"`
+	MarkedProcessed int        `json:"marked_processed"`
+	RecentArticles  int        `json:"recent_articles"` // Recorded in the last 7 days
+	Clicks          int        `json:"clicks"`
+}
+
+// handleFeedJSON returns a single feed as JSON for scripts. The feed's cookie is a secret and is
+// left out.
+func (s *Server) handleFeedJSON(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, ".json"))
+	if err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, "Invalid feed ID")
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.GetFeedByID: %w", err), "Failed to get feed", "feed_id", id)
+
+		return
+	}
+
+	articles, err := s.store.GetFeedArticles(request.Context(), id)
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.GetFeedArticles: %w", err), "Failed to load feed articles", "feed_id", id)
+
+		return
+	}
+
+	response := feedDetailResponse{
+		Feed:     *feed,
+		Schedule: s.feedSchedule(request.Context(), feed),
+		Stats:    newFeedStats(articles, time.Now()),
+	}
+	response.Feed.Cookie = ""
+	if s.worker != nil {
+		response.LastError = s.worker.FeedErrors()[id]
+		response.MovedTo = s.worker.FeedMoves()[id]
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logging.Error("Failed to write feed response", "error", err)
+	}
+}
+
+// newFeedStats counts a feed's articles as of now
+func newFeedStats(articles []models.Article, now time.Time) feedStats {
+	stats := feedStats{Articles: len(articles)}
+	for i := range articles {
+		article := &articles[i]
+		switch {
+		case article.Filtered:
+			stats.Filtered++
+		case article.MarkedProcessed:
+			stats.MarkedProcessed++
+		case article.WallabagEntryID != nil:
+			stats.Sent++
+		}
+		if now.Sub(article.CreatedAt) <= recentArticlesWindow {
+			stats.RecentArticles++
+		}
+		if stats.LastArticleAt == nil || article.CreatedAt.After(*stats.LastArticleAt) {
+			stats.LastArticleAt = &article.CreatedAt
+		}
+		stats.Clicks += article.Clicks
+	}
+
+	return stats
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleFeedJSON(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	getFeed := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		return rr
+	}
+
+	t.Run("Returns the feed with schedule and stats", func(t *testing.T) {
+		lastFetched := time.Now().Add(-30 * time.Minute).UTC().Truncate(time.Second)
+		feed := &models.Feed{ID: 5, Name: "Example", URL: "https://example.com/feed", LastFetched: &lastFetched, Cookie: "session=secret"}
+		feed.SetPollInterval(2, models.TimeUnitHours)
+		entryID := 11
+		articles := []models.Article{
+			{ID: 1, FeedID: 5, WallabagEntryID: &entryID, CreatedAt: time.Now().Add(-time.Hour), Clicks: 3},
+			{ID: 2, FeedID: 5, Filtered: true, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)},
+			{ID: 3, FeedID: 5, MarkedProcessed: true, CreatedAt: time.Now().Add(-60 * 24 * time.Hour)},
+		}
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 5).Return(feed, nil)
+		mockStore.EXPECT().GetFeedArticles(gomock.Any(), 5).Return(articles, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		rr := getFeed("/feeds/5.json")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.NotContains(t, rr.Body.String(), "secret", "the cookie is not exposed")
+
+		var response feedDetailResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, 5, response.Feed.ID)
+		assert.Equal(t, "Example", response.Feed.Name)
+		assert.Equal(t, "https://example.com/feed", response.Feed.URL)
+		assert.Equal(t, 120, response.Schedule.EffectiveIntervalMinutes)
+		require.NotNil(t, response.Schedule.NextDue)
+		assert.True(t, lastFetched.Add(2*time.Hour).Equal(*response.Schedule.NextDue))
+		assert.Equal(t, feedStats{
+			LastArticleAt:   response.Stats.LastArticleAt,
+			Articles:        3,
+			Sent:            1,
+			Filtered:        1,
+			MarkedProcessed: 1,
+			RecentArticles:  1,
+			Clicks:          3,
+		}, response.Stats)
+		require.NotNil(t, response.Stats.LastArticleAt)
+		assert.WithinDuration(t, articles[0].CreatedAt, *response.Stats.LastArticleAt, time.Second)
+	})
+
+	t.Run("JSON field names", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 6).Return(&models.Feed{ID: 6}, nil)
+		mockStore.EXPECT().GetFeedArticles(gomock.Any(), 6).Return(nil, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		rr := getFeed("/feeds/6.json")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Contains(t, body, "feed")
+		assert.Contains(t, body, "schedule")
+		assert.Contains(t, body, "stats")
+		assert.NotContains(t, body, "last_error", "omitted until the worker records an error")
+	})
+
+	t.Run("Unknown feed", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 99).Return(nil, fmt.Errorf("feed with ID 99: %w", database.ErrFeedNotFound))
+
+		rr := getFeed("/feeds/99.json")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, jsonError{Error: "Feed not found", Code: errorCodeNotFound}, decodeJSONError(t, rr))
+	})
+
+	t.Run("Store failure", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7}, nil)
+		mockStore.EXPECT().GetFeedArticles(gomock.Any(), 7).Return(nil, errors.New("database is locked"))
+
+		rr := getFeed("/feeds/7.json")
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, jsonError{Error: "Internal server error", Code: errorCodeInternal}, decodeJSONError(t, rr))
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		rr := getFeed("/feeds/abc.json")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, errorCodeInvalidRequest, decodeJSONError(t, rr).Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/feeds/5.json", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, errorCodeMethodNotAllowed, decodeJSONError(t, rr).Code)
+	})
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/worker"
+	"wallabag-rss-tool/views"
+)
+
+// filterPreviewItems is how many of the feed's newest items a filter preview annotates.
+const filterPreviewItems = 10
+
+// handleFeedFilterPreview fetches the feed and labels its newest items with whether the feed's
+// filters would send them and why. An include_categories form value is tried in place of the
+// saved filter, so an edit can be checked before saving. Nothing is saved or sent.
+func (s *Server) handleFeedFilterPreview(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/filters"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		http.Error(writer, "Invalid form data", http.StatusBadRequest)
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+	if request.PostForm.Has("include_categories") {
+		feed.IncludeCategories = models.ParseCategories(request.PostForm.Get("include_categories"))
+	}
+
+	// Fetch with the worker's processor and the feed's own settings, with sync mode "all" so
+	// every item comes back oldest first
+	probe := *feed
+	probe.SyncMode, probe.SyncCount, probe.SyncDateFrom = models.SyncModeAll, nil, nil
+	probe.InitialSyncDone = false
+	result, err := s.rssProcessor.FetchFeed(request.Context(), &probe)
+	if err != nil {
+		logging.Error("Failed to fetch feed for filter preview",
+			"error", fmt.Errorf("rssProcessor.FetchFeed: %w", err),
+			"feed_id", feed.ID,
+			"feed_url", feed.URL)
+		http.Error(writer, "Failed to fetch feed", http.StatusBadGateway)
+
+		return
+	}
+
+	// Articles come oldest first; list the newest first
+	articles := result.Articles
+	minTitleLength := 0
+	if s.worker != nil {
+		minTitleLength = s.worker.Config().MinTitleLength
+	}
+	now := time.Now()
+	var data views.FeedFilterPreviewData
+	for i := len(articles) - 1; i >= 0 && len(data.Items) < filterPreviewItems; i-- {
+		verdict := worker.ExplainFilters(feed, articles[i], minTitleLength, now)
+		data.Items = append(data.Items, views.FilterPreviewItem{
+			Title:    articles[i].Title,
+			Reason:   verdict.Reason,
+			Included: verdict.Included,
+			Deferred: verdict.Deferred,
+		})
+	}
+
+	if err := views.FeedFilterPreview(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render filter preview", http.StatusInternalServerError)
+	}
+}
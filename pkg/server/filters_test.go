@@ -0,0 +1,121 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+func TestServer_handleFeedFilterPreview(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Filters</title>
+			<item><title>Go release</title><link>https://example.com/1</link><category>Go</category><pubDate>Mon, 01 Jan 2024 10:00:00 GMT</pubDate></item>
+			<item><title>Match report</title><link>https://example.com/2</link><category>Sport</category><pubDate>Mon, 01 Jul 2024 10:00:00 GMT</pubDate></item>
+			<item><title>Untagged</title><link>https://example.com/3</link><pubDate>Wed, 01 Jan 2025 10:00:00 GMT</pubDate></item>
+		</channel></rss>`))
+	}))
+	defer upstream.Close()
+
+	t.Run("Labels items against the saved filter", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL, IncludeCategories: []string{"go"}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/filters", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Regexp(t, `Included</span> Go release <small[^>]*>Category &#34;Go&#34; is included`, body)
+		assert.Regexp(t, `Excluded</span> Match report <small[^>]*>No included category among Sport`, body)
+		assert.Regexp(t, `Excluded</span> Untagged <small[^>]*>Item has no categories`, body)
+		assert.Less(t, strings.Index(body, "Untagged"), strings.Index(body, "Go release"), "newest items come first")
+	})
+
+	t.Run("Form filter replaces the saved one", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL, IncludeCategories: []string{"go"}}, nil)
+
+		form := url.Values{"include_categories": {""}}
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/filters", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedFilterPreview(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 3, strings.Count(rr.Body.String(), "No category filter"))
+		assert.NotContains(t, rr.Body.String(), "Excluded")
+	})
+
+	t.Run("Fetches with the feed's own cookie", func(t *testing.T) {
+		private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Cookie") != "session=abc123" {
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Private</title>
+				<item><title>Members only</title><link>https://example.com/private</link></item>
+			</channel></rss>`))
+		}))
+		defer private.Close()
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 8).Return(&models.Feed{ID: 8, URL: private.URL, Cookie: "session=abc123", InitialSyncDone: true, SyncMode: models.SyncModeNone}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/8/filters", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedFilterPreview(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Members only")
+	})
+
+	t.Run("Fetches through the shared processor", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+		transport := &countingTransport{}
+		shared := NewServerWithConfig(mockStore, mockClient, w, Config{Processor: rss.NewProcessorWithTransport(transport)})
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/filters", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		shared.handleFeedFilterPreview(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Go release")
+		assert.Equal(t, int32(1), transport.requests.Load(), "the filter preview fetch goes through the configured transport")
+	})
+
+	t.Run("Without a worker", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+		noWorker := NewServer(mockStore, mockClient, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/filters", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		noWorker.handleFeedFilterPreview(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 3, strings.Count(rr.Body.String(), "No category filter"))
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/filters", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedFilterPreview(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// Health statuses reported by /healthz.
+const (
+	healthStatusOK       = "ok"
+	healthStatusDegraded = "degraded"
+)
+
+// healthResponse is the JSON body of /healthz.
+type healthResponse struct {
+	Status string                `json:"status"`
+	Worker *workerHealthResponse `json:"worker,omitempty"`
+}
+
+// workerHealthResponse describes the worker's polling cycles. Times are omitted until the
+// event has happened.
+type workerHealthResponse struct {
+	StartedAt         time.Time  `json:"started_at"`
+	LastSuccess       *time.Time `json:"last_success,omitempty"`
+	LastErrorAt       *time.Time `json:"last_error_at,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	StaleAfterSeconds int        `json:"stale_after_seconds"`
+	Stale             bool       `json:"stale"`
+}
+
+// handleHealthz reports worker health as JSON. A stale worker, one that has not completed a
+// polling cycle within its threshold, is reported as degraded. The status code stays 200 so
+// orchestrators don't restart the process over a slow cycle; monitors should check "status".
+func (s *Server) handleHealthz(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	response := healthResponse{Status: healthStatusOK}
+	if s.worker != nil {
+		health := s.worker.Health()
+		response.Worker = &workerHealthResponse{
+			StartedAt:         health.StartedAt,
+			LastError:         health.LastError,
+			StaleAfterSeconds: int(health.StaleAfter / time.Second),
+			Stale:             health.Stale,
+		}
+		if !health.LastSuccess.IsZero() {
+			response.Worker.LastSuccess = &health.LastSuccess
+		}
+		if !health.LastErrorAt.IsZero() {
+			response.Worker.LastErrorAt = &health.LastErrorAt
+		}
+		if health.Stale {
+			response.Status = healthStatusDegraded
+			logging.Warn("Health check degraded: worker has not completed a polling cycle recently",
+				"stale_after", health.StaleAfter,
+				"last_success", health.LastSuccess)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logging.Error("Failed to write health response", "error", err)
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestServer_handleHealthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+
+	getHealth := func(t *testing.T, w *worker.Worker) healthResponse {
+		serv := NewServer(mockStore, mockClient, w)
+		req := httptest.NewRequest("GET", "/healthz", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleHealthz(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var response healthResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+
+		return response
+	}
+
+	t.Run("Worker that never completes a cycle is degraded", func(t *testing.T) {
+		w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{StaleAfter: 10 * time.Millisecond})
+		time.Sleep(20 * time.Millisecond)
+
+		response := getHealth(t, w)
+
+		assert.Equal(t, healthStatusDegraded, response.Status)
+		require.NotNil(t, response.Worker)
+		assert.True(t, response.Worker.Stale)
+		assert.Nil(t, response.Worker.LastSuccess)
+	})
+
+	t.Run("Worker with a recent cycle is ok", func(t *testing.T) {
+		w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{StaleAfter: time.Hour})
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil)
+		w.ProcessFeeds()
+
+		response := getHealth(t, w)
+
+		assert.Equal(t, healthStatusOK, response.Status)
+		require.NotNil(t, response.Worker)
+		assert.False(t, response.Worker.Stale)
+		assert.NotNil(t, response.Worker.LastSuccess)
+		assert.Equal(t, 3600, response.Worker.StaleAfterSeconds)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		serv := NewServer(mockStore, mockClient, nil)
+		req := httptest.NewRequest("POST", "/healthz", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleHealthz(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"wallabag-rss-tool/pkg/config"
+	"wallabag-rss-tool/pkg/worker"
+	"wallabag-rss-tool/views"
+)
+
+// Wallabag reachability is checked at most once per wallabagReachabilityTTL, so rendering the
+// settings page does not wait on Wallabag every time.
+const (
+	wallabagReachabilityTTL     = 5 * time.Minute
+	wallabagReachabilityTimeout = 5 * time.Second
+)
+
+// reachabilityCache remembers the outcome of the last Wallabag reachability check
+type reachabilityCache struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+// configIssues checks the configuration and runtime state the settings page reports on: the
+// Wallabag credentials, whether Wallabag can be reached, whether the database accepts writes,
+// feeds whose last fetch or link check failed, that moved to another host or that are
+// disabled, and the worker's polling cycle.
+func (s *Server) configIssues(ctx context.Context) []views.ConfigIssue {
+	var issues []views.ConfigIssue
+	addIssue := func(severity views.IssueSeverity, format string, args ...any) {
+		issues = append(issues, views.ConfigIssue{Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if _, err := config.LoadWallabagConfig(); err != nil {
+		addIssue(views.IssueError, "Wallabag credentials are missing or incomplete: %v", err)
+	}
+
+	if s.config.WallabagURL != "" {
+		if err := s.checkWallabagReachable(ctx); err != nil {
+			addIssue(views.IssueWarning, "Wallabag at %s could not be reached: %v", s.config.WallabagURL, err)
+		}
+	}
+
+	if err := s.store.CheckWritable(ctx); err != nil {
+		addIssue(views.IssueError, "The database does not accept writes, so articles cannot be recorded: %v", err)
+	}
+
+	feeds, err := s.store.GetFeeds(ctx)
+	if err != nil {
+		addIssue(views.IssueError, "Feeds could not be loaded: %v", err)
+	}
+
+	var feedErrors, feedMoves map[int]string
+	var feedChecks map[int]worker.FeedCheck
+	if s.worker != nil {
+		feedErrors = s.worker.FeedErrors()
+		feedMoves = s.worker.FeedMoves()
+		feedChecks = s.worker.FeedChecks()
+	}
+	for _, feed := range feeds {
+		reason, failed := feedErrors[feed.ID]
+		if failed && !feed.Disabled {
+			addIssue(views.IssueWarning, "Feed %q failed on its last fetch: %s", feed.Name, reason)
+		}
+		// A feed failing both ways is reported once, by its fetch
+		if check, checked := feedChecks[feed.ID]; checked && !check.Healthy() && !failed && !feed.Disabled {
+			addIssue(views.IssueWarning, "Feed %q failed its last link check: %s", feed.Name, check.Error)
+		}
+		if movedTo, moved := feedMoves[feed.ID]; moved && !feed.Disabled {
+			addIssue(views.IssueInfo, "Feed %q redirects permanently to another host, %s; edit the feed to use that URL if the move is expected", feed.Name, movedTo)
+		}
+		if feed.Disabled {
+			addIssue(views.IssueInfo, "Feed %q is disabled and is not polled", feed.Name)
+		}
+	}
+
+	if s.worker != nil {
+		health := s.worker.Health()
+		if health.Stale {
+			addIssue(views.IssueWarning, "The worker has not completed a polling cycle in %s", health.StaleAfter)
+		}
+		if health.LastError != "" && health.LastErrorAt.After(health.LastSuccess) {
+			addIssue(views.IssueWarning, "The last polling cycle failed: %s", health.LastError)
+		}
+	}
+
+	return issues
+}
+
+// checkWallabagReachable reports whether the Wallabag base URL answered an HTTP request,
+// reusing the last result for wallabagReachabilityTTL. Any response counts as reachable. The
+// request goes over the Wallabag client's transport, so a proxied Wallabag is checked through
+// the proxy.
+func (s *Server) checkWallabagReachable(ctx context.Context) error {
+	cache := &s.wallabagReachability
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if !cache.checkedAt.IsZero() && time.Since(cache.checkedAt) < wallabagReachabilityTTL {
+		return cache.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wallabagReachabilityTimeout)
+	defer cancel()
+
+	cache.err = nil
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.WallabagURL, http.NoBody)
+	if err != nil {
+		cache.err = fmt.Errorf("invalid URL: %w", err)
+	} else if resp, err := s.wallabagReachabilityClient().Do(req); err != nil {
+		cache.err = err
+	} else {
+		resp.Body.Close()
+	}
+	cache.checkedAt = time.Now()
+
+	return cache.err
+}
+
+// wallabagReachabilityClient returns a client for the reachability check that uses the
+// configured Wallabag transport and gives up after wallabagReachabilityTimeout
+func (s *Server) wallabagReachabilityClient() *http.Client {
+	return &http.Client{
+		Timeout:   wallabagReachabilityTimeout,
+		Transport: s.config.WallabagTransport,
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+	"wallabag-rss-tool/views"
+)
+
+// clearWallabagEnv unsets the Wallabag credentials for the duration of the test
+func clearWallabagEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"WALLABAG_BASE_URL", "WALLABAG_CLIENT_ID", "WALLABAG_CLIENT_SECRET", "WALLABAG_USERNAME", "WALLABAG_PASSWORD"} {
+		t.Setenv(name, "") // Restores the original value when the test ends
+		os.Unsetenv(name)
+	}
+}
+
+func TestServer_configIssues(t *testing.T) {
+	t.Run("Reports missing credentials, an unwritable database and feed problems", func(t *testing.T) {
+		clearWallabagEnv(t)
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		healthy := models.Feed{ID: 1, Name: "Healthy", URL: "https://example.com/ok", PollIntervalMinutes: 30}
+		broken := models.Feed{ID: 2, Name: "Broken", URL: "https://example.com/broken", PollIntervalMinutes: 30}
+		paused := models.Feed{ID: 3, Name: "Paused", URL: "https://example.com/paused", Disabled: true}
+
+		// A polling cycle records the broken feed's fetch failure on the worker
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{broken}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(nil, errors.New("status 500"))
+		w.ProcessFeeds()
+
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(errors.New("attempt to write a readonly database"))
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{healthy, broken, paused}, nil)
+		serv := NewServer(mockStore, mockClient, w)
+
+		issues := serv.configIssues(context.Background())
+
+		require.Len(t, issues, 4)
+		assert.Equal(t, views.IssueError, issues[0].Severity)
+		assert.Contains(t, issues[0].Message, "Wallabag credentials are missing")
+		assert.Equal(t, views.IssueError, issues[1].Severity)
+		assert.Contains(t, issues[1].Message, "readonly database")
+		assert.Equal(t, views.ConfigIssue{Severity: views.IssueWarning, Message: `Feed "Broken" failed on its last fetch: status 500`}, issues[2])
+		assert.Equal(t, views.ConfigIssue{Severity: views.IssueInfo, Message: `Feed "Paused" is disabled and is not polled`}, issues[3])
+	})
+
+	t.Run("Reports feeds that moved to another host", func(t *testing.T) {
+		clearWallabagEnv(t)
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		moved := models.Feed{ID: 4, Name: "Moved", URL: "https://old.example.com/feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{moved}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{MovedTo: "https://new.example.com/feed"}, nil)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 4).Return(nil)
+		w.ProcessFeeds()
+
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{moved}, nil)
+		serv := NewServer(mockStore, mockClient, w)
+
+		issues := serv.configIssues(context.Background())
+
+		require.Len(t, issues, 2)
+		assert.Equal(t, views.IssueInfo, issues[1].Severity)
+		assert.Equal(t, `Feed "Moved" redirects permanently to another host, https://new.example.com/feed; edit the feed to use that URL if the move is expected`, issues[1].Message)
+	})
+
+	t.Run("Reports feeds that failed their link check", func(t *testing.T) {
+		clearWallabagEnv(t)
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		dead := models.Feed{ID: 5, Name: "Dead", URL: "https://example.com/dead", PollIntervalMinutes: 1440}
+		broken := models.Feed{ID: 6, Name: "Broken", URL: "https://example.com/broken", PollIntervalMinutes: 30}
+
+		// The broken feed fails both its fetch and its link check, so it is reported once
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(nil, errors.New("status 410")).Times(3)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{broken}, nil)
+		w.ProcessFeeds()
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{dead, broken}, nil)
+		w.CheckFeedLinks(context.Background())
+
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{dead, broken}, nil)
+		serv := NewServer(mockStore, mockClient, w)
+
+		issues := serv.configIssues(context.Background())
+
+		require.Len(t, issues, 3)
+		assert.Equal(t, views.ConfigIssue{Severity: views.IssueWarning, Message: `Feed "Dead" failed its last link check: status 410`}, issues[1])
+		assert.Equal(t, views.ConfigIssue{Severity: views.IssueWarning, Message: `Feed "Broken" failed on its last fetch: status 410`}, issues[2])
+	})
+
+	t.Run("Reports an unreachable Wallabag and caches the result", func(t *testing.T) {
+		t.Setenv("WALLABAG_BASE_URL", "https://wallabag.example.com")
+		t.Setenv("WALLABAG_CLIENT_ID", "id")
+		t.Setenv("WALLABAG_CLIENT_SECRET", "secret")
+		t.Setenv("WALLABAG_USERNAME", "user")
+		t.Setenv("WALLABAG_PASSWORD", "pass")
+		mockStore, mockClient, w := setupTestServer(t)
+
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+		serv := NewServerWithConfig(mockStore, mockClient, w, Config{WallabagURL: unreachable.URL})
+
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil).Times(2)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(2)
+
+		issues := serv.configIssues(context.Background())
+		require.Len(t, issues, 1)
+		assert.Equal(t, views.IssueWarning, issues[0].Severity)
+		assert.Contains(t, issues[0].Message, "could not be reached")
+
+		checkedAt := serv.wallabagReachability.checkedAt
+		assert.Equal(t, issues, serv.configIssues(context.Background()))
+		assert.Equal(t, checkedAt, serv.wallabagReachability.checkedAt, "the cached result is reused")
+	})
+
+	t.Run("Checks Wallabag through the configured transport", func(t *testing.T) {
+		t.Setenv("WALLABAG_BASE_URL", "https://wallabag.example.com")
+		t.Setenv("WALLABAG_CLIENT_ID", "id")
+		t.Setenv("WALLABAG_CLIENT_SECRET", "secret")
+		t.Setenv("WALLABAG_USERNAME", "user")
+		t.Setenv("WALLABAG_PASSWORD", "pass")
+		mockStore, mockClient, w := setupTestServer(t)
+
+		wallabagServer := httptest.NewServer(http.NotFoundHandler())
+		defer wallabagServer.Close()
+		transport := &countingTransport{}
+		serv := NewServerWithConfig(mockStore, mockClient, w, Config{WallabagURL: wallabagServer.URL, WallabagTransport: transport})
+
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+
+		assert.Empty(t, serv.configIssues(context.Background()))
+		assert.Equal(t, int32(1), transport.requests.Load(), "the check used the Wallabag transport")
+	})
+
+	t.Run("Settings page shows the check", func(t *testing.T) {
+		clearWallabagEnv(t)
+		mockStore, mockClient, w := setupTestServer(t)
+		serv := NewServer(mockStore, mockClient, w)
+
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+
+		rr := httptest.NewRecorder()
+		serv.handleSettings(rr, httptest.NewRequest(http.MethodGet, "/settings", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Configuration Check")
+		assert.Contains(t, rr.Body.String(), "Wallabag credentials are missing")
+	})
+}
+
+func TestServer_wallabagReachabilityClient(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	t.Run("Times out and uses direct connections by default", func(t *testing.T) {
+		client := NewServer(mockStore, mockClient, w).wallabagReachabilityClient()
+
+		assert.Equal(t, wallabagReachabilityTimeout, client.Timeout)
+		assert.Nil(t, client.Transport)
+	})
+
+	t.Run("Uses the configured Wallabag transport", func(t *testing.T) {
+		transport := &countingTransport{}
+		client := NewServerWithConfig(mockStore, mockClient, w, Config{WallabagTransport: transport}).wallabagReachabilityClient()
+
+		assert.Equal(t, wallabagReachabilityTimeout, client.Timeout)
+		assert.Same(t, transport, client.Transport)
+	})
+}
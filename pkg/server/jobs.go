@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"wallabag-rss-tool/pkg/jobs"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/views"
+)
+
+// handleAdminJobs serves the background jobs: GET /admin/jobs lists them as JSON, newest first,
+// GET /admin/jobs/{id} returns one and POST /admin/jobs/{id}/cancel stops it. A single job is
+// returned as JSON, or to HTMX requests as the progress fragment the UI refreshes.
+func (s *Server) handleAdminJobs(writer http.ResponseWriter, request *http.Request) {
+	path := strings.TrimSuffix(strings.TrimPrefix(request.URL.Path, "/admin/jobs"), "/")
+	if path == "" {
+		s.handleJobList(writer, request)
+
+		return
+	}
+
+	path, cancel := strings.CutSuffix(path, "/cancel")
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, "Invalid job ID")
+
+		return
+	}
+
+	if cancel {
+		s.handleJobCancel(writer, request, id)
+
+		return
+	}
+
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		writeAPIError(writer, err, "Failed to get job", "job_id", id)
+
+		return
+	}
+
+	s.renderJob(writer, request, job, http.StatusOK)
+}
+
+// handleJobList returns every remembered job as JSON
+func (s *Server) handleJobList(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(s.jobs.List()); err != nil {
+		logging.Error("Failed to write jobs response", "error", err)
+	}
+}
+
+// handleJobCancel asks a running job to stop and returns its state
+func (s *Server) handleJobCancel(writer http.ResponseWriter, request *http.Request, id int) {
+	if request.Method != http.MethodPost {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	err := s.jobs.Cancel(id)
+	switch {
+	case errors.Is(err, jobs.ErrFinished):
+		// Show the outcome; the job finished before the cancel arrived
+	case err != nil:
+		writeAPIError(writer, err, "Failed to cancel job", "job_id", id)
+
+		return
+	default:
+		logging.Info("Cancelling job, triggered by admin", "job_id", id)
+	}
+
+	job, err := s.jobs.Get(id)
+	if err != nil {
+		writeAPIError(writer, err, "Failed to get job", "job_id", id)
+
+		return
+	}
+
+	s.renderJob(writer, request, job, http.StatusOK)
+}
+
+// startJob runs fn as a background job called name and responds with its progress, with 202
+// Accepted and the job's URL in Location. If a job of that name is already running, its
+// progress is returned instead with 200 OK.
+func (s *Server) startJob(writer http.ResponseWriter, request *http.Request, name string, fn jobs.Func) {
+	job, started := s.jobs.Start(name, fn)
+	if !started {
+		s.renderJob(writer, request, job, http.StatusOK)
+
+		return
+	}
+
+	writer.Header().Set("Location", "/admin/jobs/"+strconv.Itoa(job.ID))
+	s.renderJob(writer, request, job, http.StatusAccepted)
+}
+
+// renderJob writes job as the HTMX progress fragment or, for other clients, as JSON
+func (s *Server) renderJob(writer http.ResponseWriter, request *http.Request, job jobs.Job, status int) {
+	if request.Header.Get("HX-Request") == "true" {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		writer.WriteHeader(status)
+		if err := views.JobProgress(job, s.getCSRFToken()).Render(request.Context(), writer); err != nil {
+			logging.Error("Failed to render job progress", "error", err, "job_id", job.ID)
+		}
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(job); err != nil {
+		logging.Error("Failed to write job response", "error", err, "job_id", job.ID)
+	}
+}
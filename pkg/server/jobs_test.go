@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/jobs"
+)
+
+// finishedJob reads the job a handler started from its response and returns it once finished
+func finishedJob(t *testing.T, serv *Server, rr *httptest.ResponseRecorder) jobs.Job {
+	t.Helper()
+
+	var started jobs.Job
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &started))
+	serv.jobs.Wait()
+
+	job, err := serv.jobs.Get(started.ID)
+	require.NoError(t, err)
+
+	return job
+}
+
+func TestServer_handleAdminJobs(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	release := make(chan struct{})
+	job, _ := serv.jobs.Start("Wait", func(ctx context.Context, progress func(done, total int)) (string, error) {
+		progress(1, 4)
+		select {
+		case <-ctx.Done():
+			return "Stopped after 1 of 4.", ctx.Err()
+		case <-release:
+			return "Done.", nil
+		}
+	})
+	defer close(release)
+
+	t.Run("Lists jobs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var list []jobs.Job
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		require.Len(t, list, 1)
+		assert.Equal(t, "Wait", list[0].Name)
+	})
+
+	t.Run("Shows progress to HTMX", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			current, err := serv.jobs.Get(job.ID)
+
+			return err == nil && current.Done == 1
+		}, time.Second, 5*time.Millisecond)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs/1", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `hx-trigger="every 1s"`)
+		assert.Contains(t, rr.Body.String(), `value="25"`)
+		assert.Contains(t, rr.Body.String(), "Wait: 1 of 4 done")
+		assert.Contains(t, rr.Body.String(), `hx-post="/admin/jobs/1/cancel"`)
+	})
+
+	t.Run("Cancels a running job", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/jobs/1/cancel", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+		serv.jobs.Wait()
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		current, err := serv.jobs.Get(job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusCancelled, current.Status)
+		assert.Equal(t, "Stopped after 1 of 4.", current.Result)
+	})
+
+	t.Run("Finished job stops refreshing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs/1", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.NotContains(t, rr.Body.String(), "hx-trigger")
+		assert.Contains(t, rr.Body.String(), "Cancelled")
+	})
+
+	t.Run("Unknown job", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs/99", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, errorCodeNotFound, decodeJSONError(t, rr).Code)
+	})
+
+	t.Run("Invalid job ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs/abc", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, errorCodeInvalidRequest, decodeJSONError(t, rr).Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/jobs/1/cancel", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminJobs(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, errorCodeMethodNotAllowed, decodeJSONError(t, rr).Code)
+	})
+}
+
+func TestServer_startJob_AlreadyRunning(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	release := make(chan struct{})
+	running, _ := serv.jobs.Start("Mark all processed", func(context.Context, func(done, total int)) (string, error) {
+		<-release
+
+		return "", nil
+	})
+
+	// The second request shows the running job rather than starting another
+	req := httptest.NewRequest(http.MethodPost, "/admin/mark-all-processed", http.NoBody)
+	rr := httptest.NewRecorder()
+	serv.handleAdminMarkAllProcessed(rr, req)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, running.ID, finishedJob(t, serv, rr).ID)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/jobs"
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// Codes in the JSON API's error responses. Clients match on these rather than on the message,
+// which may change.
+const (
+	errorCodeInvalidRequest   = "invalid_request"
+	errorCodeNotFound         = "not_found"
+	errorCodeDuplicateFeed    = "duplicate_feed"
+	errorCodeDuplicateArticle = "duplicate_article"
+	errorCodeMethodNotAllowed = "method_not_allowed"
+	errorCodeInternal         = "internal_error"
+)
+
+// jsonError is the body of a JSON API error response
+type jsonError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// knownErrors maps the errors the store and job manager report to the JSON API's status and
+// code. Validation errors carry their own message, since it says what to fix.
+var knownErrors = []struct {
+	err     error
+	message string
+	code    string
+	status  int
+}{
+	{err: database.ErrFeedNotFound, message: "Feed not found", code: errorCodeNotFound, status: http.StatusNotFound},
+	{err: database.ErrArticleNotFound, message: "Article not found", code: errorCodeNotFound, status: http.StatusNotFound},
+	{err: jobs.ErrNotFound, message: "Job not found", code: errorCodeNotFound, status: http.StatusNotFound},
+	{err: database.ErrFeedExists, message: "A feed with this URL already exists", code: errorCodeDuplicateFeed, status: http.StatusConflict},
+	{err: database.ErrArticleExists, message: "Article already recorded", code: errorCodeDuplicateArticle, status: http.StatusConflict},
+	{err: database.ErrNoCookieKey, code: errorCodeInvalidRequest, status: http.StatusBadRequest},
+}
+
+// writeJSONError responds with status and a structured error, {"error": message, "code": code}
+func writeJSONError(writer http.ResponseWriter, status int, code, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(jsonError{Error: message, Code: code}); err != nil {
+		logging.Error("Failed to write error response", "error", err)
+	}
+}
+
+// writeAPIError responds to err with the status and code of the known error it wraps. Any other
+// error is logged as logMessage, with args, and reported as an internal error without detail.
+func writeAPIError(writer http.ResponseWriter, err error, logMessage string, args ...any) {
+	for _, known := range knownErrors {
+		if !errors.Is(err, known.err) {
+			continue
+		}
+
+		message := known.message
+		if message == "" {
+			message = known.err.Error()
+		}
+		writeJSONError(writer, known.status, known.code, message)
+
+		return
+	}
+
+	logging.Error(logMessage, append([]any{"error", err}, args...)...)
+	writeJSONError(writer, http.StatusInternalServerError, errorCodeInternal, "Internal server error")
+}
+
+// writeMethodNotAllowed rejects a JSON API request made with the wrong method
+func writeMethodNotAllowed(writer http.ResponseWriter) {
+	writeJSONError(writer, http.StatusMethodNotAllowed, errorCodeMethodNotAllowed, "Method not allowed")
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/jobs"
+)
+
+// decodeJSONError reads a JSON API error response
+func decodeJSONError(t *testing.T, rr *httptest.ResponseRecorder) jsonError {
+	t.Helper()
+
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var body jsonError
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+
+	return body
+}
+
+func TestWriteJSONError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	writeJSONError(rr, http.StatusConflict, errorCodeDuplicateFeed, "A feed with this URL already exists")
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.JSONEq(t, `{"error": "A feed with this URL already exists", "code": "duplicate_feed"}`, rr.Body.String())
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
+
+func TestWriteAPIError(t *testing.T) {
+	tests := []struct {
+		err     error
+		name    string
+		code    string
+		message string
+		status  int
+	}{
+		{
+			name: "Feed not found", err: fmt.Errorf("store.GetFeedByID: feed with ID 3: %w", database.ErrFeedNotFound),
+			status: http.StatusNotFound, code: errorCodeNotFound, message: "Feed not found",
+		},
+		{
+			name: "Article not found", err: fmt.Errorf("store.GetArticle: %w", database.ErrArticleNotFound),
+			status: http.StatusNotFound, code: errorCodeNotFound, message: "Article not found",
+		},
+		{
+			name: "Job not found", err: jobs.ErrNotFound,
+			status: http.StatusNotFound, code: errorCodeNotFound, message: "Job not found",
+		},
+		{
+			name: "Duplicate feed", err: fmt.Errorf("store.InsertFeed: failed to insert feed: %w", database.ErrFeedExists),
+			status: http.StatusConflict, code: errorCodeDuplicateFeed, message: "A feed with this URL already exists",
+		},
+		{
+			name: "Duplicate article", err: fmt.Errorf("store.SaveArticle: failed to insert article: %w", database.ErrArticleExists),
+			status: http.StatusConflict, code: errorCodeDuplicateArticle, message: "Article already recorded",
+		},
+		{
+			name: "Validation", err: fmt.Errorf("store.UpdateFeed: %w", database.ErrNoCookieKey),
+			status: http.StatusBadRequest, code: errorCodeInvalidRequest, message: database.ErrNoCookieKey.Error(),
+		},
+		{
+			name: "Anything else", err: errors.New("store.GetFeeds: database is locked"),
+			status: http.StatusInternalServerError, code: errorCodeInternal, message: "Internal server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			writeAPIError(rr, tt.err, "Failed")
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, jsonError{Error: tt.message, Code: tt.code}, decodeJSONError(t, rr))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/views"
+)
+
+// maintenanceRetryAfter is the Retry-After value, in seconds, sent with the maintenance page.
+const maintenanceRetryAfter = "30"
+
+// SetMaintenance turns maintenance mode on or off. While it is on, UI routes answer with the
+// maintenance page so requests never reach a store that is still being migrated; /healthz and
+// /readyz keep answering.
+func (s *Server) SetMaintenance(on bool) {
+	s.maintenance.Store(on)
+}
+
+// maintenanceMode serves the maintenance page with 503 while maintenance mode is on.
+func (s *Server) maintenanceMode(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !s.maintenance.Load() {
+			next(writer, request)
+
+			return
+		}
+
+		writer.Header().Set("Retry-After", maintenanceRetryAfter)
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		data := views.PageData{Title: "Down for Maintenance"}
+		if err := views.Maintenance(data).Render(request.Context(), writer); err != nil {
+			logging.Error("Failed to render maintenance page", "error", err)
+		}
+	}
+}
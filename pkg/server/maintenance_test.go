@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServer_maintenanceMode(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	handler := serv.maintenanceMode(serv.handleSettings)
+
+	serv.SetMaintenance(true)
+
+	t.Run("UI requests get the maintenance page", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			rr := httptest.NewRecorder()
+			handler(rr, httptest.NewRequest(method, "/settings", http.NoBody))
+
+			assert.Equal(t, http.StatusServiceUnavailable, rr.Code, method)
+			assert.Equal(t, maintenanceRetryAfter, rr.Header().Get("Retry-After"))
+			assert.Contains(t, rr.Body.String(), "Down for Maintenance")
+		}
+	})
+
+	t.Run("Health endpoints keep answering", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		serv.handleHealthz(rr, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Requests succeed once maintenance is cleared", func(t *testing.T) {
+		serv.SetMaintenance(false)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest(http.MethodGet, "/settings", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "Down for Maintenance")
+	})
+}
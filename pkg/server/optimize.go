@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// handleAdminOptimize compacts the database on POST and reports its size before and after.
+// SQLite files do not shrink after deletes until this runs.
+func (s *Server) handleAdminOptimize(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ctx := request.Context()
+	before, err := s.store.DatabaseSize(ctx)
+	if err != nil {
+		logging.Error("Failed to get database size", "error", fmt.Errorf("store.DatabaseSize: %w", err))
+		http.Error(writer, "Failed to optimize database", http.StatusInternalServerError)
+
+		return
+	}
+
+	if err := s.store.Optimize(ctx); err != nil {
+		logging.Error("Failed to optimize database", "error", fmt.Errorf("store.Optimize: %w", err))
+		http.Error(writer, "Failed to optimize database", http.StatusInternalServerError)
+
+		return
+	}
+
+	after, err := s.store.DatabaseSize(ctx)
+	if err != nil {
+		logging.Error("Failed to get database size", "error", fmt.Errorf("store.DatabaseSize: %w", err))
+		http.Error(writer, "Failed to optimize database", http.StatusInternalServerError)
+
+		return
+	}
+	logging.Info("Optimized database, triggered by admin", "size_before", before, "size_after", after)
+
+	writer.WriteHeader(http.StatusOK)
+	message := fmt.Sprintf("Optimized the database: %s before, %s after.", formatBytes(before), formatBytes(after))
+	if _, err := writer.Write([]byte(message)); err != nil {
+		logging.Error("Failed to write optimize response", "error", err)
+	}
+}
+
+// formatBytes renders a size in bytes for display, e.g. "1.5 MB"
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+
+	return fmt.Sprintf("%.1f TB", value)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServer_handleAdminOptimize(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	optimize := func(method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/admin/optimize", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminOptimize(rr, req)
+
+		return rr
+	}
+
+	t.Run("Reports the size before and after", func(t *testing.T) {
+		gomock.InOrder(
+			mockStore.EXPECT().DatabaseSize(gomock.Any()).Return(int64(3<<20), nil),
+			mockStore.EXPECT().Optimize(gomock.Any()).Return(nil),
+			mockStore.EXPECT().DatabaseSize(gomock.Any()).Return(int64(1536<<10), nil),
+		)
+
+		rr := optimize(http.MethodPost)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Optimized the database: 3.0 MB before, 1.5 MB after.", rr.Body.String())
+	})
+
+	t.Run("Reports failure", func(t *testing.T) {
+		mockStore.EXPECT().DatabaseSize(gomock.Any()).Return(int64(4096), nil)
+		mockStore.EXPECT().Optimize(gomock.Any()).Return(errors.New("database is locked"))
+
+		rr := optimize(http.MethodPost)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := optimize(http.MethodGet)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.0 KB", formatBytes(1024))
+	assert.Equal(t, "2.5 MB", formatBytes(5<<19))
+	assert.Equal(t, "1.0 GB", formatBytes(1<<30))
+}
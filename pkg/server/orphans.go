@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// handleAdminOrphans reports, on GET, how many articles belong to feeds that no longer exist
+// and deletes them on POST. Such articles are left by feeds deleted while SQLite was not
+// enforcing the articles foreign key.
+func (s *Server) handleAdminOrphans(writer http.ResponseWriter, request *http.Request) {
+	var message string
+	switch request.Method {
+	case http.MethodGet:
+		orphans, err := s.store.GetOrphanedArticles(request.Context())
+		if err != nil {
+			logging.Error("Failed to find orphaned articles", "error", fmt.Errorf("store.GetOrphanedArticles: %w", err))
+			http.Error(writer, "Failed to find orphaned articles", http.StatusInternalServerError)
+
+			return
+		}
+		message = fmt.Sprintf("Found %d orphaned articles.", len(orphans))
+	case http.MethodPost:
+		deleted, err := s.store.DeleteOrphanedArticles(request.Context())
+		if err != nil {
+			logging.Error("Failed to delete orphaned articles", "error", fmt.Errorf("store.DeleteOrphanedArticles: %w", err))
+			http.Error(writer, "Failed to delete orphaned articles", http.StatusInternalServerError)
+
+			return
+		}
+		logging.Info("Deleted orphaned articles, triggered by admin", "deleted", deleted)
+		message = fmt.Sprintf("Removed %d orphaned articles.", deleted)
+	default:
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte(message)); err != nil {
+		logging.Error("Failed to write orphaned articles response", "error", err)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleAdminOrphans(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	orphans := func(method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/admin/orphans", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleAdminOrphans(rr, req)
+
+		return rr
+	}
+
+	t.Run("Reports orphans", func(t *testing.T) {
+		mockStore.EXPECT().GetOrphanedArticles(gomock.Any()).Return([]models.Article{{ID: 1}, {ID: 2}}, nil)
+
+		rr := orphans(http.MethodGet)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Found 2 orphaned articles.", rr.Body.String())
+	})
+
+	t.Run("Removes orphans", func(t *testing.T) {
+		mockStore.EXPECT().DeleteOrphanedArticles(gomock.Any()).Return(int64(3), nil)
+
+		rr := orphans(http.MethodPost)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Removed 3 orphaned articles.", rr.Body.String())
+	})
+
+	t.Run("Reports failure", func(t *testing.T) {
+		mockStore.EXPECT().DeleteOrphanedArticles(gomock.Any()).Return(int64(0), errors.New("database is locked"))
+
+		rr := orphans(http.MethodPost)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := orphans(http.MethodDelete)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// The feeds list previews each feed's latestArticlesCount newest article titles. They are read
+// for every feed in one query and reused for latestArticlesTTL, so paging through the list and
+// re-rendering single rows do not query the articles table each time.
+const (
+	latestArticlesCount = 3
+	latestArticlesTTL   = time.Minute
+)
+
+// latestArticlesCache remembers the last read of every feed's newest articles
+type latestArticlesCache struct {
+	mu        sync.Mutex
+	fetchedAt time.Time
+	byFeed    map[int][]models.Article
+}
+
+// latestArticles returns the newest articles of every feed by feed ID, reusing the last read
+// for latestArticlesTTL. On error the previews are left out rather than failing the page.
+func (s *Server) latestArticles(ctx context.Context) map[int][]models.Article {
+	cache := &s.latestArticlesCache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if !cache.fetchedAt.IsZero() && time.Since(cache.fetchedAt) < latestArticlesTTL {
+		return cache.byFeed
+	}
+
+	byFeed, err := s.store.GetLatestArticlesForFeeds(ctx, latestArticlesCount)
+	if err != nil {
+		logging.Warn("Failed to get latest articles for the feeds list",
+			"error", fmt.Errorf("store.GetLatestArticlesForFeeds: %w", err))
+
+		return nil
+	}
+	cache.byFeed = byFeed
+	cache.fetchedAt = time.Now()
+
+	return byFeed
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_feedListPreviews(t *testing.T) {
+	feeds := []models.Feed{{ID: 1, Name: "Busy"}, {ID: 2, Name: "Empty"}}
+
+	// loadPage renders the first page of feed rows as HTMX "load more" does
+	loadPage := func(serv *Server, mockStore *mocks.MockStorer) string {
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(feeds, len(feeds), nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/?page=1", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+		serv.handleFeedsGet(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		return rr.Body.String()
+	}
+
+	t.Run("Shows each feed's latest titles from one cached query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		serv := NewServer(mockStore, nil, nil)
+
+		mockStore.EXPECT().GetLatestArticlesForFeeds(gomock.Any(), latestArticlesCount).Return(map[int][]models.Article{
+			1: {{Title: "Newest post"}, {Title: "Older post"}},
+		}, nil).Times(1)
+
+		body := loadPage(serv, mockStore)
+		assert.Contains(t, body, "Newest post")
+		assert.Contains(t, body, "Older post")
+		assert.Equal(t, 1, strings.Count(body, `class="feed-latest`), "feeds without articles get no preview")
+
+		body = loadPage(serv, mockStore)
+		assert.Contains(t, body, "Newest post", "the second page load is served from the cache")
+	})
+
+	t.Run("Renders without previews when the query fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		serv := NewServer(mockStore, nil, nil)
+
+		mockStore.EXPECT().GetLatestArticlesForFeeds(gomock.Any(), latestArticlesCount).Return(nil, errors.New("db down"))
+
+		body := loadPage(serv, mockStore)
+		assert.Contains(t, body, `id="feed-1"`)
+		assert.NotContains(t, body, "feed-latest")
+	})
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"net/http"
+)
+
+// readOnly rejects every request that could change state with 403 when the server runs in
+// read-only mode. GET and HEAD requests pass through so pages still render.
+func (s *Server) readOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if s.config.ReadOnly && request.Method != http.MethodGet && request.Method != http.MethodHead {
+			http.Error(writer, "Read-only mode: changes are disabled", http.StatusForbidden)
+
+			return
+		}
+
+		next(writer, request)
+	}
+}
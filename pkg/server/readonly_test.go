@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_readOnly(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServerWithConfig(mockStore, mockClient, w, Config{ReadOnly: true})
+	handler := serv.readOnly(serv.handleFeeds)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		t.Run(method+" is forbidden", func(t *testing.T) {
+			req := httptest.NewRequest(method, "/feeds/1", http.NoBody)
+			rr := httptest.NewRecorder()
+
+			handler(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			assert.Contains(t, rr.Body.String(), "Read-only mode")
+		})
+	}
+
+	t.Run("GET renders the feeds page without edit controls", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).
+			Return([]models.Feed{{ID: 1, Name: "Test Feed 1", URL: "https://example.com/feed1.xml"}}, 1, nil).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		handler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "Test Feed 1")
+		assert.Contains(t, body, "Read-only mode")
+		assert.NotContains(t, body, "Add New Feed")
+		assert.NotContains(t, body, "hx-delete")
+		assert.NotContains(t, body, "bulk-enabled")
+	})
+
+	t.Run("GET renders the settings page without the form", func(t *testing.T) {
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/settings", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.readOnly(serv.handleSettings)(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "poll-interval-form")
+	})
+
+	t.Run("Writes pass through when not read-only", func(t *testing.T) {
+		called := false
+		writable := &Server{}
+		writable.readOnly(func(writer http.ResponseWriter, request *http.Request) {
+			called = true
+		})(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/sync", http.NoBody))
+
+		assert.True(t, called)
+	})
+}
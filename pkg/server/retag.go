@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+// handleFeedRetag starts a job adding a feed's current tags to every Wallabag entry already sent
+// for it, for bringing older entries in line after the feed's tagging settings change, and
+// reporting the totals
+func (s *Server) handleFeedRetag(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/retag"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	// A feed with many entries takes one Wallabag request each
+	s.startJob(writer, request, fmt.Sprintf("Re-tag feed %d", id), func(ctx context.Context, progress func(done, total int)) (string, error) {
+		totals, err := s.worker.RetagFeedEntries(ctx, id, progress)
+		if errors.Is(err, worker.ErrNoFeedTags) {
+			return "", errors.New("the feed has no tags to apply; enable Tag With Feed Name first")
+		}
+		if err != nil && ctx.Err() == nil {
+			logging.Error("Failed to re-tag feed entries", "error", fmt.Errorf("worker.RetagFeedEntries: %w", err), "feed_id", id)
+
+			return "", errors.New("failed to re-tag feed entries")
+		}
+
+		return fmt.Sprintf("Tagged %d entries (%d no longer in Wallabag, %d failed).",
+			totals.Tagged, totals.Missing, totals.Failed), err
+	})
+}
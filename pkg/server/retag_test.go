@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/jobs"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleFeedRetag(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Reports totals", func(t *testing.T) {
+		entryID := 11
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Go Blog", TagWithFeedName: true}, nil)
+		mockStore.EXPECT().GetFeedArticles(gomock.Any(), 7).Return([]models.Article{{WallabagEntryID: &entryID}}, nil)
+		mockClient.EXPECT().AddTagsToEntry(gomock.Any(), 11, []string{"go-blog"}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/retag", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusSucceeded, job.Status)
+		assert.Equal(t, "Tagged 1 entries (0 no longer in Wallabag, 0 failed).", job.Result)
+	})
+
+	t.Run("Feed without tags", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Go Blog"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/retag", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRetag(rr, req)
+
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusFailed, job.Status)
+		assert.Contains(t, job.Error, "no tags to apply")
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/retag", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRetag(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
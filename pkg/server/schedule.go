@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// Where a feed's effective poll interval comes from, as reported by /feeds/{id}/schedule.
+const (
+	intervalSourceFeed    = "feed"
+	intervalSourceAuto    = "auto"
+	intervalSourceDefault = "default"
+)
+
+// feedScheduleResponse is the JSON body of /feeds/{id}/schedule. Times are omitted until the
+// feed has been fetched.
+type feedScheduleResponse struct {
+	FeedID                     int             `json:"feed_id"`
+	Disabled                   bool            `json:"disabled"`
+	SnoozedUntil               *time.Time      `json:"snoozed_until,omitempty"`
+	PollInterval               int             `json:"poll_interval"`
+	PollIntervalUnit           models.TimeUnit `json:"poll_interval_unit"`
+	AutoInterval               bool            `json:"auto_interval"`
+	AutoIntervalMinutes        int             `json:"auto_interval_minutes"`
+	DefaultPollIntervalMinutes int             `json:"default_poll_interval_minutes"`
+	EffectiveIntervalMinutes   int             `json:"effective_interval_minutes"`
+	IntervalSource             string          `json:"interval_source"`
+	LastFetched                *time.Time      `json:"last_fetched,omitempty"`
+	NextDue                    *time.Time      `json:"next_due,omitempty"`
+	Due                        bool            `json:"due"`
+}
+
+// handleFeedSchedule reports how the worker schedules a feed: its configured and effective
+// poll intervals, when it was last fetched and when it is next due. Disabled feeds are never
+// due, and snoozed feeds are not due until the snooze ends.
+func (s *Server) handleFeedSchedule(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/schedule"))
+	if err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, "Invalid feed ID")
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.GetFeedByID: %w", err), "Failed to get feed", "feed_id", id)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(s.feedSchedule(request.Context(), feed)); err != nil {
+		logging.Error("Failed to write feed schedule response", "error", err)
+	}
+}
+
+// feedSchedule works out how the worker schedules feed
+func (s *Server) feedSchedule(ctx context.Context, feed *models.Feed) feedScheduleResponse {
+	defaultInterval := s.getDefaultPollIntervalWithFallback(ctx)
+	response := feedScheduleResponse{
+		FeedID:                     feed.ID,
+		Disabled:                   feed.Disabled,
+		SnoozedUntil:               feed.SnoozedUntil,
+		PollInterval:               feed.PollInterval,
+		PollIntervalUnit:           feed.PollIntervalUnit,
+		AutoInterval:               feed.AutoInterval,
+		AutoIntervalMinutes:        feed.AutoIntervalMinutes,
+		DefaultPollIntervalMinutes: defaultInterval,
+		EffectiveIntervalMinutes:   feed.OwnPollIntervalMinutes(),
+		IntervalSource:             intervalSourceFeed,
+		LastFetched:                feed.LastFetched,
+	}
+	switch {
+	case feed.AutoInterval && feed.AutoIntervalMinutes > 0:
+		response.IntervalSource = intervalSourceAuto
+	case response.EffectiveIntervalMinutes == 0:
+		response.EffectiveIntervalMinutes = defaultInterval
+		response.IntervalSource = intervalSourceDefault
+	}
+
+	response.NextDue = feed.NextPollTime(response.EffectiveIntervalMinutes)
+	response.Due = !feed.Disabled && !feed.IsSnoozed(time.Now()) && (response.NextDue == nil || !time.Now().Before(*response.NextDue))
+
+	return response
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleFeedSchedule(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	getSchedule := func(t *testing.T, feed *models.Feed) feedScheduleResponse {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), feed.ID).Return(feed, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/"+strconv.Itoa(feed.ID)+"/schedule", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var response feedScheduleResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+
+		return response
+	}
+
+	t.Run("Configured interval sets the next due time", func(t *testing.T) {
+		lastFetched := time.Now().Add(-30 * time.Minute).UTC().Truncate(time.Second)
+		feed := &models.Feed{ID: 3, LastFetched: &lastFetched}
+		feed.SetPollInterval(2, models.TimeUnitHours)
+
+		response := getSchedule(t, feed)
+
+		assert.Equal(t, 2, response.PollInterval)
+		assert.Equal(t, models.TimeUnitHours, response.PollIntervalUnit)
+		assert.Equal(t, 120, response.EffectiveIntervalMinutes)
+		assert.Equal(t, intervalSourceFeed, response.IntervalSource)
+		require.NotNil(t, response.NextDue)
+		assert.True(t, lastFetched.Add(2*time.Hour).Equal(*response.NextDue))
+		assert.False(t, response.Due)
+	})
+
+	t.Run("Auto interval overrides the configured one", func(t *testing.T) {
+		lastFetched := time.Now().Add(-3 * time.Hour)
+		feed := &models.Feed{ID: 4, LastFetched: &lastFetched, AutoInterval: true, AutoIntervalMinutes: 90}
+		feed.SetPollInterval(1, models.TimeUnitDays)
+
+		response := getSchedule(t, feed)
+
+		assert.Equal(t, 90, response.EffectiveIntervalMinutes)
+		assert.Equal(t, intervalSourceAuto, response.IntervalSource)
+		assert.True(t, response.Due)
+	})
+
+	t.Run("Feed without its own interval follows the default", func(t *testing.T) {
+		response := getSchedule(t, &models.Feed{ID: 5})
+
+		assert.Equal(t, 60, response.DefaultPollIntervalMinutes)
+		assert.Equal(t, 60, response.EffectiveIntervalMinutes)
+		assert.Equal(t, intervalSourceDefault, response.IntervalSource)
+		assert.Nil(t, response.LastFetched)
+		assert.Nil(t, response.NextDue, "a feed never fetched is due at once")
+		assert.True(t, response.Due)
+	})
+
+	t.Run("Disabled feed is never due", func(t *testing.T) {
+		response := getSchedule(t, &models.Feed{ID: 6, Disabled: true})
+
+		assert.True(t, response.Disabled)
+		assert.False(t, response.Due)
+	})
+
+	t.Run("Snoozed feed is not due until the snooze ends", func(t *testing.T) {
+		snoozedUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		response := getSchedule(t, &models.Feed{ID: 7, SnoozedUntil: &snoozedUntil})
+
+		require.NotNil(t, response.SnoozedUntil)
+		assert.True(t, snoozedUntil.Equal(*response.SnoozedUntil))
+		assert.False(t, response.Due)
+	})
+
+	t.Run("Feed not found", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 8).Return(nil, fmt.Errorf("feed with ID 8: %w", database.ErrFeedNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/8/schedule", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, errorCodeNotFound, decodeJSONError(t, rr).Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/feeds/8/schedule", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
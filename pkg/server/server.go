@@ -2,16 +2,25 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/net/http/httpguts"
+	"wallabag-rss-tool/pkg/audit"
 	"wallabag-rss-tool/pkg/config"
 	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/extract"
+	"wallabag-rss-tool/pkg/jobs"
 	"wallabag-rss-tool/pkg/logging"
 	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
 	"wallabag-rss-tool/pkg/wallabag"
 	"wallabag-rss-tool/pkg/worker"
 	"wallabag-rss-tool/views"
@@ -24,19 +33,100 @@ type Server struct {
 	store          database.Storer
 	wallabagClient wallabag.Clienter
 	worker         *worker.Worker
+	rssProcessor   rss.Processorer
 	csrfManager    *CSRFManager
+	ready          atomic.Bool
+	maintenance    atomic.Bool
+	config         Config
+	httpServerMu   sync.Mutex
+	httpServer     *http.Server  // Set by Start so Shutdown can stop it
+	jobs           *jobs.Manager // Background admin operations, cancelled by Shutdown
+	stopStreams    chan struct{} // Closed by Shutdown to end /events streams
+	stopOnce       sync.Once
+
+	wallabagReachability reachabilityCache   // Last Wallabag reachability check, for the settings page
+	latestArticlesCache  latestArticlesCache // Newest article titles previewed in the feeds list
+}
+
+// Config holds optional server behaviour settings. The zero value keeps the defaults.
+type Config struct {
+	FieldLimits  models.FieldLimits // Limits applied to feed names and URLs submitted through forms
+	MaxBodyBytes int64              // Largest request body accepted on write routes (0 = DefaultMaxBodyBytes)
+	CSRFSecret   []byte             // Key for signing CSRF tokens; random per process when empty
+	WallabagURL  string             // Wallabag base URL, for linking articles to their entries
+	ReadOnly     bool               // Reject state-changing requests and hide edit controls
+	Audit        *audit.Log         // Records feeds added, edited and deleted (nil = not recorded)
+	Headers      SecurityHeaders    // Optional security headers added to the defaults
+	EnforceTTL   bool               // Reject poll intervals shorter than the feed's advertised <ttl>
+	PageQueries  int                // Store queries run at once to build the articles page (0 = no limit)
+	Processor    rss.Processorer    // Fetches feeds for the raw and preview views, as the worker does (nil = rss.NewProcessor())
+
+	WallabagTransport http.RoundTripper // Transport the Wallabag client uses, for the reachability check (nil = direct connections)
+}
+
+// SecurityHeaders are optional response headers for deployments served over HTTPS, and an
+// opt-in stricter Content-Security-Policy. The zero value sends none of them, so plain-HTTP
+// local use keeps working.
+type SecurityHeaders struct {
+	HSTSMaxAge            int    // Strict-Transport-Security max-age in seconds (0 = no HSTS)
+	HSTSIncludeSubdomains bool   // Add includeSubDomains to Strict-Transport-Security
+	PermissionsPolicy     string // Permissions-Policy value ("" = not sent)
+	CrossOriginOpener     string // Cross-Origin-Opener-Policy value ("" = not sent)
+	ExternalScripts       bool   // Serve page scripts from /static/ and drop 'unsafe-inline' from script-src
+}
+
+// strictTransportSecurity returns the Strict-Transport-Security value, or "" when HSTS is off
+func (h SecurityHeaders) strictTransportSecurity() string {
+	if h.HSTSMaxAge <= 0 {
+		return ""
+	}
+
+	value := "max-age=" + strconv.Itoa(h.HSTSMaxAge)
+	if h.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+
+	return value
 }
 
 // NewServer creates a new Server instance.
 func NewServer(store database.Storer, wallabagClient wallabag.Clienter, worker *worker.Worker) *Server {
+	return NewServerWithConfig(store, wallabagClient, worker, Config{})
+}
+
+// NewServerWithConfig creates a new Server instance with the given configuration.
+func NewServerWithConfig(store database.Storer, wallabagClient wallabag.Clienter, worker *worker.Worker, config Config) *Server {
+	processor := config.Processor
+	if processor == nil {
+		processor = rss.NewProcessor()
+	}
+
 	return &Server{
 		store:          store,
 		wallabagClient: wallabagClient,
 		worker:         worker,
-		csrfManager:    NewCSRFManager(),
+		rssProcessor:   processor,
+		csrfManager:    newCSRFManagerForConfig(config),
+		config:         config,
+		jobs:           jobs.NewManager(),
+		stopStreams:    make(chan struct{}),
 	}
 }
 
+// newCSRFManagerForConfig uses the configured CSRF secret, falling back to a random one
+func newCSRFManagerForConfig(config Config) *CSRFManager {
+	if len(config.CSRFSecret) == 0 {
+		return NewCSRFManager()
+	}
+
+	return NewCSRFManagerWithSecret(config.CSRFSecret)
+}
+
+// SetReady marks whether startup has finished and the server may receive traffic.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
 // GetLocalIP returns the local IP address without external connections
 func GetLocalIP() string {
 	addrs, err := net.InterfaceAddrs()
@@ -61,14 +151,36 @@ func (s *Server) Start(port string) error {
 	mux := http.NewServeMux()
 	
 	
-	mux.HandleFunc("/", s.AddSecurityHeaders(s.HandleIndex))
-	mux.HandleFunc("/feeds/", s.AddSecurityHeaders(s.csrfProtection(s.handleFeeds)))
-	mux.HandleFunc("/feeds/edit/", s.AddSecurityHeaders(s.handleEditFeed))
-	mux.HandleFunc("/feeds/row/", s.AddSecurityHeaders(s.handleFeedRow))
-	mux.HandleFunc("/articles", s.AddSecurityHeaders(s.handleArticles))
-	mux.HandleFunc("/settings", s.AddSecurityHeaders(s.handleSettings))
-	mux.HandleFunc("/sync", s.AddSecurityHeaders(s.csrfProtection(s.handleSync)))
-	mux.HandleFunc("/settings/poll-interval", s.AddSecurityHeaders(s.csrfProtection(s.handleUpdateDefaultPollInterval)))
+	mux.HandleFunc("/", s.AddSecurityHeaders(s.maintenanceMode(s.HandleIndex)))
+	mux.HandleFunc("/feeds/", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.maxBodyBytes(s.csrfProtection(s.handleFeeds))))))
+	mux.HandleFunc("/feeds/bulk-enabled", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.maxBodyBytes(s.csrfProtection(s.handleFeedsBulkEnabled))))))
+	mux.HandleFunc("/feeds/merge", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.maxBodyBytes(s.csrfProtection(s.handleFeedsMerge))))))
+	mux.HandleFunc("/feeds/edit/", s.AddSecurityHeaders(s.maintenanceMode(s.handleEditFeed)))
+	mux.HandleFunc("/feeds/row/", s.AddSecurityHeaders(s.maintenanceMode(s.handleFeedRow)))
+	mux.HandleFunc("/articles", s.AddSecurityHeaders(s.maintenanceMode(s.handleArticles)))
+	mux.HandleFunc("/articles/", s.AddSecurityHeaders(s.maintenanceMode(s.handleArticlePath)))
+	mux.HandleFunc("/activity", s.AddSecurityHeaders(s.maintenanceMode(s.handleActivity)))
+	mux.HandleFunc("/api/v1/articles", s.AddSecurityHeaders(s.maintenanceMode(s.handleAPIArticles)))
+	mux.HandleFunc("/settings", s.AddSecurityHeaders(s.maintenanceMode(s.handleSettings)))
+	mux.HandleFunc("/sync", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleSync)))))
+	mux.HandleFunc("/setup/complete", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleSetupComplete)))))
+	mux.HandleFunc("/settings/poll-interval", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.maxBodyBytes(s.csrfProtection(s.handleUpdateDefaultPollInterval))))))
+	mux.HandleFunc("/static/", s.AddSecurityHeaders(s.handleStatic))
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/events", s.maintenanceMode(s.handleEvents))
+	mux.HandleFunc("/admin/reauth", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminReauth)))))
+	mux.HandleFunc("/admin/mark-all-processed", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminMarkAllProcessed)))))
+	mux.HandleFunc("/admin/upgrade-https", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminUpgradeHTTPS)))))
+	mux.HandleFunc("/admin/orphans", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminOrphans)))))
+	mux.HandleFunc("/admin/optimize", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminOptimize)))))
+	mux.HandleFunc("/admin/export", s.AddSecurityHeaders(s.maintenanceMode(s.handleAdminExport)))
+	mux.HandleFunc("/admin/audit", s.AddSecurityHeaders(s.maintenanceMode(s.handleAdminAudit)))
+	mux.HandleFunc("/admin/duplicates", s.AddSecurityHeaders(s.maintenanceMode(s.handleAdminDuplicates)))
+	mux.HandleFunc("/admin/feed-health", s.AddSecurityHeaders(s.maintenanceMode(s.handleAdminFeedHealth)))
+	mux.HandleFunc("/admin/jobs", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminJobs)))))
+	mux.HandleFunc("/admin/jobs/", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.csrfProtection(s.handleAdminJobs)))))
+	mux.HandleFunc("/admin/import", s.AddSecurityHeaders(s.maintenanceMode(s.readOnly(s.maxBodyBytes(s.csrfProtection(s.handleAdminImport))))))
 
 	server := &http.Server{
 		Addr:           ":" + port,
@@ -79,12 +191,62 @@ func (s *Server) Start(port string) error {
 		MaxHeaderBytes: 1 << 20, // 1 MB
 	}
 
+	s.httpServerMu.Lock()
+	s.httpServer = server
+	s.httpServerMu.Unlock()
+
 	ip := GetLocalIP()
 	logging.Info("Server starting", "ip", ip, "port", port, "url", fmt.Sprintf("http://%s:%s", ip, port))
 
 	return server.ListenAndServe()
 }
 
+// Shutdown stops accepting connections and waits for active requests to finish or ctx to end.
+// Start returns http.ErrServerClosed once Shutdown has been called.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.ready.Store(false)
+	// Event streams never finish on their own and would hold the shutdown open
+	s.stopOnce.Do(func() { close(s.stopStreams) })
+	if err := s.jobs.Shutdown(ctx); err != nil {
+		logging.Warn("Background jobs did not stop in time", "error", err)
+	}
+
+	s.httpServerMu.Lock()
+	server := s.httpServer
+	s.httpServerMu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down HTTP server: %w", err)
+	}
+
+	return nil
+}
+
+// contentSecurityPolicy returns the Content-Security-Policy value. Pages inline their scripts
+// unless ExternalScripts is set, when inline scripts are no longer allowed.
+func (h SecurityHeaders) contentSecurityPolicy() string {
+	scriptSrc := "script-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.jsdelivr.net"
+	if h.ExternalScripts {
+		scriptSrc = "script-src 'self' https://unpkg.com https://cdn.jsdelivr.net"
+	}
+
+	return "default-src 'self'; img-src 'self' https:; style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " + scriptSrc
+}
+
+// pageData returns the data every full page is rendered with
+func (s *Server) pageData(title string) views.PageData {
+	return views.PageData{
+		Title:           title,
+		CSRFToken:       s.getCSRFToken(),
+		ReadOnly:        s.config.ReadOnly,
+		ExternalScripts: s.config.Headers.ExternalScripts,
+	}
+}
+
 // AddSecurityHeaders adds security headers to HTTP responses
 func (s *Server) AddSecurityHeaders(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
@@ -93,7 +255,18 @@ func (s *Server) AddSecurityHeaders(next http.HandlerFunc) http.HandlerFunc {
 		writer.Header().Set("X-Frame-Options", "DENY")
 		writer.Header().Set("X-XSS-Protection", "1; mode=block")
 		writer.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		writer.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; script-src 'self' 'unsafe-inline' https://unpkg.com https://cdn.jsdelivr.net")
+		writer.Header().Set("Content-Security-Policy", s.config.Headers.contentSecurityPolicy())
+
+		// Optional headers for HTTPS deployments
+		if hsts := s.config.Headers.strictTransportSecurity(); hsts != "" {
+			writer.Header().Set("Strict-Transport-Security", hsts)
+		}
+		if s.config.Headers.PermissionsPolicy != "" {
+			writer.Header().Set("Permissions-Policy", s.config.Headers.PermissionsPolicy)
+		}
+		if s.config.Headers.CrossOriginOpener != "" {
+			writer.Header().Set("Cross-Origin-Opener-Policy", s.config.Headers.CrossOriginOpener)
+		}
 
 		// Call the next handler
 		next.ServeHTTP(writer, request)
@@ -101,7 +274,10 @@ func (s *Server) AddSecurityHeaders(next http.HandlerFunc) http.HandlerFunc {
 }
 
 func (s *Server) HandleIndex(writer http.ResponseWriter, request *http.Request) {
-	data := views.PageData{Title: "Wallabag RSS Tool", CSRFToken: s.getCSRFToken()}
+	data := views.IndexData{
+		PageData: s.pageData("Wallabag RSS Tool"),
+		Setup:    s.firstRunSetup(request.Context()),
+	}
 	if err := views.Index(data).Render(request.Context(), writer); err != nil {
 		http.Error(writer, "Failed to render template", http.StatusInternalServerError)
 	}
@@ -111,6 +287,60 @@ func (s *Server) handleFeeds(writer http.ResponseWriter, request *http.Request)
 	// Check if this is a request for a specific feed (has ID in path)
 	// Path will be either "/feeds/" (collection) or "/feeds/123" (specific feed)
 	if request.URL.Path != "/feeds/" && len(request.URL.Path) > len("/feeds/") {
+		if strings.HasSuffix(request.URL.Path, "/raw") {
+			s.handleFeedRaw(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/duplicate") {
+			s.handleFeedDuplicate(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/preview") {
+			s.handleFeedPreview(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/filters") {
+			s.handleFeedFilterPreview(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/schedule") {
+			s.handleFeedSchedule(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/export") {
+			s.handleFeedExport(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/snooze") {
+			s.handleFeedSnooze(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, "/retag") {
+			s.handleFeedRetag(writer, request)
+
+			return
+		}
+
+		if strings.HasSuffix(request.URL.Path, ".json") {
+			s.handleFeedJSON(writer, request)
+
+			return
+		}
+
 		// This is a request for a specific feed: /feeds/{id}
 		switch request.Method {
 		case "PUT":
@@ -134,20 +364,58 @@ func (s *Server) handleFeeds(writer http.ResponseWriter, request *http.Request)
 	}
 }
 
-// handleFeedsGet handles GET requests for feeds listing
+// Feed list paging: the page size used when per_page is absent, and the largest accepted.
+const (
+	DefaultFeedsPerPage = 50
+	maxFeedsPerPage     = 500
+)
+
+// handleFeedsGet handles GET requests for feeds listing. The list is paged and sorted by the
+// sort query parameter; HTMX "load more" requests get just the next page of rows.
 func (s *Server) handleFeedsGet(writer http.ResponseWriter, request *http.Request) {
-	feeds, err := s.store.GetFeeds(request.Context())
+	page, perPage := parseFeedPage(request)
+	sort := parseFeedSort(request)
+
+	if request.Header.Get("HX-Request") == "true" {
+		s.renderFeedPage(writer, request, page, perPage)
+
+		return
+	}
+
+	feeds, total, err := s.store.GetFeedsSorted(request.Context(), sort, perPage, (page-1)*perPage)
 	if err != nil {
-		logging.Error("Failed to get feeds", "error", fmt.Errorf("store.GetFeedsWithContext: %w", err))
+		logging.Error("Failed to get feeds", "error", fmt.Errorf("store.GetFeedsSorted: %w", err))
 		http.Error(writer, "Failed to get feeds", http.StatusInternalServerError)
 
 		return
 	}
 
+	// The merge selectors list every feed, which only needs another query when there are several pages
+	mergeOptions := feeds
+	if total > len(feeds) {
+		mergeOptions, err = s.store.GetFeeds(request.Context())
+		if err != nil {
+			logging.Error("Failed to get feeds", "error", fmt.Errorf("store.GetFeeds: %w", err))
+			http.Error(writer, "Failed to get feeds", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
 	defaultPollInterval := s.getDefaultPollIntervalWithFallback(request.Context())
 	data := views.FeedsData{
-		PageData:            views.PageData{Title: "Manage RSS Feeds", CSRFToken: s.getCSRFToken()},
-		Feeds:               feeds,
+		PageData: s.pageData("Manage RSS Feeds"),
+		FeedPage: views.FeedPageData{
+			Feeds:               feeds,
+			DefaultPollInterval: defaultPollInterval,
+			CSRFToken:           s.getCSRFToken(),
+			NextPage:            nextFeedPage(page, perPage, total),
+			PerPage:             perPage,
+			Sort:                sort,
+			LatestArticles:      s.latestArticles(request.Context()),
+			ReadOnly:            s.config.ReadOnly,
+		},
+		MergeOptions:        mergeOptions,
 		DefaultPollInterval: defaultPollInterval,
 	}
 
@@ -156,6 +424,71 @@ func (s *Server) handleFeedsGet(writer http.ResponseWriter, request *http.Reques
 	}
 }
 
+// renderFeedPage writes one page of feed rows followed by the "load more" control, if any
+func (s *Server) renderFeedPage(writer http.ResponseWriter, request *http.Request, page, perPage int) {
+	sort := parseFeedSort(request)
+	feeds, total, err := s.store.GetFeedsSorted(request.Context(), sort, perPage, (page-1)*perPage)
+	if err != nil {
+		logging.Error("Failed to get feeds", "error", fmt.Errorf("store.GetFeedsSorted: %w", err))
+		http.Error(writer, "Failed to get feeds", http.StatusInternalServerError)
+
+		return
+	}
+
+	data := views.FeedPageData{
+		Feeds:               feeds,
+		DefaultPollInterval: s.getDefaultPollIntervalWithFallback(request.Context()),
+		CSRFToken:           s.getCSRFToken(),
+		NextPage:            nextFeedPage(page, perPage, total),
+		PerPage:             perPage,
+		Sort:                sort,
+		LatestArticles:      s.latestArticles(request.Context()),
+		ReadOnly:            s.config.ReadOnly,
+	}
+	if err := views.FeedPage(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render feeds list", http.StatusInternalServerError)
+	}
+}
+
+// parseFeedPage reads the page and per_page query parameters, falling back to the first page
+// and DefaultFeedsPerPage for missing or invalid values
+func parseFeedPage(request *http.Request) (page, perPage int) {
+	page, err := strconv.Atoi(request.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err = strconv.Atoi(request.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = DefaultFeedsPerPage
+	}
+	if perPage > maxFeedsPerPage {
+		perPage = maxFeedsPerPage
+	}
+
+	return page, perPage
+}
+
+// parseFeedSort reads the sort query parameter, falling back to ID order for missing or
+// unknown values
+func parseFeedSort(request *http.Request) string {
+	sort := request.URL.Query().Get("sort")
+	if !database.IsFeedSort(sort) {
+		return database.FeedSortID
+	}
+
+	return sort
+}
+
+// nextFeedPage returns the page after page, or 0 when page is the last one
+func nextFeedPage(page, perPage, total int) int {
+	if page*perPage >= total {
+		return 0
+	}
+
+	return page + 1
+}
+
 // handleFeedsPost handles POST requests for creating new feeds
 func (s *Server) handleFeedsPost(writer http.ResponseWriter, request *http.Request) {
 	if err := request.ParseForm(); err != nil {
@@ -164,8 +497,104 @@ func (s *Server) handleFeedsPost(writer http.ResponseWriter, request *http.Reque
 		return
 	}
 
+	fetchTimeout, err := s.ParseFetchTimeout(request.FormValue("fetch_timeout_seconds"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	contentSelector, err := s.ParseContentSelector(request.FormValue("content_selector"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	deliveryMode, err := s.ParseDeliveryMode(request.FormValue("delivery_mode"), contentSelector)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	dateField, err := s.ParseDateField(request.FormValue("date_field"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	acceptHeader, err := s.ParseAcceptHeader(request.FormValue("accept_header"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	minAge, err := s.ParseMinAge(request.FormValue("min_age_minutes"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	minTitleLength, err := s.ParseMinTitleLength(request.FormValue("min_title_length"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	keepLastN, err := s.ParseKeepLastN(request.FormValue("keep_last_n"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	priority, err := s.ParsePriority(request.FormValue("priority"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	cookie, err := s.ParseFeedCookie(request.FormValue("cookie"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
 	feed := s.parseFeedFromForm(request)
+	feed.FetchTimeoutSeconds = fetchTimeout
+	feed.MinAgeMinutes = minAge
+	feed.MinTitleLength = minTitleLength
+	feed.KeepLastN = keepLastN
+	feed.Priority = priority
+	feed.StripQueryParams = request.FormValue("strip_query_params") == "on"
+	feed.AutoInterval = request.FormValue("auto_interval") == "on"
+	feed.TagWithFeedName = request.FormValue("tag_with_feed_name") == "on"
+	feed.CategoriesAsTags = request.FormValue("categories_as_tags") == "on"
+	feed.ContentSelector = contentSelector
+	feed.DeliveryMode = deliveryMode
+	feed.DateField = dateField
+	feed.AcceptHeader = acceptHeader
+	feed.IncludeCategories = models.ParseCategories(request.FormValue("include_categories"))
+	feed.Cookie = cookie
+	if err := s.applyFieldLimits(&feed); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
 	id, err := s.store.InsertFeed(request.Context(), &feed)
+	if errors.Is(err, database.ErrNoCookieKey) {
+		http.Error(writer, database.ErrNoCookieKey.Error(), http.StatusBadRequest)
+
+		return
+	}
 	if err != nil {
 		logging.Error("Failed to insert feed",
 			"error", fmt.Errorf("store.InsertFeed: %w", err),
@@ -182,9 +611,12 @@ func (s *Server) handleFeedsPost(writer http.ResponseWriter, request *http.Reque
 		"feed_name", feed.Name,
 		"feed_url", feed.URL,
 		"sync_mode", feed.SyncMode)
+	s.config.Audit.Record(models.AuditFeedAdded, feed.ID, fmt.Sprintf("%s (%s)", feed.Name, feed.URL))
 
-	// Queue the new feed for immediate processing
-	s.worker.QueueFeedForImmediate(feed.ID)
+	// Queue the new feed for immediate processing, and look up its details in the background
+	// so the row fills them in without holding up this response
+	s.worker.QueueFeedWithPriority(feed.ID, feed.Priority)
+	s.worker.QueueFeedEnrichment(feed.ID)
 
 	s.renderFeedRow(writer, request, &feed)
 }
@@ -217,13 +649,108 @@ func (s *Server) handleFeedsPut(writer http.ResponseWriter, request *http.Reques
 
 	pollInterval, pollIntervalUnit := s.ParsePollInterval(formValues.PollIntervalStr, formValues.PollIntervalUnitStr)
 
+	fetchTimeout, err := s.ParseFetchTimeout(formValues.FetchTimeoutStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contentSelector, err := s.ParseContentSelector(formValues.ContentSelectorStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deliveryMode, err := s.ParseDeliveryMode(formValues.DeliveryModeStr, contentSelector)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dateField, err := s.ParseDateField(formValues.DateFieldStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	acceptHeader, err := s.ParseAcceptHeader(formValues.AcceptHeaderStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	minAge, err := s.ParseMinAge(formValues.MinAgeStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	minTitleLength, err := s.ParseMinTitleLength(formValues.MinTitleLenStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	keepLastN, err := s.ParseKeepLastN(formValues.KeepLastNStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	priority, err := s.ParsePriority(formValues.PriorityStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cookie, err := s.ParseFeedCookie(formValues.CookieStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Create updated feed preserving sync settings from existing feed
 	feed := *existingFeed
 	feed.Name = formValues.Name
 	feed.URL = formValues.URL
 	feed.SetPollInterval(pollInterval, pollIntervalUnit)
+	if err := s.checkFeedTTL(request.Context(), &feed); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	feed.FetchTimeoutSeconds = fetchTimeout
+	feed.MinAgeMinutes = minAge
+	feed.MinTitleLength = minTitleLength
+	feed.KeepLastN = keepLastN
+	feed.Priority = priority
+	feed.StripQueryParams = formValues.StripQueryParamsStr == "on"
+	feed.AutoInterval = formValues.AutoIntervalStr == "on"
+	feed.TagWithFeedName = formValues.TagWithFeedNameStr == "on"
+	feed.CategoriesAsTags = formValues.CategoriesAsTagsStr == "on"
+	feed.ContentSelector = contentSelector
+	feed.DeliveryMode = deliveryMode
+	feed.DateField = dateField
+	feed.AcceptHeader = acceptHeader
+	feed.IncludeCategories = models.ParseCategories(formValues.IncludeCategoriesStr)
+	// The stored cookie is never rendered back, so a blank field keeps it
+	if cookie != "" {
+		feed.Cookie = cookie
+	}
+	if formValues.ClearCookieStr == "on" {
+		feed.Cookie = ""
+		feed.CookieUnreadable = false
+	}
+	if err := s.applyFieldLimits(&feed); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if err := s.store.UpdateFeed(request.Context(), &feed); err != nil {
+	err = s.store.UpdateFeed(request.Context(), &feed)
+	if errors.Is(err, database.ErrNoCookieKey) {
+		http.Error(writer, database.ErrNoCookieKey.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
 		logging.Error("Failed to update feed",
 			"error", fmt.Errorf("store.UpdateFeed: %w", err),
 			"feed_id", feed.ID,
@@ -236,10 +763,11 @@ func (s *Server) handleFeedsPut(writer http.ResponseWriter, request *http.Reques
 		"feed_id", feed.ID,
 		"feed_name", feed.Name,
 		"feed_url", feed.URL)
+	s.config.Audit.Record(models.AuditFeedUpdated, feed.ID, fmt.Sprintf("%s (%s)", feed.Name, feed.URL))
 
 	// Queue the updated feed for immediate re-sync if URL changed
 	if existingFeed.URL != feed.URL {
-		s.worker.QueueFeedForImmediate(feed.ID)
+		s.worker.QueueFeedWithPriority(feed.ID, feed.Priority)
 		logging.Info("Feed queued for re-sync due to URL change", "feed_id", feed.ID)
 	}
 
@@ -250,26 +778,151 @@ func (s *Server) handleFeedsPut(writer http.ResponseWriter, request *http.Reques
 func (s *Server) handleFeedsDelete(writer http.ResponseWriter, request *http.Request) {
 	id, err := s.ExtractFeedIDFromPath(request.URL.Path)
 	if err != nil {
-		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := s.store.DeleteFeed(request.Context(), id); err != nil {
+		logging.Error("Failed to delete feed",
+			"error", fmt.Errorf("store.DeleteFeed: %w", err),
+			"feed_id", id)
+		http.Error(writer, "Failed to delete feed", http.StatusInternalServerError)
+
+		return
+	}
+
+	logging.Info("Feed deleted successfully", "feed_id", id)
+	s.config.Audit.Record(models.AuditFeedDeleted, id, "")
+	writer.WriteHeader(http.StatusOK)
+}
+
+// handleFeedsBulkEnabled enables or disables several feeds at once and returns the refreshed feed list
+func (s *Server) handleFeedsBulkEnabled(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		http.Error(writer, "Failed to parse form", http.StatusBadRequest)
+
+		return
+	}
+
+	enabled, err := strconv.ParseBool(request.FormValue("enabled"))
+	if err != nil {
+		http.Error(writer, "Invalid enabled value", http.StatusBadRequest)
+
+		return
+	}
+
+	ids, err := s.parseBulkFeedIDs(request)
+	if err != nil {
+		http.Error(writer, "Invalid feed IDs", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := s.store.SetFeedsEnabled(request.Context(), ids, enabled); err != nil {
+		logging.Error("Failed to update feeds enabled state",
+			"error", fmt.Errorf("store.SetFeedsEnabled: %w", err),
+			"feed_count", len(ids),
+			"enabled", enabled)
+		http.Error(writer, "Failed to update feeds", http.StatusInternalServerError)
+
+		return
+	}
+
+	logging.Info("Feeds enabled state updated", "feed_count", len(ids), "enabled", enabled)
+
+	s.renderFeedPage(writer, request, 1, DefaultFeedsPerPage)
+}
+
+// handleFeedsMerge folds the source feed into the target feed, moving its articles across and
+// deleting it, then re-renders the feed list
+func (s *Server) handleFeedsMerge(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		http.Error(writer, "Failed to parse form", http.StatusBadRequest)
+
+		return
+	}
+
+	sourceID, err := strconv.Atoi(request.FormValue("source_id"))
+	if err != nil {
+		http.Error(writer, "Invalid source feed ID", http.StatusBadRequest)
+
+		return
+	}
+	targetID, err := strconv.Atoi(request.FormValue("target_id"))
+	if err != nil {
+		http.Error(writer, "Invalid target feed ID", http.StatusBadRequest)
 
 		return
 	}
+	if sourceID == targetID {
+		http.Error(writer, "Cannot merge a feed into itself", http.StatusBadRequest)
 
-	if err := s.store.DeleteFeed(request.Context(), id); err != nil {
-		logging.Error("Failed to delete feed",
-			"error", fmt.Errorf("store.DeleteFeed: %w", err),
-			"feed_id", id)
-		http.Error(writer, "Failed to delete feed", http.StatusInternalServerError)
+		return
+	}
+
+	moved, err := s.store.MergeFeeds(request.Context(), sourceID, targetID)
+	if err != nil {
+		logging.Error("Failed to merge feeds",
+			"error", fmt.Errorf("store.MergeFeeds: %w", err),
+			"source_feed_id", sourceID,
+			"target_feed_id", targetID)
+		http.Error(writer, "Failed to merge feeds", http.StatusInternalServerError)
 
 		return
 	}
 
-	logging.Info("Feed deleted successfully", "feed_id", id)
-	writer.WriteHeader(http.StatusOK)
+	logging.Info("Feeds merged", "source_feed_id", sourceID, "target_feed_id", targetID, "articles_moved", moved)
+
+	// The merge selectors sit outside the feed list, so have HTMX reload the page to drop the merged feed
+	writer.Header().Set("HX-Refresh", "true")
+
+	s.renderFeedPage(writer, request, 1, DefaultFeedsPerPage)
 }
 
 // Helper methods
 
+// parseBulkFeedIDs returns the feed IDs a bulk request applies to: every feed when "all" is set,
+// otherwise the submitted "ids" values
+func (s *Server) parseBulkFeedIDs(request *http.Request) ([]int, error) {
+	if all, _ := strconv.ParseBool(request.FormValue("all")); all {
+		feeds, err := s.store.GetFeeds(request.Context())
+		if err != nil {
+			return nil, fmt.Errorf("store.GetFeeds: %w", err)
+		}
+
+		ids := make([]int, 0, len(feeds))
+		for _, feed := range feeds {
+			ids = append(ids, feed.ID)
+		}
+
+		return ids, nil
+	}
+
+	ids := make([]int, 0, len(request.Form["ids"]))
+	for _, idStr := range request.Form["ids"] {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feed ID %q: %w", idStr, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // getDefaultPollIntervalWithFallback gets the default poll interval or returns fallback
 func (s *Server) getDefaultPollIntervalWithFallback(ctx context.Context) int {
 	defaultPollInterval, err := s.store.GetDefaultPollInterval(ctx)
@@ -312,7 +965,7 @@ func (s *Server) parseFeedFromForm(request *http.Request) models.Feed {
 
 	feed.SetPollInterval(pollInterval, pollIntervalUnit)
 
-	logging.Info("DEBUG: Feed created",
+	logging.Debug("Feed created",
 		"poll_interval", feed.PollInterval,
 		"poll_interval_unit", feed.PollIntervalUnit,
 		"sync_mode", feed.SyncMode,
@@ -323,42 +976,75 @@ func (s *Server) parseFeedFromForm(request *http.Request) models.Feed {
 }
 
 type FormValues struct {
-	Name                string
-	URL                 string
-	PollIntervalStr     string
-	PollIntervalUnitStr string
-	SyncModeStr         string
-	SyncCountStr        string
-	SyncDateFromStr     string
+	Name                 string
+	URL                  string
+	PollIntervalStr      string
+	PollIntervalUnitStr  string
+	SyncModeStr          string
+	SyncCountStr         string
+	SyncDateFromStr      string
+	FetchTimeoutStr      string
+	StripQueryParamsStr  string
+	AutoIntervalStr      string
+	ContentSelectorStr   string
+	DeliveryModeStr      string
+	DateFieldStr         string
+	TagWithFeedNameStr   string
+	CategoriesAsTagsStr  string
+	AcceptHeaderStr      string
+	IncludeCategoriesStr string
+	MinAgeStr            string
+	MinTitleLenStr       string
+	KeepLastNStr         string
+	PriorityStr          string
+	CookieStr            string
+	ClearCookieStr       string
 }
 
 func (s *Server) ExtractFormValues(request *http.Request) FormValues {
 	return FormValues{
-		Name:                request.FormValue("name"),
-		URL:                 request.FormValue("url"),
-		PollIntervalStr:     request.FormValue("poll_interval"),
-		PollIntervalUnitStr: request.FormValue("poll_interval_unit"),
-		SyncModeStr:         request.FormValue("sync_mode"),
-		SyncCountStr:        request.FormValue("sync_count"),
-		SyncDateFromStr:     request.FormValue("sync_date_from"),
+		Name:                 request.FormValue("name"),
+		URL:                  request.FormValue("url"),
+		PollIntervalStr:      request.FormValue("poll_interval"),
+		PollIntervalUnitStr:  request.FormValue("poll_interval_unit"),
+		SyncModeStr:          request.FormValue("sync_mode"),
+		SyncCountStr:         request.FormValue("sync_count"),
+		SyncDateFromStr:      request.FormValue("sync_date_from"),
+		FetchTimeoutStr:      request.FormValue("fetch_timeout_seconds"),
+		StripQueryParamsStr:  request.FormValue("strip_query_params"),
+		AutoIntervalStr:      request.FormValue("auto_interval"),
+		ContentSelectorStr:   request.FormValue("content_selector"),
+		DeliveryModeStr:      request.FormValue("delivery_mode"),
+		DateFieldStr:         request.FormValue("date_field"),
+		TagWithFeedNameStr:   request.FormValue("tag_with_feed_name"),
+		CategoriesAsTagsStr:  request.FormValue("categories_as_tags"),
+		AcceptHeaderStr:      request.FormValue("accept_header"),
+		IncludeCategoriesStr: request.FormValue("include_categories"),
+		MinAgeStr:            request.FormValue("min_age_minutes"),
+		MinTitleLenStr:       request.FormValue("min_title_length"),
+		KeepLastNStr:         request.FormValue("keep_last_n"),
+		PriorityStr:          request.FormValue("priority"),
+		CookieStr:            request.FormValue("cookie"),
+		ClearCookieStr:       request.FormValue("clear_cookie"),
 	}
 }
 
 func (s *Server) LogFormValues(fv *FormValues) {
-	logging.Info("DEBUG: Form values received",
+	logging.Debug("Form values received",
 		"name", fv.Name,
 		"url", fv.URL,
 		"poll_interval", fv.PollIntervalStr,
 		"poll_interval_unit", fv.PollIntervalUnitStr,
 		"sync_mode", fv.SyncModeStr,
 		"sync_count", fv.SyncCountStr,
-		"sync_date_from", fv.SyncDateFromStr)
+		"sync_date_from", fv.SyncDateFromStr,
+		"fetch_timeout_seconds", fv.FetchTimeoutStr)
 }
 
 func (s *Server) ParsePollInterval(pollIntervalStr, pollIntervalUnitStr string) (int, models.TimeUnit) {
 	pollInterval, err := strconv.Atoi(pollIntervalStr)
 	if err != nil {
-		logging.Info("DEBUG: Poll interval conversion failed", "value", pollIntervalStr, "error", err)
+		logging.Debug("Poll interval conversion failed", "value", pollIntervalStr, "error", err)
 		pollInterval = 0
 	}
 
@@ -370,6 +1056,188 @@ func (s *Server) ParsePollInterval(pollIntervalStr, pollIntervalUnitStr string)
 	return pollInterval, pollIntervalUnit
 }
 
+// applyFieldLimits truncates an over-long feed name and rejects an over-long feed URL
+func (s *Server) applyFieldLimits(feed *models.Feed) error {
+	if err := s.config.FieldLimits.ValidateURL(feed.URL); err != nil {
+		logging.Warn("Rejecting feed with over-long URL", "error", err, "feed_name", feed.Name)
+
+		return fmt.Errorf("feed URL is too long: %w", err)
+	}
+
+	feed.Name = s.config.FieldLimits.TruncateTitle(feed.Name)
+
+	return nil
+}
+
+// ParseFetchTimeout parses the per-feed fetch timeout in seconds. Empty means no override.
+func (s *Server) ParseFetchTimeout(fetchTimeoutStr string) (int, error) {
+	if fetchTimeoutStr == "" {
+		return 0, nil
+	}
+
+	timeout, err := strconv.Atoi(fetchTimeoutStr)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("fetch timeout must be a non-negative number of seconds")
+	}
+
+	return timeout, nil
+}
+
+// ParseMinAge parses the per-feed minimum article age in minutes. Empty means send at once.
+func (s *Server) ParseMinAge(minAgeStr string) (int, error) {
+	if minAgeStr == "" {
+		return 0, nil
+	}
+
+	minAge, err := strconv.Atoi(minAgeStr)
+	if err != nil || minAge < 0 {
+		return 0, fmt.Errorf("minimum age must be a non-negative number of minutes")
+	}
+
+	return minAge, nil
+}
+
+// ParseMinTitleLength parses the per-feed minimum title length in characters. Empty means use
+// the global minimum.
+func (s *Server) ParseMinTitleLength(minTitleLengthStr string) (int, error) {
+	if minTitleLengthStr == "" {
+		return 0, nil
+	}
+
+	minTitleLength, err := strconv.Atoi(minTitleLengthStr)
+	if err != nil || minTitleLength < 0 {
+		return 0, fmt.Errorf("minimum title length must be a non-negative number of characters")
+	}
+
+	return minTitleLength, nil
+}
+
+// ParseKeepLastN parses how many of a feed's articles to keep in the database. Empty means
+// keep them all.
+func (s *Server) ParseKeepLastN(keepLastNStr string) (int, error) {
+	if keepLastNStr == "" {
+		return 0, nil
+	}
+
+	keepLastN, err := strconv.Atoi(keepLastNStr)
+	if err != nil || keepLastN < 0 {
+		return 0, fmt.Errorf("articles to keep must be a non-negative number")
+	}
+
+	return keepLastN, nil
+}
+
+// ParsePriority parses the feed's processing priority. Empty means the default of 0; higher
+// values are processed first and negative ones after feeds at the default.
+func (s *Server) ParsePriority(priorityStr string) (int, error) {
+	if priorityStr == "" {
+		return 0, nil
+	}
+
+	priority, err := strconv.Atoi(priorityStr)
+	if err != nil {
+		return 0, fmt.Errorf("priority must be a whole number")
+	}
+
+	return priority, nil
+}
+
+// ParseContentSelector validates the per-feed content selector. Empty means no extraction.
+func (s *Server) ParseContentSelector(selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return "", nil
+	}
+
+	if err := extract.ValidateSelector(selector); err != nil {
+		return "", fmt.Errorf("content selector is not valid CSS: %w", err)
+	}
+
+	return selector, nil
+}
+
+// ParseDeliveryMode validates the per-feed delivery mode. The content modes need a content
+// selector. Empty means content_or_link when a selector is given and link otherwise, so
+// requests made before delivery modes existed keep their meaning.
+func (s *Server) ParseDeliveryMode(mode, contentSelector string) (models.DeliveryMode, error) {
+	deliveryMode := models.DeliveryMode(strings.TrimSpace(mode))
+	switch deliveryMode {
+	case "":
+		if contentSelector != "" {
+			return models.DeliveryModeContentOrLink, nil
+		}
+
+		return models.DeliveryModeLink, nil
+	case models.DeliveryModeLink:
+		return deliveryMode, nil
+	case models.DeliveryModeContent, models.DeliveryModeContentOrLink:
+		if contentSelector == "" {
+			return "", fmt.Errorf("delivery mode %s needs a content selector", deliveryMode)
+		}
+
+		return deliveryMode, nil
+	default:
+		return "", fmt.Errorf("unknown delivery mode %q", mode)
+	}
+}
+
+// ParseDateField validates the per-feed choice of item date. Empty means auto.
+func (s *Server) ParseDateField(field string) (models.DateField, error) {
+	dateField := models.DateField(strings.TrimSpace(field))
+	switch dateField {
+	case "":
+		return models.DateFieldAuto, nil
+	case models.DateFieldAuto, models.DateFieldPublished, models.DateFieldUpdated:
+		return dateField, nil
+	default:
+		return "", fmt.Errorf("unknown date field %q", field)
+	}
+}
+
+// maxAcceptHeaderLength bounds the per-feed Accept header.
+const maxAcceptHeaderLength = 256
+
+// ParseAcceptHeader validates the per-feed Accept header. Empty means rss.DefaultAccept.
+func (s *Server) ParseAcceptHeader(accept string) (string, error) {
+	accept = strings.TrimSpace(accept)
+	if accept == "" {
+		return "", nil
+	}
+
+	if len(accept) > maxAcceptHeaderLength {
+		return "", fmt.Errorf("accept header must be at most %d characters", maxAcceptHeaderLength)
+	}
+	if !httpguts.ValidHeaderFieldValue(accept) {
+		return "", fmt.Errorf("accept header contains invalid characters")
+	}
+
+	return accept, nil
+}
+
+// maxFeedCookieLength bounds the per-feed Cookie header.
+const maxFeedCookieLength = 4096
+
+// ParseFeedCookie validates the per-feed Cookie header, which must be semicolon-separated
+// name=value pairs as a browser would send them. Empty means no cookie.
+func (s *Server) ParseFeedCookie(cookie string) (string, error) {
+	cookie = strings.TrimSpace(cookie)
+	if cookie == "" {
+		return "", nil
+	}
+
+	if len(cookie) > maxFeedCookieLength {
+		return "", fmt.Errorf("cookie must be at most %d characters", maxFeedCookieLength)
+	}
+	if !httpguts.ValidHeaderFieldValue(cookie) {
+		return "", fmt.Errorf("cookie contains invalid characters")
+	}
+	if _, err := http.ParseCookie(cookie); err != nil {
+		return "", fmt.Errorf("cookie must be name=value pairs separated by semicolons: %w", err)
+	}
+
+	return cookie, nil
+}
+
 func (s *Server) ParseSyncMode(syncModeStr string) models.SyncMode {
 	if syncModeStr == "" {
 		syncModeStr = "none"
@@ -381,10 +1249,10 @@ func (s *Server) ParseSyncMode(syncModeStr string) models.SyncMode {
 func (s *Server) ParseSyncCount(syncCountStr string, syncMode models.SyncMode) *int {
 	if syncCountStr != "" && syncMode == models.SyncModeCount {
 		if count, err := strconv.Atoi(syncCountStr); err == nil && count > 0 {
-			logging.Info("DEBUG: Sync count parsed", "value", count)
+			logging.Debug("Sync count parsed", "value", count)
 			return &count
 		}
-		logging.Info("DEBUG: Sync count conversion failed", "value", syncCountStr)
+		logging.Debug("Sync count conversion failed", "value", syncCountStr)
 	}
 
 	return nil
@@ -393,10 +1261,10 @@ func (s *Server) ParseSyncCount(syncCountStr string, syncMode models.SyncMode) *
 func (s *Server) ParseSyncDateFrom(syncDateFromStr string, syncMode models.SyncMode) *time.Time {
 	if syncDateFromStr != "" && syncMode == models.SyncModeDateFrom {
 		if date, err := time.Parse("2006-01-02", syncDateFromStr); err == nil {
-			logging.Info("DEBUG: Sync date parsed", "value", date)
+			logging.Debug("Sync date parsed", "value", date)
 			return &date
 		}
-		logging.Info("DEBUG: Sync date conversion failed", "value", syncDateFromStr)
+		logging.Debug("Sync date conversion failed", "value", syncDateFromStr)
 	}
 
 	return nil
@@ -405,7 +1273,7 @@ func (s *Server) ParseSyncDateFrom(syncDateFromStr string, syncMode models.SyncM
 // renderFeedRow renders a feed row for HTMX responses
 func (s *Server) renderFeedRow(writer http.ResponseWriter, request *http.Request, feed *models.Feed) {
 	defaultPollInterval := s.getDefaultPollIntervalWithFallback(request.Context())
-	if err := views.FeedRow(*feed, defaultPollInterval, s.getCSRFToken()).Render(request.Context(), writer); err != nil {
+	if err := views.FeedRow(*feed, s.latestArticles(request.Context())[feed.ID], defaultPollInterval, s.getCSRFToken(), s.config.ReadOnly).Render(request.Context(), writer); err != nil {
 		http.Error(writer, "Failed to render feed row", http.StatusInternalServerError)
 	}
 }
@@ -448,6 +1316,175 @@ func (s *Server) handleEditFeed(writer http.ResponseWriter, request *http.Reques
 	}
 }
 
+// handleFeedDuplicate renders the add-feed form prefilled with an existing feed's settings.
+// The URL is left blank because feed URLs must be unique.
+func (s *Server) handleFeedDuplicate(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/duplicate"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+
+	data := views.FeedAddFormData{
+		Feed: models.Feed{
+			Name:                feed.Name,
+			PollInterval:        feed.PollInterval,
+			PollIntervalUnit:    feed.PollIntervalUnit,
+			SyncMode:            feed.SyncMode,
+			SyncCount:           feed.SyncCount,
+			SyncDateFrom:        feed.SyncDateFrom,
+			FetchTimeoutSeconds: feed.FetchTimeoutSeconds,
+			StripQueryParams:    feed.StripQueryParams,
+			AutoInterval:        feed.AutoInterval,
+			ContentSelector:     feed.ContentSelector,
+			DeliveryMode:        feed.DeliveryMode,
+			DateField:           feed.DateField,
+			TagWithFeedName:     feed.TagWithFeedName,
+			CategoriesAsTags:    feed.CategoriesAsTags,
+			AcceptHeader:        feed.AcceptHeader,
+			IncludeCategories:   feed.IncludeCategories,
+			MinAgeMinutes:       feed.MinAgeMinutes,
+			MinTitleLength:      feed.MinTitleLength,
+			KeepLastN:           feed.KeepLastN,
+			Priority:            feed.Priority,
+		},
+		DefaultPollInterval: s.getDefaultPollIntervalWithFallback(request.Context()),
+		CSRFToken:           s.getCSRFToken(),
+	}
+	if err := views.FeedAddForm(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render feed form", http.StatusInternalServerError)
+	}
+}
+
+// syncPreviewTitles is how many article titles a sync preview lists.
+const syncPreviewTitles = 5
+
+// handleFeedPreview fetches the feed and reports how many articles the sync options in the form
+// would send on an initial sync, with a sample of their titles, and whether the feed carries
+// full content or summaries. The feed is fetched by the worker's processor, so the preview sees
+// what a sync would. Nothing is saved or sent.
+func (s *Server) handleFeedPreview(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/preview"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	syncMode := s.ParseSyncMode(request.FormValue("sync_mode"))
+	switch syncMode {
+	case models.SyncModeNone, models.SyncModeAll, models.SyncModeCount, models.SyncModeDateFrom:
+	default:
+		http.Error(writer, "Invalid sync mode", http.StatusBadRequest)
+
+		return
+	}
+	syncCount := s.ParseSyncCount(request.FormValue("sync_count"), syncMode)
+	syncDateFrom := s.ParseSyncDateFrom(request.FormValue("sync_date_from"), syncMode)
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+
+	// Fetch as an initial sync with the proposed options, and with the feed's own headers
+	probe := *feed
+	probe.SyncMode, probe.SyncCount, probe.SyncDateFrom = syncMode, syncCount, syncDateFrom
+	probe.InitialSyncDone = false
+	result, err := s.rssProcessor.FetchFeed(request.Context(), &probe)
+	if err != nil {
+		logging.Error("Failed to fetch feed for sync preview",
+			"error", fmt.Errorf("rssProcessor.FetchFeed: %w", err),
+			"feed_id", feed.ID,
+			"feed_url", feed.URL)
+		http.Error(writer, "Failed to fetch feed", http.StatusBadGateway)
+
+		return
+	}
+
+	articles := result.Articles
+	data := views.FeedSyncPreviewData{SyncMode: syncMode, Count: len(articles), ContentKind: result.ContentKind}
+	for _, article := range articles[:min(len(articles), syncPreviewTitles)] {
+		data.Titles = append(data.Titles, article.Title)
+	}
+
+	if err := views.FeedSyncPreview(data).Render(request.Context(), writer); err != nil {
+		http.Error(writer, "Failed to render sync preview", http.StatusInternalServerError)
+	}
+}
+
+// handleFeedRaw returns the feed's unparsed body with its original content type, for debugging.
+// It is fetched by the processor the worker polls with, under the same proxy, TLS and redirect
+// settings.
+// The body comes from an arbitrary upstream, so it is sandboxed and sent as a download to keep
+// any HTML or script in it from running on this origin.
+func (s *Server) handleFeedRaw(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/raw"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+
+	body, contentType, err := s.rssProcessor.FetchRaw(request.Context(), feed)
+	if err != nil {
+		logging.Error("Failed to fetch raw feed",
+			"error", fmt.Errorf("rssProcessor.FetchRaw: %w", err),
+			"feed_id", feed.ID,
+			"feed_url", feed.URL)
+		http.Error(writer, "Failed to fetch feed", http.StatusBadGateway)
+
+		return
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Security-Policy", "sandbox")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="feed-%d.raw"`, feed.ID))
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write(body); err != nil {
+		logging.Error("Failed to write raw feed response", "error", err)
+	}
+}
+
 func (s *Server) handleFeedRow(writer http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodGet {
 		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
@@ -476,22 +1513,19 @@ func (s *Server) handleFeedRow(writer http.ResponseWriter, request *http.Request
 		defaultPollInterval = 60 // fallback to 60 minutes
 	}
 
-	if err := views.FeedRow(*feed, defaultPollInterval, s.getCSRFToken()).Render(request.Context(), writer); err != nil {
+	if err := views.FeedRow(*feed, s.latestArticles(request.Context())[feed.ID], defaultPollInterval, s.getCSRFToken(), s.config.ReadOnly).Render(request.Context(), writer); err != nil {
 		http.Error(writer, "Failed to render feed row", http.StatusInternalServerError)
 	}
 }
 
 func (s *Server) handleArticles(writer http.ResponseWriter, request *http.Request) {
-	articles, err := s.store.GetArticles(request.Context())
+	data, err := s.articlesPageData(request.Context(), request.URL.Query().Get("filter") == "unsent")
 	if err != nil {
+		logging.Error("Failed to load articles page", "error", err)
 		http.Error(writer, "Failed to get articles", http.StatusInternalServerError)
 
 		return
 	}
-	data := views.ArticlesData{
-		PageData: views.PageData{Title: "Processed Articles", CSRFToken: s.getCSRFToken()},
-		Articles: articles,
-	}
 	if err := views.Articles(data).Render(request.Context(), writer); err != nil {
 		http.Error(writer, "Failed to render articles", http.StatusInternalServerError)
 	}
@@ -512,9 +1546,10 @@ func (s *Server) handleSettings(writer http.ResponseWriter, request *http.Reques
 	}
 
 	data := views.SettingsData{
-		PageData:             views.PageData{Title: "Settings", CSRFToken: s.getCSRFToken()},
+		PageData:             s.pageData("Settings"),
 		WallabagConfigLoaded: wallabagConfigLoaded,
 		DefaultPollInterval:  defaultPollInterval,
+		Issues:               s.configIssues(request.Context()),
 	}
 	if err := views.Settings(data).Render(request.Context(), writer); err != nil {
 		http.Error(writer, "Failed to render settings", http.StatusInternalServerError)
@@ -544,6 +1579,125 @@ func (s *Server) handleSync(writer http.ResponseWriter, request *http.Request) {
 	}
 }
 
+// handleAdminReauth forces the Wallabag client to fetch a fresh access token
+func (s *Server) handleAdminReauth(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	logging.Info("Wallabag re-authentication triggered by admin")
+
+	if err := s.wallabagClient.Authenticate(request.Context()); err != nil {
+		logging.Error("Wallabag re-authentication failed", "error", fmt.Errorf("wallabagClient.Authenticate: %w", err))
+		http.Error(writer, "Wallabag re-authentication failed", http.StatusBadGateway)
+
+		return
+	}
+
+	logging.Info("Wallabag re-authentication succeeded")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte("Re-authenticated with Wallabag.")); err != nil {
+		logging.Error("Failed to write reauth response", "error", err)
+	}
+}
+
+// handleAdminMarkAllProcessed starts a job recording the current items of every enabled feed as
+// processed without sending them, so only items published from now on reach Wallabag
+func (s *Server) handleAdminMarkAllProcessed(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	logging.Info("Marking all feed items processed, triggered by admin")
+
+	s.startJob(writer, request, "Mark all processed", func(ctx context.Context, progress func(done, total int)) (string, error) {
+		totals, err := s.worker.MarkAllFeedsProcessed(ctx, progress)
+		if err != nil && ctx.Err() == nil {
+			logging.Error("Failed to mark feed items processed", "error", fmt.Errorf("worker.MarkAllFeedsProcessed: %w", err))
+
+			return "", errors.New("failed to mark feed items processed")
+		}
+
+		message := fmt.Sprintf("Marked %d items processed across %d feeds (%d already tracked).",
+			totals.Marked, totals.Feeds, totals.AlreadyTracked)
+		if totals.FailedFeeds > 0 {
+			message += fmt.Sprintf(" %d feeds could not be fetched.", totals.FailedFeeds)
+		}
+
+		return message, err
+	})
+}
+
+// handleAdminUpgradeHTTPS starts a job moving every http feed that is also served over https to
+// its https URL, reporting one per line which feeds were upgraded and which were left unchanged
+func (s *Server) handleAdminUpgradeHTTPS(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	logging.Info("Upgrading http feeds to https, triggered by admin")
+
+	s.startJob(writer, request, "Upgrade to HTTPS", func(ctx context.Context, progress func(done, total int)) (string, error) {
+		result, err := s.worker.UpgradeFeedsToHTTPS(ctx, progress)
+		if err != nil && ctx.Err() == nil {
+			logging.Error("Failed to upgrade feeds to https", "error", fmt.Errorf("worker.UpgradeFeedsToHTTPS: %w", err))
+
+			return "", errors.New("failed to upgrade feeds to https")
+		}
+
+		// Feeds upgraded before a cancel stay upgraded, so they are recorded either way
+		lines := []string{fmt.Sprintf("Upgraded %d of %d http feeds to https.",
+			len(result.Upgraded), len(result.Upgraded)+len(result.Failed))}
+		for _, upgrade := range result.Upgraded {
+			s.config.Audit.Record(models.AuditFeedUpdated, upgrade.FeedID,
+				fmt.Sprintf("Moved %q from %s to %s", upgrade.Name, upgrade.From, upgrade.To))
+			lines = append(lines, fmt.Sprintf("Upgraded %s: %s", upgrade.Name, upgrade.To))
+		}
+		for _, failure := range result.Failed {
+			lines = append(lines, fmt.Sprintf("Not upgraded %s (%s): %s", failure.Name, failure.URL, failure.Reason))
+		}
+
+		return strings.Join(lines, "\n"), err
+	})
+}
+
+// handleReadyz reports readiness: startup must have completed and the database must answer a ping.
+func (s *Server) handleReadyz(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !s.ready.Load() {
+		http.Error(writer, "not ready: startup in progress", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 2*time.Second)
+	defer cancel()
+
+	if err := s.store.Ping(ctx); err != nil {
+		logging.Warn("Readiness check failed", "error", err)
+		http.Error(writer, "not ready: database unavailable", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write([]byte("ready")); err != nil {
+		logging.Error("Failed to write readiness response", "error", err)
+	}
+}
+
 func (s *Server) handleUpdateDefaultPollInterval(writer http.ResponseWriter, request *http.Request) {
 	if request.Method != "PUT" {
 		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
@@ -1,16 +1,30 @@
 package server
 
 import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
 	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/jobs"
+	"wallabag-rss-tool/pkg/logging"
 	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
 	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
 	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
 	"wallabag-rss-tool/pkg/worker"
@@ -33,6 +47,36 @@ func TestNewServer(t *testing.T) {
 	})
 }
 
+func TestNewServerWithConfig_Processor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	t.Run("Injected processor fetches for the server's views", func(t *testing.T) {
+		feed := &models.Feed{ID: 7, URL: "https://example.com/feed"}
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockProcessor.EXPECT().FetchRaw(gomock.Any(), feed).Return([]byte("<rss/>"), "application/rss+xml", nil)
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(feed, nil)
+		srv := NewServerWithConfig(mockStore, mockClient, nil, Config{Processor: mockProcessor})
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/raw", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		srv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "<rss/>", rr.Body.String())
+	})
+
+	t.Run("Nil processor falls back to a default one", func(t *testing.T) {
+		srv := NewServerWithConfig(mockStore, mockClient, nil, Config{})
+
+		assert.IsType(t, &rss.Processor{}, srv.rssProcessor)
+	})
+}
+
 // Create a helper function to set up test dependencies
 func setupTestServer(t *testing.T) (*mocks.MockStorer, *wallabagmocks.MockClienter, *worker.Worker) {
 	t.Helper()
@@ -42,6 +86,8 @@ func setupTestServer(t *testing.T) (*mocks.MockStorer, *wallabagmocks.MockClient
 	mockStore := mocks.NewMockStorer(ctrl)
 	mockClient := wallabagmocks.NewMockClienter(ctrl)
 	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	// Feed lists preview each feed's latest articles; TestServer_feedListPreviews checks them on its own store
+	mockStore.EXPECT().GetLatestArticlesForFeeds(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	
 	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
 	
@@ -809,11 +855,61 @@ func TestServer_logFormValues(t *testing.T) {
 	})
 }
 
+func TestServer_formValueLoggingLevel(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	srv := NewServer(mockStore, mockClient, w)
+
+	originalLogger := logging.GetGlobalLogger()
+	defer logging.SetGlobalLogger(originalLogger)
+
+	captureLogs := func(level slog.Level) string {
+		var output strings.Builder
+		handler := slog.NewTextHandler(&output, &slog.HandlerOptions{Level: level})
+		logging.SetGlobalLogger(logging.NewSlogLogger(slog.New(handler)))
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds", nil)
+		req.Form = map[string][]string{
+			"name":           {"Secret Feed"},
+			"url":            {"https://example.com/feed.xml"},
+			"sync_mode":      {"count"},
+			"sync_count":     {"5"},
+			"sync_date_from": {"2024-01-15"},
+		}
+		srv.parseFeedFromForm(req)
+		srv.ParseSyncDateFrom("2024-01-15", models.SyncModeDateFrom)
+
+		return output.String()
+	}
+
+	t.Run("Form values are not logged at info level", func(t *testing.T) {
+		output := captureLogs(slog.LevelInfo)
+
+		assert.NotContains(t, output, "Form values received")
+		assert.NotContains(t, output, "Sync count parsed")
+		assert.NotContains(t, output, "Sync date parsed")
+		assert.NotContains(t, output, "Feed created")
+		assert.NotContains(t, output, "Secret Feed")
+	})
+
+	t.Run("Form values are logged at debug level", func(t *testing.T) {
+		output := captureLogs(slog.LevelDebug)
+
+		assert.Contains(t, output, "level=DEBUG msg=\"Form values received\"")
+		assert.Contains(t, output, "Secret Feed")
+		assert.Contains(t, output, "Sync count parsed")
+		assert.Contains(t, output, "Sync date parsed")
+		assert.Contains(t, output, "Feed created")
+		assert.NotContains(t, output, "DEBUG:")
+	})
+}
+
 func TestServer_handleIndex(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	srv := NewServer(mockStore, mockClient, w)
 	
 	t.Run("Handle index request", func(t *testing.T) {
+		mockStore.EXPECT().IsSetupComplete(gomock.Any()).Return(true, nil)
+
 		// Create a test request
 		req := httptest.NewRequest("GET", "/", http.NoBody)
 		rr := httptest.NewRecorder()
@@ -830,6 +926,8 @@ func TestServer_handleIndex(t *testing.T) {
 		
 		// Should contain the title text
 		assert.Contains(t, body, "Wallabag RSS Tool")
+		// Recent activity loads separately
+		assert.Contains(t, body, `hx-get="/activity"`)
 	})
 }
 
@@ -865,6 +963,42 @@ func TestServer_addSecurityHeaders(t *testing.T) {
 		assert.Equal(t, "1; mode=block", headers.Get("X-XSS-Protection"))
 		assert.Equal(t, "strict-origin-when-cross-origin", headers.Get("Referrer-Policy"))
 		assert.Contains(t, headers.Get("Content-Security-Policy"), "default-src 'self'")
+		assert.Empty(t, headers.Get("Strict-Transport-Security"), "HSTS is off by default")
+		assert.Empty(t, headers.Get("Permissions-Policy"))
+		assert.Empty(t, headers.Get("Cross-Origin-Opener-Policy"))
+	})
+
+	t.Run("Optional headers when configured", func(t *testing.T) {
+		configured := NewServerWithConfig(mockStore, mockClient, w, Config{Headers: SecurityHeaders{
+			HSTSMaxAge:            31536000,
+			HSTSIncludeSubdomains: true,
+			PermissionsPolicy:     "camera=(), microphone=()",
+			CrossOriginOpener:     "same-origin",
+		}})
+
+		rr := httptest.NewRecorder()
+		configured.AddSecurityHeaders(func(w http.ResponseWriter, _ *http.Request) {}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+		headers := rr.Header()
+		assert.Equal(t, "max-age=31536000; includeSubDomains", headers.Get("Strict-Transport-Security"))
+		assert.Equal(t, "camera=(), microphone=()", headers.Get("Permissions-Policy"))
+		assert.Equal(t, "same-origin", headers.Get("Cross-Origin-Opener-Policy"))
+
+		// The default headers are unchanged
+		assert.Equal(t, "nosniff", headers.Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", headers.Get("X-Frame-Options"))
+		assert.Equal(t, "1; mode=block", headers.Get("X-XSS-Protection"))
+		assert.Equal(t, "strict-origin-when-cross-origin", headers.Get("Referrer-Policy"))
+		assert.Contains(t, headers.Get("Content-Security-Policy"), "default-src 'self'")
+	})
+
+	t.Run("HSTS without subdomains", func(t *testing.T) {
+		configured := NewServerWithConfig(mockStore, mockClient, w, Config{Headers: SecurityHeaders{HSTSMaxAge: 600}})
+
+		rr := httptest.NewRecorder()
+		configured.AddSecurityHeaders(func(w http.ResponseWriter, _ *http.Request) {}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+		assert.Equal(t, "max-age=600", rr.Header().Get("Strict-Transport-Security"))
 	})
 }
 
@@ -886,8 +1020,8 @@ func TestServer_handleFeeds(t *testing.T) {
 	})
 	
 	t.Run("Handle GET method routes to handleFeedsGet", func(t *testing.T) {
-		// Mock the store to expect GetFeeds call
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil).Times(1)
+		// Mock the store to expect GetFeedsSorted call
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return([]models.Feed{}, 0, nil).Times(1)
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
 		
 		req := httptest.NewRequest("GET", "/feeds", http.NoBody)
@@ -912,7 +1046,7 @@ func TestServer_handleFeedsGet(t *testing.T) {
 			{ID: 2, Name: "Test Feed 2", URL: "https://example.com/feed2.xml"},
 		}
 		
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(testFeeds, nil).Times(1)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(testFeeds, 2, nil).Times(1)
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
 		
 		req := httptest.NewRequest("GET", "/feeds", http.NoBody)
@@ -931,9 +1065,58 @@ func TestServer_handleFeedsGet(t *testing.T) {
 		assert.Contains(t, body, "Manage RSS Feeds")
 	})
 	
+	t.Run("Handle feeds GET with sort", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			query    string
+			wantSort string
+		}{
+			{name: "Known sort is passed to the store", query: "?sort=last_fetched", wantSort: database.FeedSortLastFetched},
+			{name: "Unknown sort falls back to ID", query: "?sort=url%20DESC", wantSort: database.FeedSortID},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				feeds := []models.Feed{{ID: 1, Name: "Test Feed 1", URL: "https://example.com/feed1.xml"}}
+				mockStore.EXPECT().GetFeedsSorted(gomock.Any(), tt.wantSort, 1, 0).Return(feeds, 2, nil).Times(1)
+				mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil).Times(1)
+				mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+				req := httptest.NewRequest("GET", "/feeds/"+tt.query+"&per_page=1", http.NoBody)
+				rr := httptest.NewRecorder()
+
+				serv.handleFeedsGet(rr, req)
+
+				assert.Equal(t, http.StatusOK, rr.Code)
+				body := rr.Body.String()
+				assert.Contains(t, body, `aria-current="true" href="/feeds/?sort=`+tt.wantSort+`"`)
+				assert.Contains(t, body, "&amp;sort="+tt.wantSort, "load more keeps the sort")
+			})
+		}
+	})
+
+	t.Run("Handle feeds GET shows favicons", func(t *testing.T) {
+		feeds := []models.Feed{
+			{ID: 1, Name: "With Icon", URL: "https://example.com/feed1.xml", FaviconURL: "https://example.com/icon.png"},
+			{ID: 2, Name: "Without Icon", URL: "https://example.org/feed2.xml"},
+		}
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(feeds, 2, nil).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("GET", "/feeds/", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsGet(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, `<img src="https://example.com/icon.png"`)
+		assert.Equal(t, 1, strings.Count(body, "<img "), "feeds without a favicon show no image")
+	})
+
 	t.Run("Handle feeds GET with database error", func(t *testing.T) {
 		// Mock database error
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, assert.AnError).Times(1)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(nil, 0, assert.AnError).Times(1)
 		
 		req := httptest.NewRequest("GET", "/feeds", http.NoBody)
 		rr := httptest.NewRecorder()
@@ -951,7 +1134,7 @@ func TestServer_handleFeedsGet(t *testing.T) {
 			{ID: 1, Name: "Test Feed", URL: "https://example.com/feed.xml"},
 		}
 		
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(testFeeds, nil).Times(1)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(testFeeds, 1, nil).Times(1)
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError).Times(1)
 		
 		req := httptest.NewRequest("GET", "/feeds", http.NoBody)
@@ -968,6 +1151,75 @@ func TestServer_handleFeedsGet(t *testing.T) {
 	})
 }
 
+func TestServer_handleFeedsGet_Pagination(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("First page links to the next and lists every feed for merging", func(t *testing.T) {
+		page := []models.Feed{{ID: 1, Name: "Feed 1"}, {ID: 2, Name: "Feed 2"}}
+		all := append(page, models.Feed{ID: 3, Name: "Feed 3"})
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, 2, 0).Return(page, 3, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(all, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/?per_page=2", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsGet(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "Manage RSS Feeds")
+		assert.Contains(t, body, "/feeds/?page=2&amp;per_page=2")
+		assert.Contains(t, body, `id="feed-2"`)
+		assert.NotContains(t, body, `id="feed-3"`)
+		assert.Contains(t, body, "Feed 3 (", "the merge selector should offer feeds beyond the first page")
+	})
+
+	t.Run("HTMX load more returns only the requested rows", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, 2, 2).Return([]models.Feed{{ID: 3, Name: "Feed 3"}}, 3, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/?page=2&per_page=2", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsGet(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, `id="feed-3"`)
+		assert.NotContains(t, body, "Manage RSS Feeds")
+		assert.NotContains(t, body, "feeds-load-more", "the last page should not offer more")
+	})
+
+	t.Run("Invalid paging values fall back to defaults", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return([]models.Feed{}, 0, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/?page=-1&per_page=abc", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsGet(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Page size is capped", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, maxFeedsPerPage, 0).Return([]models.Feed{}, 0, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/?per_page=100000", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsGet(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
 func TestServer_handleFeedsPost(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
@@ -1048,672 +1300,2064 @@ func TestServer_handleFeedsPost(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		assert.Contains(t, rr.Body.String(), "Failed to add feed")
 	})
-}
 
-func TestServer_handleFeedsPut(t *testing.T) {
-	mockStore, mockClient, w := setupTestServer(t)
-	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Handle feeds PUT success", func(t *testing.T) {
-		// Mock existing feed
-		existingFeed := &models.Feed{
-			ID:              42,
-			Name:            "Old Name",
-			URL:             "https://example.com/old.xml",
-			LastFetched:     &time.Time{},
-			InitialSyncDone: true,
-		}
-		
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(existingFeed, nil).Times(1)
-		
-		// Mock successful update
-		mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx interface{}, feed *models.Feed) error {
-				// Verify the updated feed data
-				assert.Equal(t, 42, feed.ID)
-				assert.Equal(t, "Updated Feed Name", feed.Name)
-				assert.Equal(t, "https://example.com/updated.xml", feed.URL)
-				assert.Equal(t, 3, feed.PollInterval)
-				assert.Equal(t, models.TimeUnitDays, feed.PollIntervalUnit)
-				assert.Equal(t, existingFeed.SyncMode, feed.SyncMode) // Should preserve existing sync mode
-				// Should preserve existing fields
-				assert.Equal(t, existingFeed.LastFetched, feed.LastFetched)
-				assert.Equal(t, existingFeed.InitialSyncDone, feed.InitialSyncDone)
-				return nil
+	t.Run("Handle feeds POST with fetch timeout override", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, 45, feed.FetchTimeoutSeconds)
+				return 124, nil
 			},
 		).Times(1)
-		
-		// Mock for renderFeedRow
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
-		
-		// Create form data - only fields available in edit form
-		formData := make(map[string][]string)
-		formData["name"] = []string{"Updated Feed Name"}
-		formData["url"] = []string{"https://example.com/updated.xml"}
-		formData["poll_interval"] = []string{"3"}
-		formData["poll_interval_unit"] = []string{"days"}
-		
-		req := httptest.NewRequest("PUT", "/feeds/42", http.NoBody)
-		req.Form = formData
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":                  {"Slow Feed"},
+			"url":                   {"https://example.com/slow.xml"},
+			"fetch_timeout_seconds": {"45"},
+		}
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsPut(rr, req)
-		
-		// Should be successful
+
+		serv.handleFeedsPost(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		
-		// Response should contain HTML content (feed row)
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
+		assert.Contains(t, rr.Body.String(), "Fetch Timeout: 45s")
 	})
-	
-	t.Run("Handle feeds PUT with invalid ID", func(t *testing.T) {
-		req := httptest.NewRequest("PUT", "/feeds/invalid", http.NoBody)
+
+	t.Run("Handle feeds POST with strip query params", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.True(t, feed.StripQueryParams)
+				return 125, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":               {"Tracked Feed"},
+			"url":                {"https://example.com/tracked.xml"},
+			"strip_query_params": {"on"},
+		}
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsPut(rr, req)
-		
-		// Should return bad request
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Invalid feed ID")
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
 	})
-	
-	t.Run("Handle feeds PUT with non-existent feed", func(t *testing.T) {
-		// Mock feed not found
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError).Times(1)
-		
-		req := httptest.NewRequest("PUT", "/feeds/999", http.NoBody)
+
+	t.Run("Handle feeds POST with auto interval", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.True(t, feed.AutoInterval)
+				return 126, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":          {"Busy Feed"},
+			"url":           {"https://example.com/busy.xml"},
+			"auto_interval": {"on"},
+		}
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsPut(rr, req)
-		
-		// Should return not found
-		assert.Equal(t, http.StatusNotFound, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Feed not found")
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
 	})
-	
-	t.Run("Handle feeds PUT with update error", func(t *testing.T) {
-		// Mock existing feed
-		existingFeed := &models.Feed{
-			ID:   123,
-			Name: "Test Feed",
+
+	t.Run("Handle feeds POST with feed name tag", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.True(t, feed.TagWithFeedName)
+				return 128, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":               {"Tagged Feed"},
+			"url":                {"https://example.com/tagged.xml"},
+			"tag_with_feed_name": {"on"},
 		}
-		
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 123).Return(existingFeed, nil).Times(1)
-		mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
-		
-		// Create form data
-		formData := make(map[string][]string)
-		formData["name"] = []string{"Updated Name"}
-		formData["url"] = []string{"https://example.com/feed.xml"}
-		
-		req := httptest.NewRequest("PUT", "/feeds/123", http.NoBody)
-		req.Form = formData
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsPut(rr, req)
-		
-		// Should return internal server error
-		assert.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Failed to update feed")
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
 	})
-}
 
-func TestServer_handleFeedsDelete(t *testing.T) {
-	mockStore, mockClient, w := setupTestServer(t)
+	t.Run("Handle feeds POST with categories as tags", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.True(t, feed.CategoriesAsTags)
+				return 129, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":               {"Categorized Feed"},
+			"url":                {"https://example.com/categorized.xml"},
+			"categories_as_tags": {"on"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Handle feeds POST with content selector", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, "article .post-body", feed.ContentSelector)
+				assert.Equal(t, models.DeliveryModeContentOrLink, feed.DeliveryMode)
+				return 127, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":             {"Summary Feed"},
+			"url":              {"https://example.com/summary.xml"},
+			"content_selector": {" article .post-body "},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Content Selector:")
+	})
+
+	t.Run("Handle feeds POST with invalid content selector", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":             {"Summary Feed"},
+			"url":              {"https://example.com/summary.xml"},
+			"content_selector": {"div[class="},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "content selector is not valid CSS")
+	})
+
+	t.Run("Handle feeds POST with delivery mode", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, models.DeliveryModeContent, feed.DeliveryMode)
+				return 130, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":             {"Content Feed"},
+			"url":              {"https://example.com/content.xml"},
+			"content_selector": {"article"},
+			"delivery_mode":    {"content"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Delivery: content")
+	})
+
+	t.Run("Handle feeds POST with content delivery and no selector", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":          {"Content Feed"},
+			"url":           {"https://example.com/content.xml"},
+			"delivery_mode": {"content"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "delivery mode content needs a content selector")
+	})
+
+	t.Run("Handle feeds POST with date field", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, models.DateFieldPublished, feed.DateField)
+				return 131, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":       {"Atom Feed"},
+			"url":        {"https://example.com/atom.xml"},
+			"date_field": {"published"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Dated by: published")
+	})
+
+	t.Run("Handle feeds POST with unknown date field", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":       {"Atom Feed"},
+			"url":        {"https://example.com/atom.xml"},
+			"date_field": {"modified"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `unknown date field "modified"`)
+	})
+
+	t.Run("Handle feeds POST with unknown delivery mode", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":          {"Content Feed"},
+			"url":           {"https://example.com/content.xml"},
+			"delivery_mode": {"scrape"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "unknown delivery mode")
+	})
+
+	t.Run("Handle feeds POST with accept header", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, "application/atom+xml", feed.AcceptHeader)
+				return 129, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":          {"Full Feed"},
+			"url":           {"https://example.com/full.xml"},
+			"accept_header": {" application/atom+xml "},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Accept:")
+	})
+
+	t.Run("Handle feeds POST with include categories", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, []string{"Go", "Databases"}, feed.IncludeCategories)
+				return 130, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":               {"Tech Feed"},
+			"url":                {"https://example.com/tech.xml"},
+			"include_categories": {" Go, Databases ,"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Categories: Go, Databases")
+	})
+
+	t.Run("Handle feeds POST with minimum age", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, 20, feed.MinAgeMinutes)
+				return 131, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":            {"Edited Feed"},
+			"url":             {"https://example.com/edited.xml"},
+			"min_age_minutes": {"20"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Minimum Age: 20 minutes")
+	})
+
+	t.Run("Handle feeds POST with negative minimum age", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":            {"Edited Feed"},
+			"url":             {"https://example.com/edited.xml"},
+			"min_age_minutes": {"-5"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "minimum age must be a non-negative number of minutes")
+	})
+
+	t.Run("Handle feeds POST with minimum title length", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, 3, feed.MinTitleLength)
+				return 131, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":             {"Edited Feed"},
+			"url":              {"https://example.com/edited.xml"},
+			"min_title_length": {"3"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Minimum Title: 3 characters")
+	})
+
+	t.Run("Handle feeds POST with negative minimum title length", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":             {"Edited Feed"},
+			"url":              {"https://example.com/edited.xml"},
+			"min_title_length": {"-1"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "minimum title length must be a non-negative number of characters")
+	})
+
+	t.Run("Handle feeds POST with articles to keep", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, 200, feed.KeepLastN)
+				return 132, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":        {"Busy Feed"},
+			"url":         {"https://example.com/busy.xml"},
+			"keep_last_n": {"200"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Keeps Last: 200 articles")
+	})
+
+	t.Run("Handle feeds POST with negative articles to keep", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":        {"Busy Feed"},
+			"url":         {"https://example.com/busy.xml"},
+			"keep_last_n": {"-1"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "articles to keep must be a non-negative number")
+	})
+
+	t.Run("Handle feeds POST with priority", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, -2, feed.Priority)
+				return 133, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":     {"Minor Feed"},
+			"url":      {"https://example.com/minor.xml"},
+			"priority": {"-2"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Priority: -2")
+	})
+
+	t.Run("Handle feeds POST with invalid priority", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":     {"Minor Feed"},
+			"url":      {"https://example.com/minor.xml"},
+			"priority": {"high"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "priority must be a whole number")
+	})
+
+	t.Run("Handle feeds POST with invalid accept header", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":          {"Full Feed"},
+			"url":           {"https://example.com/full.xml"},
+			"accept_header": {"application/atom+xml\r\nX-Injected: yes"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "accept header contains invalid characters")
+	})
+
+	t.Run("Handle feeds POST with login cookie", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, "session=abc123; remember=1", feed.Cookie)
+				return 132, nil
+			},
+		).Times(1)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":   {"Members Feed"},
+			"url":    {"https://example.com/members.xml"},
+			"cookie": {" session=abc123; remember=1 "},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Login cookie set")
+		assert.NotContains(t, rr.Body.String(), "abc123")
+	})
+
+	t.Run("Handle feeds POST with malformed login cookie", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":   {"Members Feed"},
+			"url":    {"https://example.com/members.xml"},
+			"cookie": {"just-a-token"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "cookie must be name=value pairs separated by semicolons")
+	})
+
+	t.Run("Handle feeds POST with login cookie but no cookie key", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).
+			Return(int64(0), fmt.Errorf("wrapped: %w", database.ErrNoCookieKey)).Times(1)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":   {"Members Feed"},
+			"url":    {"https://example.com/members.xml"},
+			"cookie": {"session=abc123"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "FEED_COOKIE_KEY must be set")
+	})
+
+	t.Run("Handle feeds POST with negative fetch timeout", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name":                  {"Slow Feed"},
+			"url":                   {"https://example.com/slow.xml"},
+			"fetch_timeout_seconds": {"-5"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "non-negative")
+	})
+}
+
+func TestServer_handleFeedsPost_FieldLimits(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServerWithConfig(mockStore, mockClient, w, Config{
+		FieldLimits: models.FieldLimits{MaxTitleLength: 8, MaxURLLength: 40},
+	})
+
+	t.Run("Over-long URL is rejected", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name": {"Feed"},
+			"url":  {"https://example.com/" + strings.Repeat("x", 40)},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Over-long name is truncated", func(t *testing.T) {
+		mockStore.EXPECT().InsertFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) (int64, error) {
+				assert.Equal(t, "A long f", feed.Name)
+				return 1, nil
+			},
+		)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("POST", "/feeds", http.NoBody)
+		req.Form = map[string][]string{
+			"name": {"A long feed name"},
+			"url":  {"https://example.com/feed.xml"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPost(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestServer_handleFeedsPut(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle feeds PUT success", func(t *testing.T) {
+		// Mock existing feed
+		existingFeed := &models.Feed{
+			ID:              42,
+			Name:            "Old Name",
+			URL:             "https://example.com/old.xml",
+			LastFetched:     &time.Time{},
+			InitialSyncDone: true,
+		}
+		
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(existingFeed, nil).Times(1)
+		
+		// Mock successful update
+		mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx interface{}, feed *models.Feed) error {
+				// Verify the updated feed data
+				assert.Equal(t, 42, feed.ID)
+				assert.Equal(t, "Updated Feed Name", feed.Name)
+				assert.Equal(t, "https://example.com/updated.xml", feed.URL)
+				assert.Equal(t, 3, feed.PollInterval)
+				assert.Equal(t, models.TimeUnitDays, feed.PollIntervalUnit)
+				assert.Equal(t, existingFeed.SyncMode, feed.SyncMode) // Should preserve existing sync mode
+				// Should preserve existing fields
+				assert.Equal(t, existingFeed.LastFetched, feed.LastFetched)
+				assert.Equal(t, existingFeed.InitialSyncDone, feed.InitialSyncDone)
+				return nil
+			},
+		).Times(1)
+		
+		// Mock for renderFeedRow
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+		
+		// Create form data - only fields available in edit form
+		formData := make(map[string][]string)
+		formData["name"] = []string{"Updated Feed Name"}
+		formData["url"] = []string{"https://example.com/updated.xml"}
+		formData["poll_interval"] = []string{"3"}
+		formData["poll_interval_unit"] = []string{"days"}
+		
+		req := httptest.NewRequest("PUT", "/feeds/42", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsPut(rr, req)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		
+		// Response should contain HTML content (feed row)
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+	})
+	
+	t.Run("Handle feeds PUT keeps or clears the login cookie", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			form           map[string][]string
+			existing       models.Feed
+			wantCookie     string
+			wantUnreadable bool
+		}{
+			{name: "Blank keeps", form: map[string][]string{}, existing: models.Feed{Cookie: "session=old"}, wantCookie: "session=old"},
+			{name: "New value replaces", form: map[string][]string{"cookie": {"session=new"}}, existing: models.Feed{Cookie: "session=old"}, wantCookie: "session=new"},
+			{name: "Clear removes", form: map[string][]string{"clear_cookie": {"on"}}, existing: models.Feed{Cookie: "session=old"}, wantCookie: ""},
+			{name: "Blank keeps an unreadable cookie", form: map[string][]string{}, existing: models.Feed{CookieUnreadable: true}, wantCookie: "", wantUnreadable: true},
+			{name: "Clear removes an unreadable cookie", form: map[string][]string{"clear_cookie": {"on"}}, existing: models.Feed{CookieUnreadable: true}, wantCookie: ""},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				existingFeed := &tt.existing
+				existingFeed.ID, existingFeed.Name, existingFeed.URL = 43, "Members", "https://example.com/members.xml"
+				mockStore.EXPECT().GetFeedByID(gomock.Any(), 43).Return(existingFeed, nil).Times(1)
+				mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx interface{}, feed *models.Feed) error {
+						assert.Equal(t, tt.wantCookie, feed.Cookie)
+						assert.Equal(t, tt.wantUnreadable, feed.CookieUnreadable)
+						return nil
+					},
+				).Times(1)
+				mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+
+				tt.form["name"] = []string{"Members"}
+				tt.form["url"] = []string{"https://example.com/members.xml"}
+				req := httptest.NewRequest("PUT", "/feeds/43", http.NoBody)
+				req.Form = tt.form
+				rr := httptest.NewRecorder()
+
+				serv.handleFeedsPut(rr, req)
+
+				assert.Equal(t, http.StatusOK, rr.Code)
+			})
+		}
+	})
+
+	t.Run("Handle feeds PUT with invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/feeds/invalid", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsPut(rr, req)
+		
+		// Should return bad request
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid feed ID")
+	})
+	
+	t.Run("Handle feeds PUT with non-existent feed", func(t *testing.T) {
+		// Mock feed not found
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError).Times(1)
+		
+		req := httptest.NewRequest("PUT", "/feeds/999", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsPut(rr, req)
+		
+		// Should return not found
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Feed not found")
+	})
+	
+	t.Run("Handle feeds PUT with update error", func(t *testing.T) {
+		// Mock existing feed
+		existingFeed := &models.Feed{
+			ID:   123,
+			Name: "Test Feed",
+		}
+		
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 123).Return(existingFeed, nil).Times(1)
+		mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+		
+		// Create form data
+		formData := make(map[string][]string)
+		formData["name"] = []string{"Updated Name"}
+		formData["url"] = []string{"https://example.com/feed.xml"}
+		
+		req := httptest.NewRequest("PUT", "/feeds/123", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsPut(rr, req)
+		
+		// Should return internal server error
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to update feed")
+	})
+
+	t.Run("Handle feeds PUT with negative fetch timeout", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(&models.Feed{ID: 42, Name: "Feed"}, nil).Times(1)
+
+		req := httptest.NewRequest("PUT", "/feeds/42", http.NoBody)
+		req.Form = map[string][]string{
+			"name":                  {"Feed"},
+			"url":                   {"https://example.com/feed.xml"},
+			"fetch_timeout_seconds": {"-1"},
+		}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsPut(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestServer_handleFeedsDelete(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle feeds DELETE success", func(t *testing.T) {
+		// Mock successful delete
+		mockStore.EXPECT().DeleteFeed(gomock.Any(), 42).Return(nil).Times(1)
+		
+		req := httptest.NewRequest("DELETE", "/feeds/42", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsDelete(rr, req)
+		
+		// Should return OK status
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+	
+	t.Run("Handle feeds DELETE with invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/feeds/notanumber", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsDelete(rr, req)
+		
+		// Should return bad request
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid feed ID")
+	})
+	
+	t.Run("Handle feeds DELETE with database error", func(t *testing.T) {
+		// Mock delete error
+		mockStore.EXPECT().DeleteFeed(gomock.Any(), 123).Return(assert.AnError).Times(1)
+		
+		req := httptest.NewRequest("DELETE", "/feeds/123", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleFeedsDelete(rr, req)
+		
+		// Should return internal server error
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to delete feed")
+	})
+}
+
+func TestServer_handleArticles(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle articles GET success", func(t *testing.T) {
+		// Mock successful database call
+		testArticles := []models.Article{
+			{
+				ID:              1,
+				FeedID:          10,
+				URL:             "https://example.com/article1",
+				Title:           "Test Article 1",
+				CreatedAt:       time.Now(),
+				WallabagEntryID: func() *int { v := 100; return &v }(),
+				FeedURL:         "https://example.com/source.xml",
+			},
+			{
+				ID:              2,
+				FeedID:          10,
+				URL:             "https://example.com/article2",
+				Title:           "Test Article 2",
+				CreatedAt:       time.Now(),
+				WallabagEntryID: func() *int { v := 101; return &v }(),
+			},
+		}
+		
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(testArticles, nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, nil).Times(1)
+		
+		req := httptest.NewRequest("GET", "/articles", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleArticles(rr, req)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		
+		// Response should contain HTML content
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		
+		// Should contain the page title
+		assert.Contains(t, body, "Processed Articles")
+		assert.Contains(t, body, "https://example.com/source.xml")
+	})
+	
+	t.Run("Handle articles GET links entries to Wallabag", func(t *testing.T) {
+		linked := NewServerWithConfig(mockStore, mockClient, w, Config{WallabagURL: "https://wallabag.example.com/"})
+		testArticles := []models.Article{
+			{
+				ID:              1,
+				URL:             "https://example.com/sent",
+				Title:           "Sent Article",
+				CreatedAt:       time.Now(),
+				WallabagEntryID: func() *int { v := 4242; return &v }(),
+			},
+			{
+				ID:        2,
+				URL:       "https://example.com/unsent",
+				Title:     "Unsent Article",
+				CreatedAt: time.Now(),
+			},
+		}
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(testArticles, nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, nil).Times(1)
+
+		req := httptest.NewRequest("GET", "/articles", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		linked.handleArticles(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, `href="https://wallabag.example.com/view/4242"`)
+		assert.Equal(t, 1, strings.Count(body, "/view/"), "unsent articles have no entry link")
+	})
+
+	t.Run("Handle articles GET with database error", func(t *testing.T) {
+		// Mock database error
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(nil, assert.AnError).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, nil).Times(1)
+		
+		req := httptest.NewRequest("GET", "/articles", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleArticles(rr, req)
+		
+		// Should return internal server error
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to get articles")
+	})
+
+	t.Run("Handle articles GET unsent only", func(t *testing.T) {
+		unsent := []models.Article{
+			{ID: 3, FeedID: 10, URL: "https://example.com/unsent", Title: "Unsent Article", CreatedAt: time.Now()},
+		}
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(unsent, nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+
+		req := httptest.NewRequest("GET", "/articles?filter=unsent", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleArticles(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Unsent Article")
+	})
+}
+
+func TestServer_handleSync(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle sync POST success", func(t *testing.T) {
+		// Mock successful queue operation - need to set up store expectations for worker
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{
+			{ID: 1, Name: "Feed 1"},
+			{ID: 2, Name: "Feed 2"},
+		}, nil).Times(1)
+		
+		req := httptest.NewRequest("POST", "/sync", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleSync(rr, req)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Sync initiated.")
+	})
+	
+	t.Run("Handle sync with wrong HTTP method", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/sync", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleSync(rr, req)
+		
+		// Should return method not allowed
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Method not allowed")
+	})
+	
+	t.Run("Handle sync POST with queue error", func(t *testing.T) {
+		// Mock queue operation failure
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, assert.AnError).Times(1)
+		
+		req := httptest.NewRequest("POST", "/sync", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleSync(rr, req)
+		
+		// Should return internal server error
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to initiate sync")
+	})
+}
+
+func TestServer_handleSettings(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle settings GET success", func(t *testing.T) {
+		// Mock successful database call
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(120, nil).Times(1)
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+		
+		req := httptest.NewRequest("GET", "/settings", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleSettings(rr, req)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		
+		// Response should contain HTML content
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		
+		// Should contain the page title
+		assert.Contains(t, body, "Settings")
+	})
+	
+	t.Run("Handle settings GET with database error uses fallback", func(t *testing.T) {
+		// Mock database error
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError).Times(1)
+		mockStore.EXPECT().CheckWritable(gomock.Any()).Return(nil).Times(1)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).Times(1)
+		
+		req := httptest.NewRequest("GET", "/settings", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleSettings(rr, req)
+		
+		// Should still be successful (uses fallback)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		
+		// Response should contain HTML content
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		assert.Contains(t, body, "Settings")
+	})
+}
+
+func TestServer_csrfProtection(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
 	
-	t.Run("Handle feeds DELETE success", func(t *testing.T) {
-		// Mock successful delete
-		mockStore.EXPECT().DeleteFeed(gomock.Any(), 42).Return(nil).Times(1)
+	// Create a test handler that the CSRF middleware will protect
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("success"))
+	}
+	
+	protectedHandler := serv.csrfProtection(testHandler)
+	
+	t.Run("GET request bypasses CSRF protection", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
 		
-		req := httptest.NewRequest("DELETE", "/feeds/42", http.NoBody)
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "success", rr.Body.String())
+	})
+	
+	t.Run("POST request without CSRF token is forbidden", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+	})
+	
+	t.Run("POST request with valid CSRF token in header succeeds", func(t *testing.T) {
+		// Generate a valid token
+		token, err := serv.csrfManager.GenerateToken()
+		assert.NoError(t, err)
+		
+		req := httptest.NewRequest("POST", "/test", http.NoBody)
+		req.Header.Set("X-CSRF-Token", token)
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "success", rr.Body.String())
+	})
+	
+	t.Run("POST request with valid CSRF token in form succeeds", func(t *testing.T) {
+		// Generate a valid token
+		token, err := serv.csrfManager.GenerateToken()
+		assert.NoError(t, err)
+		
+		// Create form data
+		formData := make(map[string][]string)
+		formData["csrf_token"] = []string{token}
+		
+		req := httptest.NewRequest("POST", "/test", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "success", rr.Body.String())
+	})
+	
+	t.Run("PUT request without CSRF token is forbidden", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+	})
+	
+	t.Run("DELETE request without CSRF token is forbidden", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+	})
+	
+	t.Run("POST request with invalid CSRF token is forbidden", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", http.NoBody)
+		req.Header.Set("X-CSRF-Token", "invalid-token")
+		rr := httptest.NewRecorder()
+		
+		protectedHandler(rr, req)
+		
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+	})
+}
+
+func TestServer_handleUpdateDefaultPollInterval(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Handle update default poll interval PUT success", func(t *testing.T) {
+		// Mock successful database update
+		mockStore.EXPECT().UpdateDefaultPollInterval(gomock.Any(), 180).Return(nil).Times(1) // 3 hours = 180 minutes
+		
+		// Create form data
+		formData := make(map[string][]string)
+		formData["default_poll_interval"] = []string{"3"}
+		formData["default_poll_interval_unit"] = []string{"hours"}
+		
+		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		serv.handleUpdateDefaultPollInterval(rr, req)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		// Should return formatted HTML response
+		assert.Contains(t, rr.Body.String(), `<span id="default-poll-interval-display">3 hours</span>`)
+	})
+	
+	t.Run("Handle update with wrong HTTP method", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/settings/default-poll-interval", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.handleUpdateDefaultPollInterval(rr, req)
+		
+		// Should return method not allowed
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Method not allowed")
+	})
+	
+	t.Run("Handle update with invalid form data", func(t *testing.T) {
+		// Create invalid form data
+		formData := make(map[string][]string)
+		formData["default_poll_interval"] = []string{"invalid"}
+		formData["default_poll_interval_unit"] = []string{"hours"}
+		
+		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		serv.handleUpdateDefaultPollInterval(rr, req)
+		
+		// Should return bad request
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Invalid poll interval")
+	})
+	
+	t.Run("Handle update with database error", func(t *testing.T) {
+		// Mock database error
+		mockStore.EXPECT().UpdateDefaultPollInterval(gomock.Any(), 1440).Return(assert.AnError).Times(1) // 1 day = 1440 minutes
+		
+		// Create form data
+		formData := make(map[string][]string)
+		formData["default_poll_interval"] = []string{"1"}
+		formData["default_poll_interval_unit"] = []string{"days"}
+		
+		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
+		req.Form = formData
+		rr := httptest.NewRecorder()
+		
+		serv.handleUpdateDefaultPollInterval(rr, req)
+		
+		// Should return internal server error
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to update default poll interval")
+	})
+}
+
+func TestServer_renderFeedRow(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+	
+	t.Run("Render feed row success", func(t *testing.T) {
+		// Mock for getDefaultPollIntervalWithFallback
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
+		
+		// Create a test feed
+		feed := &models.Feed{
+			ID:               1,
+			Name:             "Test Feed",
+			URL:              "https://example.com/feed.xml",
+			PollInterval:     2,
+			PollIntervalUnit: models.TimeUnitHours,
+			SyncMode:         models.SyncModeAll,
+		}
+		
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
+		
+		serv.renderFeedRow(rr, req, feed)
+		
+		// Should be successful
+		assert.Equal(t, http.StatusOK, rr.Code)
+		
+		// Response should contain HTML content
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		// Should contain feed data
+		assert.Contains(t, body, "Test Feed")
+		assert.NotContains(t, body, "full content", "no content kind label before the first fetch")
+	})
+
+	t.Run("Shows the detected content kind", func(t *testing.T) {
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		feed := &models.Feed{ID: 2, Name: "Full Feed", URL: "https://example.com/full.xml", ContentKind: models.ContentKindFull}
+
+		rr := httptest.NewRecorder()
+		serv.renderFeedRow(rr, httptest.NewRequest(http.MethodGet, "/test", http.NoBody), feed)
+
+		assert.Contains(t, rr.Body.String(), "full content")
+	})
+
+	t.Run("Shows why the worker paused the feed", func(t *testing.T) {
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		feed := &models.Feed{ID: 3, Name: "Paused Feed", URL: "https://example.com/paused.xml", Disabled: true,
+			DisabledReason: "Paused after Wallabag rejected 5 articles in a row with 400 Bad Request."}
+
+		rr := httptest.NewRecorder()
+		serv.renderFeedRow(rr, httptest.NewRequest(http.MethodGet, "/test", http.NoBody), feed)
+
+		assert.Contains(t, rr.Body.String(), "Paused after Wallabag rejected 5 articles in a row")
+	})
+}
+
+func TestServer_handleEditFeed(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Handle edit feed GET success", func(t *testing.T) {
+		testFeed := &models.Feed{
+			ID:               42,
+			Name:             "Test Feed",
+			URL:              "https://example.com/feed.xml",
+			PollInterval:     2,
+			PollIntervalUnit: models.TimeUnitHours,
+			SyncMode:         models.SyncModeCount,
+		}
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/edit/42", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsDelete(rr, req)
-		
-		// Should return OK status
+
+		serv.handleEditFeed(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		assert.Contains(t, body, "Test Feed")
 	})
-	
-	t.Run("Handle feeds DELETE with invalid ID", func(t *testing.T) {
-		req := httptest.NewRequest("DELETE", "/feeds/notanumber", http.NoBody)
+
+	t.Run("Handle edit feed with wrong HTTP method", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds/edit/42", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsDelete(rr, req)
-		
-		// Should return bad request
+
+		serv.handleEditFeed(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+
+	t.Run("Handle edit feed with invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/feeds/edit/invalid", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleEditFeed(rr, req)
+
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Invalid feed ID")
 	})
-	
-	t.Run("Handle feeds DELETE with database error", func(t *testing.T) {
-		// Mock delete error
-		mockStore.EXPECT().DeleteFeed(gomock.Any(), 123).Return(assert.AnError).Times(1)
-		
-		req := httptest.NewRequest("DELETE", "/feeds/123", http.NoBody)
+
+	t.Run("Handle edit feed with non-existent feed", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest("GET", "/feeds/edit/999", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleFeedsDelete(rr, req)
-		
-		// Should return internal server error
-		assert.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Failed to delete feed")
+
+		serv.handleEditFeed(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Handle edit feed with default poll interval error uses fallback", func(t *testing.T) {
+		testFeed := &models.Feed{
+			ID:               42,
+			Name:             "Test Feed",
+			URL:              "https://example.com/feed.xml",
+			PollInterval:     2,
+			PollIntervalUnit: models.TimeUnitHours,
+		}
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError)
+
+		req := httptest.NewRequest("GET", "/feeds/edit/42", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleEditFeed(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		assert.Contains(t, body, "Test Feed")
 	})
 }
 
-func TestServer_handleArticles(t *testing.T) {
+func TestServer_handleFeedRow(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Handle articles GET success", func(t *testing.T) {
-		// Mock successful database call
-		testArticles := []models.Article{
-			{
-				ID:              1,
-				FeedID:          10,
-				URL:             "https://example.com/article1",
-				Title:           "Test Article 1",
-				CreatedAt:       time.Now(),
-				WallabagEntryID: func() *int { v := 100; return &v }(),
-			},
-			{
-				ID:              2,
-				FeedID:          10,
-				URL:             "https://example.com/article2",
-				Title:           "Test Article 2",
-				CreatedAt:       time.Now(),
-				WallabagEntryID: func() *int { v := 101; return &v }(),
-			},
+
+	t.Run("Handle feed row GET success", func(t *testing.T) {
+		testFeed := &models.Feed{
+			ID:               42,
+			Name:             "Test Feed",
+			URL:              "https://example.com/feed.xml",
+			PollInterval:     2,
+			PollIntervalUnit: models.TimeUnitHours,
 		}
-		
-		mockStore.EXPECT().GetArticles(gomock.Any()).Return(testArticles, nil).Times(1)
-		
-		req := httptest.NewRequest("GET", "/articles", http.NoBody)
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("GET", "/feeds/row/42", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleArticles(rr, req)
-		
-		// Should be successful
+
+		serv.handleFeedRow(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		
-		// Response should contain HTML content
 		body := rr.Body.String()
 		assert.NotEmpty(t, body)
-		
-		// Should contain the page title
-		assert.Contains(t, body, "Processed Articles")
+		assert.Contains(t, body, "Test Feed")
 	})
-	
-	t.Run("Handle articles GET with database error", func(t *testing.T) {
-		// Mock database error
-		mockStore.EXPECT().GetArticles(gomock.Any()).Return(nil, assert.AnError).Times(1)
-		
-		req := httptest.NewRequest("GET", "/articles", http.NoBody)
+
+	t.Run("Handle feed row with wrong HTTP method", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds/row/42", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRow(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+
+	t.Run("Handle feed row with invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/feeds/row/invalid", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRow(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Handle feed row with non-existent feed", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest("GET", "/feeds/row/999", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRow(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Handle feed row with default poll interval error uses fallback", func(t *testing.T) {
+		testFeed := &models.Feed{
+			ID:               42,
+			Name:             "Test Feed",
+			URL:              "https://example.com/feed.xml",
+			PollInterval:     2,
+			PollIntervalUnit: models.TimeUnitHours,
+		}
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError)
+
+		req := httptest.NewRequest("GET", "/feeds/row/42", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedRow(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.NotEmpty(t, body)
+		assert.Contains(t, body, "Test Feed")
+	})
+}
+
+func TestServer_handleFeedsBulkEnabled(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Disable selected feeds", func(t *testing.T) {
+		mockStore.EXPECT().SetFeedsEnabled(gomock.Any(), []int{1, 3}, false).Return(nil)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return([]models.Feed{
+			{ID: 1, Name: "First Feed", Disabled: true},
+			{ID: 2, Name: "Second Feed"},
+			{ID: 3, Name: "Third Feed", Disabled: true},
+		}, 3, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("POST", "/feeds/bulk-enabled", http.NoBody)
+		req.Form = map[string][]string{"enabled": {"false"}, "ids": {"1", "3"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsBulkEnabled(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, "Second Feed")
+		assert.Contains(t, body, "Disabled")
+	})
+
+	t.Run("Enable all feeds", func(t *testing.T) {
+		feeds := []models.Feed{{ID: 4, Name: "Feed A"}, {ID: 5, Name: "Feed B"}}
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockStore.EXPECT().SetFeedsEnabled(gomock.Any(), []int{4, 5}, true).Return(nil)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return(feeds, 2, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("POST", "/feeds/bulk-enabled", http.NoBody)
+		req.Form = map[string][]string{"enabled": {"true"}, "all": {"true"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsBulkEnabled(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid feed ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds/bulk-enabled", http.NoBody)
+		req.Form = map[string][]string{"enabled": {"true"}, "ids": {"abc"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsBulkEnabled(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore.EXPECT().SetFeedsEnabled(gomock.Any(), []int{1}, true).Return(assert.AnError)
+
+		req := httptest.NewRequest("POST", "/feeds/bulk-enabled", http.NoBody)
+		req.Form = map[string][]string{"enabled": {"true"}, "ids": {"1"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsBulkEnabled(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestServer_handleFeedsMerge(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Merges source into target", func(t *testing.T) {
+		mockStore.EXPECT().MergeFeeds(gomock.Any(), 2, 1).Return(int64(4), nil)
+		mockStore.EXPECT().GetFeedsSorted(gomock.Any(), database.FeedSortID, DefaultFeedsPerPage, 0).Return([]models.Feed{{ID: 1, Name: "Blog"}}, 1, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest("POST", "/feeds/merge", http.NoBody)
+		req.Form = map[string][]string{"source_id": {"2"}, "target_id": {"1"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsMerge(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("HX-Refresh"))
+		assert.Contains(t, rr.Body.String(), "Blog")
+	})
+
+	t.Run("Same feed is rejected", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds/merge", http.NoBody)
+		req.Form = map[string][]string{"source_id": {"1"}, "target_id": {"1"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsMerge(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Invalid feed ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/feeds/merge", http.NoBody)
+		req.Form = map[string][]string{"source_id": {"abc"}, "target_id": {"1"}}
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsMerge(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore.EXPECT().MergeFeeds(gomock.Any(), 2, 1).Return(int64(0), assert.AnError)
+
+		req := httptest.NewRequest("POST", "/feeds/merge", http.NoBody)
+		req.Form = map[string][]string{"source_id": {"2"}, "target_id": {"1"}}
 		rr := httptest.NewRecorder()
-		
-		serv.handleArticles(rr, req)
-		
-		// Should return internal server error
+
+		serv.handleFeedsMerge(rr, req)
+
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Failed to get articles")
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/feeds/merge", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		serv.handleFeedsMerge(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
 }
 
-func TestServer_handleSync(t *testing.T) {
+func TestServer_handleFeedDuplicate(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Handle sync POST success", func(t *testing.T) {
-		// Mock successful queue operation - need to set up store expectations for worker
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{
-			{ID: 1, Name: "Feed 1"},
-			{ID: 2, Name: "Feed 2"},
-		}, nil).Times(1)
-		
-		req := httptest.NewRequest("POST", "/sync", http.NoBody)
+
+	t.Run("Prefills form from existing feed with blank URL", func(t *testing.T) {
+		count := 25
+		source := &models.Feed{
+			ID:                  5,
+			Name:                "Tech News",
+			URL:                 "https://example.com/tech.xml",
+			PollInterval:        6,
+			PollIntervalUnit:    models.TimeUnitHours,
+			SyncMode:            models.SyncModeCount,
+			SyncCount:           &count,
+			FetchTimeoutSeconds: 40,
+		}
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 5).Return(source, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/5/duplicate", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleSync(rr, req)
-		
-		// Should be successful
+
+		serv.handleFeeds(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Sync initiated.")
+		body := rr.Body.String()
+		assert.Contains(t, body, `id="add-feed-form"`)
+		assert.Contains(t, body, `name="name" value="Tech News"`)
+		assert.Contains(t, body, `name="poll_interval" value="6"`)
+		assert.Contains(t, body, `<option value="hours" selected>`)
+		assert.Contains(t, body, `<option value="count" selected>`)
+		assert.Contains(t, body, `name="sync_count" min="1" max="1000" value="25"`)
+		assert.Contains(t, body, `name="fetch_timeout_seconds" value="40"`)
+		assert.Contains(t, body, `<input type="url" class="form-control" id="feedURL" name="url" required>`)
+		assert.NotContains(t, body, "https://example.com/tech.xml")
 	})
-	
-	t.Run("Handle sync with wrong HTTP method", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/sync", http.NoBody)
+
+	t.Run("Feed not found", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 6).Return(nil, errors.New("feed with ID 6 not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/6/duplicate", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleSync(rr, req)
-		
-		// Should return method not allowed
-		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Method not allowed")
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
-	
-	t.Run("Handle sync POST with queue error", func(t *testing.T) {
-		// Mock queue operation failure
-		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, assert.AnError).Times(1)
-		
-		req := httptest.NewRequest("POST", "/sync", http.NoBody)
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/feeds/5/duplicate", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleSync(rr, req)
-		
-		// Should return internal server error
-		assert.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Failed to initiate sync")
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
 }
 
-func TestServer_handleSettings(t *testing.T) {
+func TestServer_handleFeedRaw(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Handle settings GET success", func(t *testing.T) {
-		// Mock successful database call
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(120, nil).Times(1)
-		
-		req := httptest.NewRequest("GET", "/settings", http.NoBody)
+
+	feedXML := `<?xml version="1.0"?><rss version="2.0"><channel><title>Raw</title></channel></rss>`
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		w.Write([]byte(feedXML))
+	}))
+	defer upstream.Close()
+
+	t.Run("Returns raw body and content type", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleSettings(rr, req)
-		
-		// Should be successful
+
+		serv.handleFeeds(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		
-		// Response should contain HTML content
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		
-		// Should contain the page title
-		assert.Contains(t, body, "Settings")
+		assert.Equal(t, "application/rss+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, feedXML, rr.Body.String())
 	})
-	
-	t.Run("Handle settings GET with database error uses fallback", func(t *testing.T) {
-		// Mock database error
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError).Times(1)
-		
-		req := httptest.NewRequest("GET", "/settings", http.NoBody)
+
+	t.Run("HTML upstream is served sandboxed", func(t *testing.T) {
+		page := `<html><body><script>alert(document.cookie)</script></body></html>`
+		htmlUpstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(page))
+		}))
+		defer htmlUpstream.Close()
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 10).Return(&models.Feed{ID: 10, URL: htmlUpstream.URL}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/10/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.handleSettings(rr, req)
-		
-		// Should still be successful (uses fallback)
+
+		serv.AddSecurityHeaders(serv.handleFeeds)(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		
-		// Response should contain HTML content
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		assert.Contains(t, body, "Settings")
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "sandbox", rr.Header().Get("Content-Security-Policy"))
+		assert.Equal(t, `attachment; filename="feed-10.raw"`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, page, rr.Body.String())
 	})
-}
 
-func TestServer_csrfProtection(t *testing.T) {
-	mockStore, mockClient, w := setupTestServer(t)
-	serv := NewServer(mockStore, mockClient, w)
-	
-	// Create a test handler that the CSRF middleware will protect
-	testHandler := func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("success"))
-	}
-	
-	protectedHandler := serv.csrfProtection(testHandler)
-	
-	t.Run("GET request bypasses CSRF protection", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/test", http.NoBody)
+	t.Run("Feed not found", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 8).Return(nil, errors.New("feed with ID 8 not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/8/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.Equal(t, "success", rr.Body.String())
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
-	
-	t.Run("POST request without CSRF token is forbidden", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/test", http.NoBody)
+
+	t.Run("Invalid feed ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/feeds/abc/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusForbidden, rr.Code)
-		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
-	
-	t.Run("POST request with valid CSRF token in header succeeds", func(t *testing.T) {
-		// Generate a valid token
-		token, err := serv.csrfManager.GenerateToken()
-		assert.NoError(t, err)
-		
-		req := httptest.NewRequest("POST", "/test", http.NoBody)
-		req.Header.Set("X-CSRF-Token", token)
+
+	t.Run("Upstream failure", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 9).Return(&models.Feed{ID: 9, URL: failing.URL}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/feeds/9/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.Equal(t, "success", rr.Body.String())
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
 	})
-	
-	t.Run("POST request with valid CSRF token in form succeeds", func(t *testing.T) {
-		// Generate a valid token
-		token, err := serv.csrfManager.GenerateToken()
-		assert.NoError(t, err)
-		
-		// Create form data
-		formData := make(map[string][]string)
-		formData["csrf_token"] = []string{token}
-		
-		req := httptest.NewRequest("POST", "/test", http.NoBody)
-		req.Form = formData
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusOK, rr.Code)
-		assert.Equal(t, "success", rr.Body.String())
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
-	
-	t.Run("PUT request without CSRF token is forbidden", func(t *testing.T) {
-		req := httptest.NewRequest("PUT", "/test", http.NoBody)
+}
+
+// countingTransport counts the requests sent through it, standing in for a configured feed
+// transport such as the SOCKS5 proxy
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServer_handleFeedRaw_SharedProcessor(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<rss version="2.0"><channel><title>Proxied</title></channel></rss>`))
+	}))
+	defer upstream.Close()
+
+	transport := &countingTransport{}
+	serv := NewServerWithConfig(mockStore, mockClient, w, Config{Processor: rss.NewProcessorWithTransport(transport)})
+	mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/7/raw", http.NoBody)
+	rr := httptest.NewRecorder()
+
+	serv.handleFeeds(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "<title>Proxied</title>")
+	assert.Equal(t, int32(1), transport.requests.Load(), "the raw fetch goes through the configured transport")
+}
+
+func TestServer_handleFeedRaw_ConfiguredTLS(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<rss version="2.0"><channel><title>Internal</title></channel></rss>`))
+	}))
+	defer upstream.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: upstream.Certificate().Raw}), 0o600))
+
+	fetchRaw := func(serv *Server) *httptest.ResponseRecorder {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/raw", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusForbidden, rr.Code)
-		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+
+		serv.handleFeeds(rr, req)
+
+		return rr
+	}
+
+	t.Run("Private CA trusted by the configured processor", func(t *testing.T) {
+		tlsConfig, err := rss.NewTLSConfig(rss.TLSOptions{CAFile: caFile})
+		require.NoError(t, err)
+		processor := rss.NewProcessorWithTransport(rss.NewTLSTransport(nil, tlsConfig))
+
+		rr := fetchRaw(NewServerWithConfig(mockStore, mockClient, w, Config{Processor: processor}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "<title>Internal</title>")
 	})
-	
-	t.Run("DELETE request without CSRF token is forbidden", func(t *testing.T) {
-		req := httptest.NewRequest("DELETE", "/test", http.NoBody)
-		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusForbidden, rr.Code)
-		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+
+	t.Run("Private CA rejected without it", func(t *testing.T) {
+		rr := fetchRaw(NewServer(mockStore, mockClient, w))
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
 	})
-	
-	t.Run("POST request with invalid CSRF token is forbidden", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/test", http.NoBody)
-		req.Header.Set("X-CSRF-Token", "invalid-token")
+}
+
+func TestServer_handleFeedPreview_SharedProcessor(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Proxied</title>
+			<item><title>Through the proxy</title><link>https://example.com/proxied</link></item>
+		</channel></rss>`))
+	}))
+	defer upstream.Close()
+
+	transport := &countingTransport{}
+	serv := NewServerWithConfig(mockStore, mockClient, w, Config{Processor: rss.NewProcessorWithTransport(transport)})
+	mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/feeds/7/preview", strings.NewReader("sync_mode=all"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+
+	serv.handleFeeds(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "<li>Through the proxy</li>")
+	assert.Equal(t, int32(1), transport.requests.Load(), "the preview fetch goes through the configured transport")
+}
+
+func TestServer_handleFeedPreview_CrossHostRedirect(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Moved</title>
+			<item><title>Moved post</title><link>https://example.com/moved</link></item>
+		</channel></rss>`))
+	}))
+	defer target.Close()
+	// The same server reached by another host name
+	otherHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, otherHost+"/feed", http.StatusPermanentRedirect)
+	}))
+	defer origin.Close()
+
+	preview := func(serv *Server) *httptest.ResponseRecorder {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: origin.URL}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/preview", strings.NewReader("sync_mode=all"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rr := httptest.NewRecorder()
-		
-		protectedHandler(rr, req)
-		
-		assert.Equal(t, http.StatusForbidden, rr.Code)
-		assert.Contains(t, rr.Body.String(), "CSRF token missing or invalid")
+
+		serv.handleFeeds(rr, req)
+
+		return rr
+	}
+
+	t.Run("Rejected when the processor blocks cross-host redirects", func(t *testing.T) {
+		processor := rss.NewProcessor()
+		processor.BlockCrossHostRedirects = true
+
+		rr := preview(NewServerWithConfig(mockStore, mockClient, w, Config{Processor: processor}))
+
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "Moved post")
+	})
+
+	t.Run("Followed when the processor allows them", func(t *testing.T) {
+		rr := preview(NewServerWithConfig(mockStore, mockClient, w, Config{Processor: rss.NewProcessor()}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "<li>Moved post</li>")
 	})
 }
 
-func TestServer_handleUpdateDefaultPollInterval(t *testing.T) {
+func TestServer_handleFeedPreview(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Handle update default poll interval PUT success", func(t *testing.T) {
-		// Mock successful database update
-		mockStore.EXPECT().UpdateDefaultPollInterval(gomock.Any(), 180).Return(nil).Times(1) // 3 hours = 180 minutes
-		
-		// Create form data
-		formData := make(map[string][]string)
-		formData["default_poll_interval"] = []string{"3"}
-		formData["default_poll_interval_unit"] = []string{"hours"}
-		
-		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
-		req.Form = formData
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Preview</title>
+			<item><title>Oldest</title><link>https://example.com/1</link><pubDate>Mon, 01 Jan 2024 10:00:00 GMT</pubDate></item>
+			<item><title>Middle</title><link>https://example.com/2</link><pubDate>Mon, 01 Jul 2024 10:00:00 GMT</pubDate></item>
+			<item><title>Newest</title><link>https://example.com/3</link><pubDate>Wed, 01 Jan 2025 10:00:00 GMT</pubDate></item>
+		</channel></rss>`))
+	}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		wantCount  string
+		wantTitles []string
+		notTitles  []string
+	}{
+		{name: "All", form: url.Values{"sync_mode": {"all"}}, wantCount: "would send 3 historical", wantTitles: []string{"Oldest", "Middle", "Newest"}},
+		{name: "Count", form: url.Values{"sync_mode": {"count"}, "sync_count": {"2"}}, wantCount: "would send 2 historical", wantTitles: []string{"Middle", "Newest"}, notTitles: []string{"Oldest"}},
+		{name: "Date from", form: url.Values{"sync_mode": {"date_from"}, "sync_date_from": {"2024-12-01"}}, wantCount: "would send 1 historical article ", wantTitles: []string{"Newest"}, notTitles: []string{"Middle"}},
+		{name: "None", form: url.Values{"sync_mode": {"none"}}, wantCount: "would send no historical articles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL, InitialSyncDone: true}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/feeds/7/preview", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+
+			serv.handleFeeds(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			body := rr.Body.String()
+			assert.Contains(t, body, tt.wantCount)
+			for _, title := range tt.wantTitles {
+				assert.Contains(t, body, "<li>"+title+"</li>")
+			}
+			for _, title := range tt.notTitles {
+				assert.NotContains(t, body, title)
+			}
+		})
+	}
+
+	t.Run("Labels summary-only feeds", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: upstream.URL, InitialSyncDone: true}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/preview", strings.NewReader("sync_mode=none"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rr := httptest.NewRecorder()
-		
-		serv.handleUpdateDefaultPollInterval(rr, req)
-		
-		// Should be successful
+
+		serv.handleFeeds(rr, req)
+
 		assert.Equal(t, http.StatusOK, rr.Code)
-		// Should return formatted HTML response
-		assert.Contains(t, rr.Body.String(), `<span id="default-poll-interval-display">3 hours</span>`)
-	})
-	
-	t.Run("Handle update with wrong HTTP method", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/settings/default-poll-interval", http.NoBody)
-		rr := httptest.NewRecorder()
-		
-		serv.handleUpdateDefaultPollInterval(rr, req)
-		
-		// Should return method not allowed
-		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Method not allowed")
+		assert.Contains(t, rr.Body.String(), "summary only")
 	})
-	
-	t.Run("Handle update with invalid form data", func(t *testing.T) {
-		// Create invalid form data
-		formData := make(map[string][]string)
-		formData["default_poll_interval"] = []string{"invalid"}
-		formData["default_poll_interval_unit"] = []string{"hours"}
-		
-		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
-		req.Form = formData
+
+	t.Run("Invalid sync mode", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/feeds/7/preview", strings.NewReader("sync_mode=everything"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rr := httptest.NewRecorder()
-		
-		serv.handleUpdateDefaultPollInterval(rr, req)
-		
-		// Should return bad request
+
+		serv.handleFeeds(rr, req)
+
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Invalid poll interval")
 	})
-	
-	t.Run("Handle update with database error", func(t *testing.T) {
-		// Mock database error
-		mockStore.EXPECT().UpdateDefaultPollInterval(gomock.Any(), 1440).Return(assert.AnError).Times(1) // 1 day = 1440 minutes
-		
-		// Create form data
-		formData := make(map[string][]string)
-		formData["default_poll_interval"] = []string{"1"}
-		formData["default_poll_interval_unit"] = []string{"days"}
-		
-		req := httptest.NewRequest("PUT", "/settings/default-poll-interval", http.NoBody)
-		req.Form = formData
+
+	t.Run("Feed not found", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 8).Return(nil, errors.New("feed with ID 8 not found"))
+
+		req := httptest.NewRequest(http.MethodPost, "/feeds/8/preview", strings.NewReader("sync_mode=all"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rr := httptest.NewRecorder()
-		
-		serv.handleUpdateDefaultPollInterval(rr, req)
-		
-		// Should return internal server error
-		assert.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.Contains(t, rr.Body.String(), "Failed to update default poll interval")
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
-}
 
-func TestServer_renderFeedRow(t *testing.T) {
-	mockStore, mockClient, w := setupTestServer(t)
-	serv := NewServer(mockStore, mockClient, w)
-	
-	t.Run("Render feed row success", func(t *testing.T) {
-		// Mock for getDefaultPollIntervalWithFallback
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).Times(1)
-		
-		// Create a test feed
-		feed := &models.Feed{
-			ID:               1,
-			Name:             "Test Feed",
-			URL:              "https://example.com/feed.xml",
-			PollInterval:     2,
-			PollIntervalUnit: models.TimeUnitHours,
-			SyncMode:         models.SyncModeAll,
-		}
-		
-		req := httptest.NewRequest("GET", "/test", http.NoBody)
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/feeds/7/preview", http.NoBody)
 		rr := httptest.NewRecorder()
-		
-		serv.renderFeedRow(rr, req, feed)
-		
-		// Should be successful
-		assert.Equal(t, http.StatusOK, rr.Code)
-		
-		// Response should contain HTML content
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		// Should contain feed data
-		assert.Contains(t, body, "Test Feed")
+
+		serv.handleFeeds(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
 }
 
-func TestServer_handleEditFeed(t *testing.T) {
+func TestServer_handleAdminReauth(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
 
-	t.Run("Handle edit feed GET success", func(t *testing.T) {
-		testFeed := &models.Feed{
-			ID:               42,
-			Name:             "Test Feed",
-			URL:              "https://example.com/feed.xml",
-			PollInterval:     2,
-			PollIntervalUnit: models.TimeUnitHours,
-			SyncMode:         models.SyncModeCount,
-		}
-
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+	t.Run("Triggers a fresh authentication", func(t *testing.T) {
+		mockClient.EXPECT().Authenticate(gomock.Any()).Return(nil)
 
-		req := httptest.NewRequest("GET", "/feeds/edit/42", http.NoBody)
+		req := httptest.NewRequest(http.MethodPost, "/admin/reauth", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleEditFeed(rr, req)
+		serv.handleAdminReauth(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		assert.Contains(t, body, "Test Feed")
+		assert.Contains(t, rr.Body.String(), "Re-authenticated")
 	})
 
-	t.Run("Handle edit feed with wrong HTTP method", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/feeds/edit/42", http.NoBody)
+	t.Run("Reports failure", func(t *testing.T) {
+		mockClient.EXPECT().Authenticate(gomock.Any()).Return(errors.New("authentication failed with status 401"))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reauth", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleEditFeed(rr, req)
+		serv.handleAdminReauth(rr, req)
 
-		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, http.StatusBadGateway, rr.Code)
 	})
 
-	t.Run("Handle edit feed with invalid ID", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/feeds/edit/invalid", http.NoBody)
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/reauth", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleEditFeed(rr, req)
+		serv.handleAdminReauth(rr, req)
 
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
+}
 
-	t.Run("Handle edit feed with non-existent feed", func(t *testing.T) {
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError)
+func TestServer_handleAdminMarkAllProcessed(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
 
-		req := httptest.NewRequest("GET", "/feeds/edit/999", http.NoBody)
+	t.Run("Reports totals", func(t *testing.T) {
+		// Disabled feeds are skipped, so nothing is fetched
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{{ID: 1, Name: "Disabled", Disabled: true}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/mark-all-processed", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleEditFeed(rr, req)
+		serv.handleAdminMarkAllProcessed(rr, req)
 
-		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusSucceeded, job.Status)
+		assert.Equal(t, "Marked 0 items processed across 0 feeds (0 already tracked).", job.Result)
 	})
 
-	t.Run("Handle edit feed with default poll interval error uses fallback", func(t *testing.T) {
-		testFeed := &models.Feed{
-			ID:               42,
-			Name:             "Test Feed",
-			URL:              "https://example.com/feed.xml",
-			PollInterval:     2,
-			PollIntervalUnit: models.TimeUnitHours,
-		}
+	t.Run("Reports failure", func(t *testing.T) {
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, errors.New("database closed"))
 
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError)
+		req := httptest.NewRequest(http.MethodPost, "/admin/mark-all-processed", http.NoBody)
+		rr := httptest.NewRecorder()
 
-		req := httptest.NewRequest("GET", "/feeds/edit/42", http.NoBody)
+		serv.handleAdminMarkAllProcessed(rr, req)
+
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusFailed, job.Status)
+		assert.Equal(t, "failed to mark feed items processed", job.Error)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/mark-all-processed", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleEditFeed(rr, req)
+		serv.handleAdminMarkAllProcessed(rr, req)
 
-		assert.Equal(t, http.StatusOK, rr.Code)
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		assert.Contains(t, body, "Test Feed")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
 }
 
-func TestServer_handleFeedRow(t *testing.T) {
+func TestServer_handleAdminUpgradeHTTPS(t *testing.T) {
 	mockStore, mockClient, w := setupTestServer(t)
 	serv := NewServer(mockStore, mockClient, w)
 
-	t.Run("Handle feed row GET success", func(t *testing.T) {
-		testFeed := &models.Feed{
-			ID:               42,
-			Name:             "Test Feed",
-			URL:              "https://example.com/feed.xml",
-			PollInterval:     2,
-			PollIntervalUnit: models.TimeUnitHours,
-		}
+	t.Run("Reports feeds left unchanged", func(t *testing.T) {
+		// The https URL is taken, so nothing is fetched
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{
+			{ID: 1, Name: "Plain", URL: "http://example.com/feed"},
+			{ID: 2, Name: "Secure", URL: "https://example.com/feed"},
+		}, nil)
 
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		req := httptest.NewRequest(http.MethodPost, "/admin/upgrade-https", http.NoBody)
+		rr := httptest.NewRecorder()
 
-		req := httptest.NewRequest("GET", "/feeds/row/42", http.NoBody)
+		serv.handleAdminUpgradeHTTPS(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusSucceeded, job.Status)
+		assert.Equal(t, "Upgraded 0 of 1 http feeds to https.\n"+
+			"Not upgraded Plain (http://example.com/feed): another feed already uses https://example.com/feed", job.Result)
+	})
+
+	t.Run("Reports failure", func(t *testing.T) {
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, errors.New("database closed"))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/upgrade-https", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleFeedRow(rr, req)
+		serv.handleAdminUpgradeHTTPS(rr, req)
 
-		assert.Equal(t, http.StatusOK, rr.Code)
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		assert.Contains(t, body, "Test Feed")
+		job := finishedJob(t, serv, rr)
+		assert.Equal(t, jobs.StatusFailed, job.Status)
 	})
 
-	t.Run("Handle feed row with wrong HTTP method", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/feeds/row/42", http.NoBody)
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/admin/upgrade-https", http.NoBody)
 		rr := httptest.NewRecorder()
 
-		serv.handleFeedRow(rr, req)
+		serv.handleAdminUpgradeHTTPS(rr, req)
 
 		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
+}
 
-	t.Run("Handle feed row with invalid ID", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/feeds/row/invalid", http.NoBody)
+func TestServer_handleReadyz(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Not ready before startup completes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
 		rr := httptest.NewRecorder()
 
-		serv.handleFeedRow(rr, req)
+		serv.handleReadyz(rr, req)
 
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
 	})
 
-	t.Run("Handle feed row with non-existent feed", func(t *testing.T) {
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 999).Return(nil, assert.AnError)
+	t.Run("Ready once startup completes", func(t *testing.T) {
+		serv.SetReady(true)
+		mockStore.EXPECT().Ping(gomock.Any()).Return(nil)
 
-		req := httptest.NewRequest("GET", "/feeds/row/999", http.NoBody)
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
 		rr := httptest.NewRecorder()
 
-		serv.handleFeedRow(rr, req)
+		serv.handleReadyz(rr, req)
 
-		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "ready", rr.Body.String())
 	})
 
-	t.Run("Handle feed row with default poll interval error uses fallback", func(t *testing.T) {
-		testFeed := &models.Feed{
-			ID:               42,
-			Name:             "Test Feed",
-			URL:              "https://example.com/feed.xml",
-			PollInterval:     2,
-			PollIntervalUnit: models.TimeUnitHours,
-		}
+	t.Run("Not ready when database ping fails", func(t *testing.T) {
+		mockStore.EXPECT().Ping(gomock.Any()).Return(errors.New("database is closed"))
 
-		mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(testFeed, nil)
-		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, assert.AnError)
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rr := httptest.NewRecorder()
 
-		req := httptest.NewRequest("GET", "/feeds/row/42", http.NoBody)
+		serv.handleReadyz(rr, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/readyz", nil)
 		rr := httptest.NewRecorder()
 
-		serv.handleFeedRow(rr, req)
+		serv.handleReadyz(rr, req)
 
-		assert.Equal(t, http.StatusOK, rr.Code)
-		body := rr.Body.String()
-		assert.NotEmpty(t, body)
-		assert.Contains(t, body, "Test Feed")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
 }
 
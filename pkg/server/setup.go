@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"wallabag-rss-tool/pkg/config"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/views"
+)
+
+// firstRunSetup returns the setup steps to show on the home page of a new install: one with
+// no feeds whose setup was never finished. It returns nil otherwise. Once feeds exist the
+// setup is recorded as finished, so deleting them all later does not bring it back.
+func (s *Server) firstRunSetup(ctx context.Context) *views.SetupData {
+	if s.config.ReadOnly {
+		return nil
+	}
+
+	complete, err := s.store.IsSetupComplete(ctx)
+	if err != nil {
+		logging.Warn("Failed to get setup state, not showing setup", "error", fmt.Errorf("store.IsSetupComplete: %w", err))
+
+		return nil
+	}
+	if complete {
+		return nil
+	}
+
+	feeds, err := s.store.GetFeeds(ctx)
+	if err != nil {
+		logging.Warn("Failed to get feeds, not showing setup", "error", fmt.Errorf("store.GetFeeds: %w", err))
+
+		return nil
+	}
+	if len(feeds) > 0 {
+		if err := s.store.MarkSetupComplete(ctx); err != nil {
+			logging.Warn("Failed to record setup as complete", "error", fmt.Errorf("store.MarkSetupComplete: %w", err))
+		}
+
+		return nil
+	}
+
+	setup := &views.SetupData{DefaultPollInterval: s.getDefaultPollIntervalWithFallback(ctx)}
+	if wallabagConfig, err := config.LoadWallabagConfig(); err == nil {
+		setup.WallabagConfigLoaded = true
+		setup.WallabagURL = wallabagConfig.BaseURL
+	}
+
+	return setup
+}
+
+// handleSetupComplete records the first-run setup as finished, or skipped, and reloads the
+// page so the home page shows instead.
+func (s *Server) handleSetupComplete(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := s.store.MarkSetupComplete(request.Context()); err != nil {
+		logging.Error("Failed to record setup as complete", "error", fmt.Errorf("store.MarkSetupComplete: %w", err))
+		http.Error(writer, "Failed to save setup state", http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("HX-Refresh", "true")
+	writer.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_firstRunSetup(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	index := func() string {
+		rr := httptest.NewRecorder()
+		serv.HandleIndex(rr, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		return rr.Body.String()
+	}
+
+	t.Run("Shown on a new install", func(t *testing.T) {
+		t.Setenv("WALLABAG_BASE_URL", "https://wallabag.example.com")
+		t.Setenv("WALLABAG_CLIENT_ID", "id")
+		t.Setenv("WALLABAG_CLIENT_SECRET", "secret")
+		t.Setenv("WALLABAG_USERNAME", "user")
+		t.Setenv("WALLABAG_PASSWORD", "pass")
+		mockStore.EXPECT().IsSetupComplete(gomock.Any()).Return(false, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(120, nil)
+
+		body := index()
+
+		assert.Contains(t, body, "Get Started")
+		assert.Contains(t, body, "https://wallabag.example.com")
+		assert.Contains(t, body, `hx-put="/settings/poll-interval"`)
+		assert.Contains(t, body, "2 hours")
+		assert.Contains(t, body, "Add a Feed")
+		assert.NotContains(t, body, "Manual Sync")
+	})
+
+	t.Run("Not shown once complete", func(t *testing.T) {
+		mockStore.EXPECT().IsSetupComplete(gomock.Any()).Return(true, nil)
+
+		body := index()
+
+		assert.NotContains(t, body, "Get Started")
+		assert.Contains(t, body, "Manual Sync")
+	})
+
+	t.Run("Recorded as complete once feeds exist", func(t *testing.T) {
+		mockStore.EXPECT().IsSetupComplete(gomock.Any()).Return(false, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{{ID: 1}}, nil)
+		mockStore.EXPECT().MarkSetupComplete(gomock.Any()).Return(nil)
+
+		assert.NotContains(t, index(), "Get Started")
+	})
+
+	t.Run("Not shown when the state can't be read", func(t *testing.T) {
+		mockStore.EXPECT().IsSetupComplete(gomock.Any()).Return(false, errors.New("database is locked"))
+
+		assert.NotContains(t, index(), "Get Started")
+	})
+
+	t.Run("Not shown read-only", func(t *testing.T) {
+		readOnly := NewServerWithConfig(mockStore, mockClient, w, Config{ReadOnly: true})
+		rr := httptest.NewRecorder()
+
+		readOnly.HandleIndex(rr, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+		assert.NotContains(t, rr.Body.String(), "Get Started")
+	})
+}
+
+func TestServer_handleSetupComplete(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	t.Run("Records completion and reloads", func(t *testing.T) {
+		mockStore.EXPECT().MarkSetupComplete(gomock.Any()).Return(nil)
+		rr := httptest.NewRecorder()
+
+		serv.handleSetupComplete(rr, httptest.NewRequest(http.MethodPost, "/setup/complete", http.NoBody))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("HX-Refresh"))
+	})
+
+	t.Run("Reports failure", func(t *testing.T) {
+		mockStore.EXPECT().MarkSetupComplete(gomock.Any()).Return(errors.New("database is locked"))
+		rr := httptest.NewRecorder()
+
+		serv.handleSetupComplete(rr, httptest.NewRequest(http.MethodPost, "/setup/complete", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		serv.handleSetupComplete(rr, httptest.NewRequest(http.MethodGet, "/setup/complete", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
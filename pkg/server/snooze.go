@@ -0,0 +1,60 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// maxSnooze bounds how long a feed can be snoozed; longer pauses are what disabling is for.
+const maxSnooze = 90 * 24 * time.Hour
+
+// handleFeedSnooze pauses a feed for the duration in the form (POST, e.g. duration=24h) or
+// wakes it again (DELETE), and responds with the feed's updated row. The worker resumes a
+// snoozed feed by itself once the time passes.
+func (s *Server) handleFeedSnooze(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost && request.Method != http.MethodDelete {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id, err := s.ExtractFeedIDFromPath(strings.TrimSuffix(request.URL.Path, "/snooze"))
+	if err != nil {
+		http.Error(writer, "Invalid feed ID", http.StatusBadRequest)
+
+		return
+	}
+
+	var until *time.Time
+	if request.Method == http.MethodPost {
+		duration, err := time.ParseDuration(request.FormValue("duration"))
+		if err != nil || duration <= 0 || duration > maxSnooze {
+			http.Error(writer, "Invalid snooze duration; use e.g. 30m, 24h or 168h, up to 90 days", http.StatusBadRequest)
+
+			return
+		}
+		snoozedUntil := time.Now().Add(duration)
+		until = &snoozedUntil
+	}
+
+	feed, err := s.store.GetFeedByID(request.Context(), id)
+	if err != nil {
+		http.Error(writer, "Feed not found", http.StatusNotFound)
+
+		return
+	}
+
+	if err := s.store.SnoozeFeed(request.Context(), id, until); err != nil {
+		logging.Error("Failed to snooze feed", "error", fmt.Errorf("store.SnoozeFeed: %w", err), "feed_id", id)
+		http.Error(writer, "Failed to snooze feed", http.StatusInternalServerError)
+
+		return
+	}
+	feed.SnoozedUntil = until
+
+	s.renderFeedRow(writer, request, feed)
+}
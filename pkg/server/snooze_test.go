@@ -0,0 +1,87 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleFeedSnooze(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	snooze := func(method, duration string) *httptest.ResponseRecorder {
+		form := url.Values{"duration": {duration}}
+		req := httptest.NewRequest(method, "/feeds/7/snooze", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		serv.handleFeeds(rr, req)
+
+		return rr
+	}
+
+	t.Run("Snoozes for the duration", func(t *testing.T) {
+		var until *time.Time
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Noisy"}, nil)
+		mockStore.EXPECT().SnoozeFeed(gomock.Any(), 7, gomock.Not(gomock.Nil())).DoAndReturn(
+			func(_ any, _ int, snoozedUntil *time.Time) error {
+				until = snoozedUntil
+
+				return nil
+			})
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		rr := snooze(http.MethodPost, "24h")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NotNil(t, until)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *until, time.Minute)
+		assert.Contains(t, rr.Body.String(), "Snoozed until")
+		assert.Contains(t, rr.Body.String(), "Unsnooze")
+	})
+
+	t.Run("Unsnoozes", func(t *testing.T) {
+		snoozedUntil := time.Now().Add(time.Hour)
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Noisy", SnoozedUntil: &snoozedUntil}, nil)
+		mockStore.EXPECT().SnoozeFeed(gomock.Any(), 7, gomock.Nil()).Return(nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		rr := snooze(http.MethodDelete, "")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "Snoozed until")
+		assert.Contains(t, rr.Body.String(), "Snooze 24h")
+	})
+
+	t.Run("Invalid duration", func(t *testing.T) {
+		for _, duration := range []string{"", "soon", "-1h", "0s", "2161h"} {
+			rr := snooze(http.MethodPost, duration)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, duration)
+		}
+	})
+
+	t.Run("Feed not found", func(t *testing.T) {
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(nil, errors.New("feed with ID 7 not found"))
+
+		rr := snooze(http.MethodPost, "1h")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := snooze(http.MethodGet, "1h")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// handleAdminExport returns every feed's configuration and the settings as one JSON document,
+// for moving the tool to another host with POST /admin/import. Article history and secrets
+// such as feed cookies are not exported.
+func (s *Server) handleAdminExport(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	feeds, err := s.store.GetFeeds(request.Context())
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.GetFeeds: %w", err), "Failed to get feeds for export")
+
+		return
+	}
+
+	state := models.State{
+		Version:    models.StateVersion,
+		ExportedAt: time.Now().UTC(),
+		Settings:   models.StateSettings{DefaultPollIntervalMinutes: s.getDefaultPollIntervalWithFallback(request.Context())},
+		Feeds:      make([]models.FeedConfig, 0, len(feeds)),
+	}
+	for i := range feeds {
+		state.Feeds = append(state.Feeds, models.NewFeedConfig(&feeds[i]))
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Content-Disposition", `attachment; filename="wallabag-rss-export.json"`)
+	writer.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		logging.Error("Failed to write export response", "error", err)
+	}
+}
+
+// handleAdminImport restores a document from GET /admin/export: feeds are matched by URL and
+// updated or created, and the settings are applied, all in one transaction.
+func (s *Server) handleAdminImport(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeMethodNotAllowed(writer)
+
+		return
+	}
+
+	var state models.State
+	if err := json.NewDecoder(request.Body).Decode(&state); err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, "Invalid export document: "+err.Error())
+
+		return
+	}
+	if err := s.validateState(&state); err != nil {
+		writeJSONError(writer, http.StatusBadRequest, errorCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	created, updated, err := s.store.ImportState(request.Context(), &state)
+	if err != nil {
+		writeAPIError(writer, fmt.Errorf("store.ImportState: %w", err), "Failed to import state")
+
+		return
+	}
+
+	logging.Info("Imported feeds and settings", "created", created, "updated", updated)
+
+	writer.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(writer, "Imported %d feeds (%d created, %d updated).", created+updated, created, updated); err != nil {
+		logging.Error("Failed to write import response", "error", err)
+	}
+}
+
+// validateState rejects documents in an unsupported version or with feeds the add form would
+// not accept, and truncates over-long feed names
+func (s *Server) validateState(state *models.State) error {
+	if err := state.CheckVersion(); err != nil {
+		return err
+	}
+	if state.Settings.DefaultPollIntervalMinutes < 0 {
+		return fmt.Errorf("default poll interval must not be negative")
+	}
+
+	seen := make(map[string]bool, len(state.Feeds))
+	for i := range state.Feeds {
+		config := &state.Feeds[i]
+		if config.Name == "" || config.URL == "" {
+			return fmt.Errorf("feed %d: name and URL are required", i+1)
+		}
+		if seen[config.URL] {
+			return fmt.Errorf("feed %d: URL %s appears more than once", i+1, config.URL)
+		}
+		seen[config.URL] = true
+
+		switch config.SyncMode {
+		case "":
+			config.SyncMode = models.SyncModeNone
+		case models.SyncModeNone, models.SyncModeAll, models.SyncModeCount, models.SyncModeDateFrom:
+		default:
+			return fmt.Errorf("feed %d: unknown sync mode %q", i+1, config.SyncMode)
+		}
+
+		deliveryMode, err := s.ParseDeliveryMode(string(config.DeliveryMode), config.ContentSelector)
+		if err != nil {
+			return fmt.Errorf("feed %d: %w", i+1, err)
+		}
+		config.DeliveryMode = deliveryMode
+
+		dateField, err := s.ParseDateField(string(config.DateField))
+		if err != nil {
+			return fmt.Errorf("feed %d: %w", i+1, err)
+		}
+		config.DateField = dateField
+
+		feed := models.Feed{Name: config.Name, URL: config.URL}
+		if err := s.applyFieldLimits(&feed); err != nil {
+			return fmt.Errorf("feed %d: %w", i+1, err)
+		}
+		config.Name = feed.Name
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleAdminExportImport(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	feeds := []models.Feed{
+		{ID: 1, Name: "First", URL: "https://example.com/one.xml", SyncMode: models.SyncModeAll, Priority: 4, Cookie: "session=secret-value",
+			DeliveryMode: models.DeliveryModeLink, DateField: models.DateFieldAuto},
+		{ID: 2, Name: "Second", URL: "https://example.com/two.xml", SyncMode: models.SyncModeNone, Disabled: true,
+			ContentSelector: "article", DeliveryMode: models.DeliveryModeContent, DateField: models.DateFieldPublished},
+	}
+	feeds[0].SetPollInterval(30, models.TimeUnitMinutes)
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(120, nil)
+
+	rr := httptest.NewRecorder()
+	serv.handleAdminExport(rr, httptest.NewRequest(http.MethodGet, "/admin/export", http.NoBody))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	exported := rr.Body.String()
+	assert.NotContains(t, exported, "secret-value", "cookies are never exported")
+
+	var state models.State
+	require.NoError(t, json.Unmarshal([]byte(exported), &state))
+	assert.Equal(t, models.StateVersion, state.Version)
+	assert.Equal(t, 120, state.Settings.DefaultPollIntervalMinutes)
+	require.Len(t, state.Feeds, 2)
+
+	t.Run("Import restores the exported document", func(t *testing.T) {
+		var imported *models.State
+		mockStore.EXPECT().ImportState(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, state *models.State) (int, int, error) {
+				imported = state
+				return 1, 1, nil
+			})
+
+		rr := httptest.NewRecorder()
+		serv.handleAdminImport(rr, httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader(exported)))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "Imported 2 feeds (1 created, 1 updated).", rr.Body.String())
+		require.NotNil(t, imported)
+		assert.Equal(t, state.Settings, imported.Settings)
+		assert.Equal(t, []models.FeedConfig{models.NewFeedConfig(&feeds[0]), models.NewFeedConfig(&feeds[1])}, imported.Feeds)
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "Malformed JSON", body: `{"version":`, want: "Invalid export document"},
+		{name: "Newer version", body: `{"version": 99, "feeds": []}`, want: "unsupported export version 99"},
+		{name: "Feed without URL", body: `{"version": 1, "feeds": [{"name": "No URL"}]}`, want: "feed 1: name and URL are required"},
+		{name: "Unknown sync mode", body: `{"version": 1, "feeds": [{"name": "A", "url": "https://a.example/feed", "sync_mode": "some"}]}`, want: `unknown sync mode "some"`},
+		{name: "Content delivery without a selector", body: `{"version": 1, "feeds": [{"name": "A", "url": "https://a.example/feed", "delivery_mode": "content"}]}`, want: "feed 1: delivery mode content needs a content selector"},
+		{name: "Unknown date field", body: `{"version": 1, "feeds": [{"name": "A", "url": "https://a.example/feed", "date_field": "modified"}]}`, want: `feed 1: unknown date field "modified"`},
+		{name: "Duplicate URL", body: `{"version": 1, "feeds": [{"name": "A", "url": "https://a.example/feed"}, {"name": "B", "url": "https://a.example/feed"}]}`, want: "appears more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" is rejected", func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			serv.handleAdminImport(rr, httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader(tt.body)))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			body := decodeJSONError(t, rr)
+			assert.Equal(t, errorCodeInvalidRequest, body.Code)
+			assert.Contains(t, body.Error, tt.want)
+		})
+	}
+
+	t.Run("Import store failures", func(t *testing.T) {
+		tests := []struct {
+			err    error
+			name   string
+			code   string
+			status int
+		}{
+			{name: "Duplicate feed", err: fmt.Errorf("failed to insert imported feed: %w", database.ErrFeedExists), status: http.StatusConflict, code: errorCodeDuplicateFeed},
+			{name: "Database error", err: fmt.Errorf("failed to commit import transaction: database is locked"), status: http.StatusInternalServerError, code: errorCodeInternal},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockStore.EXPECT().ImportState(gomock.Any(), gomock.Any()).Return(0, 0, tt.err)
+
+				rr := httptest.NewRecorder()
+				serv.handleAdminImport(rr, httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader(exported)))
+
+				assert.Equal(t, tt.status, rr.Code)
+				assert.Equal(t, tt.code, decodeJSONError(t, rr).Code)
+				assert.NotContains(t, rr.Body.String(), "database is locked", "store details are not exposed")
+			})
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"wallabag-rss-tool/views"
+)
+
+// staticMaxAge is how long browsers may reuse a static file before checking it is current.
+// The files carry an ETag, so after an upgrade changes them the check fetches the new ones.
+const staticMaxAge = time.Hour
+
+// handleStatic serves the pages' scripts embedded in views.Static, for SecurityHeaders.ExternalScripts
+func (s *Server) handleStatic(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	name := strings.TrimPrefix(request.URL.Path, "/static/")
+	content, err := views.Static.ReadFile("static/" + name)
+	if name == "" || err != nil {
+		http.NotFound(writer, request)
+
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	writer.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+	writer.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(staticMaxAge.Seconds())))
+	http.ServeContent(writer, request, name, time.Time{}, bytes.NewReader(content))
+}
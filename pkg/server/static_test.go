@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServer_handleStatic(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+	serv := NewServer(mockStore, mockClient, w)
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		serv.handleStatic(rr, httptest.NewRequest(method, path, http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Serves a script", func(t *testing.T) {
+		rr := get(http.MethodGet, "/static/feeds.js")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/javascript; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=3600", rr.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, rr.Header().Get("ETag"))
+		assert.Contains(t, rr.Body.String(), "function togglePollInterval()")
+	})
+
+	t.Run("Unchanged script is not sent again", func(t *testing.T) {
+		etag := get(http.MethodGet, "/static/articles.js").Header().Get("ETag")
+
+		req := httptest.NewRequest(http.MethodGet, "/static/articles.js", http.NoBody)
+		req.Header.Set("If-None-Match", etag)
+		rr := httptest.NewRecorder()
+		serv.handleStatic(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+	})
+
+	t.Run("Unknown file", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/static/missing.js").Code)
+		assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/static/").Code)
+		assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/static/../static.go").Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, get(http.MethodPost, "/static/feeds.js").Code)
+	})
+}
+
+func TestServer_ExternalScripts(t *testing.T) {
+	mockStore, mockClient, w := setupTestServer(t)
+
+	render := func(serv *Server) *httptest.ResponseRecorder {
+		mockStore.EXPECT().GetArticles(gomock.Any()).Return(nil, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil)
+		mockStore.EXPECT().GetUnsentArticles(gomock.Any()).Return(nil, nil)
+		rr := httptest.NewRecorder()
+		serv.AddSecurityHeaders(serv.handleArticles).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/articles", http.NoBody))
+
+		return rr
+	}
+
+	t.Run("Inline by default", func(t *testing.T) {
+		rr := render(NewServer(mockStore, mockClient, w))
+
+		assert.Contains(t, rr.Header().Get("Content-Security-Policy"), "script-src 'self' 'unsafe-inline'")
+		assert.Contains(t, rr.Body.String(), "new EventSource('/events')")
+		assert.NotContains(t, rr.Body.String(), `src="/static/articles.js"`)
+	})
+
+	t.Run("External when enabled, with inline scripts forbidden", func(t *testing.T) {
+		rr := render(NewServerWithConfig(mockStore, mockClient, w, Config{Headers: SecurityHeaders{ExternalScripts: true}}))
+
+		csp := rr.Header().Get("Content-Security-Policy")
+		assert.Contains(t, csp, "script-src 'self' https://unpkg.com")
+		assert.NotContains(t, csp, "script-src 'self' 'unsafe-inline'")
+		assert.Contains(t, rr.Body.String(), `<script src="/static/articles.js"></script>`)
+		assert.NotContains(t, rr.Body.String(), "new EventSource('/events')")
+	})
+}
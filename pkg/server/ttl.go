@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// checkFeedTTL compares feed's poll interval with the <ttl> the feed advertised. An interval that
+// polls more often is rejected when Config.EnforceTTL is set and otherwise only logged; the edit
+// form shows the same warning next to the interval.
+func (s *Server) checkFeedTTL(ctx context.Context, feed *models.Feed) error {
+	if feed.TTLMinutes <= 0 || !feed.PollsFasterThanTTL(s.getDefaultPollIntervalWithFallback(ctx)) {
+		return nil
+	}
+
+	if s.config.EnforceTTL {
+		return fmt.Errorf("the feed asks to be polled at most every %d minutes; choose an interval of at least that", feed.TTLMinutes)
+	}
+
+	logging.Info("Feed polled more often than its ttl asks",
+		"feed_id", feed.ID,
+		"feed_name", feed.Name,
+		"poll_interval_minutes", feed.PollIntervalMinutes,
+		"ttl_minutes", feed.TTLMinutes)
+
+	return nil
+}
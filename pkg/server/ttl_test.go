@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/models"
+)
+
+func TestServer_handleFeedsPut_TTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		interval   string
+		enforce    bool
+		wantStatus int
+	}{
+		{name: "Interval below ttl is saved when not enforced", interval: "5", wantStatus: http.StatusOK},
+		{name: "Interval below ttl is rejected when enforced", interval: "5", enforce: true, wantStatus: http.StatusBadRequest},
+		{name: "Interval at ttl is saved when enforced", interval: "60", enforce: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore, mockClient, w := setupTestServer(t)
+			serv := NewServerWithConfig(mockStore, mockClient, w, Config{EnforceTTL: tt.enforce})
+
+			existingFeed := &models.Feed{ID: 42, Name: "Hourly", URL: "https://example.com/feed.xml", TTLMinutes: 60}
+			mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(existingFeed, nil)
+			mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(1440, nil).AnyTimes()
+			if tt.wantStatus == http.StatusOK {
+				mockStore.EXPECT().UpdateFeed(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/feeds/42", http.NoBody)
+			req.Form = map[string][]string{
+				"name":               {"Hourly"},
+				"url":                {"https://example.com/feed.xml"},
+				"poll_interval":      {tt.interval},
+				"poll_interval_unit": {"minutes"},
+			}
+			rr := httptest.NewRecorder()
+
+			serv.handleFeedsPut(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				assert.Contains(t, rr.Body.String(), "at most every 60 minutes")
+			}
+		})
+	}
+}
+
+func TestServer_handleEditFeed_TTLWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		interval    int
+		wantWarning bool
+	}{
+		{name: "Interval below ttl warns", interval: 5, wantWarning: true},
+		{name: "Interval at ttl does not", interval: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore, mockClient, w := setupTestServer(t)
+			serv := NewServer(mockStore, mockClient, w)
+
+			feed := &models.Feed{ID: 42, Name: "Hourly", URL: "https://example.com/feed.xml", TTLMinutes: 60}
+			feed.SetPollInterval(tt.interval, models.TimeUnitMinutes)
+			mockStore.EXPECT().GetFeedByID(gomock.Any(), 42).Return(feed, nil)
+			mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(1440, nil).AnyTimes()
+
+			req := httptest.NewRequest(http.MethodGet, "/feeds/edit/42", http.NoBody)
+			rr := httptest.NewRecorder()
+
+			serv.handleEditFeed(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), "The feed asks to be polled at most every <strong>1 hour</strong>.")
+			if tt.wantWarning {
+				assert.Contains(t, rr.Body.String(), "This interval polls it more often.")
+			} else {
+				assert.NotContains(t, rr.Body.String(), "This interval polls it more often.")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package wallabag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	entryListURLPath     = "/api/entries/lists.json"
+	entryTagsListURLPath = "/api/entries/tags/lists.json"
+)
+
+// BatchSize is how many URLs AddEntriesBatch sends per request, Wallabag's default limit for
+// mass actions (api_limit_mass_actions); larger lists are split.
+const BatchSize = 10
+
+// listResult is one URL's outcome from the entries list endpoints: the entry ID, or false when
+// Wallabag could not add it
+type listResult struct {
+	URL   string          `json:"url"`
+	Entry json.RawMessage `json:"entry"`
+}
+
+// AddEntriesBatch adds urls to Wallabag BatchSize at a time through its entries list endpoint,
+// then applies the instance tag, if set, with one more request per batch. Wallabag versions
+// without the endpoint answer 404; from then on the client adds each URL with AddEntry
+// instead. Entries are returned in the order of urls, with ID 0 for any Wallabag could not
+// add. On another error the entries added so far are returned with it.
+func (c *Client) AddEntriesBatch(ctx context.Context, urls []string) ([]Entry, error) {
+	entries := make([]Entry, 0, len(urls))
+	for start := 0; start < len(urls); start += BatchSize {
+		batch := urls[start:min(start+BatchSize, len(urls))]
+
+		var added []Entry
+		var err error
+		if c.batchUnsupported.Load() {
+			added, err = c.addEntriesInTurn(ctx, batch)
+		} else {
+			added, err = c.postEntryList(ctx, batch)
+		}
+		entries = append(entries, added...)
+		if err != nil {
+			return entries, err
+		}
+	}
+
+	return entries, nil
+}
+
+// addEntriesInTurn adds urls one request at a time, for Wallabag versions without the list
+// endpoint. URLs Wallabag refuses get ID 0, as in a batch.
+func (c *Client) addEntriesInTurn(ctx context.Context, urls []string) ([]Entry, error) {
+	entries := make([]Entry, 0, len(urls))
+	for _, entryURL := range urls {
+		entry, err := c.AddEntry(ctx, entryURL, nil)
+		switch {
+		case IsRejected(err):
+			entries = append(entries, Entry{URL: entryURL})
+		case err != nil:
+			return entries, err
+		default:
+			entries = append(entries, *entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// postEntryList adds urls with one request to the entries list endpoint, falling back to
+// addEntriesInTurn if Wallabag does not have it
+func (c *Client) postEntryList(ctx context.Context, urls []string) ([]Entry, error) {
+	encoded, err := json.Marshal(urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal entry list: %w", err)
+	}
+
+	results, status, err := c.postList(ctx, entryListURLPath, "urls", encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add entry list: %w", err)
+	}
+	if status == http.StatusNotFound {
+		c.batchUnsupported.Store(true)
+
+		return c.addEntriesInTurn(ctx, urls)
+	}
+	if status != http.StatusOK {
+		return nil, &AddEntryError{StatusCode: status}
+	}
+
+	ids := make(map[string]int, len(results))
+	for _, result := range results {
+		var id int
+		if err := json.Unmarshal(result.Entry, &id); err == nil { // false for a URL Wallabag could not add
+			ids[result.URL] = id
+		}
+	}
+	entries := make([]Entry, 0, len(urls))
+	for _, entryURL := range urls {
+		entries = append(entries, Entry{URL: entryURL, ID: ids[entryURL]})
+	}
+
+	if c.instanceTag != "" {
+		if err := c.tagEntryList(ctx, entries); err != nil {
+			return entries, err
+		}
+	}
+
+	return entries, nil
+}
+
+// tagEntryList adds the instance tag to the added entries with one request
+func (c *Client) tagEntryList(ctx context.Context, entries []Entry) error {
+	type tagged struct {
+		URL  string `json:"url"`
+		Tags string `json:"tags"`
+	}
+	list := make([]tagged, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ID != 0 {
+			list = append(list, tagged{URL: entry.URL, Tags: joinTags(nil, c.instanceTag)})
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry tags list: %w", err)
+	}
+
+	_, status, err := c.postList(ctx, entryTagsListURLPath, "list", encoded)
+	if err != nil {
+		return fmt.Errorf("failed to tag entry list: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to tag entry list with status %d", status)
+	}
+
+	return nil
+}
+
+// postList sends a list endpoint request, which takes its JSON-encoded list in the query
+// parameter param, and returns its results and status
+func (c *Client) postList(ctx context.Context, path, param string, encoded []byte) ([]listResult, int, error) {
+	accessToken, err := c.validToken(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set(param, string(encoded))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			// Log error but don't return since the entries were already handled
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, nil
+	}
+
+	var results []listResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return results, resp.StatusCode, nil
+}
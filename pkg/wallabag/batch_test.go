@@ -0,0 +1,167 @@
+package wallabag_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+// batchServer is a stub Wallabag that records the requests it receives after authentication.
+// With listSupported false it answers the list endpoints 404, like versions without them.
+// URLs ending in "/broken" are refused.
+func batchServer(t *testing.T, listSupported bool) (*httptest.Server, func() []*http.Request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []*http.Request
+	nextID := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/oauth/v2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+
+		switch {
+		case r.URL.Path == "/api/entries/lists.json" && listSupported:
+			var urls []string
+			require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("urls")), &urls))
+			results := make([]map[string]interface{}, 0, len(urls))
+			for _, entryURL := range urls {
+				result := map[string]interface{}{"url": entryURL, "entry": false}
+				if entryURL != "https://example.com/broken" {
+					nextID++
+					result["entry"] = nextID
+				}
+				results = append(results, result)
+			}
+			json.NewEncoder(w).Encode(results)
+		case r.URL.Path == "/api/entries/tags/lists.json" && listSupported:
+			json.NewEncoder(w).Encode([]interface{}{})
+		case r.URL.Path == "/api/entries.json":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["url"] == "https://example.com/broken" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+			nextID++
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": nextID, "url": body["url"]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]*http.Request(nil), requests...)
+	}
+}
+
+// articleURLs returns n article URLs
+func articleURLs(n int) []string {
+	urls := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		urls = append(urls, fmt.Sprintf("https://example.com/%d", i))
+	}
+
+	return urls
+}
+
+func TestClient_AddEntriesBatch(t *testing.T) {
+	t.Run("Sends BatchSize URLs per request", func(t *testing.T) {
+		server, requests := batchServer(t, true)
+		defer server.Close()
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		urls := append(articleURLs(wallabag.BatchSize+2), "https://example.com/broken")
+
+		entries, err := client.AddEntriesBatch(context.Background(), urls)
+		require.NoError(t, err)
+
+		require.Len(t, entries, len(urls))
+		assert.Equal(t, wallabag.Entry{URL: "https://example.com/1", ID: 101}, entries[0])
+		assert.Equal(t, wallabag.Entry{URL: "https://example.com/broken"}, entries[len(entries)-1], "refused URLs have no ID")
+
+		sent := requests()
+		require.Len(t, sent, 2, "no tag requests without an instance tag")
+		for i, want := range [][]string{urls[:wallabag.BatchSize], urls[wallabag.BatchSize:]} {
+			assert.Equal(t, http.MethodPost, sent[i].Method)
+			assert.Equal(t, "Bearer token", sent[i].Header.Get("Authorization"))
+			var got []string
+			require.NoError(t, json.Unmarshal([]byte(sent[i].URL.Query().Get("urls")), &got))
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("Tags each batch with the instance tag", func(t *testing.T) {
+		server, requests := batchServer(t, true)
+		defer server.Close()
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		client.SetInstanceTag("homelab")
+
+		_, err := client.AddEntriesBatch(context.Background(), []string{"https://example.com/1", "https://example.com/broken"})
+		require.NoError(t, err)
+
+		sent := requests()
+		require.Len(t, sent, 2)
+		assert.Equal(t, "/api/entries/tags/lists.json", sent[1].URL.Path)
+		assert.JSONEq(t, `[{"url": "https://example.com/1", "tags": "homelab"}]`, sent[1].URL.Query().Get("list"))
+	})
+
+	t.Run("Falls back to one request per URL", func(t *testing.T) {
+		server, requests := batchServer(t, false)
+		defer server.Close()
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+		urls := append(articleURLs(wallabag.BatchSize+1), "https://example.com/broken")
+
+		entries, err := client.AddEntriesBatch(context.Background(), urls)
+		require.NoError(t, err)
+
+		require.Len(t, entries, len(urls))
+		assert.Equal(t, wallabag.Entry{URL: "https://example.com/1", ID: 101}, entries[0])
+		assert.Equal(t, wallabag.Entry{URL: "https://example.com/broken"}, entries[len(entries)-1])
+
+		sent := requests()
+		require.Len(t, sent, 1+len(urls), "the list endpoint is only tried once")
+		assert.Equal(t, "/api/entries/lists.json", sent[0].URL.Path)
+		for _, r := range sent[1:] {
+			assert.Equal(t, "/api/entries.json", r.URL.Path)
+		}
+	})
+
+	t.Run("Other errors stop the batch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/oauth/v2/token" {
+				json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+		entries, err := client.AddEntriesBatch(context.Background(), articleURLs(3))
+
+		var addErr *wallabag.AddEntryError
+		require.ErrorAs(t, err, &addErr)
+		assert.Equal(t, http.StatusInternalServerError, addErr.StatusCode)
+		assert.Empty(t, entries)
+	})
+}
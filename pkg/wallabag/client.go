@@ -5,21 +5,58 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	tokenURLPath = "/oauth/v2/token"
-	entryURLPath = "/api/entries.json"
+	tokenURLPath       = "/oauth/v2/token"
+	entryURLPath       = "/api/entries.json"
+	entryExistsURLPath = "/api/entries/exists.json"
+	entryByIDURLPath   = "/api/entries/%d.json"
+	entryTagsURLPath   = "/api/entries/%d/tags.json"
+	requestTimeout     = 10 * time.Second
 )
 
 // Clienter defines the interface for Wallabag API interactions.
 type Clienter interface {
 	Authenticate(ctx context.Context) error
-	AddEntry(ctx context.Context, urlToAdd string) (*Entry, error)
+	AddEntry(ctx context.Context, urlToAdd string, tags []string) (*Entry, error)
+	AddEntryWithContent(ctx context.Context, urlToAdd, title, content string, tags []string) (*Entry, error)
+	EntryExists(ctx context.Context, entryURL string) (bool, int, error)
+	AddTagsToEntry(ctx context.Context, entryID int, tags []string) error
+}
+
+// ErrEntryNotFound is returned when Wallabag has no entry with the requested ID, for example
+// because it was deleted there.
+var ErrEntryNotFound = errors.New("wallabag entry not found")
+
+// AddEntryError is returned when Wallabag answers a request to add an entry with an error
+// status. The response body is left out so it can't leak into logs or the UI.
+type AddEntryError struct {
+	StatusCode int
+}
+
+func (e *AddEntryError) Error() string {
+	return fmt.Sprintf("failed to add entry with status %d", e.StatusCode)
+}
+
+// Rejected reports whether Wallabag refused the entry itself with 400 Bad Request, as it does
+// when it can't fetch or parse the page, rather than failing for a reason unrelated to the URL.
+func (e *AddEntryError) Rejected() bool {
+	return e.StatusCode == http.StatusBadRequest
+}
+
+// IsRejected reports whether err is an AddEntryError for an entry Wallabag refused.
+func IsRejected(err error) bool {
+	var addErr *AddEntryError
+
+	return errors.As(err, &addErr) && addErr.Rejected()
 }
 
 // Client represents the Wallabag API client.
@@ -32,6 +69,11 @@ type Client struct {
 	username     string
 	password     string
 	accessToken  string
+	instanceTag  string       // Tag added to every entry to identify which instance sent it
+	authMu       sync.Mutex   // Serialises token requests so a forced re-auth and a lazy one don't interleave
+	tokenMu      sync.RWMutex // Guards accessToken and expiresAt for requests in flight
+
+	batchUnsupported atomic.Bool // Wallabag answered 404 to the entries list endpoint
 }
 
 // HTTPClient interface for mocking http.Client
@@ -47,10 +89,21 @@ func NewClient(baseURL, clientID, clientSecret, username, password string) *Clie
 		clientSecret: clientSecret,
 		username:     username,
 		password:     password,
-		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		httpClient:   &http.Client{Timeout: requestTimeout},
 	}
 }
 
+// SetInstanceTag sets a tag that is included on every entry this client adds, so entries
+// sent by several instances to the same Wallabag can be told apart. An empty tag disables it.
+func (c *Client) SetInstanceTag(tag string) {
+	c.instanceTag = tag
+}
+
+// SetTransport routes the client's Wallabag API requests through transport, e.g. a proxy.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient = &http.Client{Timeout: requestTimeout, Transport: transport}
+}
+
 // TokenResponse represents the response from the OAuth2 token endpoint.
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
@@ -67,8 +120,12 @@ type Entry struct {
 	ID    int    `json:"id"`
 }
 
-// Authenticate performs OAuth2 authentication and sets the access token.
+// Authenticate performs OAuth2 authentication and sets the access token. It is safe to call
+// while other requests are in flight; they keep using the old token until the new one is stored.
 func (c *Client) Authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
 	data := url.Values{}
 	data.Set("grant_type", "password")
 	data.Set("client_id", c.clientID)
@@ -103,21 +160,56 @@ func (c *Client) Authenticate(ctx context.Context) error {
 		return fmt.Errorf("failed to decode token response: %w", err)
 	}
 
+	c.tokenMu.Lock()
 	c.accessToken = tokenResp.AccessToken
 	c.expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	c.tokenMu.Unlock()
 
 	return nil
 }
 
-// AddEntry adds a new entry to Wallabag.
-func (c *Client) AddEntry(ctx context.Context, urlToAdd string) (*Entry, error) {
-	if c.accessToken == "" || time.Now().After(c.expiresAt) {
-		if err := c.Authenticate(ctx); err != nil {
-			return nil, fmt.Errorf("failed to authenticate before adding entry: %w", err)
-		}
+// validToken returns an access token that has not expired, authenticating first if needed
+func (c *Client) validToken(ctx context.Context) (string, error) {
+	if accessToken, valid := c.currentToken(); valid {
+		return accessToken, nil
+	}
+	if err := c.Authenticate(ctx); err != nil {
+		return "", err
+	}
+	accessToken, _ := c.currentToken()
+
+	return accessToken, nil
+}
+
+// currentToken returns the access token and whether it is still valid
+func (c *Client) currentToken() (string, bool) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+
+	return c.accessToken, c.accessToken != "" && time.Now().Before(c.expiresAt)
+}
+
+// AddEntry adds a new entry to Wallabag with the given tags, plus the instance tag if set.
+func (c *Client) AddEntry(ctx context.Context, urlToAdd string, tags []string) (*Entry, error) {
+	return c.postEntry(ctx, map[string]string{"url": urlToAdd}, tags)
+}
+
+// AddEntryWithContent adds a new entry with the article content supplied, so Wallabag stores
+// it instead of fetching the page itself.
+func (c *Client) AddEntryWithContent(ctx context.Context, urlToAdd, title, content string, tags []string) (*Entry, error) {
+	return c.postEntry(ctx, map[string]string{"url": urlToAdd, "title": title, "content": content}, tags)
+}
+
+// postEntry sends entryData with its tags to the entries endpoint, authenticating first if needed
+func (c *Client) postEntry(ctx context.Context, entryData map[string]string, tags []string) (*Entry, error) {
+	accessToken, err := c.validToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate before adding entry: %w", err)
 	}
 
-	entryData := map[string]string{"url": urlToAdd}
+	if joined := joinTags(tags, c.instanceTag); joined != "" {
+		entryData["tags"] = joined
+	}
 	jsonBody, err := json.Marshal(entryData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal entry data: %w", err)
@@ -128,7 +220,7 @@ func (c *Client) AddEntry(ctx context.Context, urlToAdd string) (*Entry, error)
 		return nil, fmt.Errorf("failed to create add entry request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -143,7 +235,7 @@ func (c *Client) AddEntry(ctx context.Context, urlToAdd string) (*Entry, error)
 	if resp.StatusCode != http.StatusOK {
 		// Don't include response body in error to prevent information disclosure
 
-		return nil, fmt.Errorf("failed to add entry with status %d", resp.StatusCode)
+		return nil, &AddEntryError{StatusCode: resp.StatusCode}
 	}
 
 	var entry Entry
@@ -153,3 +245,128 @@ func (c *Client) AddEntry(ctx context.Context, urlToAdd string) (*Entry, error)
 
 	return &entry, nil
 }
+
+// EntryExists reports whether entryURL is already saved in Wallabag and, if so, the ID of its
+// entry. Wallabag versions that cannot return the ID report existence with an ID of 0.
+func (c *Client) EntryExists(ctx context.Context, entryURL string) (bool, int, error) {
+	accessToken, err := c.validToken(ctx)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to authenticate before checking entry: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("url", entryURL)
+	query.Set("return_id", "1")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+entryExistsURLPath+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create entry exists request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to send entry exists request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			// Log error but don't return since we're processing response
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, 0, fmt.Errorf("failed to check entry with status %d", resp.StatusCode)
+	}
+
+	// With return_id the answer is the entry ID or null; older versions answer true or false
+	var body struct {
+		Exists json.RawMessage `json:"exists"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, 0, fmt.Errorf("failed to decode entry exists response: %w", err)
+	}
+
+	switch raw := string(body.Exists); raw {
+	case "", "null", "false":
+		return false, 0, nil
+	case "true":
+		return true, 0, nil
+	default:
+		var id int
+		if err := json.Unmarshal(body.Exists, &id); err != nil {
+			return false, 0, fmt.Errorf("failed to decode entry exists response: unexpected value %s", raw)
+		}
+
+		return true, id, nil
+	}
+}
+
+// DeleteEntry removes the entry with the given ID from Wallabag.
+func (c *Client) DeleteEntry(ctx context.Context, id int) error {
+	accessToken, err := c.validToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate before deleting entry: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+fmt.Sprintf(entryByIDURLPath, id), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create delete entry request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send delete entry request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			// Log error but don't return since the entry was already handled
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to delete entry with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// AddTagsToEntry adds tags, plus the instance tag, to an existing entry. Tags the entry already
+// has are kept. It returns ErrEntryNotFound if the entry no longer exists.
+func (c *Client) AddTagsToEntry(ctx context.Context, entryID int, tags []string) error {
+	accessToken, err := c.validToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate before tagging entry: %w", err)
+	}
+
+	jsonBody, err := json.Marshal(map[string]string{"tags": joinTags(tags, c.instanceTag)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry tags: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+fmt.Sprintf(entryTagsURLPath, entryID), bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create tag entry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send tag entry request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			// Log error but don't return since the entry was already handled
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("entry %d: %w", entryID, ErrEntryNotFound)
+	default:
+		return fmt.Errorf("failed to tag entry with status %d", resp.StatusCode)
+	}
+}
@@ -3,8 +3,13 @@ package wallabag_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,7 +137,7 @@ func TestClient_AddEntry(t *testing.T) {
 
 		client := wallabag.NewClient(server.URL, "test_client", "test_secret", "test_user", "test_pass")
 
-		entry, err := client.AddEntry(context.Background(), "https://example.com/article")
+		entry, err := client.AddEntry(context.Background(), "https://example.com/article", nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, entry)
 		// Cannot access entry fields from external test package
@@ -150,11 +155,294 @@ func TestClient_AddEntry(t *testing.T) {
 
 		client := wallabag.NewClient(server.URL, "wrong_client", "wrong_secret", "wrong_user", "wrong_pass")
 
-		entry, err := client.AddEntry(context.Background(), "https://example.com/article")
+		entry, err := client.AddEntry(context.Background(), "https://example.com/article", nil)
 		assert.Error(t, err)
 		assert.Nil(t, entry)
 		assert.Contains(t, err.Error(), "failed to authenticate before adding entry")
 	})
+
+	for _, tt := range []struct {
+		status   int
+		rejected bool
+	}{
+		{status: http.StatusBadRequest, rejected: true},
+		{status: http.StatusInternalServerError, rejected: false},
+	} {
+		t.Run(fmt.Sprintf("Add entry failure - status %d", tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/oauth/v2/token" {
+					json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+			_, err := client.AddEntry(context.Background(), "https://example.com/article", nil)
+			var addErr *wallabag.AddEntryError
+			assert.True(t, errors.As(err, &addErr))
+			assert.Equal(t, tt.status, addErr.StatusCode)
+			assert.Equal(t, fmt.Sprintf("failed to add entry with status %d", tt.status), err.Error())
+			assert.Equal(t, tt.rejected, wallabag.IsRejected(fmt.Errorf("wrapped: %w", err)))
+		})
+	}
+}
+
+func TestClient_ReauthenticateWhileAdding(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/v2/token":
+			n := tokenRequests.Add(1)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": fmt.Sprintf("token-%d", n),
+				"expires_in":   3600,
+			})
+		case "/api/entries.json":
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "url": "https://example.com"})
+		}
+	}))
+	defer server.Close()
+
+	client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+	assert.NoError(t, client.Authenticate(context.Background()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := client.AddEntry(context.Background(), "https://example.com", nil)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Authenticate(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	// Every forced re-auth hits the token endpoint; adds reuse whichever token is current
+	assert.Equal(t, int32(11), tokenRequests.Load())
+}
+
+func TestClient_AddEntryWithContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/v2/token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+		case "/api/entries.json":
+			var entryData map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&entryData))
+			assert.Equal(t, "https://example.com/article", entryData["url"])
+			assert.Equal(t, "Extracted Article", entryData["title"])
+			assert.Equal(t, "<p>Body</p>", entryData["content"])
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "url": entryData["url"]})
+		}
+	}))
+	defer server.Close()
+
+	client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+	entry, err := client.AddEntryWithContent(context.Background(), "https://example.com/article", "Extracted Article", "<p>Body</p>", nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, 7, entry.ID)
+	}
+}
+
+func TestClient_EntryExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		status     int
+		wantExists bool
+		wantID     int
+		wantErr    string
+	}{
+		{name: "Existing entry with ID", response: `{"exists": 42}`, status: http.StatusOK, wantExists: true, wantID: 42},
+		{name: "Missing entry", response: `{"exists": null}`, status: http.StatusOK},
+		{name: "Older Wallabag reports true", response: `{"exists": true}`, status: http.StatusOK, wantExists: true},
+		{name: "Older Wallabag reports false", response: `{"exists": false}`, status: http.StatusOK},
+		{name: "Unexpected value", response: `{"exists": "yes"}`, status: http.StatusOK, wantErr: "unexpected value"},
+		{name: "Malformed response", response: `not json`, status: http.StatusOK, wantErr: "failed to decode entry exists response"},
+		{name: "Server error", response: `{}`, status: http.StatusInternalServerError, wantErr: "failed to check entry with status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/oauth/v2/token":
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+				case "/api/entries/exists.json":
+					assert.Equal(t, "GET", r.Method)
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					assert.Equal(t, "https://example.com/article?id=1", r.URL.Query().Get("url"))
+					assert.Equal(t, "1", r.URL.Query().Get("return_id"))
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.response))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+			exists, id, err := client.EntryExists(context.Background(), "https://example.com/article?id=1")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestClient_DeleteEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{name: "Deleted", status: http.StatusOK},
+		{name: "Missing entry", status: http.StatusNotFound, wantErr: "failed to delete entry with status 404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/oauth/v2/token":
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+				case "/api/entries/42.json":
+					assert.Equal(t, "DELETE", r.Method)
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					w.WriteHeader(tt.status)
+					w.Write([]byte(`{}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+			err := client.DeleteEntry(context.Background(), 42)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_AddTagsToEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantErr  string
+		notFound bool
+	}{
+		{name: "Tagged", status: http.StatusOK},
+		{name: "Missing entry", status: http.StatusNotFound, wantErr: "entry 42", notFound: true},
+		{name: "Server error", status: http.StatusInternalServerError, wantErr: "failed to tag entry with status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/oauth/v2/token":
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+				case "/api/entries/42/tags.json":
+					assert.Equal(t, "POST", r.Method)
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					var body map[string]string
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "golang,homelab", body["tags"])
+					w.WriteHeader(tt.status)
+					w.Write([]byte(`{}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+			client.SetInstanceTag("homelab")
+
+			err := client.AddTagsToEntry(context.Background(), 42, []string{"golang"})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, tt.notFound, errors.Is(err, wallabag.ErrEntryNotFound))
+
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_InstanceTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		instanceTag string
+		entryTags   []string
+		wantTags    string
+		wantPresent bool
+	}{
+		{name: "Instance tag sent on every entry", instanceTag: "homelab", wantTags: "homelab", wantPresent: true},
+		{name: "No tags when unset", instanceTag: "", wantPresent: false},
+		{name: "Entry tags come before the instance tag", instanceTag: "homelab", entryTags: []string{"hacker-news"}, wantTags: "hacker-news,homelab", wantPresent: true},
+		{name: "Entry tags without an instance tag", entryTags: []string{"hacker-news"}, wantTags: "hacker-news", wantPresent: true},
+		{name: "Repeated and empty tags dropped", instanceTag: "homelab", entryTags: []string{"homelab", "", "tech"}, wantTags: "homelab,tech", wantPresent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/oauth/v2/token":
+					json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+				case "/api/entries.json":
+					requests.Add(1)
+					var entryData map[string]string
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&entryData))
+					tags, present := entryData["tags"]
+					assert.Equal(t, tt.wantPresent, present)
+					assert.Equal(t, tt.wantTags, tags)
+					json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "url": entryData["url"]})
+				}
+			}))
+			defer server.Close()
+
+			client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+			client.SetInstanceTag(tt.instanceTag)
+
+			for _, articleURL := range []string{"https://example.com/1", "https://example.com/2"} {
+				_, err := client.AddEntry(context.Background(), articleURL, tt.entryTags)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, int32(2), requests.Load())
+		})
+	}
 }
 
 func TestClient_Interface(t *testing.T) {
@@ -165,7 +453,7 @@ func TestClient_Interface(t *testing.T) {
 		// Test that we can call interface methods
 		assert.NotPanics(t, func() {
 			client.Authenticate(context.Background())
-			client.AddEntry(context.Background(), "https://example.com/article")
+			client.AddEntry(context.Background(), "https://example.com/article", nil)
 		})
 	})
 }
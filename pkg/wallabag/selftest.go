@@ -0,0 +1,32 @@
+package wallabag
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// selfTestURL is the address of the entry SelfTest adds. A unique query string is appended so
+// the entry never matches one the user saved, which Wallabag would return instead of creating
+// a new one and which SelfTest would then delete.
+const selfTestURL = "https://example.com/wallabag-rss-selftest"
+
+// SelfTest checks end to end that the client can authenticate, add an entry and delete it
+// again. The entry is added with its own content, so Wallabag does not fetch the page.
+func (c *Client) SelfTest(ctx context.Context) error {
+	entryURL := fmt.Sprintf("%s?run=%d", selfTestURL, time.Now().UnixNano())
+	entry, err := c.AddEntryWithContent(ctx, entryURL, "wallabag-rss self-test",
+		"<p>Added and removed by the wallabag-rss startup self-test.</p>", nil)
+	if err != nil {
+		return fmt.Errorf("self-test add failed: %w", err)
+	}
+	if entry.ID == 0 {
+		return fmt.Errorf("self-test add returned no entry ID")
+	}
+
+	if err := c.DeleteEntry(ctx, entry.ID); err != nil {
+		return fmt.Errorf("self-test delete of entry %d failed: %w", entry.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package wallabag_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+// selfTestServer is a stub Wallabag that records the entry requests it receives, answering the
+// delete with deleteStatus
+func selfTestServer(t *testing.T, deleteStatus int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/v2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/entries.json":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.True(t, strings.HasPrefix(body["url"], "https://example.com/wallabag-rss-selftest?run="))
+			assert.NotEmpty(t, body["content"])
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "url": body["url"]})
+		case r.Method == "DELETE" && r.URL.Path == "/api/entries/7.json":
+			w.WriteHeader(deleteStatus)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestClient_SelfTest(t *testing.T) {
+	t.Run("Adds then deletes the test entry", func(t *testing.T) {
+		server, calls := selfTestServer(t, http.StatusOK)
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+		err := client.SelfTest(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"POST /api/entries.json", "DELETE /api/entries/7.json"}, calls())
+	})
+
+	t.Run("Reports a failed delete", func(t *testing.T) {
+		server, calls := selfTestServer(t, http.StatusForbidden)
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+		err := client.SelfTest(context.Background())
+
+		assert.ErrorContains(t, err, "self-test delete of entry 7 failed")
+		assert.Len(t, calls(), 2)
+	})
+
+	t.Run("Does not delete when the add fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/oauth/v2/token" {
+				json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+
+				return
+			}
+			assert.NotEqual(t, "DELETE", r.Method)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := wallabag.NewClient(server.URL, "id", "secret", "user", "pass")
+
+		err := client.SelfTest(context.Background())
+
+		assert.ErrorContains(t, err, "self-test add failed")
+	})
+}
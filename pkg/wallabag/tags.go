@@ -0,0 +1,44 @@
+package wallabag
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SlugifyTag turns a name into a Wallabag tag: lowercased, with each run of spaces or
+// punctuation collapsed to a single hyphen. Letters and digits from any script are kept.
+func SlugifyTag(name string) string {
+	var builder strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingHyphen && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			pendingHyphen = false
+			builder.WriteRune(r)
+
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	return builder.String()
+}
+
+// joinTags merges entry tags with the instance tag into Wallabag's comma-separated form.
+// Entry tags come first in the order given; empty and repeated tags are dropped.
+func joinTags(entryTags []string, instanceTag string) string {
+	seen := make(map[string]bool, len(entryTags)+1)
+	merged := make([]string, 0, len(entryTags)+1)
+	for _, tag := range append(append([]string{}, entryTags...), instanceTag) {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		merged = append(merged, tag)
+	}
+
+	return strings.Join(merged, ",")
+}
@@ -0,0 +1,32 @@
+package wallabag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+func TestSlugifyTag(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "Single word", in: "Tech", want: "tech"},
+		{name: "Spaces", in: "Hacker News", want: "hacker-news"},
+		{name: "Repeated and surrounding spaces", in: "  The   Verge  ", want: "the-verge"},
+		{name: "Punctuation", in: "Ars Technica: Science & Tech!", want: "ars-technica-science-tech"},
+		{name: "Commas cannot split the tag", in: "News, Politics", want: "news-politics"},
+		{name: "Digits kept", in: "C++ Weekly #42", want: "c-weekly-42"},
+		{name: "Accented letters", in: "Café Müller", want: "café-müller"},
+		{name: "Non-Latin script", in: "Новости Мира", want: "новости-мира"},
+		{name: "Only punctuation", in: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wallabag.SlugifyTag(tt.in))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// sendDebounce tracks each feed's open debounce window. While a window is open the feed's new
+// articles are held back; once it closes they are all sent on the feed's next run, which the
+// worker queues for the moment the window closes. The window is kept apart from the feed's
+// fetch bookkeeping, so the feed still keeps to its poll interval until then.
+type sendDebounce struct {
+	mu      sync.Mutex
+	windows map[int]time.Time // Feed ID to when its window closes
+}
+
+// holding reports whether a new article of feedID found at now must wait. The first new article
+// opens a window of the given length and calls onOpen with it, so the caller can schedule the
+// feed for when it closes.
+func (d *sendDebounce) holding(feedID int, window time.Duration, now time.Time, onOpen func(time.Duration)) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.windows == nil {
+		d.windows = make(map[int]time.Time)
+	}
+	closes, open := d.windows[feedID]
+	if !open {
+		closes = now.Add(window)
+		d.windows[feedID] = closes
+		onOpen(window)
+	}
+
+	return now.Before(closes)
+}
+
+// release forgets feedID's window if it had closed by now, so the next new article opens another
+func (d *sendDebounce) release(feedID int, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if closes, open := d.windows[feedID]; open && !now.Before(closes) {
+		delete(d.windows, feedID)
+	}
+}
+
+// closed reports whether feedID has a window that had closed by now and still holds articles
+// waiting to be sent
+func (d *sendDebounce) closed(feedID int, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	closes, open := d.windows[feedID]
+
+	return open && !now.Before(closes)
+}
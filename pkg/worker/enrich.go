@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// enrichQueueCapacity is how many feeds may wait for enrichment. Feeds queued beyond it are
+// enriched by their next regular fetch instead.
+const enrichQueueCapacity = 100
+
+// enrichTimeout bounds the fetch and favicon lookup of one feed's enrichment.
+const enrichTimeout = 2 * time.Minute
+
+// QueueFeedEnrichment queues a background lookup of a feed's details, its favicon and whether
+// it carries full content, so a newly added feed shows them without waiting for the form
+// submission or its first regular fetch. Subscribers to Events get a feed-updated event when
+// it is done. It never blocks: when the queue is full the feed is left to its regular fetch.
+func (w *Worker) QueueFeedEnrichment(feedID int) {
+	select {
+	case w.enrichQueue <- feedID:
+		logging.Debug("Feed queued for enrichment", "feed_id", feedID)
+	default:
+		logging.Warn("Enrichment queue is full, feed details will be filled in by its next fetch",
+			"feed_id", feedID,
+			"queue_capacity", enrichQueueCapacity)
+	}
+}
+
+// processEnrichQueue enriches queued feeds until the worker stops. Start runs
+// Config.EnrichConcurrency of these.
+func (w *Worker) processEnrichQueue() {
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case feedID := <-w.enrichQueue:
+			ctx, cancel := context.WithTimeout(context.Background(), enrichTimeout)
+			w.enrichFeed(ctx, feedID)
+			cancel()
+		}
+	}
+}
+
+// enrichFeed fetches a feed to record its favicon and content kind, then announces the change.
+// Failures are logged and otherwise ignored; the feed itself is never changed or removed.
+func (w *Worker) enrichFeed(ctx context.Context, feedID int) {
+	feed, err := w.store.GetFeedByID(ctx, feedID)
+	if err != nil {
+		logging.Warn("Failed to load feed for enrichment", "feed_id", feedID,
+			"error", fmt.Errorf("store.GetFeedByID: %w", err))
+
+		return
+	}
+	feedLogger := logging.With("feed_id", feed.ID, "feed_name", feed.Name, "feed_url", feed.URL)
+
+	// Every item counts towards the content kind, whatever the feed's sync options
+	probe := *feed
+	probe.InitialSyncDone = true
+	result, err := w.rssProcessor.FetchFeed(ctx, &probe)
+	if err != nil {
+		feedLogger.Info("Feed enrichment fetch failed, details will be filled in by its next fetch", "error", err)
+
+		return
+	}
+
+	w.refreshFavicon(ctx, feedLogger, feed, result)
+	w.updateContentKind(ctx, feedLogger, feed, result)
+	w.updateTTL(ctx, feedLogger, feed, result)
+
+	w.events.Publish(events.Event{Type: events.TypeFeedUpdated, Data: events.FeedData{FeedID: feed.ID}})
+}
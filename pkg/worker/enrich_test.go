@@ -0,0 +1,110 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_QueueFeedEnrichment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	// The polling loop runs alongside with no feeds to process
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).AnyTimes()
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{EnrichConcurrency: 2})
+	feedEvents, unsubscribe := w.Events().Subscribe()
+	defer unsubscribe()
+	w.Start()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx))
+	}()
+
+	waitForEvent := func(t *testing.T, eventType string) events.Event {
+		t.Helper()
+		for {
+			select {
+			case event := <-feedEvents:
+				if event.Type == eventType {
+					return event
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no %s event", eventType)
+			}
+		}
+	}
+
+	t.Run("Runs in the background after queueing", func(t *testing.T) {
+		release := make(chan struct{})
+		fetched := make(chan struct{})
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, URL: "https://example.com/new", SyncMode: models.SyncModeNone}, nil)
+		// Every item is considered, whatever the feed's sync options
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Cond(func(f *models.Feed) bool { return f.InitialSyncDone })).DoAndReturn(
+			func(context.Context, *models.Feed) (*rss.FeedResult, error) {
+				close(fetched)
+				<-release
+
+				return &rss.FeedResult{ContentKind: models.ContentKindFull}, nil
+			})
+		mockStore.EXPECT().UpdateFeedContentKind(gomock.Any(), 7, models.ContentKindFull).Return(nil)
+
+		w.QueueFeedEnrichment(7)
+
+		// Queueing returned while the fetch is still held open
+		select {
+		case <-fetched:
+		case <-time.After(5 * time.Second):
+			t.Fatal("enrichment fetch did not start")
+		}
+		close(release)
+
+		event := waitForEvent(t, events.TypeFeedUpdated)
+		assert.Equal(t, events.FeedData{FeedID: 7}, event.Data)
+	})
+
+	t.Run("Failed fetch leaves the feed alone", func(t *testing.T) {
+		done := make(chan struct{})
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 8).Return(&models.Feed{ID: 8, URL: "https://example.com/broken"}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/broken")).DoAndReturn(
+			func(context.Context, *models.Feed) (*rss.FeedResult, error) {
+				defer close(done)
+
+				return nil, errors.New("connection refused")
+			})
+
+		w.QueueFeedEnrichment(8)
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("enrichment fetch did not run")
+		}
+		// No store update, delete or event follows; the mocks fail on any unexpected call
+		time.Sleep(50 * time.Millisecond)
+		select {
+		case event := <-feedEvents:
+			require.NotEqual(t, events.TypeFeedUpdated, event.Type)
+		default:
+		}
+	})
+}
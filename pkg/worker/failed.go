@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+// ErrArticleNotRetryable is returned by RetryArticle for an article that was sent, filtered or
+// marked processed rather than refused by Wallabag.
+var ErrArticleNotRetryable = errors.New("article is not an unsent article")
+
+// recordFailedArticle saves an article Wallabag refused as unsent, for Config.SaveOnFailure. A
+// failure to save is logged and the article is simply retried on the next poll.
+func (w *Worker) recordFailedArticle(ctx context.Context, articleLogger logging.Logger, feed *models.Feed, article rss.Article, originalURL string) {
+	modelArticle := models.Article{
+		Title:       article.Title,
+		URL:         article.URL,
+		PublishedAt: article.PublishedAt,
+	}
+	if originalURL != article.URL {
+		modelArticle.OriginalURL = originalURL
+	}
+
+	if err := w.store.SaveFailedArticle(ctx, feed.ID, &modelArticle); err != nil {
+		articleLogger.Error("Failed to record article refused by Wallabag",
+			"error", fmt.Errorf("store.SaveFailedArticle: %w", err))
+
+		return
+	}
+	articleLogger.Info("Recorded refused article as unsent so it is not retried every poll")
+}
+
+// RetryArticle sends an unsent article to Wallabag again the way its feed delivers articles,
+// and records the entry it gets. An article whose feed has since been deleted is sent as a
+// link.
+func (w *Worker) RetryArticle(ctx context.Context, articleID int) (*wallabag.Entry, error) {
+	article, err := w.store.GetArticleByID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("store.GetArticleByID: %w", err)
+	}
+	if article.WallabagEntryID != nil || article.Filtered || article.MarkedProcessed {
+		return nil, ErrArticleNotRetryable
+	}
+
+	feed, err := w.store.GetFeedByID(ctx, article.FeedID)
+	if err != nil {
+		feed = &models.Feed{ID: article.FeedID}
+	}
+
+	articleLogger := logging.With("feed_id", feed.ID, "article_id", article.ID, "article_url", article.URL)
+	articleLogger.Info("Retrying unsent article")
+	entry, err := w.sendToWallabag(ctx, articleLogger, feed, rss.Article{
+		Title:       article.Title,
+		URL:         article.URL,
+		PublishedAt: article.PublishedAt,
+	})
+	if err != nil {
+		w.Config().Audit.Record(models.AuditArticleFailed, feed.ID, fmt.Sprintf("%s: %v", article.URL, err))
+
+		return nil, err
+	}
+	w.Config().Audit.Record(models.AuditArticleSent, feed.ID,
+		fmt.Sprintf("%s (Wallabag entry %d)", article.URL, entry.ID))
+
+	if err := w.store.SetArticleWallabagEntry(ctx, article.ID, entry.ID); err != nil {
+		return nil, fmt.Errorf("store.SetArticleWallabagEntry: %w", err)
+	}
+
+	w.events.Publish(events.Event{Type: events.TypeArticle, Data: events.ArticleData{
+		FeedID:          feed.ID,
+		FeedName:        feed.Name,
+		Title:           article.Title,
+		URL:             article.URL,
+		WallabagEntryID: entry.ID,
+	}})
+
+	return entry, nil
+}
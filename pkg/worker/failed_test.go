@@ -0,0 +1,118 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	"wallabag-rss-tool/pkg/wallabag"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_SaveOnFailure(t *testing.T) {
+	const refusedURL = "https://example.com/unscrapeable"
+	feeds := []models.Feed{{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}}
+	result := &rss.FeedResult{Articles: []rss.Article{{Title: "Refused", URL: refusedURL}}}
+
+	setup := func(t *testing.T) (*mocks.MockStorer, *wallabagmocks.MockClienter, *rssmocks.MockProcessorer) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil).Times(2)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil).Times(2)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil).Times(2)
+
+		return mockStore, mockClient, mockProcessor
+	}
+
+	t.Run("Off: not saved and retried on the next poll", func(t *testing.T) {
+		mockStore, mockClient, mockProcessor := setup(t)
+		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), refusedURL).Return(false, nil).Times(2)
+		mockClient.EXPECT().AddEntry(gomock.Any(), refusedURL, nil).Return(nil, errors.New("status 500")).Times(2)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.ProcessFeeds()
+		w.ProcessFeeds()
+	})
+
+	t.Run("On: saved as unsent and not retried", func(t *testing.T) {
+		mockStore, mockClient, mockProcessor := setup(t)
+		saved := false
+		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), refusedURL).DoAndReturn(
+			func(context.Context, string) (bool, error) {
+				return saved, nil
+			}).Times(2)
+		mockClient.EXPECT().AddEntry(gomock.Any(), refusedURL, nil).Return(nil, errors.New("status 500"))
+		mockStore.EXPECT().SaveFailedArticle(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ int, article *models.Article) error {
+				assert.Equal(t, refusedURL, article.URL)
+				saved = true
+
+				return nil
+			})
+
+		w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{SaveOnFailure: true})
+		w.ProcessFeeds()
+		w.ProcessFeeds()
+	})
+}
+
+func TestWorker_RetryArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	ctx := context.Background()
+
+	t.Run("Sends the article and records its entry", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 3).Return(&models.Article{ID: 3, FeedID: 1, Title: "Refused", URL: "https://example.com/refused"}, nil)
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 1).Return(&models.Feed{ID: 1, Name: "Feed"}, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/refused", nil).Return(&wallabag.Entry{ID: 77}, nil)
+		mockStore.EXPECT().SetArticleWallabagEntry(gomock.Any(), 3, 77).Return(nil)
+
+		entry, err := w.RetryArticle(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 77, entry.ID)
+	})
+
+	t.Run("Still refused", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 3).Return(&models.Article{ID: 3, FeedID: 1, URL: "https://example.com/refused"}, nil)
+		// The feed was deleted since; the article is sent as a link
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 1).Return(nil, errors.New("feed with ID 1 not found"))
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/refused", nil).Return(nil, errors.New("status 500"))
+
+		_, err := w.RetryArticle(ctx, 3)
+		assert.ErrorContains(t, err, "status 500")
+	})
+
+	t.Run("Sent articles are not retried", func(t *testing.T) {
+		entryID := 5
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 4).Return(&models.Article{ID: 4, WallabagEntryID: &entryID}, nil)
+
+		_, err := w.RetryArticle(ctx, 4)
+		assert.ErrorIs(t, err, worker.ErrArticleNotRetryable)
+	})
+
+	t.Run("Unknown article", func(t *testing.T) {
+		mockStore.EXPECT().GetArticleByID(gomock.Any(), 9).Return(nil, database.ErrArticleNotFound)
+
+		_, err := w.RetryArticle(ctx, 9)
+		assert.ErrorIs(t, err, database.ErrArticleNotFound)
+	})
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"wallabag-rss-tool/pkg/favicon"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+// FaviconRefreshInterval is how long a feed's favicon lookup is trusted before the site is
+// checked again.
+const FaviconRefreshInterval = 7 * 24 * time.Hour
+
+// refreshFavicon looks up the favicon of the feed's site when favicons are enabled and the last
+// lookup is missing or stale. Failures store an empty icon so the site is not retried on every
+// poll.
+func (w *Worker) refreshFavicon(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, result *rss.FeedResult) {
+	if w.favicons == nil {
+		return
+	}
+	if feed.FaviconCheckedAt != nil && time.Since(*feed.FaviconCheckedAt) < FaviconRefreshInterval {
+		return
+	}
+
+	site := faviconSite(feed.URL, result.SiteURL)
+	icon, err := w.favicons.Discover(ctx, site)
+	if err != nil {
+		if errors.Is(err, favicon.ErrNotFound) {
+			feedLogger.Debug("Feed site has no favicon", "site_url", site)
+		} else {
+			feedLogger.Warn("Failed to look up feed favicon", "error", err, "site_url", site)
+		}
+		icon = ""
+	}
+
+	if err := w.store.UpdateFeedFavicon(ctx, feed.ID, icon); err != nil {
+		feedLogger.Error("Failed to update feed favicon",
+			"error", fmt.Errorf("store.UpdateFeedFavicon: %w", err))
+
+		return
+	}
+
+	now := time.Now()
+	feed.FaviconURL = icon
+	feed.FaviconCheckedAt = &now
+}
+
+// faviconSite picks the page to look for a favicon on: the site the feed links to, or the root
+// of the feed's own host when the feed names no site
+func faviconSite(feedURL, siteURL string) string {
+	if site, err := url.Parse(siteURL); err == nil && (site.Scheme == "http" || site.Scheme == "https") && site.Host != "" {
+		return siteURL
+	}
+
+	parsed, err := url.Parse(feedURL)
+	if err != nil {
+		return feedURL
+	}
+
+	return (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: "/"}).String()
+}
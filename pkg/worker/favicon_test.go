@@ -0,0 +1,59 @@
+package worker_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_RefreshFavicon(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.Write([]byte(`<html><head><link rel="icon" href="/icon.png"></head></html>`))
+
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer site.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	recent := time.Now().Add(-time.Hour)
+	stale := time.Now().Add(-2 * worker.FaviconRefreshInterval)
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/new", Name: "Never checked", PollIntervalMinutes: 30, InitialSyncDone: true},
+		{ID: 2, URL: "https://example.com/recent", Name: "Recently checked", PollIntervalMinutes: 30, InitialSyncDone: true, FaviconCheckedAt: &recent},
+		{ID: 3, URL: "https://example.com/stale", Name: "Stale", PollIntervalMinutes: 30, InitialSyncDone: true, FaviconURL: "https://old.example/icon.png", FaviconCheckedAt: &stale},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/new")).
+		Return(&rss.FeedResult{SiteURL: site.URL + "/"}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/recent")).
+		Return(&rss.FeedResult{SiteURL: site.URL + "/"}, nil)
+	// The site no longer has an icon, so the stale one is cleared rather than kept
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/stale")).
+		Return(&rss.FeedResult{SiteURL: site.URL + "/missing"}, nil)
+
+	mockStore.EXPECT().UpdateFeedFavicon(gomock.Any(), 1, site.URL+"/icon.png").Return(nil)
+	mockStore.EXPECT().UpdateFeedFavicon(gomock.Any(), 3, "").Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{Favicons: true})
+	w.ProcessFeeds()
+}
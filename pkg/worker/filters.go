@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+// FilterVerdict is what a feed's filters would do with one item, and why.
+type FilterVerdict struct {
+	Reason   string
+	Included bool // The item would be sent
+	Deferred bool // The item passes the filters but is held until it reaches the feed's minimum age
+}
+
+// ExplainFilters applies the feed's minimum title length, category filter and minimum age to an
+// item the way processing does, describing which rule decided the outcome. globalMinTitle is
+// Config.MinTitleLength, used for feeds without a minimum of their own.
+func ExplainFilters(feed *models.Feed, article rss.Article, globalMinTitle int, now time.Time) FilterVerdict {
+	if minimum := minTitleLength(feed, globalMinTitle); isTitleTooShort(article, minimum) {
+		return FilterVerdict{Reason: fmt.Sprintf("Title shorter than %d characters", minimum)}
+	}
+
+	var verdict FilterVerdict
+	switch {
+	case len(feed.IncludeCategories) == 0:
+		verdict = FilterVerdict{Included: true, Reason: "No category filter"}
+	case len(article.Categories) == 0:
+		return FilterVerdict{Reason: "Item has no categories"}
+	default:
+		category, ok := feed.MatchingCategory(article.Categories)
+		if !ok {
+			return FilterVerdict{Reason: fmt.Sprintf("No included category among %s", strings.Join(article.Categories, ", "))}
+		}
+		verdict = FilterVerdict{Included: true, Reason: fmt.Sprintf("Category %q is included", category)}
+	}
+
+	if isTooRecent(feed, article, now) {
+		verdict.Deferred = true
+		verdict.Reason += fmt.Sprintf("; held until %d minutes old", feed.MinAgeMinutes)
+	}
+
+	return verdict
+}
+
+// minTitleLength returns the feed's minimum title length, or global for feeds without one
+func minTitleLength(feed *models.Feed, global int) int {
+	if feed.MinTitleLength > 0 {
+		return feed.MinTitleLength
+	}
+
+	return global
+}
+
+// isTitleTooShort reports whether the article's title, ignoring surrounding space, has fewer than
+// minimum characters. A minimum of zero or less lets every title through.
+func isTitleTooShort(article rss.Article, minimum int) bool {
+	return minimum > 0 && utf8.RuneCountInString(strings.TrimSpace(article.Title)) < minimum
+}
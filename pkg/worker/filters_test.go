@@ -0,0 +1,67 @@
+package worker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestExplainFilters(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-10 * time.Minute)
+	filtered := &models.Feed{IncludeCategories: []string{"Go", "Databases"}}
+
+	tests := []struct {
+		name    string
+		feed    *models.Feed
+		article rss.Article
+		want    worker.FilterVerdict
+	}{
+		{
+			name:    "No filter",
+			feed:    &models.Feed{},
+			article: rss.Article{Title: "Anything"},
+			want:    worker.FilterVerdict{Included: true, Reason: "No category filter"},
+		},
+		{
+			name:    "Included category",
+			feed:    filtered,
+			article: rss.Article{Categories: []string{"Sport", " databases "}},
+			want:    worker.FilterVerdict{Included: true, Reason: `Category "databases" is included`},
+		},
+		{
+			name:    "No included category",
+			feed:    filtered,
+			article: rss.Article{Categories: []string{"Sport", "Music"}},
+			want:    worker.FilterVerdict{Reason: "No included category among Sport, Music"},
+		},
+		{
+			name:    "No categories",
+			feed:    filtered,
+			article: rss.Article{},
+			want:    worker.FilterVerdict{Reason: "Item has no categories"},
+		},
+		{
+			name:    "Title too short",
+			feed:    &models.Feed{MinTitleLength: 3},
+			article: rss.Article{Title: "Go"},
+			want:    worker.FilterVerdict{Reason: "Title shorter than 3 characters"},
+		},
+		{
+			name:    "Too recent",
+			feed:    &models.Feed{MinAgeMinutes: 60},
+			article: rss.Article{PublishedAt: &recent},
+			want:    worker.FilterVerdict{Included: true, Deferred: true, Reason: "No category filter; held until 60 minutes old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, worker.ExplainFilters(tt.feed, tt.article, 0, now))
+		})
+	}
+}
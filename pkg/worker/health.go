@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStaleAfter is how long a worker may go without completing a polling cycle before it
+// is reported stale, when Config.StaleAfter is unset and the polling interval is not yet known.
+// Once the polling loop has read its interval, twice that interval is used instead.
+const DefaultStaleAfter = time.Hour
+
+// FetchErrorLogWindow is how often a feed that keeps failing with the same error is logged
+// again, as a summary of the failures skipped in between. A different error logs at once.
+const FetchErrorLogWindow = time.Hour
+
+// WorkerHealth is a snapshot of the worker's polling cycle state.
+type WorkerHealth struct {
+	StartedAt   time.Time // When the worker was created
+	LastSuccess time.Time // When the last polling cycle completed; zero if none has
+	LastErrorAt time.Time // When the last polling cycle failed; zero if none has
+	LastError   string    // Why the last failed polling cycle failed
+	StaleAfter  time.Duration
+	Stale       bool // No cycle has completed within StaleAfter, so the worker may be stuck
+}
+
+// healthState records polling cycle outcomes. It is shared by the polling loop, which writes
+// it, and health checks, which read it.
+type healthState struct {
+	mu            sync.Mutex
+	startedAt     time.Time
+	lastSuccess   time.Time
+	lastErrorAt   time.Time
+	lastError     string
+	cycleInterval time.Duration
+	feedErrors    map[int]string    // Feed ID to why its last fetch failed; cleared by a successful fetch
+	feedMoves     map[int]string    // Feed ID to the URL on another host its last fetch was permanently redirected to
+	feedChecks    map[int]FeedCheck // Feed ID to the outcome of its last link check
+}
+
+func (h *healthState) recordSuccess(at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSuccess = at
+}
+
+func (h *healthState) recordError(at time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastErrorAt = at
+	h.lastError = err.Error()
+}
+
+func (h *healthState) recordFeedError(feedID int, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.feedErrors == nil {
+		h.feedErrors = make(map[int]string)
+	}
+	h.feedErrors[feedID] = err.Error()
+}
+
+func (h *healthState) clearFeedError(feedID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.feedErrors, feedID)
+}
+
+// recordFeedMove remembers where a feed's last successful fetch was permanently redirected to,
+// forgetting it when movedTo is ""
+func (h *healthState) recordFeedMove(feedID int, movedTo string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if movedTo == "" {
+		delete(h.feedMoves, feedID)
+
+		return
+	}
+	if h.feedMoves == nil {
+		h.feedMoves = make(map[int]string)
+	}
+	h.feedMoves[feedID] = movedTo
+}
+
+func (h *healthState) setFeedChecks(checks map[int]FeedCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.feedChecks = checks
+}
+
+func (h *healthState) setCycleInterval(interval time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cycleInterval = interval
+}
+
+// Health reports when the worker last completed and last failed a polling cycle, and whether
+// it is stale: no cycle has completed within the threshold, measured from the last success or,
+// before the first one, from when the worker was created.
+func (w *Worker) Health() WorkerHealth {
+	w.health.mu.Lock()
+	defer w.health.mu.Unlock()
+
+	staleAfter := w.Config().StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+		if w.health.cycleInterval > 0 {
+			staleAfter = 2 * w.health.cycleInterval
+		}
+	}
+
+	since := w.health.lastSuccess
+	if since.IsZero() {
+		since = w.health.startedAt
+	}
+
+	return WorkerHealth{
+		StartedAt:   w.health.startedAt,
+		LastSuccess: w.health.lastSuccess,
+		LastErrorAt: w.health.lastErrorAt,
+		LastError:   w.health.lastError,
+		StaleAfter:  staleAfter,
+		Stale:       time.Since(since) > staleAfter,
+	}
+}
+
+// FeedErrors returns why each feed whose last fetch failed did so, by feed ID. Feeds drop out
+// once they are fetched successfully again.
+func (w *Worker) FeedErrors() map[int]string {
+	w.health.mu.Lock()
+	defer w.health.mu.Unlock()
+
+	feedErrors := make(map[int]string, len(w.health.feedErrors))
+	for feedID, reason := range w.health.feedErrors {
+		feedErrors[feedID] = reason
+	}
+
+	return feedErrors
+}
+
+// FeedMoves returns, by feed ID, the URL on another host each feed was permanently redirected
+// to on its last successful fetch, so the feed's URL can be updated.
+func (w *Worker) FeedMoves() map[int]string {
+	w.health.mu.Lock()
+	defer w.health.mu.Unlock()
+
+	feedMoves := make(map[int]string, len(w.health.feedMoves))
+	for feedID, movedTo := range w.health.feedMoves {
+		feedMoves[feedID] = movedTo
+	}
+
+	return feedMoves
+}
@@ -0,0 +1,150 @@
+package worker_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_Health(t *testing.T) {
+	newWorker := func(t *testing.T, staleAfter time.Duration) (*mocks.MockStorer, *worker.Worker) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+		mockStore := mocks.NewMockStorer(ctrl)
+		w := worker.NewWorkerWithConfig(mockStore, rssmocks.NewMockProcessorer(ctrl), wallabagmocks.NewMockClienter(ctrl),
+			worker.Config{StaleAfter: staleAfter})
+
+		return mockStore, w
+	}
+
+	t.Run("Worker that never completes a cycle goes stale", func(t *testing.T) {
+		_, w := newWorker(t, 10*time.Millisecond)
+
+		assert.False(t, w.Health().Stale, "a new worker is not stale yet")
+
+		time.Sleep(20 * time.Millisecond)
+		health := w.Health()
+		assert.True(t, health.Stale)
+		assert.True(t, health.LastSuccess.IsZero())
+		assert.Equal(t, 10*time.Millisecond, health.StaleAfter)
+	})
+
+	t.Run("Completed cycle records success", func(t *testing.T) {
+		mockStore, w := newWorker(t, time.Hour)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil)
+
+		before := time.Now()
+		w.ProcessFeeds()
+
+		health := w.Health()
+		assert.False(t, health.Stale)
+		assert.False(t, health.LastSuccess.Before(before))
+		assert.Empty(t, health.LastError)
+	})
+
+	t.Run("Failed cycle records the error and does not count as success", func(t *testing.T) {
+		mockStore, w := newWorker(t, 10*time.Millisecond)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, errors.New("database is locked")).Times(2)
+
+		w.ProcessFeeds()
+		time.Sleep(20 * time.Millisecond)
+		w.ProcessFeeds()
+
+		health := w.Health()
+		assert.True(t, health.Stale, "failed cycles should not keep the worker fresh")
+		assert.True(t, health.LastSuccess.IsZero())
+		assert.Contains(t, health.LastError, "database is locked")
+		assert.False(t, health.LastErrorAt.IsZero())
+	})
+
+	t.Run("Default threshold applies when unset", func(t *testing.T) {
+		_, w := newWorker(t, 0)
+
+		assert.Equal(t, worker.DefaultStaleAfter, w.Health().StaleAfter)
+	})
+
+	t.Run("Health can be read while cycles run", func(t *testing.T) {
+		mockStore, w := newWorker(t, time.Hour)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil).Times(20)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				w.ProcessFeeds()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				_ = w.Health()
+			}
+		}()
+		wg.Wait()
+
+		assert.False(t, w.Health().LastSuccess.IsZero())
+	})
+}
+
+func TestWorker_RepeatedFetchErrors(t *testing.T) {
+	originalLogger := logging.GetGlobalLogger()
+	defer logging.SetGlobalLogger(originalLogger)
+	logger := logging.NewMockLogger()
+	logging.SetGlobalLogger(logger)
+
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	w := worker.NewWorker(mockStore, mockProcessor, wallabagmocks.NewMockClienter(ctrl))
+
+	feed := models.Feed{ID: 4, Name: "Broken", URL: "https://example.com/broken.xml", PollIntervalMinutes: 30, InitialSyncDone: true}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil).Times(4)
+	gomock.InOrder(
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(nil, errors.New("status 500")).Times(3),
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+	)
+
+	for range 3 {
+		w.ProcessFeeds()
+	}
+	assert.Equal(t, 1, logger.CountByLevel("ERROR"), "identical failures are logged once per window")
+	assert.Equal(t, map[int]string{4: "status 500"}, w.FeedErrors())
+
+	w.ProcessFeeds()
+	assert.Equal(t, 2, logger.CountByLevel("ERROR"), "a different failure is logged at once")
+	assert.Equal(t, map[int]string{4: "connection refused"}, w.FeedErrors())
+}
+
+func TestWorker_FeedMoves(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	w := worker.NewWorker(mockStore, mockProcessor, wallabagmocks.NewMockClienter(ctrl))
+
+	feed := models.Feed{ID: 5, Name: "Moved", URL: "https://old.example.com/feed.xml", PollIntervalMinutes: 30, InitialSyncDone: true}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil).Times(2)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 5).Return(nil).Times(2)
+	gomock.InOrder(
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{MovedTo: "https://new.example.com/feed.xml"}, nil),
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{}, nil),
+	)
+
+	w.ProcessFeeds()
+	assert.Equal(t, map[int]string{5: "https://new.example.com/feed.xml"}, w.FeedMoves())
+
+	// A fetch that is no longer redirected forgets the move
+	w.ProcessFeeds()
+	assert.Empty(t, w.FeedMoves())
+}
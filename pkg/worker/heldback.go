@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// heldBackRetries tracks when each feed's articles held back by its minimum age become old
+// enough to send. The feed's fetch is recorded as usual, so it keeps to its poll interval; the
+// worker queues it for the retry time, and a retry that has come due lets it run early.
+type heldBackRetries struct {
+	mu      sync.Mutex
+	retries map[int]time.Time // Feed ID to when its oldest held-back article reaches the minimum age
+}
+
+// hold records that feedID has articles held back until at, replacing any earlier record. It
+// reports whether at is a new retry time, so the caller can schedule the feed for it.
+func (h *heldBackRetries) hold(feedID int, at time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.retries == nil {
+		h.retries = make(map[int]time.Time)
+	}
+	if current, ok := h.retries[feedID]; ok && current.Equal(at) {
+		return false
+	}
+	h.retries[feedID] = at
+
+	return true
+}
+
+// due reports whether feedID has held-back articles that are old enough to send by now
+func (h *heldBackRetries) due(feedID int, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	at, ok := h.retries[feedID]
+
+	return ok && !now.Before(at)
+}
+
+// clear forgets feedID's retry once the feed has been processed again
+func (h *heldBackRetries) clear(feedID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.retries, feedID)
+}
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// FeedURLUpgrade is a feed moved from http to https by UpgradeFeedsToHTTPS.
+type FeedURLUpgrade struct {
+	FeedID int
+	Name   string
+	From   string
+	To     string
+}
+
+// FeedURLUpgradeFailure is an http feed UpgradeFeedsToHTTPS left as it was, and why.
+type FeedURLUpgradeFailure struct {
+	Name   string
+	URL    string
+	Reason string
+}
+
+// HTTPSUpgradeResult summarises an UpgradeFeedsToHTTPS run.
+type HTTPSUpgradeResult struct {
+	Upgraded []FeedURLUpgrade
+	Failed   []FeedURLUpgradeFailure
+}
+
+// UpgradeFeedsToHTTPS tries every feed fetched over http at its https equivalent and, where that
+// serves a feed that parses, switches the feed to it. Feeds whose https URL another feed already
+// uses, or that cannot be fetched over https, are reported and left unchanged. Progress is
+// reported per feed; when ctx is cancelled the result so far is returned with ctx's error.
+func (w *Worker) UpgradeFeedsToHTTPS(ctx context.Context, progress ProgressFunc) (HTTPSUpgradeResult, error) {
+	feeds, err := w.store.GetFeeds(ctx)
+	if err != nil {
+		return HTTPSUpgradeResult{}, fmt.Errorf("store.GetFeeds: %w", err)
+	}
+
+	inUse := make(map[string]bool, len(feeds))
+	for _, feed := range feeds {
+		inUse[feed.URL] = true
+	}
+
+	var result HTTPSUpgradeResult
+	for i := range feeds {
+		progress.report(i, len(feeds))
+		feed := &feeds[i]
+		httpsURL, ok := httpsEquivalent(feed.URL)
+		if !ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		fail := func(reason string) {
+			result.Failed = append(result.Failed, FeedURLUpgradeFailure{Name: feed.Name, URL: feed.URL, Reason: reason})
+		}
+		if inUse[httpsURL] {
+			fail("another feed already uses " + httpsURL)
+
+			continue
+		}
+		if err := w.upgradeFeedURL(ctx, feed, httpsURL); err != nil {
+			logging.Warn("Could not upgrade feed to https",
+				"error", err,
+				"feed_id", feed.ID,
+				"feed_name", feed.Name,
+				"feed_url", feed.URL)
+			fail(err.Error())
+
+			continue
+		}
+
+		inUse[httpsURL] = true
+		result.Upgraded = append(result.Upgraded, FeedURLUpgrade{FeedID: feed.ID, Name: feed.Name, From: feed.URL, To: httpsURL})
+	}
+
+	progress.report(len(feeds), len(feeds))
+	logging.Info("Upgraded feeds to https",
+		"upgraded", len(result.Upgraded),
+		"failed", len(result.Failed))
+
+	return result, nil
+}
+
+// upgradeFeedURL fetches feed from httpsURL and, if it parses, stores httpsURL as the feed's URL
+func (w *Worker) upgradeFeedURL(ctx context.Context, feed *models.Feed, httpsURL string) error {
+	defer w.lockFeed(feed.ID)()
+
+	// Probe with the feed's own settings so cookies and headers still apply
+	probe := *feed
+	probe.URL = httpsURL
+	probe.InitialSyncDone = true
+	if _, err := w.rssProcessor.FetchFeed(ctx, &probe); err != nil {
+		return fmt.Errorf("https fetch failed: %w", err)
+	}
+
+	if err := w.store.UpdateFeedURL(ctx, feed.ID, httpsURL); err != nil {
+		return fmt.Errorf("store.UpdateFeedURL: %w", err)
+	}
+
+	return nil
+}
+
+// httpsEquivalent returns feedURL with its http scheme replaced by https, reporting false for
+// URLs that are not plain http
+func httpsEquivalent(feedURL string) (string, bool) {
+	const prefix = "http://"
+	if len(feedURL) <= len(prefix) || !strings.EqualFold(feedURL[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return "https://" + feedURL[len(prefix):], true
+}
@@ -0,0 +1,66 @@
+package worker_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_UpgradeFeedsToHTTPS(t *testing.T) {
+	// Serves a valid feed at /feed over https only; plain http requests to a TLS server fail
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed" {
+			http.NotFound(w, r)
+
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Stub</title>` +
+			`<item><title>Post</title><link>https://example.com/post</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	processor := rss.NewProcessor()
+	processor.FeedParser.Client = server.Client()
+
+	_, err := processor.FetchFeed(context.Background(), &models.Feed{URL: "http://" + host + "/feed", InitialSyncDone: true})
+	require.Error(t, err, "the stub must not serve the feed over plain http")
+
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "http://" + host + "/feed", Name: "Upgradable"},
+		{ID: 2, URL: "http://" + host + "/missing", Name: "No https feed"},
+		{ID: 3, URL: "http://example.com/taken", Name: "Duplicate"},
+		{ID: 4, URL: "https://example.com/taken", Name: "Already https"},
+	}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockStore.EXPECT().UpdateFeedURL(gomock.Any(), 1, "https://"+host+"/feed").Return(nil)
+
+	w := worker.NewWorker(mockStore, processor, mockClient)
+	result, err := w.UpgradeFeedsToHTTPS(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []worker.FeedURLUpgrade{
+		{FeedID: 1, Name: "Upgradable", From: "http://" + host + "/feed", To: "https://" + host + "/feed"},
+	}, result.Upgraded)
+	require.Len(t, result.Failed, 2)
+	assert.Equal(t, "No https feed", result.Failed[0].Name)
+	assert.Contains(t, result.Failed[0].Reason, "https fetch failed")
+	assert.Equal(t, "Duplicate", result.Failed[1].Name)
+	assert.Contains(t, result.Failed[1].Reason, "another feed already uses https://example.com/taken")
+}
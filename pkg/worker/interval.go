@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+)
+
+// Bounds and sample size for auto intervals. Feeds are polled at least every MaxAutoInterval
+// and at most every MinAutoInterval, whatever their publish cadence.
+const (
+	MinAutoInterval     = 15 * time.Minute
+	MaxAutoInterval     = 24 * time.Hour
+	autoIntervalSamples = 10
+)
+
+// SuggestPollInterval derives a poll interval from a feed's recent publish times. It aims to
+// poll about twice per publishing gap, lengthening the interval when the feed has gone quiet
+// for longer than its usual gap. Fewer than two timestamps yields MaxAutoInterval.
+func SuggestPollInterval(publishTimes []time.Time, now time.Time) time.Duration {
+	if len(publishTimes) < 2 {
+		return MaxAutoInterval
+	}
+
+	sorted := make([]time.Time, len(publishTimes))
+	copy(sorted, publishTimes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].After(sorted[j]) })
+	if len(sorted) > autoIntervalSamples {
+		sorted = sorted[:autoIntervalSamples]
+	}
+
+	averageGap := sorted[0].Sub(sorted[len(sorted)-1]) / time.Duration(len(sorted)-1)
+
+	// A dormant feed is polled relative to how long it has been silent
+	gap := averageGap
+	if sinceLatest := now.Sub(sorted[0]); sinceLatest > gap {
+		gap = sinceLatest
+	}
+
+	suggested := gap / 2
+	if suggested < MinAutoInterval {
+		return MinAutoInterval
+	}
+	if suggested > MaxAutoInterval {
+		return MaxAutoInterval
+	}
+
+	return suggested
+}
+
+// updateAutoInterval recomputes the interval of an auto-interval feed from the articles just
+// fetched and stores it when it changed
+func (w *Worker) updateAutoInterval(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, articles []rss.Article) {
+	if !feed.AutoInterval {
+		return
+	}
+
+	publishTimes := make([]time.Time, 0, len(articles))
+	for _, article := range articles {
+		if article.PublishedAt != nil {
+			publishTimes = append(publishTimes, *article.PublishedAt)
+		}
+	}
+
+	minutes := int(SuggestPollInterval(publishTimes, time.Now()) / time.Minute)
+	if minutes == feed.AutoIntervalMinutes {
+		return
+	}
+
+	feedLogger.Info("Auto interval adjusted",
+		"previous_minutes", feed.AutoIntervalMinutes,
+		"new_minutes", minutes,
+		"samples", len(publishTimes))
+
+	if err := w.store.UpdateFeedAutoInterval(ctx, feed.ID, minutes); err != nil {
+		feedLogger.Error("Failed to update feed auto interval",
+			"error", fmt.Errorf("store.UpdateFeedAutoInterval: %w", err))
+
+		return
+	}
+
+	feed.AutoIntervalMinutes = minutes
+}
@@ -0,0 +1,120 @@
+package worker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+// cadence returns count publish times spaced gap apart, the latest at latest
+func cadence(latest time.Time, gap time.Duration, count int) []time.Time {
+	times := make([]time.Time, count)
+	for i := range times {
+		times[i] = latest.Add(-time.Duration(i) * gap)
+	}
+
+	return times
+}
+
+func TestSuggestPollInterval(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		publishTimes []time.Time
+		expected     time.Duration
+	}{
+		{
+			name:         "No history uses maximum",
+			publishTimes: nil,
+			expected:     worker.MaxAutoInterval,
+		},
+		{
+			name:         "Single article uses maximum",
+			publishTimes: []time.Time{now.Add(-time.Hour)},
+			expected:     worker.MaxAutoInterval,
+		},
+		{
+			name:         "Posts every four hours polls every two",
+			publishTimes: cadence(now.Add(-time.Hour), 4*time.Hour, 5),
+			expected:     2 * time.Hour,
+		},
+		{
+			name:         "Very frequent posts clamp to minimum",
+			publishTimes: cadence(now, 2*time.Minute, 10),
+			expected:     worker.MinAutoInterval,
+		},
+		{
+			name:         "Daily posts clamp to maximum",
+			publishTimes: cadence(now.Add(-time.Hour), 72*time.Hour, 4),
+			expected:     worker.MaxAutoInterval,
+		},
+		{
+			name:         "Dormant feed backs off by silence",
+			publishTimes: cadence(now.Add(-10*time.Hour), time.Hour, 5),
+			expected:     5 * time.Hour,
+		},
+		{
+			name:         "Unsorted history is ordered first",
+			publishTimes: []time.Time{now.Add(-5 * time.Hour), now.Add(-time.Hour), now.Add(-3 * time.Hour)},
+			expected:     time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, worker.SuggestPollInterval(tt.publishTimes, now))
+		})
+	}
+}
+
+func TestSuggestPollInterval_Direction(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	hourly := worker.SuggestPollInterval(cadence(now, time.Hour, 10), now)
+	sixHourly := worker.SuggestPollInterval(cadence(now, 6*time.Hour, 10), now)
+	assert.Less(t, hourly, sixHourly, "busier feeds should be polled more often")
+
+	active := worker.SuggestPollInterval(cadence(now, time.Hour, 10), now)
+	quiet := worker.SuggestPollInterval(cadence(now.Add(-8*time.Hour), time.Hour, 10), now)
+	assert.Greater(t, quiet, active, "feeds that stop publishing should be polled less often")
+}
+
+func TestWorker_AutoInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	now := time.Now()
+	first := now.Add(-2 * time.Hour)
+	second := now.Add(-6 * time.Hour)
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true, AutoInterval: true},
+	}
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "First", URL: "https://example.com/1", PublishedAt: &first},
+			{Title: "Second", URL: "https://example.com/2", PublishedAt: &second},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().UpdateFeedAutoInterval(gomock.Any(), 1, 120).Return(nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
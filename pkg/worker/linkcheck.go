@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// linkCheckTimeout bounds the check of one feed without a fetch timeout of its own.
+const linkCheckTimeout = time.Minute
+
+// FeedCheck is the outcome of the last link check of a feed: whether its URL could still be
+// fetched and parsed as a feed.
+type FeedCheck struct {
+	CheckedAt time.Time
+	Error     string // Why the feed could not be fetched or parsed ("" = healthy)
+	FeedID    int
+}
+
+// Healthy reports whether the feed passed the check.
+func (c FeedCheck) Healthy() bool {
+	return c.Error == ""
+}
+
+// runLinkCheckLoop checks every feed's URL every interval until the worker stops
+func (w *Worker) runLinkCheckLoop(interval time.Duration) {
+	logging.Info("Feed link check configured", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			checks := w.CheckFeedLinks(context.Background())
+			unhealthy := 0
+			for _, check := range checks {
+				if !check.Healthy() {
+					unhealthy++
+				}
+			}
+			logging.Info("Feed link check completed", "feeds", len(checks), "unhealthy", unhealthy)
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// CheckFeedLinks fetches and parses every feed, whatever its poll schedule, the way polling
+// does, so a dead feed is found even if it is rarely polled. Nothing is sent or recorded as
+// processed. The outcomes replace those of the last pass and are returned in feed order. A feed
+// that fails after passing, or on its first check, is published as a feed-health event and
+// recorded in the audit log; one that recovers is published too. When the worker stops, the pass
+// ends early with the feeds checked so far, and the rest keep their last outcome.
+func (w *Worker) CheckFeedLinks(ctx context.Context) []FeedCheck {
+	feeds, err := w.store.GetFeeds(ctx)
+	if err != nil {
+		logging.Error("Failed to get feeds for link check", "error", fmt.Errorf("store.GetFeeds: %w", err))
+
+		return nil
+	}
+
+	previous := w.FeedChecks()
+	checks := make([]FeedCheck, 0, len(feeds))
+	for i := range feeds {
+		if w.stopping() || ctx.Err() != nil {
+			break
+		}
+
+		check := w.checkFeedLink(ctx, &feeds[i])
+		checks = append(checks, check)
+
+		last, checked := previous[check.FeedID]
+		switch {
+		case !check.Healthy() && (!checked || last.Healthy()):
+			logging.Warn("Feed failed its link check",
+				"feed_id", feeds[i].ID,
+				"feed_url", feeds[i].URL,
+				"error", check.Error)
+			w.Config().Audit.Record(models.AuditFeedUnreachable, feeds[i].ID, fmt.Sprintf("%s: %s", feeds[i].URL, check.Error))
+			w.publishFeedHealth(check)
+		case check.Healthy() && checked && !last.Healthy():
+			logging.Info("Feed passed its link check again", "feed_id", feeds[i].ID, "feed_url", feeds[i].URL)
+			w.publishFeedHealth(check)
+		}
+	}
+
+	// Feeds an early stop left unchecked keep their last outcome; deleted feeds are dropped
+	current := make(map[int]FeedCheck, len(feeds))
+	for _, feed := range feeds {
+		if last, checked := previous[feed.ID]; checked {
+			current[feed.ID] = last
+		}
+	}
+	for _, check := range checks {
+		current[check.FeedID] = check
+	}
+	w.health.setFeedChecks(current)
+
+	return checks
+}
+
+// checkFeedLink fetches and parses feed with its own request settings and reports the outcome
+func (w *Worker) checkFeedLink(ctx context.Context, feed *models.Feed) FeedCheck {
+	if feed.FetchTimeoutSeconds <= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, linkCheckTimeout)
+		defer cancel()
+	}
+
+	// Only reachability matters, so the initial sync options are left out
+	checked := *feed
+	checked.InitialSyncDone = true
+
+	check := FeedCheck{FeedID: feed.ID}
+	if _, err := w.rssProcessor.FetchFeed(ctx, &checked); err != nil {
+		check.Error = err.Error()
+	}
+	check.CheckedAt = time.Now()
+
+	return check
+}
+
+// publishFeedHealth tells live clients a feed's link check outcome changed
+func (w *Worker) publishFeedHealth(check FeedCheck) {
+	w.events.Publish(events.Event{Type: events.TypeFeedHealth, Data: events.FeedHealthData{
+		FeedID:  check.FeedID,
+		Healthy: check.Healthy(),
+		Error:   check.Error,
+	}})
+}
+
+// FeedChecks returns the outcome of the last link check of each feed, by feed ID. Feeds added
+// since the last pass, or every feed before the first, have no entry.
+func (w *Worker) FeedChecks() map[int]FeedCheck {
+	w.health.mu.Lock()
+	defer w.health.mu.Unlock()
+
+	feedChecks := make(map[int]FeedCheck, len(w.health.feedChecks))
+	for feedID, check := range w.health.feedChecks {
+		feedChecks[feedID] = check
+	}
+
+	return feedChecks
+}
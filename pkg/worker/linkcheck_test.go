@@ -0,0 +1,74 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_CheckFeedLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, Name: "Good", URL: "https://example.com/good.xml"},
+		{ID: 2, Name: "Dead", URL: "https://example.com/dead.xml"},
+	}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil).Times(2)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{})
+	stream, unsubscribe := w.Events().Subscribe()
+	defer unsubscribe()
+
+	t.Run("Unreachable feed is unhealthy and a good one healthy", func(t *testing.T) {
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/good.xml")).
+			Return(&rss.FeedResult{}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/dead.xml")).
+			Return(nil, errors.New("failed to fetch feed: status 404"))
+
+		checks := w.CheckFeedLinks(context.Background())
+		require.Len(t, checks, 2)
+		assert.True(t, checks[0].Healthy())
+		assert.False(t, checks[1].Healthy())
+		assert.Contains(t, checks[1].Error, "404")
+
+		stored := w.FeedChecks()
+		assert.True(t, stored[1].Healthy())
+		assert.False(t, stored[2].Healthy())
+		assert.False(t, stored[2].CheckedAt.IsZero())
+
+		// Only the feed that started failing is published
+		require.Len(t, stream, 1)
+		event := <-stream
+		assert.Equal(t, events.TypeFeedHealth, event.Type)
+		assert.Equal(t, events.FeedHealthData{FeedID: 2, Error: checks[1].Error}, event.Data)
+	})
+
+	t.Run("Recovered feed is healthy again", func(t *testing.T) {
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{}, nil).Times(2)
+
+		checks := w.CheckFeedLinks(context.Background())
+		require.Len(t, checks, 2)
+		assert.True(t, checks[1].Healthy())
+		assert.True(t, w.FeedChecks()[2].Healthy())
+
+		require.Len(t, stream, 1)
+		event := <-stream
+		assert.Equal(t, events.FeedHealthData{FeedID: 2, Healthy: true}, event.Data)
+	})
+}
@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+)
+
+// markProcessedConcurrency bounds how many feeds MarkAllFeedsProcessed fetches at once.
+const markProcessedConcurrency = 4
+
+// MarkProcessedTotals summarises a MarkAllFeedsProcessed run.
+type MarkProcessedTotals struct {
+	Feeds          int // Enabled feeds fetched and marked
+	FailedFeeds    int // Enabled feeds that could not be fetched; their items are left as they were
+	Marked         int // Items newly recorded as processed
+	AlreadyTracked int // Items that had already been processed
+}
+
+// MarkAllFeedsProcessed fetches every enabled feed and records its current items as processed
+// without sending them to Wallabag, then marks the feed's initial sync done, so only items
+// published from now on are sent. Feeds are fetched a few at a time; a feed that fails is
+// counted and skipped rather than aborting the run. Progress is reported per feed. When ctx is
+// cancelled no more feeds are started and the totals so far are returned with ctx's error;
+// running it again skips the items already marked.
+func (w *Worker) MarkAllFeedsProcessed(ctx context.Context, progress ProgressFunc) (MarkProcessedTotals, error) {
+	feeds, err := w.store.GetFeeds(ctx)
+	if err != nil {
+		return MarkProcessedTotals{}, fmt.Errorf("store.GetFeeds: %w", err)
+	}
+
+	enabled := 0
+	for _, feed := range feeds {
+		if !feed.Disabled {
+			enabled++
+		}
+	}
+	progress.report(0, enabled)
+
+	var (
+		totals MarkProcessedTotals
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+	)
+	slots := make(chan struct{}, markProcessedConcurrency)
+	for i := range feeds {
+		feed := &feeds[i]
+		if feed.Disabled {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			marked, already, err := w.markFeedProcessed(ctx, feed)
+
+			mu.Lock()
+			defer mu.Unlock()
+			defer func() { progress.report(totals.Feeds+totals.FailedFeeds, enabled) }()
+			if err != nil {
+				logging.Error("Failed to mark feed items processed",
+					"error", err,
+					"feed_id", feed.ID,
+					"feed_name", feed.Name)
+				totals.FailedFeeds++
+
+				return
+			}
+			totals.Feeds++
+			totals.Marked += marked
+			totals.AlreadyTracked += already
+		}()
+	}
+	wg.Wait()
+
+	logging.Info("Marked existing feed items processed",
+		"feeds", totals.Feeds,
+		"failed_feeds", totals.FailedFeeds,
+		"marked", totals.Marked,
+		"already_tracked", totals.AlreadyTracked)
+
+	if ctx.Err() != nil {
+		return totals, ctx.Err()
+	}
+
+	return totals, nil
+}
+
+// markFeedProcessed records every item currently in feed as processed, returning how many were
+// newly marked and how many were already processed
+func (w *Worker) markFeedProcessed(ctx context.Context, feed *models.Feed) (marked, already int, err error) {
+	feedLogger := logging.With("feed_id", feed.ID, "feed_name", feed.Name, "feed_url", feed.URL)
+	defer w.lockFeed(feed.ID)()
+
+	// Fetch as if the initial sync were done so the sync mode does not hide any items
+	fetchFeed := *feed
+	fetchFeed.InitialSyncDone = true
+	result, err := w.rssProcessor.FetchFeed(ctx, &fetchFeed)
+	if err != nil {
+		return 0, 0, fmt.Errorf("rssProcessor.FetchFeed: %w", err)
+	}
+
+	limits := w.Config().FieldLimits
+	for _, article := range result.Articles {
+		if err := limits.ValidateURL(article.URL); err != nil {
+			feedLogger.Warn("Skipping article with over-long URL", "error", err)
+
+			continue
+		}
+
+		// Record under the same URL the worker dedupes on
+		originalURL := article.URL
+		if feed.StripQueryParams {
+			article.URL = stripQueryParams(article.URL)
+		}
+
+		processed, err := w.store.IsArticleAlreadyProcessed(ctx, article.URL)
+		if err != nil {
+			return marked, already, fmt.Errorf("store.IsArticleAlreadyProcessed: %w", err)
+		}
+		if processed {
+			already++
+
+			continue
+		}
+
+		modelArticle := models.Article{
+			Title:       limits.TruncateTitle(article.Title),
+			URL:         article.URL,
+			PublishedAt: article.PublishedAt,
+		}
+		if originalURL != article.URL {
+			modelArticle.OriginalURL = originalURL
+		}
+		if err := w.store.SaveMarkedArticle(ctx, feed.ID, &modelArticle); err != nil {
+			return marked, already, fmt.Errorf("store.SaveMarkedArticle: %w", err)
+		}
+		marked++
+	}
+
+	if !feed.InitialSyncDone {
+		if err := w.store.MarkFeedInitialSyncCompleted(ctx, feed.ID); err != nil {
+			return marked, already, fmt.Errorf("store.MarkFeedInitialSyncCompleted: %w", err)
+		}
+	}
+
+	feedLogger.Info("Marked feed items processed", "marked", marked, "already_tracked", already)
+
+	return marked, already, nil
+}
@@ -0,0 +1,115 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_MarkAllFeedsProcessed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	// No AddEntry expectations: marking must never send anything to Wallabag
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/new", Name: "New", SyncMode: models.SyncModeNone},
+		{ID: 2, URL: "https://example.com/synced", Name: "Synced", InitialSyncDone: true, StripQueryParams: true},
+		{ID: 3, URL: "https://example.com/broken", Name: "Broken", InitialSyncDone: true},
+		{ID: 4, URL: "https://example.com/disabled", Name: "Disabled", Disabled: true},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	// Feeds are fetched as if synced, so the sync mode cannot hide existing items
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/new")).DoAndReturn(
+		func(_ context.Context, feed *models.Feed) (*rss.FeedResult, error) {
+			assert.True(t, feed.InitialSyncDone)
+
+			return &rss.FeedResult{Articles: []rss.Article{
+				{Title: "One", URL: "https://example.com/new/1"},
+				{Title: "Two", URL: "https://example.com/new/2"},
+			}}, nil
+		})
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/synced")).Return(&rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "Sent", URL: "https://example.com/synced/sent"},
+			{Title: "Tracked", URL: "https://example.com/synced/tracked?utm_source=rss"},
+		},
+	}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/broken")).Return(nil, errors.New("timeout"))
+
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/synced/sent").Return(true, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+
+	var mu sync.Mutex
+	var marked []string
+	mockStore.EXPECT().SaveMarkedArticle(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article) error {
+			mu.Lock()
+			defer mu.Unlock()
+			marked = append(marked, article.URL)
+
+			return nil
+		}).Times(3)
+	mockStore.EXPECT().MarkFeedInitialSyncCompleted(gomock.Any(), 1).Return(nil)
+
+	var reports [][2]int
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	totals, err := w.MarkAllFeedsProcessed(context.Background(), func(done, total int) {
+		reports = append(reports, [2]int{done, total})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, worker.MarkProcessedTotals{Feeds: 2, FailedFeeds: 1, Marked: 3, AlreadyTracked: 1}, totals)
+	assert.Equal(t, [][2]int{{0, 3}, {1, 3}, {2, 3}, {3, 3}}, reports, "Progress is reported per enabled feed")
+	sort.Strings(marked)
+	assert.Equal(t, []string{
+		"https://example.com/new/1",
+		"https://example.com/new/2",
+		"https://example.com/synced/tracked",
+	}, marked)
+}
+
+func TestWorker_MarkAllFeedsProcessed_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, errors.New("database closed"))
+
+	w := worker.NewWorker(mockStore, rssmocks.NewMockProcessorer(ctrl), wallabagmocks.NewMockClienter(ctrl))
+	_, err := w.MarkAllFeedsProcessed(context.Background(), nil)
+	assert.ErrorContains(t, err, "store.GetFeeds")
+}
+
+func TestWorker_MarkAllFeedsProcessed_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{{ID: 1, URL: "https://example.com/feed", Name: "Feed"}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// No feed is fetched once cancelled
+	w := worker.NewWorker(mockStore, rssmocks.NewMockProcessorer(ctrl), wallabagmocks.NewMockClienter(ctrl))
+	totals, err := w.MarkAllFeedsProcessed(ctx, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, totals)
+}
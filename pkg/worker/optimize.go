@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"wallabag-rss-tool/pkg/logging"
+)
+
+// optimizeTimeout bounds one scheduled database optimization.
+const optimizeTimeout = 30 * time.Minute
+
+// runOptimizeLoop optimizes the database every interval until the worker stops
+func (w *Worker) runOptimizeLoop(interval time.Duration) {
+	logging.Info("Scheduled database optimization configured", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.optimizeDatabase()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// optimizeDatabase compacts the database, logging its size before and after
+func (w *Worker) optimizeDatabase() {
+	ctx, cancel := context.WithTimeout(context.Background(), optimizeTimeout)
+	defer cancel()
+
+	before, err := w.store.DatabaseSize(ctx)
+	if err != nil {
+		logging.Warn("Failed to get database size before optimizing", "error", fmt.Errorf("store.DatabaseSize: %w", err))
+	}
+	if err := w.store.Optimize(ctx); err != nil {
+		logging.Error("Scheduled database optimization failed", "error", fmt.Errorf("store.Optimize: %w", err))
+
+		return
+	}
+	after, err := w.store.DatabaseSize(ctx)
+	if err != nil {
+		logging.Warn("Failed to get database size after optimizing", "error", fmt.Errorf("store.DatabaseSize: %w", err))
+	}
+
+	logging.Info("Optimized database", "size_before", before, "size_after", after)
+}
@@ -0,0 +1,53 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_OptimizeInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	// The polling loop runs alongside with no feeds to process
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).AnyTimes()
+
+	optimized := make(chan struct{})
+	var once sync.Once
+	mockStore.EXPECT().DatabaseSize(gomock.Any()).Return(int64(4096), nil).AnyTimes()
+	// A failed run is logged and the next one still happens
+	mockStore.EXPECT().Optimize(gomock.Any()).Return(errors.New("database is locked"))
+	mockStore.EXPECT().Optimize(gomock.Any()).DoAndReturn(func(context.Context) error {
+		once.Do(func() { close(optimized) })
+
+		return nil
+	}).MinTimes(1)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{OptimizeInterval: 10 * time.Millisecond})
+	w.Start()
+
+	select {
+	case <-optimized:
+	case <-time.After(5 * time.Second):
+		t.Fatal("database was not optimized on schedule")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, w.Shutdown(ctx))
+}
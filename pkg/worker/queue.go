@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// priorityQueueCapacity is how many feeds can wait for immediate processing at once.
+const priorityQueueCapacity = 100
+
+// queuedFeed is a feed waiting in a feedQueue
+type queuedFeed struct {
+	feedID   int
+	priority int
+	seq      uint64 // Queue order, so equal priorities are taken first in, first out
+}
+
+// queuedFeedHeap orders queued feeds highest priority first; it implements heap.Interface
+type queuedFeedHeap []queuedFeed
+
+func (h queuedFeedHeap) Len() int { return len(h) }
+
+func (h queuedFeedHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+func (h queuedFeedHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *queuedFeedHeap) Push(x any) { *h = append(*h, x.(queuedFeed)) }
+
+func (h *queuedFeedHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return last
+}
+
+// feedQueue is a bounded queue of feeds awaiting immediate processing. Higher-priority feeds
+// are taken first; feeds of equal priority are taken in the order they were queued.
+type feedQueue struct {
+	mu       sync.Mutex
+	items    queuedFeedHeap
+	seq      uint64
+	capacity int
+	ready    chan struct{} // Signalled when a feed is queued; buffered so push never blocks
+}
+
+// newFeedQueue creates an empty queue holding at most capacity feeds
+func newFeedQueue(capacity int) *feedQueue {
+	return &feedQueue{capacity: capacity, ready: make(chan struct{}, 1)}
+}
+
+// push queues a feed, reporting false without queuing it when the queue is full
+func (q *feedQueue) push(feedID, priority int) bool {
+	q.mu.Lock()
+	if len(q.items) >= q.capacity {
+		q.mu.Unlock()
+
+		return false
+	}
+	q.seq++
+	heap.Push(&q.items, queuedFeed{feedID: feedID, priority: priority, seq: q.seq})
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+
+	return true
+}
+
+// pop removes and returns the highest-priority feed, or false when the queue is empty
+func (q *feedQueue) pop() (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return 0, false
+	}
+
+	return heap.Pop(&q.items).(queuedFeed).feedID, true
+}
+
+// len returns how many feeds are queued
+func (q *feedQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.items)
+}
@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+// rejectionCounter counts, by feed, the articles Wallabag has rejected in a row, for
+// Config.PauseAfterRejections. The counts live only as long as the worker.
+type rejectionCounter struct {
+	mu     sync.Mutex
+	counts map[int]int
+}
+
+// record counts another rejected article for the feed and returns its run so far
+func (r *rejectionCounter) record(feedID int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counts == nil {
+		r.counts = make(map[int]int)
+	}
+	r.counts[feedID]++
+
+	return r.counts[feedID]
+}
+
+// reset ends the feed's run of rejections
+func (r *rejectionCounter) reset(feedID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.counts, feedID)
+}
+
+// reached reports whether the feed's run has reached threshold; a threshold of zero never is
+func (r *rejectionCounter) reached(feedID, threshold int) bool {
+	if threshold <= 0 {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.counts[feedID] >= threshold
+}
+
+// countRejection counts an article Wallabag rejected with 400 Bad Request towards pausing its
+// feed, and disables the feed once Config.PauseAfterRejections are rejected in a row. Articles
+// that failed any other way, such as a network error or Wallabag being down, say nothing about
+// the feed's pages and are not counted.
+func (w *Worker) countRejection(ctx context.Context, articleLogger logging.Logger, feed *models.Feed, err error) {
+	threshold := w.Config().PauseAfterRejections
+	if threshold <= 0 || !wallabag.IsRejected(err) {
+		return
+	}
+
+	// Only the rejection that completes the run pauses the feed; with concurrent sends, others
+	// may land after it
+	if w.rejections.record(feed.ID) != threshold {
+		return
+	}
+
+	reason := fmt.Sprintf("Paused after Wallabag rejected %d articles in a row with 400 Bad Request. "+
+		"Wallabag may be unable to fetch pages from this site; enable the feed to try again.", threshold)
+	if err := w.store.DisableFeed(ctx, feed.ID, reason); err != nil {
+		articleLogger.Error("Failed to pause feed after repeated Wallabag rejections",
+			"error", fmt.Errorf("store.DisableFeed: %w", err))
+
+		return
+	}
+
+	articleLogger.Warn("Paused feed after repeated Wallabag rejections", "rejections", threshold)
+	w.Config().Audit.Record(models.AuditFeedPaused, feed.ID, reason)
+}
+
+// resumeRejectedFeed starts a new run of rejections for a feed that reached the threshold
+// before. A paused feed is only processed again once it has been enabled by hand, or when
+// disabling it failed, and either way it gets another full run before it is paused.
+func (w *Worker) resumeRejectedFeed(feedLogger logging.Logger, feed *models.Feed) {
+	if !w.rejections.reached(feed.ID, w.Config().PauseAfterRejections) {
+		return
+	}
+
+	feedLogger.Info("Feed paused after repeated Wallabag rejections is being processed again, resetting its count")
+	w.rejections.reset(feed.ID)
+}
@@ -0,0 +1,99 @@
+package worker_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	"wallabag-rss-tool/pkg/wallabag"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_PauseAfterRejections(t *testing.T) {
+	rejected := &wallabag.AddEntryError{StatusCode: http.StatusBadRequest}
+	serverError := &wallabag.AddEntryError{StatusCode: http.StatusInternalServerError}
+	sent := &wallabag.Entry{ID: 1}
+
+	// run polls a feed whose articles get the given AddEntry outcomes, in order, followed by
+	// unsent articles that must not be sent, from a worker that pauses feeds after three
+	// rejections in a row
+	run := func(t *testing.T, outcomes []error, unsent int, expect func(*mocks.MockStorer)) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+		result := &rss.FeedResult{}
+		var calls []any
+		for i, outcome := range outcomes {
+			url := fmt.Sprintf("https://example.com/%d", i)
+			result.Articles = append(result.Articles, rss.Article{Title: url, URL: url})
+			if outcome == nil {
+				calls = append(calls, mockClient.EXPECT().AddEntry(gomock.Any(), url, nil).Return(sent, nil))
+				mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 1).Return(nil)
+			} else {
+				calls = append(calls, mockClient.EXPECT().AddEntry(gomock.Any(), url, nil).Return(nil, outcome))
+			}
+		}
+		gomock.InOrder(calls...)
+		for i := range unsent {
+			url := fmt.Sprintf("https://example.com/unsent-%d", i)
+			result.Articles = append(result.Articles, rss.Article{Title: url, URL: url})
+		}
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(result, nil)
+		if unsent == 0 {
+			// Unsent articles leave the feed due, so they are sent once it is enabled again
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+		}
+		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+		expect(mockStore)
+
+		w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{PauseAfterRejections: 3})
+		w.ProcessFeeds()
+	}
+
+	t.Run("Repeated rejections pause the feed", func(t *testing.T) {
+		run(t, []error{rejected, rejected, rejected}, 0, func(mockStore *mocks.MockStorer) {
+			mockStore.EXPECT().DisableFeed(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+				func(_ any, _ int, reason string) error {
+					assert.Contains(t, reason, "rejected 3 articles in a row")
+
+					return nil
+				})
+		})
+	})
+
+	t.Run("Articles after the pause are left unsent", func(t *testing.T) {
+		run(t, []error{rejected, rejected, rejected}, 2, func(mockStore *mocks.MockStorer) {
+			mockStore.EXPECT().DisableFeed(gomock.Any(), 1, gomock.Any()).Return(nil)
+		})
+	})
+
+	t.Run("Intermittent successes reset the run", func(t *testing.T) {
+		run(t, []error{rejected, rejected, nil, rejected, rejected, nil, rejected, rejected}, 0, func(*mocks.MockStorer) {})
+	})
+
+	t.Run("Other failures are not counted", func(t *testing.T) {
+		run(t, []error{errors.New("connection refused"), rejected, serverError, rejected, serverError}, 0, func(*mocks.MockStorer) {})
+	})
+
+	t.Run("Other failures do not break the run", func(t *testing.T) {
+		run(t, []error{rejected, serverError, rejected, rejected}, 0, func(mockStore *mocks.MockStorer) {
+			mockStore.EXPECT().DisableFeed(gomock.Any(), 1, gomock.Any()).Return(nil)
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wallabag-rss-tool/pkg/logging"
+	"wallabag-rss-tool/pkg/rss"
+	"wallabag-rss-tool/pkg/wallabag"
+)
+
+// ErrNoFeedTags is returned by RetagFeedEntries for a feed whose settings produce no tags.
+var ErrNoFeedTags = errors.New("feed has no tags to apply")
+
+// RetagTotals summarises a RetagFeedEntries run.
+type RetagTotals struct {
+	Tagged  int // Entries the feed's tags were added to
+	Missing int // Entries no longer in Wallabag
+	Failed  int // Entries that could not be tagged for another reason
+}
+
+// RetagFeedEntries adds the feed's current tags to every Wallabag entry already sent for it, so
+// entries sent before a tagging change match the new scheme. Item categories are not stored,
+// so only the feed-level tags are applied. An entry that fails is counted and skipped rather
+// than aborting the run. Progress is reported per entry; when ctx is cancelled the totals so far
+// are returned with ctx's error.
+func (w *Worker) RetagFeedEntries(ctx context.Context, feedID int, progress ProgressFunc) (RetagTotals, error) {
+	feed, err := w.store.GetFeedByID(ctx, feedID)
+	if err != nil {
+		return RetagTotals{}, fmt.Errorf("store.GetFeedByID: %w", err)
+	}
+
+	tags := w.entryTags(feed, rss.Article{})
+	if len(tags) == 0 {
+		return RetagTotals{}, ErrNoFeedTags
+	}
+
+	articles, err := w.store.GetFeedArticles(ctx, feedID)
+	if err != nil {
+		return RetagTotals{}, fmt.Errorf("store.GetFeedArticles: %w", err)
+	}
+
+	feedLogger := logging.With("feed_id", feed.ID, "feed_name", feed.Name)
+	var totals RetagTotals
+	for i, article := range articles {
+		progress.report(i, len(articles))
+		if article.WallabagEntryID == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return totals, ctx.Err()
+		}
+
+		err := w.wallabagClient.AddTagsToEntry(ctx, *article.WallabagEntryID, tags)
+		switch {
+		case errors.Is(err, wallabag.ErrEntryNotFound):
+			feedLogger.Debug("Entry no longer in Wallabag, skipping", "wallabag_entry_id", *article.WallabagEntryID)
+			totals.Missing++
+		case err != nil:
+			feedLogger.Error("Failed to tag Wallabag entry",
+				"error", fmt.Errorf("wallabagClient.AddTagsToEntry: %w", err),
+				"wallabag_entry_id", *article.WallabagEntryID)
+			totals.Failed++
+		default:
+			totals.Tagged++
+		}
+	}
+	progress.report(len(articles), len(articles))
+
+	feedLogger.Info("Re-tagged feed entries",
+		"tags", tags,
+		"tagged", totals.Tagged,
+		"missing", totals.Missing,
+		"failed", totals.Failed)
+
+	return totals, nil
+}
@@ -0,0 +1,57 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	"wallabag-rss-tool/pkg/wallabag"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_RetagFeedEntries(t *testing.T) {
+	entryID := func(id int) *int { return &id }
+
+	t.Run("Tags every sent entry and counts missing and failed ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Go Blog", TagWithFeedName: true}, nil)
+		mockStore.EXPECT().GetFeedArticles(gomock.Any(), 7).Return([]models.Article{
+			{URL: "https://example.com/1", WallabagEntryID: entryID(1)},
+			{URL: "https://example.com/2", WallabagEntryID: entryID(2)},
+			{URL: "https://example.com/3", WallabagEntryID: entryID(3)},
+			{URL: "https://example.com/filtered", Filtered: true},
+		}, nil)
+
+		tags := []string{"go-blog"}
+		mockClient.EXPECT().AddTagsToEntry(gomock.Any(), 1, tags).Return(nil)
+		mockClient.EXPECT().AddTagsToEntry(gomock.Any(), 2, tags).Return(fmt.Errorf("entry 2: %w", wallabag.ErrEntryNotFound))
+		mockClient.EXPECT().AddTagsToEntry(gomock.Any(), 3, tags).Return(errors.New("failed to tag entry with status 500"))
+
+		w := worker.NewWorker(mockStore, rssmocks.NewMockProcessorer(ctrl), mockClient)
+		totals, err := w.RetagFeedEntries(context.Background(), 7, nil)
+		require.NoError(t, err)
+		assert.Equal(t, worker.RetagTotals{Tagged: 1, Missing: 1, Failed: 1}, totals)
+	})
+
+	t.Run("Feed without tags", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStorer(ctrl)
+
+		mockStore.EXPECT().GetFeedByID(gomock.Any(), 7).Return(&models.Feed{ID: 7, Name: "Go Blog"}, nil)
+
+		w := worker.NewWorker(mockStore, rssmocks.NewMockProcessorer(ctrl), wallabagmocks.NewMockClienter(ctrl))
+		_, err := w.RetagFeedEntries(context.Background(), 7, nil)
+		assert.ErrorIs(t, err, worker.ErrNoFeedTags)
+	})
+}
@@ -0,0 +1,44 @@
+package worker
+
+import "sync"
+
+// urlLocks serializes the processing of articles that share a normalized URL across feeds, so
+// two feeds that find the same article at the same moment don't both see it as new and send
+// it twice. Locks are dropped once nobody holds or waits for them.
+type urlLocks struct {
+	mu    sync.Mutex
+	locks map[string]*urlLock
+}
+
+type urlLock struct {
+	sync.Mutex
+	users int // Goroutines holding or waiting for the lock
+}
+
+// lock blocks until no other goroutine holds key and returns a func that releases it
+func (l *urlLocks) lock(key string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*urlLock)
+	}
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &urlLock{}
+		l.locks[key] = entry
+	}
+	entry.users++
+	l.mu.Unlock()
+
+	entry.Lock()
+
+	return func() {
+		entry.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		entry.users--
+		if entry.users == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
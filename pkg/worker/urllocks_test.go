@@ -0,0 +1,102 @@
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/models"
+	"wallabag-rss-tool/pkg/rss"
+	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	"wallabag-rss-tool/pkg/wallabag"
+	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
+	"wallabag-rss-tool/pkg/worker"
+)
+
+func TestWorker_SameURLFromConcurrentFeeds(t *testing.T) {
+	const sharedURL = "https://example.com/shared"
+	feedA := models.Feed{ID: 1, URL: "https://a.example.com/feed", Name: "A", PollIntervalMinutes: 30, InitialSyncDone: true}
+	feedB := models.Feed{ID: 2, URL: "https://b.example.com/feed", Name: "B", PollIntervalMinutes: 30, InitialSyncDone: true}
+	result := &rss.FeedResult{Articles: []rss.Article{{Title: "Shared", URL: sharedURL}}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	// Each cycle starts with a different feed, so the two cycles reach the URL at once
+	var cycles atomic.Int32
+	mockStore.EXPECT().GetFeeds(gomock.Any()).DoAndReturn(func(context.Context) ([]models.Feed, error) {
+		if cycles.Add(1) == 1 {
+			return []models.Feed{feedA, feedB}, nil
+		}
+
+		return []models.Feed{feedB, feedA}, nil
+	}).Times(2)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(result, nil).AnyTimes()
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	var mu sync.Mutex
+	recorded := false
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), sharedURL).DoAndReturn(func(context.Context, string) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return recorded, nil
+	}).AnyTimes()
+	mockClient.EXPECT().AddEntry(gomock.Any(), sharedURL, nil).DoAndReturn(func(context.Context, string, []string) (*wallabag.Entry, error) {
+		// Hold the send open long enough for the other cycle to check the URL
+		time.Sleep(50 * time.Millisecond)
+
+		return &wallabag.Entry{ID: 7, URL: sharedURL}, nil
+	}).Times(1)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), gomock.Any(), gomock.Any(), 7).DoAndReturn(func(context.Context, int, *models.Article, int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = true
+
+		return nil
+	}).Times(1)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.ProcessFeeds()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWorker_ArticleRecordedByPeer(t *testing.T) {
+	const articleURL = "https://example.com/article"
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{Articles: []rss.Article{{Title: "Article", URL: articleURL}}}, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), articleURL).Return(false, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), articleURL, nil).Return(&wallabag.Entry{ID: 3}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 3).
+		Return(fmt.Errorf("failed to insert article: %w", database.ErrArticleExists))
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
@@ -3,13 +3,26 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"wallabag-rss-tool/pkg/audit"
 	"wallabag-rss-tool/pkg/database"
+	"wallabag-rss-tool/pkg/events"
+	"wallabag-rss-tool/pkg/extract"
+	"wallabag-rss-tool/pkg/favicon"
 	"wallabag-rss-tool/pkg/logging"
 	"wallabag-rss-tool/pkg/models"
 	"wallabag-rss-tool/pkg/rss"
+	"wallabag-rss-tool/pkg/sanitize"
 	"wallabag-rss-tool/pkg/wallabag"
 )
 
@@ -18,33 +31,370 @@ type Worker struct {
 	store          database.Storer
 	rssProcessor   rss.Processorer
 	wallabagClient wallabag.Clienter
+	extractor      *extract.Extractor // Pulls article content for feeds with a content selector
+	favicons       *favicon.Finder    // Looks up feed site icons; nil when Config.Favicons is off
 	stopChan       chan struct{}
-	priorityQueue  chan int // Channel for immediate feed processing
+	startOnce      sync.Once
+	stopOnce       sync.Once
+	priorityQueue  *feedQueue // Feeds queued for immediate processing, highest priority first
+	config         atomic.Pointer[Config] // Swapped by Reload; read through Config
+	loops          sync.WaitGroup // Polling, priority queue and enrichment goroutines started by Start
+	inFlightMu     sync.Mutex
+	inFlight       map[int]string // Feed ID to URL for feeds being processed right now
+	feedLocksMu    sync.Mutex
+	feedLocks      map[int]*sync.Mutex // Per-feed locks so a feed is processed by one goroutine at a time
+	health         healthState
+	fetchErrorLog  *logging.Throttler // Coalesces the errors of a feed that fails the same way every poll
+	debounce       sendDebounce       // Open Config.SendDebounce windows by feed
+	heldBack       heldBackRetries    // When articles held back by a feed's minimum age can be sent
+	events         *events.Hub // Live activity for SSE clients
+	enrichQueue    chan int    // Feed IDs waiting for QueueFeedEnrichment
+	rejections     rejectionCounter // Articles Wallabag rejected in a row, by feed
+	articleLocks   urlLocks         // Held by normalized article URL from the processed check until the article is recorded
+}
+
+// Config holds optional worker behaviour settings. The zero value keeps the defaults.
+type Config struct {
+	// MaxSendsPerCycle caps the number of Wallabag sends across all feeds in one polling
+	// cycle. Articles over the cap are left unprocessed for the next cycle. Zero means no cap.
+	MaxSendsPerCycle int
+	// FieldLimits bounds article titles and URLs; articles with over-long URLs are skipped.
+	FieldLimits models.FieldLimits
+	// Transport, when set, is used to fetch article pages for content extraction, so they
+	// go through the same proxy as the feeds.
+	Transport http.RoundTripper
+	// TagWithFeedName tags every entry with its feed's slugified name, as if each feed had
+	// TagWithFeedName set.
+	TagWithFeedName bool
+	// StaleAfter is how long the worker may go without completing a polling cycle before
+	// Health reports it stale. Zero means twice the polling interval.
+	StaleAfter time.Duration
+	// SanitizePolicy is applied to page content before it is sent to Wallabag. Anything other
+	// than sanitize.Lenient, including the zero value, means sanitize.Strict.
+	SanitizePolicy sanitize.Policy
+	// Favicons looks up the favicon of each feed's site after a successful fetch, refreshing
+	// it every FaviconRefreshInterval, so the UI can show it next to the feed.
+	Favicons bool
+	// CheckExistingEntries asks Wallabag whether each new article is already saved before
+	// adding it, and records the existing entry instead, so a lost or reset database does not
+	// create duplicates. It costs one extra API call per new article.
+	CheckExistingEntries bool
+	// CrossFeedDedup also skips articles whose normalized URL matches one already recorded by
+	// any feed, so an article reached through both its site's feed and an aggregator, under
+	// slightly different URLs, is sent once.
+	CrossFeedDedup bool
+	// CategoryTagPrefix is prepended to the tags made from item categories for feeds with
+	// CategoriesAsTags set, e.g. "rss-" turns category "Go" into tag "rss-go".
+	CategoryTagPrefix string
+	// FallbackTag is sent with articles that would otherwise get no tags from their feed, so
+	// no entry arrives in Wallabag untagged. The instance tag, which every entry gets, does not
+	// count. Empty adds nothing.
+	FallbackTag string
+	// Audit records completed polling cycles and each article sent to or rejected by
+	// Wallabag. Nil records nothing.
+	Audit *audit.Log
+	// SendConcurrency is how many of a feed's new articles are sent to Wallabag at once.
+	// Articles sharing a normalized URL are always handled one after another, so duplicates
+	// are still caught. Zero or one sends sequentially.
+	SendConcurrency int
+	// SendDebounce holds a feed's new articles for this long after the first one is found, then
+	// sends everything found meanwhile together, so a burst of items goes out as one batch and,
+	// with CrossFeedDedup, near-duplicates within it are sent once. Zero sends at once.
+	SendDebounce time.Duration
+	// SaveOnFailure records articles Wallabag refused as unsent instead of retrying them on
+	// every poll, so a page Wallabag can never fetch is not tried forever. They are retried
+	// only through RetryArticle.
+	SaveOnFailure bool
+	// EnrichConcurrency is how many feeds queued by QueueFeedEnrichment are looked up at once.
+	// Zero or one looks them up one at a time.
+	EnrichConcurrency int
+	// OptimizeInterval is how often the database is compacted with Storer.Optimize, which
+	// briefly locks it. Zero never optimizes on a schedule.
+	OptimizeInterval time.Duration
+	// PauseAfterRejections disables a feed once Wallabag has rejected this many of its articles
+	// in a row with 400 Bad Request, as it does for pages it can't fetch, recording why on the
+	// feed. Other failures neither count nor break the run. Zero never pauses.
+	PauseAfterRejections int
+	// MinTitleLength skips articles whose titles are shorter than this many characters, recording
+	// them as processed so they are not considered again, for feeds that carry spam or placeholder
+	// items. A feed's own MinTitleLength takes precedence. Zero lets every title through.
+	MinTitleLength int
+	// LinkCheckInterval is how often every feed's URL is fetched and parsed, apart from its poll
+	// schedule, to find dead feeds; see CheckFeedLinks. Zero never checks.
+	LinkCheckInterval time.Duration
+	// PruneExpiredArticles deletes the articles recorded longer ago than the store's dedup
+	// window at the end of each polling cycle, so the database does not keep every URL forever.
+	PruneExpiredArticles bool
 }
 
 // NewWorker creates a new Worker instance.
 func NewWorker(store database.Storer, rssProcessor rss.Processorer, wallabagClient wallabag.Clienter) *Worker {
-	return &Worker{
+	return NewWorkerWithConfig(store, rssProcessor, wallabagClient, Config{})
+}
+
+// NewWorkerWithConfig creates a new Worker instance with the given configuration.
+func NewWorkerWithConfig(store database.Storer, rssProcessor rss.Processorer, wallabagClient wallabag.Clienter, config Config) *Worker {
+	extractor := extract.NewExtractor()
+	if config.Transport != nil {
+		extractor.Client.Transport = config.Transport
+	}
+
+	var favicons *favicon.Finder
+	if config.Favicons {
+		favicons = favicon.NewFinder()
+		if config.Transport != nil {
+			favicons.Client.Transport = config.Transport
+		}
+	}
+
+	w := &Worker{
 		store:          store,
 		rssProcessor:   rssProcessor,
 		wallabagClient: wallabagClient,
+		extractor:      extractor,
+		favicons:       favicons,
 		stopChan:       make(chan struct{}),
-		priorityQueue:  make(chan int, 100), // Buffered channel to prevent blocking
+		priorityQueue:  newFeedQueue(priorityQueueCapacity),
+		inFlight:       make(map[int]string),
+		feedLocks:      make(map[int]*sync.Mutex),
+		health:         healthState{startedAt: time.Now()},
+		fetchErrorLog:  logging.NewThrottler(FetchErrorLogWindow),
+		events:         events.NewHub(),
+		enrichQueue:    make(chan int, enrichQueueCapacity),
 	}
+	w.config.Store(&config)
+
+	return w
+}
+
+// Events returns the hub the worker publishes sync and article events to.
+func (w *Worker) Events() *events.Hub {
+	return w.events
 }
 
-// Start begins the worker's polling loop.
+// Config returns the worker's current configuration.
+func (w *Worker) Config() Config {
+	return *w.config.Load()
+}
+
+// Reload applies the hot-reloadable settings from config: MaxSendsPerCycle, TagWithFeedName,
+// StaleAfter, SanitizePolicy, CheckExistingEntries, SaveOnFailure, CrossFeedDedup,
+// CategoryTagPrefix, FallbackTag, SendConcurrency, SendDebounce, PauseAfterRejections and
+// MinTitleLength. The send cap takes effect from the next polling cycle, a new SendDebounce from
+// the next window opened, and the rest from the next article handled. FieldLimits, Transport,
+// Favicons, Audit, EnrichConcurrency, OptimizeInterval and LinkCheckInterval are fixed when the
+// worker is created or started and are left unchanged.
+func (w *Worker) Reload(config Config) {
+	updated := w.Config()
+	updated.MaxSendsPerCycle = config.MaxSendsPerCycle
+	updated.TagWithFeedName = config.TagWithFeedName
+	updated.StaleAfter = config.StaleAfter
+	updated.SanitizePolicy = config.SanitizePolicy
+	updated.CheckExistingEntries = config.CheckExistingEntries
+	updated.SaveOnFailure = config.SaveOnFailure
+	updated.CrossFeedDedup = config.CrossFeedDedup
+	updated.CategoryTagPrefix = config.CategoryTagPrefix
+	updated.FallbackTag = config.FallbackTag
+	updated.SendConcurrency = config.SendConcurrency
+	updated.SendDebounce = config.SendDebounce
+	updated.PauseAfterRejections = config.PauseAfterRejections
+	updated.MinTitleLength = config.MinTitleLength
+	w.config.Store(&updated)
+
+	logging.Info("Worker configuration reloaded",
+		"max_sends_per_cycle", updated.MaxSendsPerCycle,
+		"tag_with_feed_name", updated.TagWithFeedName,
+		"stale_after", updated.StaleAfter.String(),
+		"sanitize_policy", updated.SanitizePolicy,
+		"check_existing_entries", updated.CheckExistingEntries,
+		"save_on_failure", updated.SaveOnFailure,
+		"cross_feed_dedup", updated.CrossFeedDedup,
+		"category_tag_prefix", updated.CategoryTagPrefix,
+		"fallback_tag", updated.FallbackTag,
+		"send_concurrency", updated.SendConcurrency,
+		"send_debounce", updated.SendDebounce.String(),
+		"pause_after_rejections", updated.PauseAfterRejections,
+		"min_title_length", updated.MinTitleLength)
+}
+
+// sendBudget tracks how many Wallabag sends remain in the current cycle. A nil budget is unlimited.
+// It is safe for concurrent use.
+type sendBudget struct {
+	mu        sync.Mutex
+	remaining int
+}
+
+// newSendBudget returns a budget for limit sends, or nil when limit is not positive
+func newSendBudget(limit int) *sendBudget {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &sendBudget{remaining: limit}
+}
+
+// exhausted reports whether no further sends are allowed
+func (b *sendBudget) exhausted() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.remaining <= 0
+}
+
+// take reserves a send, reporting false when none remain
+func (b *sendBudget) take() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining--
+
+	return true
+}
+
+// refund returns a send reserved by take that turned out not to be needed
+func (b *sendBudget) refund() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remaining++
+}
+
+// Start begins the worker's polling loop. A worker starts at most once: calling Start again,
+// or after Stop, does nothing.
 func (w *Worker) Start() {
-	logging.Info("Worker started")
-	go w.run()
-	go w.processPriorityQueue()
+	w.startOnce.Do(func() {
+		if w.stopping() {
+			return
+		}
+
+		logging.Info("Worker started")
+		w.loops.Add(2)
+		go func() {
+			defer w.loops.Done()
+			w.run()
+		}()
+		go func() {
+			defer w.loops.Done()
+			w.processPriorityQueue()
+		}()
+		for range max(w.Config().EnrichConcurrency, 1) {
+			w.loops.Add(1)
+			go func() {
+				defer w.loops.Done()
+				w.processEnrichQueue()
+			}()
+		}
+		if interval := w.Config().OptimizeInterval; interval > 0 {
+			w.loops.Add(1)
+			go func() {
+				defer w.loops.Done()
+				w.runOptimizeLoop(interval)
+			}()
+		}
+		if interval := w.Config().LinkCheckInterval; interval > 0 {
+			w.loops.Add(1)
+			go func() {
+				defer w.loops.Done()
+				w.runLinkCheckLoop(interval)
+			}()
+		}
+	})
 }
 
-// Stop signals the worker to stop its polling loop.
+// Stop signals the worker to stop its polling loop and priority queue. Feeds being processed
+// finish, but no further feeds are started; Stop does not wait for them, use Shutdown for
+// that. Calling Stop more than once is safe.
 func (w *Worker) Stop() {
-	logging.Info("Worker stopping...")
-	close(w.stopChan)
-	// priorityQueue is left open to avoid panic if QueueFeedForImmediate is called during shutdown
+	w.stopOnce.Do(func() {
+		logging.Info("Worker stopping...")
+		close(w.stopChan)
+	})
+}
+
+// stopping reports whether Stop has been called
+func (w *Worker) stopping() bool {
+	select {
+	case <-w.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown stops the worker and waits for feeds being processed to finish. If ctx ends first
+// it returns an error naming the feeds still in flight.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		w.loops.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logging.Info("Worker shut down cleanly")
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("worker still processing feeds %v: %w", w.InFlight(), ctx.Err())
+	}
+}
+
+// InFlight returns the URLs of feeds currently being processed, sorted.
+func (w *Worker) InFlight() []string {
+	w.inFlightMu.Lock()
+	defer w.inFlightMu.Unlock()
+
+	urls := make([]string, 0, len(w.inFlight))
+	for _, feedURL := range w.inFlight {
+		urls = append(urls, feedURL)
+	}
+	sort.Strings(urls)
+
+	return urls
+}
+
+// lockFeed blocks until no other goroutine is processing the feed with the given ID and returns
+// a func that releases it. Different feeds never wait on each other.
+func (w *Worker) lockFeed(feedID int) func() {
+	w.feedLocksMu.Lock()
+	lock, ok := w.feedLocks[feedID]
+	if !ok {
+		lock = &sync.Mutex{}
+		w.feedLocks[feedID] = lock
+	}
+	w.feedLocksMu.Unlock()
+
+	lock.Lock()
+
+	return lock.Unlock
+}
+
+// trackInFlight records feed as being processed and returns a func that clears it
+func (w *Worker) trackInFlight(feed *models.Feed) func() {
+	w.inFlightMu.Lock()
+	w.inFlight[feed.ID] = feed.URL
+	w.inFlightMu.Unlock()
+
+	return func() {
+		w.inFlightMu.Lock()
+		delete(w.inFlight, feed.ID)
+		w.inFlightMu.Unlock()
+	}
 }
 
 func (w *Worker) run() {
@@ -61,6 +411,7 @@ func (w *Worker) run() {
 	}
 
 	logging.Info("Worker polling configured", "interval_minutes", defaultInterval)
+	w.health.setCycleInterval(time.Duration(defaultInterval) * time.Minute)
 	ticker := time.NewTicker(time.Duration(defaultInterval) * time.Minute)
 	defer ticker.Stop()
 
@@ -76,41 +427,61 @@ func (w *Worker) run() {
 	}
 }
 
-// processPriorityQueue handles immediate feed processing requests
+// processPriorityQueue handles immediate feed processing requests, highest priority first
 func (w *Worker) processPriorityQueue() {
 	for {
 		select {
-		case feedID := <-w.priorityQueue:
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-			
-			logging.Info("Processing priority feed from queue", "feed_id", feedID)
-			
-			if err := w.processSingleFeedByID(ctx, feedID); err != nil {
-				logging.Error("Failed to process priority feed",
-					"error", err,
-					"feed_id", feedID)
-			}
-			
-			cancel()
-			
 		case <-w.stopChan:
 			logging.Info("Priority queue processor stopped")
 			return
+		default:
+		}
+
+		feedID, ok := w.priorityQueue.pop()
+		if !ok {
+			select {
+			case <-w.priorityQueue.ready:
+			case <-w.stopChan:
+				logging.Info("Priority queue processor stopped")
+				return
+			}
+
+			continue
 		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+
+		logging.Info("Processing priority feed from queue", "feed_id", feedID)
+
+		if err := w.processSingleFeedByID(ctx, feedID); err != nil {
+			logging.Error("Failed to process priority feed",
+				"error", err,
+				"feed_id", feedID)
+		}
+
+		cancel()
 	}
 }
 
-// QueueFeedForImmediate adds a feed to the priority queue for immediate processing
+// QueueFeedForImmediate adds a feed to the priority queue for immediate processing at the
+// default priority
 func (w *Worker) QueueFeedForImmediate(feedID int) {
-	select {
-	case w.priorityQueue <- feedID:
-		logging.Info("Feed queued for immediate processing", "feed_id", feedID)
-	default:
-		// Channel is full, log warning but don't block
-		logging.Warn("Priority queue is full, feed will be processed in next scheduled run",
-			"feed_id", feedID,
-			"queue_capacity", cap(w.priorityQueue))
+	w.QueueFeedWithPriority(feedID, 0)
+}
+
+// QueueFeedWithPriority adds a feed to the priority queue for immediate processing. Queued
+// feeds with a higher priority are processed first.
+func (w *Worker) QueueFeedWithPriority(feedID, priority int) {
+	if w.priorityQueue.push(feedID, priority) {
+		logging.Info("Feed queued for immediate processing", "feed_id", feedID, "priority", priority)
+
+		return
 	}
+
+	// Queue is full, log warning but don't block
+	logging.Warn("Priority queue is full, feed will be processed in next scheduled run",
+		"feed_id", feedID,
+		"queue_capacity", priorityQueueCapacity)
 }
 
 // QueueAllFeedsForImmediate queues all feeds for immediate processing (used for manual sync)
@@ -121,18 +492,15 @@ func (w *Worker) QueueAllFeedsForImmediate(ctx context.Context) error {
 	}
 	
 	queuedCount := 0
-queueLoop:
 	for _, feed := range feeds {
-		select {
-		case w.priorityQueue <- feed.ID:
-			queuedCount++
-		default:
+		if !w.priorityQueue.push(feed.ID, feed.Priority) {
 			logging.Warn("Priority queue full, remaining feeds will sync on schedule",
 				"queued", queuedCount,
 				"total", len(feeds))
 
-			break queueLoop
+			break
 		}
+		queuedCount++
 	}
 	
 	logging.Info("Queued feeds for immediate processing",
@@ -144,7 +512,7 @@ queueLoop:
 
 // GetQueueStats returns statistics about the priority queue
 func (w *Worker) GetQueueStats() (int, int) {
-	return len(w.priorityQueue), cap(w.priorityQueue)
+	return w.priorityQueue.len(), priorityQueueCapacity
 }
 
 // ProcessFeeds fetches all active feeds and processes them.
@@ -155,25 +523,69 @@ func (w *Worker) ProcessFeeds() {
 // ProcessFeedsWithContext fetches all active feeds and processes them with context support.
 func (w *Worker) ProcessFeedsWithContext(ctx context.Context) {
 	logging.Info("Processing feeds started")
+	w.events.Publish(events.Event{Type: events.TypeSyncStarted})
+	defer w.events.Publish(events.Event{Type: events.TypeSyncFinished})
+
 	feeds, err := w.store.GetFeeds(ctx)
 	if err != nil {
-		logging.Error("Failed to get feeds from database", "error", fmt.Errorf("store.GetFeeds: %w", err))
+		err = fmt.Errorf("store.GetFeeds: %w", err)
+		logging.Error("Failed to get feeds from database", "error", err)
+		w.health.recordError(time.Now(), err)
 
 		return
 	}
 
 	logging.Info("Retrieved feeds for processing", "feed_count", len(feeds))
 
+	// Higher-priority feeds go first so they reach Wallabag sooner when many are due at once
+	// or the send cap runs out
+	sort.SliceStable(feeds, func(i, j int) bool {
+		return feeds[i].Priority > feeds[j].Priority
+	})
+
+	maxSends := w.Config().MaxSendsPerCycle
+	budget := newSendBudget(maxSends)
 	for _, feed := range feeds {
 		if w.shouldStopProcessing(ctx) {
 			return
 		}
+		if w.stopping() {
+			logging.Info("Worker stopping, deferring remaining feeds to next start")
+
+			return
+		}
 
-		w.processSingleFeed(ctx, &feed)
+		if budget.exhausted() {
+			logging.Info("Send cap reached, deferring remaining feeds to next cycle",
+				"max_sends_per_cycle", maxSends)
+
+			break
+		}
+
+		w.processSingleFeed(ctx, &feed, budget)
 	}
+	if w.Config().PruneExpiredArticles {
+		w.pruneExpiredArticles(ctx)
+	}
+	w.health.recordSuccess(time.Now())
+	w.Config().Audit.Record(models.AuditSyncRun, 0, fmt.Sprintf("Completed a polling cycle over %d feeds", len(feeds)))
 	logging.Info("Processing feeds completed")
 }
 
+// pruneExpiredArticles deletes the articles that have aged out of the dedup window
+func (w *Worker) pruneExpiredArticles(ctx context.Context) {
+	pruned, err := w.store.PruneExpiredArticles(ctx)
+	if err != nil {
+		logging.Error("Failed to prune expired articles",
+			"error", fmt.Errorf("store.PruneExpiredArticles: %w", err))
+
+		return
+	}
+	if pruned > 0 {
+		logging.Info("Pruned articles older than the dedup window", "pruned", pruned)
+	}
+}
+
 // processSingleFeedByID processes a single feed by its ID immediately
 func (w *Worker) processSingleFeedByID(ctx context.Context, feedID int) error {
 	feed, err := w.store.GetFeedByID(ctx, feedID)
@@ -186,7 +598,7 @@ func (w *Worker) processSingleFeedByID(ctx context.Context, feedID int) error {
 		"feed_name", feed.Name,
 		"feed_url", feed.URL)
 
-	w.processSingleFeed(ctx, feed)
+	w.processSingleFeed(ctx, feed, nil)
 
 	return nil
 }
@@ -204,32 +616,73 @@ func (w *Worker) shouldStopProcessing(ctx context.Context) bool {
 	}
 }
 
-// processSingleFeed processes a single feed
-func (w *Worker) processSingleFeed(ctx context.Context, feed *models.Feed) {
+// processSingleFeed processes a single feed, sending at most what budget allows
+func (w *Worker) processSingleFeed(ctx context.Context, feed *models.Feed, budget *sendBudget) {
 	feedLogger := logging.With("feed_id", feed.ID, "feed_name", feed.Name, "feed_url", feed.URL)
 
+	if feed.Disabled {
+		feedLogger.Debug("Skipping disabled feed")
+
+		return
+	}
+
+	if feed.IsSnoozed(time.Now()) {
+		feedLogger.Debug("Skipping snoozed feed", "snoozed_until", feed.SnoozedUntil)
+
+		return
+	}
+
 	// Check if it's time to fetch this feed
 	effectiveInterval := w.getEffectiveInterval(ctx, feedLogger, feed)
 	if w.shouldSkipFeed(feedLogger, feed, effectiveInterval) {
 		return
 	}
 
+	// A feed on its regular tick and queued for immediate processing must not run twice at
+	// once, or both runs could send the same new articles before either records them
+	defer w.lockFeed(feed.ID)()
+	defer w.trackInFlight(feed)()
+
 	// Fetch articles
-	articles := w.fetchFeedArticles(feedLogger, feed)
-	if articles == nil {
+	result := w.fetchFeedArticles(ctx, feedLogger, feed)
+	if result == nil {
 		return // Error already logged
 	}
 
+	w.updateAutoInterval(ctx, feedLogger, feed, result.Articles)
+	w.refreshFavicon(ctx, feedLogger, feed, result)
+	w.updateContentKind(ctx, feedLogger, feed, result)
+	w.updateTTL(ctx, feedLogger, feed, result)
+
+	if w.isFeedUnchanged(feed, result) {
+		feedLogger.Debug("Feed build date unchanged since last fetch, skipping article processing",
+			"last_build_date", result.LastBuildDate)
+		w.updateLastFetched(ctx, feedLogger, feed)
+
+		return
+	}
+
 	// Process articles
-	stats := w.processArticles(ctx, feedLogger, feed, articles)
+	stats := w.processArticles(ctx, feedLogger, feed, result.Articles, budget)
 
 	// Log results and update feed
-	w.finalizeFeedProcessing(ctx, feedLogger, feed, articles, stats)
+	w.finalizeFeedProcessing(ctx, feedLogger, feed, result, stats)
+}
+
+// isFeedUnchanged reports whether the feed's build date matches the one recorded on the last
+// processed fetch. Feeds without a build date, and feeds still awaiting their initial sync,
+// are always processed.
+func (w *Worker) isFeedUnchanged(feed *models.Feed, result *rss.FeedResult) bool {
+	if !feed.InitialSyncDone || feed.LastBuildDate == nil || result.LastBuildDate == nil {
+		return false
+	}
+
+	return feed.LastBuildDate.Equal(*result.LastBuildDate)
 }
 
 // getEffectiveInterval determines the effective polling interval for a feed
 func (w *Worker) getEffectiveInterval(ctx context.Context, feedLogger logging.Logger, feed *models.Feed) int {
-	effectiveInterval := feed.PollIntervalMinutes
+	effectiveInterval := feed.OwnPollIntervalMinutes()
 	if effectiveInterval == 0 {
 		defaultInterval, err := w.store.GetDefaultPollInterval(ctx)
 		if err != nil {
@@ -247,10 +700,21 @@ func (w *Worker) getEffectiveInterval(ctx context.Context, feedLogger logging.Lo
 
 // shouldSkipFeed checks if a feed should be skipped based on timing
 func (w *Worker) shouldSkipFeed(feedLogger logging.Logger, feed *models.Feed, effectiveInterval int) bool {
-	if feed.LastFetched != nil && time.Since(*feed.LastFetched) < time.Duration(effectiveInterval)*time.Minute {
-		nextFetch := time.Duration(effectiveInterval)*time.Minute - time.Since(*feed.LastFetched)
+	now := time.Now()
+	if w.heldBack.due(feed.ID, now) {
+		feedLogger.Debug("Articles held back by the minimum age are ready, fetching early")
+
+		return false
+	}
+	if w.debounce.closed(feed.ID, now) {
+		feedLogger.Debug("Send debounce window closed, fetching early to send the batch")
+
+		return false
+	}
+
+	if next := feed.NextPollTime(effectiveInterval); next != nil && now.Before(*next) {
 		feedLogger.Debug("Skipping feed, not yet time to fetch",
-			"next_fetch_in", nextFetch.Round(time.Second),
+			"next_fetch_in", time.Until(*next).Round(time.Second),
 			"poll_interval_minutes", effectiveInterval)
 
 		return true
@@ -260,37 +724,50 @@ func (w *Worker) shouldSkipFeed(feedLogger logging.Logger, feed *models.Feed, ef
 }
 
 // fetchFeedArticles fetches articles for a feed based on sync status
-func (w *Worker) fetchFeedArticles(feedLogger logging.Logger, feed *models.Feed) []rss.Article {
+func (w *Worker) fetchFeedArticles(ctx context.Context, feedLogger logging.Logger, feed *models.Feed) *rss.FeedResult {
 	feedLogger.Info("Fetching articles for feed",
 		"sync_mode", feed.SyncMode,
 		"initial_sync_done", feed.InitialSyncDone)
 
-	var articles []rss.Article
-	var err error
+	result, err := w.rssProcessor.FetchFeed(ctx, feed)
+	if err != nil {
+		w.health.recordFeedError(feed.ID, err)
+		msg := "Failed to fetch and parse feed"
+		if !feed.InitialSyncDone {
+			msg = "Failed to fetch and parse feed for initial sync"
+		}
+		w.fetchErrorLog.Error(feedLogger, strconv.Itoa(feed.ID), err.Error(), msg,
+			"error", fmt.Errorf("rssProcessor.FetchFeed: %w", err))
 
-	if !feed.InitialSyncDone {
-		articles, err = w.rssProcessor.FetchAndParseWithSyncOptions(feed.URL, feed.SyncMode, feed.SyncCount, feed.SyncDateFrom)
-		if err != nil {
-			feedLogger.Error("Failed to fetch and parse feed for initial sync",
-				"error", fmt.Errorf("rssProcessor.FetchAndParseWithSyncOptions: %w", err))
+		return nil
+	}
+	w.health.clearFeedError(feed.ID)
+	w.health.recordFeedMove(feed.ID, result.MovedTo)
+	w.fetchErrorLog.Reset(strconv.Itoa(feed.ID))
+	if result.MovedTo != "" {
+		feedLogger.Warn("Feed has moved permanently to another host", "moved_to", result.MovedTo)
+	}
 
-			return nil
-		}
+	if !feed.InitialSyncDone {
 		feedLogger.Info("Initial sync completed",
-			"articles_found", len(articles),
+			"articles_found", len(result.Articles),
 			"sync_mode", feed.SyncMode)
 	} else {
-		articles, err = w.rssProcessor.FetchAndParse(feed.URL)
-		if err != nil {
-			feedLogger.Error("Failed to fetch and parse feed",
-				"error", fmt.Errorf("rssProcessor.FetchAndParse: %w", err))
-
-			return nil
-		}
-		feedLogger.Debug("Regular sync completed", "articles_found", len(articles))
+		feedLogger.Debug("Regular sync completed", "articles_found", len(result.Articles))
 	}
 
-	return articles
+	return result
+}
+
+// ProgressFunc receives how much of a bulk operation is done out of its total, so a caller
+// running it as a background job can show progress. A nil ProgressFunc reports nothing.
+type ProgressFunc func(done, total int)
+
+// report calls p if it is set
+func (p ProgressFunc) report(done, total int) {
+	if p != nil {
+		p(done, total)
+	}
 }
 
 // ProcessingStats holds statistics for article processing
@@ -298,10 +775,41 @@ type ProcessingStats struct {
 	ProcessedCount int
 	NewCount       int
 	ErrorCount     int
+	DeferredCount  int       // New articles left for the next cycle because the send cap was reached or the feed was paused
+	SkippedCount   int       // Articles rejected by the field limits
+	FilteredCount  int       // Articles recorded without sending because they matched none of the feed's categories
+	TooRecentCount int       // New articles held back until they reach the feed's minimum age
+	DebouncedCount int       // New articles held back until the feed's send debounce window closes
+	TooRecentUntil time.Time // When the first article held back by the minimum age reaches it (zero = none held)
+}
+
+// add adds other's counts to s
+func (s *ProcessingStats) add(other ProcessingStats) {
+	s.ProcessedCount += other.ProcessedCount
+	s.NewCount += other.NewCount
+	s.ErrorCount += other.ErrorCount
+	s.DeferredCount += other.DeferredCount
+	s.SkippedCount += other.SkippedCount
+	s.FilteredCount += other.FilteredCount
+	s.TooRecentCount += other.TooRecentCount
+	s.DebouncedCount += other.DebouncedCount
+	if !other.TooRecentUntil.IsZero() && (s.TooRecentUntil.IsZero() || other.TooRecentUntil.Before(s.TooRecentUntil)) {
+		s.TooRecentUntil = other.TooRecentUntil
+	}
 }
 
-// processArticles processes all articles for a feed
-func (w *Worker) processArticles(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, articles []rss.Article) ProcessingStats {
+// processArticles processes all articles for a feed, up to Config.SendConcurrency at a time
+func (w *Worker) processArticles(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, articles []rss.Article, budget *sendBudget) ProcessingStats {
+	if w.Config().SendDebounce > 0 {
+		defer func() { w.debounce.release(feed.ID, time.Now()) }()
+	}
+
+	w.resumeRejectedFeed(feedLogger, feed)
+
+	if concurrency := w.Config().SendConcurrency; concurrency > 1 {
+		return w.processArticlesConcurrently(ctx, feedLogger, feed, articles, budget, concurrency)
+	}
+
 	stats := ProcessingStats{}
 
 	for _, article := range articles {
@@ -311,16 +819,93 @@ func (w *Worker) processArticles(ctx context.Context, feedLogger logging.Logger,
 			return stats
 		}
 
-		w.processIndividualArticle(ctx, feedLogger, feed, article, &stats)
+		w.processIndividualArticle(ctx, feedLogger, feed, article, budget, &stats)
 	}
 
 	return stats
 }
 
+// processArticlesConcurrently processes a feed's articles with up to concurrency goroutines.
+// Articles are grouped by normalized URL and each group is processed in order by one goroutine,
+// so a URL listed twice is recorded by the first before the second checks for it.
+func (w *Worker) processArticlesConcurrently(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, articles []rss.Article, budget *sendBudget, concurrency int) ProcessingStats {
+	var groups [][]rss.Article
+	groupIndex := make(map[string]int)
+	for _, article := range articles {
+		key := article.URL
+		if feed.StripQueryParams {
+			key = stripQueryParams(key)
+		}
+		key = models.NormalizeArticleURL(key)
+
+		index, found := groupIndex[key]
+		if !found {
+			index = len(groups)
+			groupIndex[key] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], article)
+	}
+
+	var (
+		stats   ProcessingStats
+		statsMu sync.Mutex
+		wg      sync.WaitGroup
+	)
+	slots := make(chan struct{}, concurrency)
+	for _, group := range groups {
+		if w.shouldStopProcessing(ctx) {
+			feedLogger.Info("Article processing canceled by context", "reason", ctx.Err())
+
+			break
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(group []rss.Article) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			var groupStats ProcessingStats
+			for _, article := range group {
+				if w.shouldStopProcessing(ctx) {
+					break
+				}
+				w.processIndividualArticle(ctx, feedLogger, feed, article, budget, &groupStats)
+			}
+
+			statsMu.Lock()
+			stats.add(groupStats)
+			statsMu.Unlock()
+		}(group)
+	}
+	wg.Wait()
+
+	return stats
+}
+
 // processIndividualArticle processes a single article
-func (w *Worker) processIndividualArticle(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, article rss.Article, stats *ProcessingStats) {
+func (w *Worker) processIndividualArticle(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, article rss.Article, budget *sendBudget, stats *ProcessingStats) {
+	if err := w.Config().FieldLimits.ValidateURL(article.URL); err != nil {
+		feedLogger.Warn("Skipping article with over-long URL", "error", err)
+		stats.SkippedCount++
+
+		return
+	}
+	article.Title = w.Config().FieldLimits.TruncateTitle(article.Title)
+
+	// Dedupe and send on the cleaned URL, but remember what the feed published
+	originalURL := article.URL
+	if feed.StripQueryParams {
+		article.URL = stripQueryParams(article.URL)
+	}
+
 	articleLogger := feedLogger.With("article_title", article.Title, "article_url", article.URL)
 
+	// Another feed that finds the same article meanwhile waits here until it is recorded, then
+	// sees it as processed instead of sending it again
+	defer w.articleLocks.lock(models.NormalizeArticleURL(article.URL))()
+
 	processed, err := w.store.IsArticleAlreadyProcessed(ctx, article.URL)
 	if err != nil {
 		articleLogger.Error("Failed to check if article is already processed",
@@ -336,17 +921,99 @@ func (w *Worker) processIndividualArticle(ctx context.Context, feedLogger loggin
 		return
 	}
 
-	articleLogger.Info("Processing new article")
-	wallabagEntry, err := w.wallabagClient.AddEntry(ctx, article.URL)
-	if err != nil {
-		articleLogger.Error("Failed to add article to Wallabag",
-			"error", fmt.Errorf("wallabagClient.AddEntry: %w", err))
-		stats.ErrorCount++
+	if w.Config().CrossFeedDedup {
+		similar, err := w.store.IsSimilarArticleProcessed(ctx, article.URL)
+		if err != nil {
+			articleLogger.Error("Failed to check for the article under another URL",
+				"error", fmt.Errorf("store.IsSimilarArticleProcessed: %w", err))
+			stats.ErrorCount++
+
+			return
+		}
+		if similar {
+			articleLogger.Debug("Article already processed under another URL or feed, skipping")
+			stats.ProcessedCount++
+
+			return
+		}
+	}
+
+	if minimum := minTitleLength(feed, w.Config().MinTitleLength); isTitleTooShort(article, minimum) {
+		articleLogger.Debug("Article title shorter than the minimum, skipping",
+			"title", article.Title,
+			"min_title_length", minimum)
+		w.recordFilteredArticle(ctx, articleLogger, feed, article, originalURL, stats)
+
+		return
+	}
+
+	if !feed.MatchesCategories(article.Categories) {
+		articleLogger.Debug("Article matches none of the feed's categories, skipping",
+			"categories", article.Categories,
+			"include_categories", feed.IncludeCategories)
+		w.recordFilteredArticle(ctx, articleLogger, feed, article, originalURL, stats)
+
+		return
+	}
+
+	if isTooRecent(feed, article, time.Now()) {
+		articleLogger.Debug("Article newer than the feed's minimum age, deferring",
+			"published_at", article.PublishedAt,
+			"min_age_minutes", feed.MinAgeMinutes)
+		stats.TooRecentCount++
+		if settles := article.PublishedAt.Add(time.Duration(feed.MinAgeMinutes) * time.Minute); stats.TooRecentUntil.IsZero() || settles.Before(stats.TooRecentUntil) {
+			stats.TooRecentUntil = settles
+		}
+
+		return
+	}
+
+	if w.isDebounced(feed) {
+		articleLogger.Debug("Send debounce window open, holding article for the batch",
+			"send_debounce", w.Config().SendDebounce.String())
+		stats.DebouncedCount++
 
 		return
 	}
 
-	articleLogger.Info("Article successfully added to Wallabag", "wallabag_entry_id", wallabagEntry.ID)
+	if w.rejections.reached(feed.ID, w.Config().PauseAfterRejections) {
+		articleLogger.Debug("Feed paused after repeated Wallabag rejections, leaving article unsent")
+		stats.DeferredCount++
+
+		return
+	}
+
+	if !budget.take() {
+		articleLogger.Debug("Send cap reached, deferring article to next cycle")
+		stats.DeferredCount++
+
+		return
+	}
+
+	articleLogger.Info("Processing new article")
+	wallabagEntry := w.existingWallabagEntry(ctx, articleLogger, article)
+	alreadyInWallabag := wallabagEntry != nil
+	if alreadyInWallabag {
+		budget.refund()
+	} else {
+		wallabagEntry, err = w.sendToWallabag(ctx, articleLogger, feed, article)
+		if err != nil {
+			articleLogger.Error("Failed to add article to Wallabag", "error", err)
+			stats.ErrorCount++
+			w.Config().Audit.Record(models.AuditArticleFailed, feed.ID, fmt.Sprintf("%s: %v", article.URL, err))
+			if w.Config().SaveOnFailure {
+				w.recordFailedArticle(ctx, articleLogger, feed, article, originalURL)
+			}
+			w.countRejection(ctx, articleLogger, feed, err)
+
+			return
+		}
+
+		w.rejections.reset(feed.ID)
+		articleLogger.Info("Article successfully added to Wallabag", "wallabag_entry_id", wallabagEntry.ID)
+		w.Config().Audit.Record(models.AuditArticleSent, feed.ID,
+			fmt.Sprintf("%s (Wallabag entry %d)", article.URL, wallabagEntry.ID))
+	}
 
 	// Convert and save article
 	modelArticle := models.Article{
@@ -354,32 +1021,236 @@ func (w *Worker) processIndividualArticle(ctx context.Context, feedLogger loggin
 		URL:         article.URL,
 		PublishedAt: article.PublishedAt,
 	}
+	if originalURL != article.URL {
+		modelArticle.OriginalURL = originalURL
+	}
 
-	if err := w.store.SaveArticle(ctx, feed.ID, &modelArticle, wallabagEntry.ID); err != nil {
+	err = w.store.SaveArticle(ctx, feed.ID, &modelArticle, wallabagEntry.ID)
+	if errors.Is(err, database.ErrArticleExists) {
+		// Recorded meanwhile by a peer the URL lock can't see, such as another instance sharing
+		// the database; the article is processed either way
+		articleLogger.Info("Article already recorded by a peer, keeping its record",
+			"wallabag_entry_id", wallabagEntry.ID)
+		stats.ProcessedCount++
+	} else if err != nil {
 		articleLogger.Error("Failed to save article to database",
 			"error", fmt.Errorf("store.SaveArticle: %w", err),
 			"wallabag_entry_id", wallabagEntry.ID)
 		stats.ErrorCount++
+	} else if alreadyInWallabag {
+		stats.ProcessedCount++
 	} else {
 		stats.NewCount++
+		w.events.Publish(events.Event{Type: events.TypeArticle, Data: events.ArticleData{
+			FeedID:          feed.ID,
+			FeedName:        feed.Name,
+			Title:           article.Title,
+			URL:             article.URL,
+			WallabagEntryID: wallabagEntry.ID,
+		}})
+	}
+}
+
+// isTooRecent reports whether the article was published within the feed's minimum age. Articles
+// without their own publish date are never held back, since their fallback date is no guide.
+func isTooRecent(feed *models.Feed, article rss.Article, now time.Time) bool {
+	if feed.MinAgeMinutes <= 0 || article.PublishedAt == nil || article.DateGuessed {
+		return false
+	}
+
+	return article.PublishedAt.After(now.Add(-time.Duration(feed.MinAgeMinutes) * time.Minute))
+}
+
+// isDebounced reports whether the feed's new articles are being held for its send debounce
+// window, opening the window if needed and queueing the feed for when it closes
+func (w *Worker) isDebounced(feed *models.Feed) bool {
+	window := w.Config().SendDebounce
+	if window <= 0 {
+		return false
+	}
+
+	feedID, priority := feed.ID, feed.Priority
+
+	return w.debounce.holding(feedID, window, time.Now(), func(window time.Duration) {
+		time.AfterFunc(window, func() {
+			if !w.stopping() {
+				w.QueueFeedWithPriority(feedID, priority)
+			}
+		})
+	})
+}
+
+// recordFilteredArticle marks an article excluded by the feed's title length or category filter
+// as processed, so it is not considered again
+func (w *Worker) recordFilteredArticle(ctx context.Context, articleLogger logging.Logger, feed *models.Feed, article rss.Article, originalURL string, stats *ProcessingStats) {
+	modelArticle := models.Article{
+		Title:       article.Title,
+		URL:         article.URL,
+		PublishedAt: article.PublishedAt,
+	}
+	if originalURL != article.URL {
+		modelArticle.OriginalURL = originalURL
+	}
+
+	if err := w.store.SaveFilteredArticle(ctx, feed.ID, &modelArticle); err != nil {
+		articleLogger.Error("Failed to record filtered article",
+			"error", fmt.Errorf("store.SaveFilteredArticle: %w", err))
+		stats.ErrorCount++
+
+		return
+	}
+	stats.FilteredCount++
+}
+
+// existingWallabagEntry returns the article's entry when CheckExistingEntries is set and
+// Wallabag already has it, otherwise nil. A failed check is logged and the article is added
+// as usual.
+func (w *Worker) existingWallabagEntry(ctx context.Context, articleLogger logging.Logger, article rss.Article) *wallabag.Entry {
+	if !w.Config().CheckExistingEntries {
+		return nil
+	}
+
+	exists, entryID, err := w.wallabagClient.EntryExists(ctx, article.URL)
+	if err != nil {
+		articleLogger.Warn("Failed to check Wallabag for an existing entry, adding the article",
+			"error", fmt.Errorf("wallabagClient.EntryExists: %w", err))
+
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+
+	articleLogger.Info("Article already in Wallabag, recording it without adding", "wallabag_entry_id", entryID)
+
+	return &wallabag.Entry{ID: entryID, URL: article.URL, Title: article.Title}
+}
+
+// sendToWallabag adds the article to Wallabag the way the feed's delivery mode asks: as a link
+// for Wallabag to fetch, or with the page content extracted by the feed's content selector,
+// sanitized. In content_or_link mode a failed extraction falls back to sending the link; in
+// content mode it fails the article so it is retried on the next poll.
+func (w *Worker) sendToWallabag(ctx context.Context, articleLogger logging.Logger, feed *models.Feed, article rss.Article) (*wallabag.Entry, error) {
+	if feed.DeliveryMode.UsesContent() {
+		content, err := w.extractContent(ctx, feed, article)
+		if err == nil {
+			entry, err := w.wallabagClient.AddEntryWithContent(ctx, article.URL, article.Title, content, w.entryTags(feed, article))
+			if err != nil {
+				return nil, fmt.Errorf("wallabagClient.AddEntryWithContent: %w", err)
+			}
+
+			return entry, nil
+		}
+		if feed.DeliveryMode == models.DeliveryModeContent {
+			return nil, fmt.Errorf("content extraction: %w", err)
+		}
+		articleLogger.Warn("Content extraction failed, letting Wallabag fetch the page",
+			"error", err,
+			"content_selector", feed.ContentSelector)
+	}
+
+	entry, err := w.wallabagClient.AddEntry(ctx, article.URL, w.entryTags(feed, article))
+	if err != nil {
+		return nil, fmt.Errorf("wallabagClient.AddEntry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// extractContent extracts the article's page content with the feed's content selector and
+// sanitizes it for sending to Wallabag
+func (w *Worker) extractContent(ctx context.Context, feed *models.Feed, article rss.Article) (string, error) {
+	if feed.ContentSelector == "" {
+		return "", fmt.Errorf("feed has no content selector")
+	}
+
+	content, err := w.extractor.Extract(ctx, article.URL, feed.ContentSelector)
+	if err != nil {
+		return "", err
+	}
+
+	return sanitize.HTML(content, w.Config().SanitizePolicy)
+}
+
+// entryTags returns the tags to send with an article: its feed's slugified name when tagging
+// by feed name is enabled globally or for the feed, followed by the item's slugified
+// categories, prefixed with Config.CategoryTagPrefix, when the feed has CategoriesAsTags set.
+// When neither yields a tag, Config.FallbackTag is sent instead. The client adds the instance
+// tag and drops duplicates.
+func (w *Worker) entryTags(feed *models.Feed, article rss.Article) []string {
+	var tags []string
+	if w.Config().TagWithFeedName || feed.TagWithFeedName {
+		if tag := wallabag.SlugifyTag(feed.Name); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if feed.CategoriesAsTags {
+		prefix := strings.TrimSpace(w.Config().CategoryTagPrefix)
+		for _, category := range article.Categories {
+			if tag := wallabag.SlugifyTag(category); tag != "" {
+				tags = append(tags, prefix+tag)
+			}
+		}
+	}
+
+	if len(tags) == 0 {
+		if fallback := strings.TrimSpace(w.Config().FallbackTag); fallback != "" {
+			tags = append(tags, fallback)
+		}
+	}
+
+	return tags
+}
+
+// stripQueryParams removes the query string from rawURL. URLs that fail to parse are returned unchanged.
+func stripQueryParams(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
 	}
+
+	parsed.RawQuery = ""
+	parsed.ForceQuery = false
+
+	return parsed.String()
 }
 
 // finalizeFeedProcessing logs results and updates feed status
-func (w *Worker) finalizeFeedProcessing(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, articles []rss.Article, stats ProcessingStats) {
+func (w *Worker) finalizeFeedProcessing(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, result *rss.FeedResult, stats ProcessingStats) {
 	feedLogger.Info("Feed processing completed",
-		"total_articles", len(articles),
+		"total_articles", len(result.Articles),
 		"new_articles", stats.NewCount,
 		"already_processed", stats.ProcessedCount,
-		"errors", stats.ErrorCount)
+		"errors", stats.ErrorCount,
+		"deferred", stats.DeferredCount,
+		"skipped", stats.SkippedCount,
+		"filtered", stats.FilteredCount,
+		"too_recent", stats.TooRecentCount,
+		"debounced", stats.DebouncedCount)
+
+	// Leave the feed due so the deferred articles are picked up on the next cycle
+	if stats.DeferredCount > 0 {
+		return
+	}
 
-	if err := w.store.UpdateFeedLastFetched(ctx, feed.ID); err != nil {
-		feedLogger.Error("Failed to update feed last fetched time",
-			"error", fmt.Errorf("store.UpdateFeedLastFetched: %w", err))
+	w.updateLastFetched(ctx, feedLogger, feed)
+	w.pruneArticles(ctx, feedLogger, feed, len(result.Articles))
+	w.scheduleHeldBack(feedLogger, feed, stats.TooRecentUntil)
+
+	// Only remember the build date once every article went through, otherwise an unchanged
+	// feed would never retry the articles that failed or were held back
+	held := stats.TooRecentCount > 0 || stats.DebouncedCount > 0
+	if result.LastBuildDate != nil && stats.ErrorCount == 0 && !held {
+		if err := w.store.UpdateFeedLastBuildDate(ctx, feed.ID, *result.LastBuildDate); err != nil {
+			feedLogger.Error("Failed to update feed last build date",
+				"error", fmt.Errorf("store.UpdateFeedLastBuildDate: %w", err))
+		}
 	}
 
-	// Mark initial sync as completed if this was the first sync
-	if !feed.InitialSyncDone {
+	// Mark initial sync as completed if this was the first sync. Held-back articles are still
+	// part of it, so the next fetch filters by the sync options again.
+	if !feed.InitialSyncDone && !held {
 		if err := w.store.MarkFeedInitialSyncCompleted(ctx, feed.ID); err != nil {
 			feedLogger.Error("Failed to mark initial sync as completed",
 				"error", fmt.Errorf("store.MarkFeedInitialSyncCompleted: %w", err))
@@ -388,3 +1259,91 @@ func (w *Worker) finalizeFeedProcessing(ctx context.Context, feedLogger logging.
 		}
 	}
 }
+
+// scheduleHeldBack queues the feed for when its oldest article held back by the minimum age is
+// old enough to send, so it need not wait out its poll interval. A zero until means nothing is
+// held back.
+func (w *Worker) scheduleHeldBack(feedLogger logging.Logger, feed *models.Feed, until time.Time) {
+	if until.IsZero() {
+		w.heldBack.clear(feed.ID)
+
+		return
+	}
+	if !w.heldBack.hold(feed.ID, until) {
+		return
+	}
+
+	feedLogger.Debug("Articles held back by the minimum age, retrying when the first is old enough",
+		"retry_at", until)
+	feedID, priority := feed.ID, feed.Priority
+	time.AfterFunc(time.Until(until), func() {
+		if !w.stopping() {
+			w.QueueFeedWithPriority(feedID, priority)
+		}
+	})
+}
+
+// updateContentKind stores whether the fetched items carried full content, when it differs
+// from what the feed last recorded. A fetch with no items leaves the record as it was.
+func (w *Worker) updateContentKind(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, result *rss.FeedResult) {
+	if result.ContentKind == "" || result.ContentKind == feed.ContentKind {
+		return
+	}
+
+	if err := w.store.UpdateFeedContentKind(ctx, feed.ID, result.ContentKind); err != nil {
+		feedLogger.Error("Failed to update feed content kind",
+			"error", fmt.Errorf("store.UpdateFeedContentKind: %w", err))
+
+		return
+	}
+
+	feedLogger.Debug("Feed content kind detected", "content_kind", result.ContentKind)
+	feed.ContentKind = result.ContentKind
+}
+
+// updateTTL stores the <ttl> the feed advertised, when it differs from what the feed last
+// recorded, so the UI can warn about polling it more often than it asks
+func (w *Worker) updateTTL(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, result *rss.FeedResult) {
+	if result.TTLMinutes == feed.TTLMinutes {
+		return
+	}
+
+	if err := w.store.UpdateFeedTTL(ctx, feed.ID, result.TTLMinutes); err != nil {
+		feedLogger.Error("Failed to update feed ttl",
+			"error", fmt.Errorf("store.UpdateFeedTTL: %w", err))
+
+		return
+	}
+
+	feedLogger.Debug("Feed ttl changed", "ttl_minutes", result.TTLMinutes)
+	feed.TTLMinutes = result.TTLMinutes
+}
+
+// updateLastFetched records that the feed was just polled
+func (w *Worker) updateLastFetched(ctx context.Context, feedLogger logging.Logger, feed *models.Feed) {
+	if err := w.store.UpdateFeedLastFetched(ctx, feed.ID); err != nil {
+		feedLogger.Error("Failed to update feed last fetched time",
+			"error", fmt.Errorf("store.UpdateFeedLastFetched: %w", err))
+	}
+}
+
+// pruneArticles trims the feed's stored articles to its KeepLastN. The window never shrinks
+// below the number of items the feed lists, so items still in the feed keep the record that
+// stops them being sent again.
+func (w *Worker) pruneArticles(ctx context.Context, feedLogger logging.Logger, feed *models.Feed, listed int) {
+	if feed.KeepLastN <= 0 {
+		return
+	}
+
+	keep := max(feed.KeepLastN, listed)
+	pruned, err := w.store.PruneFeedArticlesKeepingLatest(ctx, feed.ID, keep)
+	if err != nil {
+		feedLogger.Error("Failed to prune feed articles",
+			"error", fmt.Errorf("store.PruneFeedArticlesKeepingLatest: %w", err))
+
+		return
+	}
+	if pruned > 0 {
+		feedLogger.Info("Pruned old feed articles", "pruned", pruned, "kept", keep)
+	}
+}
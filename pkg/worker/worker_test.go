@@ -3,21 +3,35 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"wallabag-rss-tool/pkg/audit"
 	"wallabag-rss-tool/pkg/database/mocks"
+	"wallabag-rss-tool/pkg/events"
 	"wallabag-rss-tool/pkg/models"
 	"wallabag-rss-tool/pkg/rss"
 	rssmocks "wallabag-rss-tool/pkg/rss/mocks"
+	"wallabag-rss-tool/pkg/sanitize"
 	"wallabag-rss-tool/pkg/wallabag"
 	wallabagmocks "wallabag-rss-tool/pkg/wallabag/mocks"
 	"wallabag-rss-tool/pkg/worker"
 )
 
+// feedWithURL matches the feed passed to FetchFeed by its URL
+func feedWithURL(url string) gomock.Matcher {
+	return gomock.Cond(func(f *models.Feed) bool { return f.URL == url })
+}
+
 func TestNewWorker(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -64,6 +78,60 @@ func TestWorker_StartAndStop(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 }
 
+func TestWorker_StartStopLifecycle(t *testing.T) {
+	t.Run("Stop releases the worker's goroutines", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		baseline := runtime.NumGoroutine()
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.Start()
+		w.Start() // A second Start must not start another polling loop and queue consumers
+		// Polling loop, priority queue and one enrichment consumer
+		assert.Equal(t, baseline+3, runtime.NumGoroutine())
+
+		assert.NotPanics(t, func() {
+			w.Stop()
+			w.Stop()
+		})
+		// Polled by hand: assert.Eventually runs its condition in a goroutine of its own
+		deadline := time.Now().Add(5 * time.Second)
+		for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.Equal(t, baseline, runtime.NumGoroutine(), "goroutines left running after Stop")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx), "Shutdown after Stop returns at once")
+	})
+
+	t.Run("Start after Stop does nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// No store calls: the worker never polls
+		w := worker.NewWorker(mocks.NewMockStorer(ctrl), rssmocks.NewMockProcessorer(ctrl), wallabagmocks.NewMockClienter(ctrl))
+		baseline := runtime.NumGoroutine()
+
+		w.Stop()
+		w.Start()
+
+		assert.Equal(t, baseline, runtime.NumGoroutine())
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx))
+	})
+}
+
 func TestWorker_ProcessFeeds(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -144,9 +212,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(30, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed1").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed1")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/article1").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/article1").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/article1", nil).Return(entry, nil)
 		// Expect SaveArticle to be called with the converted models.Article
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 123).Return(nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
@@ -187,9 +255,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed2").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed2")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/article2").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/article2").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/article2", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 2, gomock.Any(), 456).Return(nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 2).Return(nil)
 
@@ -222,7 +290,7 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed3").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed3")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/processed").Return(true, nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 3).Return(nil)
 
@@ -265,11 +333,11 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed4").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed4")).Return(&rss.FeedResult{Articles: articles}, nil)
 
 		// First article is new
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/new").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/new").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/new", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 4, gomock.Any(), 789).Return(nil)
 
 		// Second article is already processed
@@ -313,9 +381,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
 		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(0, errors.New("settings error"))
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed5").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed5")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/fallback").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/fallback").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/fallback", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 5, gomock.Any(), 101).Return(nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 5).Return(nil)
 
@@ -341,7 +409,7 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://invalid.com/feed").Return(nil, errors.New("feed error"))
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://invalid.com/feed")).Return(nil, errors.New("feed error"))
 
 		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
 		w.ProcessFeeds()
@@ -372,7 +440,7 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed7").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed7")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/check-error").Return(false, errors.New("database error"))
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 7).Return(nil)
 
@@ -405,9 +473,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed8").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed8")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/wallabag-error").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/wallabag-error").Return(nil, errors.New("wallabag API error"))
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/wallabag-error", nil).Return(nil, errors.New("wallabag API error"))
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 8).Return(nil)
 
 		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
@@ -445,9 +513,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed9").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed9")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/save-error").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/save-error").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/save-error", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 9, gomock.Any(), 999).Return(errors.New("database save error"))
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 9).Return(nil)
 
@@ -486,9 +554,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParse("https://example.com/feed10").Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed10")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/update-error").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/update-error").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/update-error", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 10, gomock.Any(), 888).Return(nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 10).Return(errors.New("update error"))
 
@@ -529,9 +597,9 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		}
 
 		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
-		mockProcessor.EXPECT().FetchAndParseWithSyncOptions("https://example.com/feed11", models.SyncModeCount, &count, (*time.Time)(nil)).Return(articles, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed11")).Return(&rss.FeedResult{Articles: articles}, nil)
 		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/initial").Return(false, nil)
-		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/initial").Return(entry, nil)
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/initial", nil).Return(entry, nil)
 		mockStore.EXPECT().SaveArticle(gomock.Any(), 11, gomock.Any(), 777).Return(nil)
 		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 11).Return(nil)
 		mockStore.EXPECT().MarkFeedInitialSyncCompleted(gomock.Any(), 11).Return(nil)
@@ -539,6 +607,941 @@ func TestWorker_ProcessFeeds(t *testing.T) {
 		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
 		w.ProcessFeeds()
 	})
+
+	t.Run("Unchanged build date skips articles", func(t *testing.T) {
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		buildDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		feeds := []models.Feed{
+			{
+				ID:                  12,
+				URL:                 "https://example.com/feed12",
+				Name:                "Feed 12",
+				PollIntervalMinutes: 30,
+				InitialSyncDone:     true,
+				LastBuildDate:       &buildDate,
+			},
+		}
+
+		sameDate := buildDate
+		result := &rss.FeedResult{
+			LastBuildDate: &sameDate,
+			Articles:      []rss.Article{{Title: "Old Article", URL: "https://example.com/old"}},
+		}
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed12")).Return(result, nil)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 12).Return(nil)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.ProcessFeeds()
+	})
+
+	t.Run("Changed build date is recorded", func(t *testing.T) {
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		oldDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		newDate := oldDate.Add(time.Hour)
+		feeds := []models.Feed{
+			{
+				ID:                  13,
+				URL:                 "https://example.com/feed13",
+				Name:                "Feed 13",
+				PollIntervalMinutes: 30,
+				InitialSyncDone:     true,
+				LastBuildDate:       &oldDate,
+			},
+		}
+
+		result := &rss.FeedResult{
+			LastBuildDate: &newDate,
+			Articles:      []rss.Article{{Title: "New Article", URL: "https://example.com/new"}},
+		}
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed13")).Return(result, nil)
+		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/new").Return(true, nil)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 13).Return(nil)
+		mockStore.EXPECT().UpdateFeedLastBuildDate(gomock.Any(), 13, newDate).Return(nil)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.ProcessFeeds()
+	})
+}
+
+func TestWorker_MaxSendsPerCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	buildDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed1", Name: "Feed 1", PollIntervalMinutes: 30, InitialSyncDone: true},
+		{ID: 2, URL: "https://example.com/feed2", Name: "Feed 2", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	result := &rss.FeedResult{
+		LastBuildDate: &buildDate,
+		Articles: []rss.Article{
+			{Title: "Article 1", URL: "https://example.com/1"},
+			{Title: "Article 2", URL: "https://example.com/2"},
+			{Title: "Article 3", URL: "https://example.com/3"},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed1")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/1", nil).Return(&wallabag.Entry{ID: 101}, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/2", nil).Return(&wallabag.Entry{ID: 102}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 101).Return(nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 102).Return(nil)
+	// The third article is neither sent nor saved, the feed is left due for the next cycle
+	// and the second feed is not fetched at all
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{MaxSendsPerCycle: 2})
+	w.ProcessFeeds()
+}
+
+func TestWorker_MaxSendsPerCycle_ResumesBackfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, SyncMode: models.SyncModeAll}
+	published := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Backfill order from the processor: oldest first, ties by URL
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "A", URL: "https://example.com/a", PublishedAt: &published},
+			{Title: "B", URL: "https://example.com/b", PublishedAt: &published},
+			{Title: "C", URL: "https://example.com/c", PublishedAt: &published},
+		},
+	}
+	sent := map[string]bool{}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil).Times(2)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(feed.URL)).Return(result, nil).Times(2)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, articleURL string) (bool, error) {
+			return sent[articleURL], nil
+		}).AnyTimes()
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, _ int, article *models.Article, _ int) error {
+			sent[article.URL] = true
+
+			return nil
+		}).Times(3)
+	gomock.InOrder(
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/a", nil).Return(&wallabag.Entry{ID: 101}, nil),
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/b", nil).Return(&wallabag.Entry{ID: 102}, nil),
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/c", nil).Return(&wallabag.Entry{ID: 103}, nil),
+	)
+	// The initial sync only completes on the cycle that sends the last article
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+	mockStore.EXPECT().MarkFeedInitialSyncCompleted(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{MaxSendsPerCycle: 2})
+	w.ProcessFeeds()
+	assert.Equal(t, map[string]bool{"https://example.com/a": true, "https://example.com/b": true}, sent)
+
+	w.ProcessFeeds()
+	assert.True(t, sent["https://example.com/c"])
+}
+
+func TestWorker_Reload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	buildDate := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed1", Name: "Feed 1", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	result := &rss.FeedResult{
+		LastBuildDate: &buildDate,
+		Articles: []rss.Article{
+			{Title: "Article 1", URL: "https://example.com/1"},
+			{Title: "Article 2", URL: "https://example.com/2"},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed1")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/1", nil).Return(&wallabag.Entry{ID: 101}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 101).Return(nil)
+	// The reloaded cap of one send holds back the second article
+
+	limits := models.FieldLimits{MaxTitleLength: 50}
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{FieldLimits: limits})
+	w.Reload(worker.Config{MaxSendsPerCycle: 1, StaleAfter: time.Hour})
+
+	cfg := w.Config()
+	assert.Equal(t, 1, cfg.MaxSendsPerCycle)
+	assert.Equal(t, time.Hour, cfg.StaleAfter)
+	assert.Equal(t, limits, cfg.FieldLimits, "field limits are not hot-reloadable")
+
+	w.ProcessFeeds()
+}
+
+func TestWorker_Reload_SendSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+	var listed []rss.Article
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil).Times(3)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *models.Feed) (*rss.FeedResult, error) {
+			return &rss.FeedResult{Articles: listed}, nil
+		}).Times(3)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil).Times(3)
+	gomock.InOrder(
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/1", nil).Return(&wallabag.Entry{ID: 1}, nil),
+		mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/2", []string{"untagged"}).Return(&wallabag.Entry{ID: 2}, nil),
+	)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+
+	listed = []rss.Article{{Title: "First", URL: "https://example.com/1"}}
+	w.ProcessFeeds()
+
+	// A reloaded fallback tag is sent with the next article
+	w.Reload(worker.Config{FallbackTag: "untagged", SendConcurrency: 4})
+	assert.Equal(t, 4, w.Config().SendConcurrency)
+	listed = []rss.Article{{Title: "Second", URL: "https://example.com/2"}}
+	w.ProcessFeeds()
+
+	// A reloaded debounce holds the next article instead of sending it
+	w.Reload(worker.Config{FallbackTag: "untagged", SendDebounce: time.Hour})
+	listed = []rss.Article{{Title: "Third", URL: "https://example.com/3"}}
+	w.ProcessFeeds()
+}
+
+func TestWorker_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	result := &rss.FeedResult{
+		Articles: []rss.Article{{Title: "Article", URL: "https://example.com/1"}},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/1").Return(false, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/1", nil).Return(&wallabag.Entry{ID: 42}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 42).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	stream, unsubscribe := w.Events().Subscribe()
+	defer unsubscribe()
+
+	w.ProcessFeeds()
+
+	require.Len(t, stream, 3)
+	assert.Equal(t, events.TypeSyncStarted, (<-stream).Type)
+	article := <-stream
+	assert.Equal(t, events.TypeArticle, article.Type)
+	assert.Equal(t, events.ArticleData{
+		FeedID:          1,
+		FeedName:        "Feed",
+		Title:           "Article",
+		URL:             "https://example.com/1",
+		WallabagEntryID: 42,
+	}, article.Data)
+	assert.Equal(t, events.TypeSyncFinished, (<-stream).Type)
+}
+
+func TestWorker_IncludeCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true,
+			IncludeCategories: []string{"Go", "Databases"}},
+	}
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "Generics", URL: "https://example.com/generics", Categories: []string{"go", "Programming"}},
+			{Title: "Football", URL: "https://example.com/football", Categories: []string{"Sport"}},
+			{Title: "Uncategorised", URL: "https://example.com/uncategorised"},
+			{Title: "Indexes", URL: "https://example.com/indexes", Categories: []string{"Databases"}},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(4)
+	// Matching items are sent
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/generics", nil).Return(&wallabag.Entry{ID: 1}, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/indexes", nil).Return(&wallabag.Entry{ID: 2}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 1).Return(nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 2).Return(nil)
+	// The rest, including the item without categories, are only recorded as processed
+	var filtered []string
+	mockStore.EXPECT().SaveFilteredArticle(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article) error {
+			filtered = append(filtered, article.URL)
+
+			return nil
+		}).Times(2)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+
+	assert.Equal(t, []string{"https://example.com/football", "https://example.com/uncategorised"}, filtered)
+}
+
+func TestWorker_MinTitleLength(t *testing.T) {
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "Go", URL: "https://example.com/go"},
+			{Title: "Generics", URL: "https://example.com/generics"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		minTitleLength int
+		feedMinimum    int
+		wantSent       []string
+		wantFiltered   []string
+	}{
+		{name: "Short title is skipped", minTitleLength: 3,
+			wantSent: []string{"https://example.com/generics"}, wantFiltered: []string{"https://example.com/go"}},
+		{name: "No minimum keeps short titles", minTitleLength: 0,
+			wantSent: []string{"https://example.com/go", "https://example.com/generics"}},
+		{name: "Feed minimum overrides the global one", minTitleLength: 3, feedMinimum: 10,
+			wantFiltered: []string{"https://example.com/go", "https://example.com/generics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			feeds := []models.Feed{
+				{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true,
+					MinTitleLength: tt.feedMinimum},
+			}
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+
+			var sent, filtered []string
+			mockClient.EXPECT().AddEntry(gomock.Any(), gomock.Any(), nil).DoAndReturn(
+				func(_ context.Context, entryURL string, _ []string) (*wallabag.Entry, error) {
+					sent = append(sent, entryURL)
+
+					return &wallabag.Entry{ID: len(sent)}, nil
+				}).Times(len(tt.wantSent))
+			mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil).Times(len(tt.wantSent))
+			// Skipped items are recorded as processed, so they are not considered again
+			mockStore.EXPECT().SaveFilteredArticle(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ int, article *models.Article) error {
+					filtered = append(filtered, article.URL)
+
+					return nil
+				}).Times(len(tt.wantFiltered))
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{MinTitleLength: tt.minTitleLength})
+			w.ProcessFeeds()
+
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Equal(t, tt.wantFiltered, filtered)
+		})
+	}
+}
+
+func TestWorker_MinAge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	// The recent article reaches the 15 minute minimum age shortly after the first poll
+	settlesIn := 150 * time.Millisecond
+	almostSettled := time.Now().Add(-15*time.Minute + settlesIn)
+	anHourAgo := time.Now().Add(-time.Hour)
+	buildDate := time.Now().Add(-time.Minute)
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true, MinAgeMinutes: 15}
+	result := &rss.FeedResult{
+		LastBuildDate: &buildDate,
+		Articles: []rss.Article{
+			{Title: "Still Updating", URL: "https://example.com/new", PublishedAt: &almostSettled},
+			{Title: "Settled", URL: "https://example.com/old", PublishedAt: &anHourAgo},
+			{Title: "Undated", URL: "https://example.com/undated", PublishedAt: &almostSettled, DateGuessed: true},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/old", nil).Return(&wallabag.Entry{ID: 1}, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/undated", nil).Return(&wallabag.Entry{ID: 2}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 1).Return(nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 2).Return(nil)
+	// The fetch is recorded, but not the build date, which would hide the held article
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+
+	// Just fetched and the held article not yet old enough: the feed keeps to its interval
+	lastFetched := time.Now()
+	feed.LastFetched = &lastFetched
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	w.ProcessFeeds()
+
+	assert.Eventually(t, func() bool {
+		queued, _ := w.GetQueueStats()
+
+		return queued == 1
+	}, time.Second, 10*time.Millisecond, "the feed is queued for when the held article is old enough")
+
+	// Once it is, the feed is fetched early and the article sent
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(&rss.FeedResult{
+		LastBuildDate: &buildDate,
+		Articles:      result.Articles[:1],
+	}, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/new").Return(false, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/new", nil).Return(&wallabag.Entry{ID: 3}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 3).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastBuildDate(gomock.Any(), 1, buildDate).Return(nil)
+	w.ProcessFeeds()
+
+	// Nothing is held back any more, so the feed waits for its interval again
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	w.ProcessFeeds()
+}
+
+func TestWorker_Snooze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	snoozedUntil := time.Now().Add(time.Hour)
+	feed := models.Feed{ID: 1, URL: "https://example.com/noisy", Name: "Noisy", PollIntervalMinutes: 30, InitialSyncDone: true, SnoozedUntil: &snoozedUntil}
+
+	// While snoozed the feed is not fetched
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+
+	// Once the snooze has passed it is processed again
+	snoozedUntil = time.Now().Add(-time.Minute)
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(feed.URL)).Return(&rss.FeedResult{}, nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w.ProcessFeeds()
+}
+
+func TestWorker_ContentKind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	detected := models.Feed{ID: 1, URL: "https://example.com/full", Name: "Full", PollIntervalMinutes: 30, InitialSyncDone: true}
+	known := models.Feed{ID: 2, URL: "https://example.com/known", Name: "Known", PollIntervalMinutes: 30, InitialSyncDone: true, ContentKind: models.ContentKindSummary}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{detected, known}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(detected.URL)).Return(&rss.FeedResult{ContentKind: models.ContentKindFull}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(known.URL)).Return(&rss.FeedResult{ContentKind: models.ContentKindSummary}, nil)
+	// Only the change is stored
+	mockStore.EXPECT().UpdateFeedContentKind(gomock.Any(), 1, models.ContentKindFull).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
+
+func TestWorker_FeedTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	advertised := models.Feed{ID: 1, URL: "https://example.com/ttl", Name: "TTL", PollIntervalMinutes: 30, InitialSyncDone: true}
+	known := models.Feed{ID: 2, URL: "https://example.com/known", Name: "Known", PollIntervalMinutes: 30, InitialSyncDone: true, TTLMinutes: 60}
+	dropped := models.Feed{ID: 3, URL: "https://example.com/dropped", Name: "Dropped", PollIntervalMinutes: 30, InitialSyncDone: true, TTLMinutes: 60}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{advertised, known, dropped}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(advertised.URL)).Return(&rss.FeedResult{TTLMinutes: 60}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(known.URL)).Return(&rss.FeedResult{TTLMinutes: 60}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(dropped.URL)).Return(&rss.FeedResult{}, nil)
+	// Only changes are stored, including a ttl the feed no longer sends
+	mockStore.EXPECT().UpdateFeedTTL(gomock.Any(), 1, 60).Return(nil)
+	mockStore.EXPECT().UpdateFeedTTL(gomock.Any(), 3, 0).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
+
+func TestWorker_FieldLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	longURL := "https://example.com/" + strings.Repeat("x", 100)
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "Huge URL", URL: longURL},
+			{Title: "An article with a rather long title", URL: "https://example.com/ok"},
+		},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	// The over-long URL is skipped before any lookup or send
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/ok").Return(false, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/ok", nil).Return(&wallabag.Entry{ID: 5}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 5).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article, _ int) error {
+			assert.Equal(t, "An article", article.Title)
+
+			return nil
+		})
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{
+		FieldLimits: models.FieldLimits{MaxTitleLength: 10, MaxURLLength: 50},
+	})
+	w.ProcessFeeds()
+}
+
+func TestWorker_StripQueryParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true, StripQueryParams: true},
+	}
+	result := &rss.FeedResult{
+		Articles: []rss.Article{{Title: "Tracked", URL: "https://example.com/post?utm_source=rss&session=abc"}},
+	}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/post").Return(false, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/post", nil).Return(&wallabag.Entry{ID: 9}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 9).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article, _ int) error {
+			assert.Equal(t, "https://example.com/post", article.URL)
+			assert.Equal(t, "https://example.com/post?utm_source=rss&session=abc", article.OriginalURL)
+
+			return nil
+		})
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
+
+func TestWorker_TagWithFeedName(t *testing.T) {
+	tests := []struct {
+		name       string
+		feedOption bool
+		global     bool
+		wantTags   []string
+	}{
+		{name: "Disabled sends no tags", wantTags: nil},
+		{name: "Enabled for the feed", feedOption: true, wantTags: []string{"hacker-news"}},
+		{name: "Enabled globally", global: true, wantTags: []string{"hacker-news"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			feeds := []models.Feed{
+				{ID: 1, URL: "https://example.com/feed", Name: "Hacker News", PollIntervalMinutes: 30, InitialSyncDone: true, TagWithFeedName: tt.feedOption},
+			}
+			result := &rss.FeedResult{Articles: []rss.Article{{Title: "Post", URL: "https://example.com/post"}}}
+
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/post").Return(false, nil)
+			mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/post", gomock.Eq(tt.wantTags)).Return(&wallabag.Entry{ID: 9}, nil)
+			mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 9).Return(nil)
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{TagWithFeedName: tt.global})
+			w.ProcessFeeds()
+		})
+	}
+}
+
+func TestWorker_CategoriesAsTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		prefix     string
+		feedOption bool
+		wantTags   []string
+	}{
+		{name: "Categories join the feed name tag", categories: []string{"go", "web"}, feedOption: true, wantTags: []string{"hacker-news", "go", "web"}},
+		{name: "Categories are slugified and prefixed", categories: []string{"Web Development"}, prefix: "rss-", feedOption: true, wantTags: []string{"hacker-news", "rss-web-development"}},
+		{name: "Item without categories", feedOption: true, wantTags: []string{"hacker-news"}},
+		{name: "Option off ignores categories", categories: []string{"go", "web"}, wantTags: []string{"hacker-news"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			feeds := []models.Feed{
+				{ID: 1, URL: "https://example.com/feed", Name: "Hacker News", PollIntervalMinutes: 30, InitialSyncDone: true,
+					TagWithFeedName: true, CategoriesAsTags: tt.feedOption},
+			}
+			result := &rss.FeedResult{Articles: []rss.Article{{Title: "Post", URL: "https://example.com/post", Categories: tt.categories}}}
+
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/post").Return(false, nil)
+			mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/post", gomock.Eq(tt.wantTags)).Return(&wallabag.Entry{ID: 9}, nil)
+			mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 9).Return(nil)
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{CategoryTagPrefix: tt.prefix})
+			w.ProcessFeeds()
+		})
+	}
+}
+
+func TestWorker_FallbackTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		feed     models.Feed
+		wantTags []string
+	}{
+		{name: "Feed with tags does not get the fallback", feed: models.Feed{TagWithFeedName: true}, wantTags: []string{"hacker-news"}},
+		{name: "Feed with no tags gets the fallback", feed: models.Feed{}, wantTags: []string{"untagged"}},
+		{name: "Categories option without categories gets the fallback", feed: models.Feed{CategoriesAsTags: true}, wantTags: []string{"untagged"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			feed := tt.feed
+			feed.ID, feed.URL, feed.Name = 1, "https://example.com/feed", "Hacker News"
+			feed.PollIntervalMinutes, feed.InitialSyncDone = 30, true
+			result := &rss.FeedResult{Articles: []rss.Article{{Title: "Post", URL: "https://example.com/post"}}}
+
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), "https://example.com/post").Return(false, nil)
+			mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/post", gomock.Eq(tt.wantTags)).Return(&wallabag.Entry{ID: 9}, nil)
+			mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 9).Return(nil)
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{FallbackTag: "untagged"})
+			w.ProcessFeeds()
+		})
+	}
+}
+
+func TestWorker_ContentSelector(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><nav>Menu</nav><div class="post-body"><p>Full text</p></div>` +
+			`<div class="rich"><section class="intro"><p onclick="track()">Rich <b>text</b></p></section></div></body></html>`))
+	}))
+	defer page.Close()
+
+	tests := []struct {
+		name     string
+		selector string
+		policy   sanitize.Policy
+		expect   func(mockClient *wallabagmocks.MockClienter, articleURL string)
+	}{
+		{
+			name:     "Extracted content is sent with the entry",
+			selector: ".post-body",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntryWithContent(gomock.Any(), articleURL, "Summary Only", "<p>Full text</p>", nil).
+					Return(&wallabag.Entry{ID: 5}, nil)
+			},
+		},
+		{
+			name:     "Strict sanitization keeps only basic formatting",
+			selector: ".rich",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntryWithContent(gomock.Any(), articleURL, "Summary Only", "<p>Rich <b>text</b></p>", nil).
+					Return(&wallabag.Entry{ID: 5}, nil)
+			},
+		},
+		{
+			name:     "Lenient sanitization keeps other markup",
+			selector: ".rich",
+			policy:   sanitize.Lenient,
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntryWithContent(gomock.Any(), articleURL, "Summary Only", `<section class="intro"><p>Rich <b>text</b></p></section>`, nil).
+					Return(&wallabag.Entry{ID: 5}, nil)
+			},
+		},
+		{
+			name:     "Falls back to plain add when selector matches nothing",
+			selector: ".missing",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntry(gomock.Any(), articleURL, nil).Return(&wallabag.Entry{ID: 5}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			articleURL := page.URL + "/post"
+			feeds := []models.Feed{
+				{
+					ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true,
+					ContentSelector: tt.selector, DeliveryMode: models.DeliveryModeContentOrLink,
+				},
+			}
+			result := &rss.FeedResult{Articles: []rss.Article{{Title: "Summary Only", URL: articleURL}}}
+
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), articleURL).Return(false, nil)
+			tt.expect(mockClient, articleURL)
+			mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 5).Return(nil)
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{SanitizePolicy: tt.policy})
+			w.ProcessFeeds()
+		})
+	}
+}
+
+func TestWorker_DeliveryMode(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><div class="post-body"><p>Full text</p></div></body></html>`))
+	}))
+	defer page.Close()
+
+	tests := []struct {
+		name     string
+		mode     models.DeliveryMode
+		selector string
+		expect   func(mockClient *wallabagmocks.MockClienter, articleURL string)
+		saved    bool
+	}{
+		{
+			name:     "Link mode sends the URL even with a selector",
+			mode:     models.DeliveryModeLink,
+			selector: ".post-body",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntry(gomock.Any(), articleURL, nil).Return(&wallabag.Entry{ID: 5}, nil)
+			},
+			saved: true,
+		},
+		{
+			name:     "Unset mode sends the URL",
+			selector: ".post-body",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntry(gomock.Any(), articleURL, nil).Return(&wallabag.Entry{ID: 5}, nil)
+			},
+			saved: true,
+		},
+		{
+			name:     "Content mode sends the extracted content",
+			mode:     models.DeliveryModeContent,
+			selector: ".post-body",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntryWithContent(gomock.Any(), articleURL, "Post", "<p>Full text</p>", nil).
+					Return(&wallabag.Entry{ID: 5}, nil)
+			},
+			saved: true,
+		},
+		{
+			name:     "Content mode fails the article when extraction fails",
+			mode:     models.DeliveryModeContent,
+			selector: ".missing",
+			expect:   func(*wallabagmocks.MockClienter, string) {},
+		},
+		{
+			name:     "Content or link mode sends the extracted content",
+			mode:     models.DeliveryModeContentOrLink,
+			selector: ".post-body",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntryWithContent(gomock.Any(), articleURL, "Post", "<p>Full text</p>", nil).
+					Return(&wallabag.Entry{ID: 5}, nil)
+			},
+			saved: true,
+		},
+		{
+			name:     "Content or link mode falls back to the URL when extraction fails",
+			mode:     models.DeliveryModeContentOrLink,
+			selector: ".missing",
+			expect: func(mockClient *wallabagmocks.MockClienter, articleURL string) {
+				mockClient.EXPECT().AddEntry(gomock.Any(), articleURL, nil).Return(&wallabag.Entry{ID: 5}, nil)
+			},
+			saved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			articleURL := page.URL + "/post"
+			feeds := []models.Feed{
+				{
+					ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true,
+					ContentSelector: tt.selector, DeliveryMode: tt.mode,
+				},
+			}
+			result := &rss.FeedResult{Articles: []rss.Article{{Title: "Post", URL: articleURL}}}
+
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+			mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+			mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), articleURL).Return(false, nil)
+			tt.expect(mockClient, articleURL)
+			if tt.saved {
+				mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 5).Return(nil)
+			}
+			mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+			w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+			w.ProcessFeeds()
+		})
+	}
+}
+
+func TestWorker_Audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	var recorded []models.AuditEntry
+	mockStore.EXPECT().AppendAuditEntry(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, entry *models.AuditEntry) error {
+			recorded = append(recorded, *entry)
+			return nil
+		}).Times(3)
+	auditLog := audit.NewLog(mockStore)
+
+	feeds := []models.Feed{{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}}
+	result := &rss.FeedResult{Articles: []rss.Article{
+		{Title: "Sent", URL: "https://example.com/sent"},
+		{Title: "Failed", URL: "https://example.com/failed"},
+	}}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(result, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/sent", nil).Return(&wallabag.Entry{ID: 5}, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/failed", nil).Return(nil, errors.New("status 500"))
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 5).Return(nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{Audit: auditLog})
+	w.ProcessFeeds()
+	auditLog.Close()
+
+	require.Len(t, recorded, 3)
+	assert.Equal(t, models.AuditArticleSent, recorded[0].Action)
+	assert.Equal(t, 1, recorded[0].FeedID)
+	assert.Equal(t, "https://example.com/sent (Wallabag entry 5)", recorded[0].Detail)
+	assert.Equal(t, models.AuditArticleFailed, recorded[1].Action)
+	assert.Contains(t, recorded[1].Detail, "https://example.com/failed")
+	assert.Contains(t, recorded[1].Detail, "status 500")
+	assert.Equal(t, models.AuditSyncRun, recorded[2].Action)
+	assert.Zero(t, recorded[2].FeedID)
 }
 
 func TestWorker_StopChannel(t *testing.T) {
@@ -555,6 +1558,108 @@ func TestWorker_StopChannel(t *testing.T) {
 	assert.NotNil(t, w)
 }
 
+func TestWorker_Shutdown(t *testing.T) {
+	t.Run("Idle worker shuts down cleanly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.Start()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx))
+	})
+
+	t.Run("Times out while a feed is in flight", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		feeds := []models.Feed{
+			{ID: 1, URL: "https://example.com/slow", Name: "Slow", PollIntervalMinutes: 30, InitialSyncDone: true},
+		}
+		fetching := make(chan struct{})
+		release := make(chan struct{})
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/slow")).DoAndReturn(
+			func(_ context.Context, _ *models.Feed) (*rss.FeedResult, error) {
+				close(fetching)
+				<-release
+
+				return nil, errors.New("fetch abandoned")
+			})
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.Start()
+		<-fetching
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := w.Shutdown(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "https://example.com/slow")
+		assert.Equal(t, []string{"https://example.com/slow"}, w.InFlight())
+
+		// Once the fetch finishes a second shutdown completes
+		close(release)
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx))
+		assert.Empty(t, w.InFlight())
+	})
+
+	t.Run("Feed in flight finishes but no further feed starts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		feeds := []models.Feed{
+			{ID: 1, URL: "https://example.com/first", Name: "First", PollIntervalMinutes: 30, InitialSyncDone: true},
+			{ID: 2, URL: "https://example.com/second", Name: "Second", PollIntervalMinutes: 30, InitialSyncDone: true},
+		}
+		fetching := make(chan struct{})
+		release := make(chan struct{})
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/first")).DoAndReturn(
+			func(_ context.Context, _ *models.Feed) (*rss.FeedResult, error) {
+				close(fetching)
+				<-release
+
+				return &rss.FeedResult{}, nil
+			})
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+		mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil)
+		// The second feed is never fetched
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+		w.Start()
+		<-fetching
+		w.Stop()
+		close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, w.Shutdown(ctx))
+	})
+}
+
 func TestWorker_QueueFeedForImmediate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -580,12 +1685,7 @@ func TestWorker_QueueFeedForImmediate(t *testing.T) {
 
 	// Expect the feed to be fetched and processed
 	mockStore.EXPECT().GetFeedByID(gomock.Any(), 123).Return(&testFeed, nil)
-	mockProcessor.EXPECT().FetchAndParseWithSyncOptions(
-		testFeed.URL,
-		testFeed.SyncMode,
-		testFeed.SyncCount,
-		testFeed.SyncDateFrom,
-	).Return([]rss.Article{}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(testFeed.URL)).Return(&rss.FeedResult{}, nil)
 	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), testFeed.ID).Return(nil)
 	mockStore.EXPECT().MarkFeedInitialSyncCompleted(gomock.Any(), testFeed.ID).Return(nil)
 
@@ -764,3 +1864,506 @@ func TestWorker_ConcurrentQueueOperations(t *testing.T) {
 	assert.GreaterOrEqual(t, length, 10) // At least some should be queued
 	assert.LessOrEqual(t, length, 100)   // Can't exceed capacity
 }
+
+// overlapRecorder counts how many FetchFeed calls are running at once
+type overlapRecorder struct {
+	active  atomic.Int32
+	maxSeen atomic.Int32
+}
+
+func (r *overlapRecorder) enter() {
+	active := r.active.Add(1)
+	for {
+		seen := r.maxSeen.Load()
+		if active <= seen || r.maxSeen.CompareAndSwap(seen, active) {
+			return
+		}
+	}
+}
+
+func (r *overlapRecorder) leave() {
+	r.active.Add(-1)
+}
+
+func TestWorker_FeedProcessingIsSerializedPerFeed(t *testing.T) {
+	t.Run("Concurrent runs of the same feed do not overlap", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+		var recorder overlapRecorder
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil).Times(2)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(feed.URL)).DoAndReturn(
+			func(_ context.Context, _ *models.Feed) (*rss.FeedResult, error) {
+				recorder.enter()
+				defer recorder.leave()
+				time.Sleep(50 * time.Millisecond)
+
+				return &rss.FeedResult{}, nil
+			}).Times(2)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil).Times(2)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.ProcessFeeds()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), recorder.maxSeen.Load(), "the second run should wait for the first")
+	})
+
+	t.Run("Different feeds still run in parallel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		first := models.Feed{ID: 1, URL: "https://example.com/first", Name: "First", PollIntervalMinutes: 30, InitialSyncDone: true}
+		second := models.Feed{ID: 2, URL: "https://example.com/second", Name: "Second", PollIntervalMinutes: 30, InitialSyncDone: true}
+		var recorder overlapRecorder
+		var started sync.WaitGroup
+		started.Add(2)
+
+		// Each fetch waits until both have started, which only happens if they overlap
+		fetch := func(_ context.Context, _ *models.Feed) (*rss.FeedResult, error) {
+			recorder.enter()
+			defer recorder.leave()
+			started.Done()
+			started.Wait()
+
+			return &rss.FeedResult{}, nil
+		}
+
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{first}, nil)
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{second}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(first.URL)).DoAndReturn(fetch)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL(second.URL)).DoAndReturn(fetch)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+
+		done := make(chan struct{})
+		go func() {
+			var wg sync.WaitGroup
+			for range 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					w.ProcessFeeds()
+				}()
+			}
+			wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("feeds with different IDs blocked each other")
+		}
+		assert.Equal(t, int32(2), recorder.maxSeen.Load())
+	})
+}
+
+func TestWorker_PrunesArticlesToKeepLastN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/small", Name: "Small window", PollIntervalMinutes: 30, InitialSyncDone: true, KeepLastN: 1},
+		{ID: 2, URL: "https://example.com/large", Name: "Large window", PollIntervalMinutes: 30, InitialSyncDone: true, KeepLastN: 50},
+		{ID: 3, URL: "https://example.com/all", Name: "Keep all", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	listed := &rss.FeedResult{Articles: []rss.Article{
+		{Title: "One", URL: "https://example.com/1"},
+		{Title: "Two", URL: "https://example.com/2"},
+		{Title: "Three", URL: "https://example.com/3"},
+	}}
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(listed, nil).Times(3)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	// A window smaller than the feed still keeps every item the feed lists
+	mockStore.EXPECT().PruneFeedArticlesKeepingLatest(gomock.Any(), 1, 3).Return(int64(4), nil)
+	mockStore.EXPECT().PruneFeedArticlesKeepingLatest(gomock.Any(), 2, 50).Return(int64(0), nil)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+}
+
+func TestWorker_CheckExistingEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{Articles: []rss.Article{
+		{Title: "Saved", URL: "https://example.com/saved"},
+		{Title: "New", URL: "https://example.com/new"},
+		{Title: "Unchecked", URL: "https://example.com/unchecked"},
+	}}, nil)
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+
+	// Already in Wallabag: recorded under its existing entry without being added again
+	mockClient.EXPECT().EntryExists(gomock.Any(), "https://example.com/saved").Return(true, 42, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 42).Return(nil)
+
+	mockClient.EXPECT().EntryExists(gomock.Any(), "https://example.com/new").Return(false, 0, nil)
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/new", gomock.Any()).Return(&wallabag.Entry{ID: 7}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 7).Return(nil)
+
+	// A failed check falls back to adding the article
+	mockClient.EXPECT().EntryExists(gomock.Any(), "https://example.com/unchecked").Return(false, 0, errors.New("timeout"))
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/unchecked", gomock.Any()).Return(&wallabag.Entry{ID: 8}, nil)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 8).Return(nil)
+
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{CheckExistingEntries: true})
+	w.ProcessFeeds()
+}
+
+func TestWorker_ProcessFeedsByPriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/low", Name: "Low", PollIntervalMinutes: 30, InitialSyncDone: true, Priority: -1},
+		{ID: 2, URL: "https://example.com/default", Name: "Default", PollIntervalMinutes: 30, InitialSyncDone: true},
+		{ID: 3, URL: "https://example.com/high", Name: "High", PollIntervalMinutes: 30, InitialSyncDone: true, Priority: 10},
+		{ID: 4, URL: "https://example.com/default-2", Name: "Default 2", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	var order []string
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, feed *models.Feed) (*rss.FeedResult, error) {
+			order = append(order, feed.Name)
+
+			return &rss.FeedResult{}, nil
+		}).Times(4)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+	w.ProcessFeeds()
+
+	// Equal priorities keep the store's order
+	assert.Equal(t, []string{"High", "Default", "Default 2", "Low"}, order)
+}
+
+func TestWorker_PriorityQueueOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	w := worker.NewWorker(mockStore, mockProcessor, mockClient)
+
+	// Queued before the worker starts so all three are waiting at once
+	w.QueueFeedForImmediate(1)
+	w.QueueFeedWithPriority(2, 5)
+	w.QueueFeedWithPriority(3, 5)
+
+	var mu sync.Mutex
+	var order []int
+	done := make(chan struct{})
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{}, nil).AnyTimes()
+	mockStore.EXPECT().GetDefaultPollInterval(gomock.Any()).Return(60, nil).AnyTimes()
+	mockStore.EXPECT().GetFeedByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id int) (*models.Feed, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, id)
+			if len(order) == 3 {
+				close(done)
+			}
+
+			return nil, errors.New("feed not found")
+		}).Times(3)
+
+	w.Start()
+	defer w.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued feeds were not processed")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int{2, 3, 1}, order)
+}
+
+func TestWorker_CrossFeedDedup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feeds := []models.Feed{
+		{ID: 1, URL: "https://example.com/feed", Name: "Site", PollIntervalMinutes: 30, InitialSyncDone: true},
+		{ID: 2, URL: "https://aggregator.example/feed", Name: "Aggregator", PollIntervalMinutes: 30, InitialSyncDone: true},
+	}
+	mockStore.EXPECT().GetFeeds(gomock.Any()).Return(feeds, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://example.com/feed")).Return(&rss.FeedResult{
+		Articles: []rss.Article{{Title: "Post", URL: "https://example.com/post"}},
+	}, nil)
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), feedWithURL("https://aggregator.example/feed")).Return(&rss.FeedResult{
+		Articles: []rss.Article{{Title: "Post", URL: "http://www.example.com/post/?utm_source=aggregator"}},
+	}, nil)
+
+	// A store that remembers what was saved, keyed the way the SQL store dedupes
+	saved := map[string]bool{}
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, url string) (bool, error) { return saved[url], nil }).Times(2)
+	mockStore.EXPECT().IsSimilarArticleProcessed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, url string) (bool, error) { return saved[models.NormalizeArticleURL(url)], nil }).Times(2)
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), 5).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article, _ int) error {
+			saved[article.URL] = true
+			saved[models.NormalizeArticleURL(article.URL)] = true
+
+			return nil
+		})
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	// Sent once, from whichever feed delivered it first
+	mockClient.EXPECT().AddEntry(gomock.Any(), "https://example.com/post", gomock.Any()).Return(&wallabag.Entry{ID: 5}, nil).Times(1)
+
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{CrossFeedDedup: true})
+	w.ProcessFeeds()
+}
+
+func TestWorker_SendConcurrency(t *testing.T) {
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+	result := &rss.FeedResult{
+		Articles: []rss.Article{
+			{Title: "Article 1", URL: "https://example.com/1"},
+			{Title: "Article 2", URL: "https://example.com/2"},
+			{Title: "Article 3", URL: "https://example.com/3"},
+			{Title: "Article 4", URL: "https://example.com/4"},
+			{Title: "Article 1 again", URL: "https://example.com/1"},
+		},
+	}
+
+	// setup returns a worker whose store remembers saved URLs and whose Wallabag client
+	// records how many sends were in flight at once
+	setup := func(t *testing.T, config worker.Config, sends int) (*worker.Worker, *atomic.Int32) {
+		t.Helper()
+		ctrl := gomock.NewController(t)
+
+		mockStore := mocks.NewMockStorer(ctrl)
+		mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+		mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+		var savedMu sync.Mutex
+		saved := map[string]bool{}
+		mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+		mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(result, nil)
+		mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, url string) (bool, error) {
+				savedMu.Lock()
+				defer savedMu.Unlock()
+
+				return saved[url], nil
+			}).AnyTimes()
+		mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ int, article *models.Article, _ int) error {
+				savedMu.Lock()
+				defer savedMu.Unlock()
+				saved[article.URL] = true
+
+				return nil
+			}).Times(sends)
+		mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		var inFlight, maxInFlight atomic.Int32
+		mockClient.EXPECT().AddEntry(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, url string, _ []string) (*wallabag.Entry, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					peak := maxInFlight.Load()
+					if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+
+				return &wallabag.Entry{ID: len(url)}, nil
+			}).Times(sends)
+
+		return worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, config), &maxInFlight
+	}
+
+	t.Run("Sends articles concurrently without duplicating a repeated URL", func(t *testing.T) {
+		w, maxInFlight := setup(t, worker.Config{SendConcurrency: 3}, 4)
+		w.ProcessFeeds()
+
+		assert.Greater(t, maxInFlight.Load(), int32(1))
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	})
+
+	t.Run("Respects the send cap", func(t *testing.T) {
+		w, _ := setup(t, worker.Config{SendConcurrency: 3, MaxSendsPerCycle: 2}, 2)
+		w.ProcessFeeds()
+	})
+
+	t.Run("Sends one at a time by default", func(t *testing.T) {
+		w, maxInFlight := setup(t, worker.Config{}, 4)
+		w.ProcessFeeds()
+
+		assert.Equal(t, int32(1), maxInFlight.Load())
+	})
+}
+
+func TestWorker_SendDebounce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStorer(ctrl)
+	mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+	mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+	feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Bursty", PollIntervalMinutes: 30, InitialSyncDone: true}
+	first := rss.Article{Title: "First", URL: "https://example.com/1"}
+	second := rss.Article{Title: "Second", URL: "https://example.com/2"}
+	listed := []rss.Article{first}
+	buildDate := time.Now().Add(-time.Minute)
+
+	mockStore.EXPECT().GetFeeds(gomock.Any()).DoAndReturn(
+		func(context.Context) ([]models.Feed, error) {
+			return []models.Feed{feed}, nil
+		}).AnyTimes()
+	fetches := 0
+	mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *models.Feed) (*rss.FeedResult, error) {
+			fetches++
+
+			return &rss.FeedResult{Articles: listed, LastBuildDate: &buildDate}, nil
+		}).AnyTimes()
+
+	var savedMu sync.Mutex
+	saved := map[string]bool{}
+	mockStore.EXPECT().IsArticleAlreadyProcessed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, url string) (bool, error) {
+			savedMu.Lock()
+			defer savedMu.Unlock()
+
+			return saved[url], nil
+		}).AnyTimes()
+	mockStore.EXPECT().SaveArticle(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, article *models.Article, _ int) error {
+			savedMu.Lock()
+			defer savedMu.Unlock()
+			saved[article.URL] = true
+
+			return nil
+		}).Times(2)
+
+	var sent []string
+	mockClient.EXPECT().AddEntry(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, url string, _ []string) (*wallabag.Entry, error) {
+			sent = append(sent, url)
+
+			return &wallabag.Entry{ID: len(sent)}, nil
+		}).Times(2)
+	// Every fetch is recorded, but the build date only once the batch has gone out
+	mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).Return(nil).Times(3)
+	mockStore.EXPECT().UpdateFeedLastBuildDate(gomock.Any(), 1, buildDate).Return(nil).Times(1)
+
+	window := 100 * time.Millisecond
+	w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, worker.Config{SendDebounce: window})
+
+	w.ProcessFeeds()
+	assert.Empty(t, sent, "the first new article opens the window and is held")
+
+	listed = []rss.Article{first, second}
+	w.ProcessFeeds()
+	assert.Empty(t, sent, "articles found while the window is open are held too")
+
+	// Fetched just now, so only the closing window makes the feed due early
+	lastFetched := time.Now()
+	feed.LastFetched = &lastFetched
+	w.ProcessFeeds()
+	assert.Equal(t, 2, fetches, "the feed keeps to its poll interval while the window is open")
+
+	time.Sleep(window)
+	w.ProcessFeeds()
+	assert.Equal(t, []string{"https://example.com/1", "https://example.com/2"}, sent,
+		"once the window closes everything found during it is sent together")
+
+	w.ProcessFeeds()
+	assert.Equal(t, 3, fetches, "with the batch sent the feed waits for its interval again")
+
+	assert.Eventually(t, func() bool {
+		queued, _ := w.GetQueueStats()
+
+		return queued == 1
+	}, time.Second, 10*time.Millisecond, "the feed is queued for when its window closes")
+}
+
+func TestWorker_PruneExpiredArticles(t *testing.T) {
+	tests := []struct {
+		name   string
+		config worker.Config
+		prunes bool
+	}{
+		{name: "Pruned at the end of the cycle", config: worker.Config{PruneExpiredArticles: true}, prunes: true},
+		{name: "Kept when pruning is off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStorer(ctrl)
+			mockProcessor := rssmocks.NewMockProcessorer(ctrl)
+			mockClient := wallabagmocks.NewMockClienter(ctrl)
+
+			feed := models.Feed{ID: 1, URL: "https://example.com/feed", Name: "Feed", PollIntervalMinutes: 30, InitialSyncDone: true}
+			mockStore.EXPECT().GetFeeds(gomock.Any()).Return([]models.Feed{feed}, nil)
+			fetch := mockProcessor.EXPECT().FetchFeed(gomock.Any(), gomock.Any()).Return(&rss.FeedResult{}, nil)
+			updated := mockStore.EXPECT().UpdateFeedLastFetched(gomock.Any(), 1).After(fetch).Return(nil)
+			if tt.prunes {
+				mockStore.EXPECT().PruneExpiredArticles(gomock.Any()).After(updated).Return(int64(3), nil)
+			}
+
+			w := worker.NewWorkerWithConfig(mockStore, mockProcessor, mockClient, tt.config)
+			w.ProcessFeeds()
+		})
+	}
+}
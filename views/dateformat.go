@@ -0,0 +1,45 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Named date formats accepted by SetDateFormat.
+const (
+	DateFormatISO = "iso"
+	DateFormatUS  = "us"
+	DateFormatEU  = "eu"
+)
+
+// DefaultDateFormat matches the DD/MM/YYYY display the UI has always used.
+const DefaultDateFormat = DateFormatEU
+
+var dateLayouts = map[string]string{
+	DateFormatISO: "2006-01-02 15:04:05",
+	DateFormatUS:  "01/02/2006 03:04:05 PM",
+	DateFormatEU:  "02/01/2006 15:04:05",
+}
+
+// dateLayout is the layout used by formatDateTime. It is set once at startup.
+var dateLayout = dateLayouts[DefaultDateFormat]
+
+// SetDateFormat selects the named format used wherever times are rendered. Unknown names
+// leave the default in place and return an error.
+func SetDateFormat(name string) error {
+	layout, ok := dateLayouts[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		dateLayout = dateLayouts[DefaultDateFormat]
+
+		return fmt.Errorf("unknown date format %q, expected one of iso, us, eu", name)
+	}
+	dateLayout = layout
+
+	return nil
+}
+
+// formatDateTime renders t in the configured date format.
+func formatDateTime(t time.Time) string {
+	return t.Format(dateLayout)
+}
@@ -0,0 +1,38 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDateTime(t *testing.T) {
+	defer func() { _ = SetDateFormat(DefaultDateFormat) }()
+
+	fixed := time.Date(2024, 3, 7, 14, 5, 9, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "ISO", format: "iso", expected: "2024-03-07 14:05:09"},
+		{name: "US", format: "us", expected: "03/07/2024 02:05:09 PM"},
+		{name: "EU", format: "eu", expected: "07/03/2024 14:05:09"},
+		{name: "Case and whitespace ignored", format: " ISO ", expected: "2024-03-07 14:05:09"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, SetDateFormat(tt.format))
+			assert.Equal(t, tt.expected, formatDateTime(fixed))
+		})
+	}
+
+	t.Run("Unknown format falls back to default", func(t *testing.T) {
+		assert.NoError(t, SetDateFormat("iso"))
+		assert.Error(t, SetDateFormat("klingon"))
+		assert.Equal(t, "07/03/2024 14:05:09", formatDateTime(fixed))
+	})
+}
@@ -8,7 +8,20 @@ package views
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
-func Index(data PageData) templ.Component {
+// IndexData is the data for the home page.
+type IndexData struct {
+	PageData
+	Setup *SetupData // First-run setup shown in place of the welcome text (nil = already set up)
+}
+
+// SetupData is the state of the first-run setup's steps.
+type SetupData struct {
+	WallabagURL          string
+	WallabagConfigLoaded bool
+	DefaultPollInterval  int
+}
+
+func Index(data IndexData) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -41,26 +54,179 @@ func Index(data PageData) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"p-5 mb-4 bg-light rounded-3\"><div class=\"container-fluid py-5\"><h1 class=\"display-5 fw-bold\">Welcome to Wallabag RSS Tool</h1><p class=\"col-md-8 fs-4\">Your personal tool to pull articles from RSS feeds and send them to your Wallabag instance.</p><hr class=\"my-4\"><p>Use the navigation above to manage your RSS feeds, view processed articles, or configure settings.</p><form style=\"display: inline;\"><input type=\"hidden\" name=\"csrf_token\" value=\"")
+			if data.Setup != nil {
+				templ_7745c5c3_Err = setupPanel(data.Setup, data.CSRFToken).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			} else {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"p-5 mb-4 bg-light rounded-3\"><div class=\"container-fluid py-5\"><h1 class=\"display-5 fw-bold\">Welcome to Wallabag RSS Tool</h1><p class=\"col-md-8 fs-4\">Your personal tool to pull articles from RSS feeds and send them to your Wallabag instance.</p><hr class=\"my-4\"><p>Use the navigation above to manage your RSS feeds, view processed articles, or configure settings.</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				if !data.ReadOnly {
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<form style=\"display: inline;\"><input type=\"hidden\" name=\"csrf_token\" value=\"")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					var templ_7745c5c3_Var3 string
+					templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(data.CSRFToken)
+					if templ_7745c5c3_Err != nil {
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 29, Col: 68}
+					}
+					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"> <button class=\"btn btn-primary btn-lg\" type=\"button\" hx-post=\"/sync\" hx-include=\"[name='csrf_token']\" hx-indicator=\"#sync-indicator\">Manual Sync</button></form><span id=\"sync-indicator\" class=\"spinner-border spinner-border-sm ms-2 d-none\" role=\"status\" aria-hidden=\"true\"></span>")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</div></div><div class=\"row\"><div class=\"col-md-6\"><h2>Feeds Overview</h2><p>Quick summary of your configured feeds.</p><a class=\"btn btn-secondary\" href=\"/feeds\">Manage Feeds &raquo;</a></div><div class=\"col-md-6\"><h2>Articles Log</h2><p>View recently processed articles.</p><a class=\"btn btn-secondary\" href=\"/articles\">View Articles &raquo;</a></div></div><div id=\"recent-activity\" class=\"mt-4\" hx-get=\"/activity\" hx-trigger=\"load\" hx-swap=\"innerHTML\"><p class=\"text-muted\">Loading recent activity...</p></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			return nil
+		})
+		templ_7745c5c3_Err = Layout(data.PageData).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+// setupPanel guides a new install through connecting Wallabag, choosing the default poll
+// interval and adding the first feed
+func setupPanel(setup *SetupData, csrfToken string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var4 == nil {
+			templ_7745c5c3_Var4 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div id=\"setup-panel\" class=\"card mb-4\"><div class=\"card-header\"><h1 class=\"h4 mb-0\">Get Started</h1></div><div class=\"card-body\"><p>Three steps to start sending articles from your feeds to Wallabag.</p><ol class=\"list-group list-group-numbered mb-3\"><li class=\"list-group-item\"><strong>Connect to Wallabag.</strong> ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if setup.WallabagConfigLoaded {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<span class=\"badge bg-success ms-1\">Done</span><p class=\"mb-0 mt-1\">Using the Wallabag instance at <code>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var3 string
-			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(data.CSRFToken)
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(setup.WallabagURL)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 12, Col: 66}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 69, Col: 83}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\"> <button class=\"btn btn-primary btn-lg\" type=\"button\" hx-post=\"/sync\" hx-include=\"[name='csrf_token']\" hx-indicator=\"#sync-indicator\">Manual Sync</button></form><span id=\"sync-indicator\" class=\"spinner-border spinner-border-sm ms-2 d-none\" role=\"status\" aria-hidden=\"true\"></span></div></div><div class=\"row\"><div class=\"col-md-6\"><h2>Feeds Overview</h2><p>Quick summary of your configured feeds.</p><a class=\"btn btn-secondary\" href=\"/feeds\">Manage Feeds &raquo;</a></div><div class=\"col-md-6\"><h2>Articles Log</h2><p>View recently processed articles.</p><a class=\"btn btn-secondary\" href=\"/articles\">View Articles &raquo;</a></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</code>.</p>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			return nil
-		})
-		templ_7745c5c3_Err = Layout(data).Render(templ.WithChildren(ctx, templ_7745c5c3_Var2), templ_7745c5c3_Buffer)
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<p class=\"mb-0 mt-1\">Set <code>WALLABAG_BASE_URL</code>, <code>WALLABAG_CLIENT_ID</code>, <code>WALLABAG_CLIENT_SECRET</code>, <code>WALLABAG_USERNAME</code> and <code>WALLABAG_PASSWORD</code> in the environment or <code>.env</code> file, then restart. Create the client ID and secret under API clients management in Wallabag.</p>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "</li><li class=\"list-group-item\"><strong>Choose how often feeds are checked.</strong><form class=\"row g-2 mt-1 align-items-center\" hx-put=\"/settings/poll-interval\" hx-target=\"#default-poll-interval-display\" hx-swap=\"outerHTML\"><input type=\"hidden\" name=\"csrf_token\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(csrfToken)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 77, Col: 62}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "\"><div class=\"col-auto\"><input type=\"number\" class=\"form-control\" name=\"default_poll_interval\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(getIntervalValue(setup.DefaultPollInterval))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 79, Col: 129}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "\" min=\"1\" required aria-label=\"Default poll interval\"></div><div class=\"col-auto\"><select class=\"form-control\" name=\"default_poll_interval_unit\" aria-label=\"Default poll interval unit\"><option value=\"minutes\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if getIntervalUnit(setup.DefaultPollInterval) == "minutes" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, " selected")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, ">Minutes</option> <option value=\"hours\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if getIntervalUnit(setup.DefaultPollInterval) == "hours" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " selected")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, ">Hours</option> <option value=\"days\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if getIntervalUnit(setup.DefaultPollInterval) == "days" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, " selected")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, ">Days</option></select></div><div class=\"col-auto\"><button type=\"submit\" class=\"btn btn-outline-primary\">Save</button></div><div class=\"col-auto\">Current: <span id=\"default-poll-interval-display\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = pollIntervalText(setup.DefaultPollInterval).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "</span></div></form></li><li class=\"list-group-item\"><strong>Add your first feed.</strong><p class=\"mb-2 mt-1\">Feeds can be given their own interval, and choose whether older items are sent when they are first added.</p><a class=\"btn btn-primary\" href=\"/feeds\">Add a Feed &raquo;</a></li></ol><form style=\"display: inline;\"><input type=\"hidden\" name=\"csrf_token\" value=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(csrfToken)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `views/index.templ`, Line: 101, Col: 60}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "\"> <button class=\"btn btn-link p-0\" type=\"button\" hx-post=\"/setup/complete\" hx-include=\"[name='csrf_token']\" hx-swap=\"none\">Skip setup</button></form></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -0,0 +1,19 @@
+package views
+
+import "embed"
+
+// Static holds the pages' scripts. They are served under /static/ when PageData.ExternalScripts
+// is set, and inlined into the page otherwise.
+//
+//go:embed static
+var Static embed.FS
+
+// staticScript returns the script static/name, or "" if there is none
+func staticScript(name string) string {
+	script, err := Static.ReadFile("static/" + name)
+	if err != nil {
+		return ""
+	}
+
+	return string(script)
+}
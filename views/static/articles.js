@@ -0,0 +1,7 @@
+// Refresh the list as the worker sends new articles
+if (window.EventSource) {
+	var articleEvents = new EventSource('/events');
+	articleEvents.addEventListener('article', function() {
+		document.body.dispatchEvent(new Event('articles-changed'));
+	});
+}
@@ -0,0 +1,111 @@
+function togglePollInterval() {
+	var unit = document.getElementById('pollIntervalUnit');
+	var input = document.getElementById('pollInterval');
+	if (unit && input) {
+		if (unit.value === 'default') {
+			input.disabled = true;
+			input.value = '0';
+		} else {
+			input.disabled = false;
+			if (input.value === '0') input.value = '1';
+		}
+	}
+}
+
+function toggleSyncOptions() {
+	var syncMode = document.getElementById('syncMode');
+	var countDiv = document.getElementById('syncCountDiv');
+	var dateDiv = document.getElementById('syncDateFromDiv');
+
+	if (syncMode && countDiv && dateDiv) {
+		countDiv.style.display = syncMode.value === 'count' ? 'block' : 'none';
+		dateDiv.style.display = syncMode.value === 'date_from' ? 'block' : 'none';
+	}
+}
+
+function toggleEditPollInterval(feedId) {
+	var unitSelect = document.getElementById('editPollIntervalUnit-' + feedId);
+	var input = document.getElementById('editPollInterval-' + feedId);
+
+	if (unitSelect && input) {
+		var unit = unitSelect.value;
+		if (unit === 'default') {
+			input.disabled = true;
+			input.value = '0';
+		} else {
+			input.disabled = false;
+			if (input.value === '0') input.value = '1';
+		}
+	}
+}
+
+
+// Make functions globally available
+window.togglePollInterval = togglePollInterval;
+window.toggleSyncOptions = toggleSyncOptions;
+window.toggleEditPollInterval = toggleEditPollInterval;
+
+function initializeEverything() {
+	// Initialize main form
+	togglePollInterval();
+	toggleSyncOptions();
+
+	// Add event listeners to main form
+	var pollUnit = document.getElementById('pollIntervalUnit');
+	var syncMode = document.getElementById('syncMode');
+
+	if (pollUnit) {
+		pollUnit.addEventListener('change', togglePollInterval);
+	}
+	if (syncMode) {
+		syncMode.addEventListener('change', toggleSyncOptions);
+	}
+
+	// Initialize edit forms
+	var editPollSelects = document.querySelectorAll('[id^="editPollIntervalUnit-"]');
+
+	editPollSelects.forEach(function(select) {
+		var feedId = select.id.replace('editPollIntervalUnit-', '');
+		toggleEditPollInterval(feedId);
+		select.addEventListener('change', function() {
+			toggleEditPollInterval(feedId);
+		});
+	});
+}
+
+// Initialize immediately if DOM is ready, otherwise wait
+if (document.readyState === 'loading') {
+	document.addEventListener('DOMContentLoaded', initializeEverything);
+} else {
+	initializeEverything();
+}
+
+// HTMX event handlers
+document.body.addEventListener('htmx:afterSwap', function() {
+	setTimeout(initializeEverything, 100);
+});
+
+document.body.addEventListener('htmx:afterSettle', function() {
+	setTimeout(initializeEverything, 100);
+});
+
+// Clear the add form once it has been submitted
+document.body.addEventListener('htmx:afterRequest', function(event) {
+	var form = event.detail.elt;
+	if (form.matches && form.matches('#add-feed-form form')) {
+		form.reset();
+	}
+});
+
+// Reload a feed's row when its details are filled in in the background, unless it
+// is being edited
+if (window.EventSource) {
+	var feedEvents = new EventSource('/events');
+	feedEvents.addEventListener('feed-updated', function(event) {
+		var feedId = JSON.parse(event.data).feed_id;
+		var row = document.getElementById('feed-' + feedId);
+		if (row && !row.querySelector('[name="name"]')) {
+			htmx.ajax('GET', '/feeds/row/' + feedId, {target: row, swap: 'outerHTML'});
+		}
+	});
+}